@@ -14,7 +14,10 @@ import (
 	"github.com/pkg/errors"
 	"github.com/tomchavakis/geo-api/config"
 	phhtp "github.com/tomchavakis/geo-api/internal/infra/http"
+	"github.com/tomchavakis/geo-api/internal/infra/repository/dataset"
+	"github.com/tomchavakis/geo-api/internal/infra/repository/gazetteer"
 	measurement "github.com/tomchavakis/geo-api/internal/infra/repository/geo"
+	"github.com/tomchavakis/geo-api/internal/infra/repository/network"
 )
 
 var build = "develop"
@@ -56,8 +59,55 @@ func run(lg *log.Logger) error {
 		return errors.New("main: can't initialize measurement service")
 	}
 
+	dsSvc, err := dataset.New(cfg.Data.DatasetsDir)
+	if err != nil {
+		lg.Printf("error: %v", err)
+		return errors.New("main: can't initialize dataset service")
+	}
+
+	netSvc, err := network.New(cfg.Data.RoadsFile)
+	if err != nil {
+		lg.Printf("error: %v", err)
+		return errors.New("main: can't initialize road network service")
+	}
+
+	gazSvc, err := gazetteer.New(gazetteer.Files{
+		Countries: cfg.Data.CountriesFile,
+		Regions:   cfg.Data.RegionsFile,
+		Places:    cfg.Data.PlacesFile,
+		Addresses: cfg.Data.AddressesFile,
+	})
+	if err != nil {
+		lg.Printf("error: %v", err)
+		return errors.New("main: can't initialize gazetteer service")
+	}
+
 	// HTTP initialisation
-	r := phhtp.New(msrSvc)
+	r := phhtp.New(phhtp.Services{
+		Measurement: msrSvc,
+		Cell:        msrSvc,
+		PlusCode:    msrSvc,
+		Extent:      msrSvc,
+		Centre:      msrSvc,
+		Simplify:    msrSvc,
+		Hull:        msrSvc,
+		Overlay:     msrSvc,
+		Validate:    msrSvc,
+		Transform:   msrSvc,
+		LineOps:     msrSvc,
+		Grid:        msrSvc,
+		Aggregate:   msrSvc,
+		Cluster:     msrSvc,
+		Heatmap:     msrSvc,
+		Tiles:       dsSvc,
+		TileMath:    msrSvc,
+		Track:       msrSvc,
+		MapMatch:    netSvc,
+		Routing:     netSvc,
+		Isochrone:   netSvc,
+		Optimize:    netSvc,
+		Geocode:     gazSvc,
+	})
 	r.RouteBuilder()
 
 	api := http.Server{
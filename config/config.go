@@ -17,9 +17,20 @@ type Web struct {
 	DebugMode          bool
 }
 
+// Data defines where the stored datasets, the road network and the gazetteer are read from
+type Data struct {
+	DatasetsDir   string
+	RoadsFile     string
+	CountriesFile string
+	RegionsFile   string
+	PlacesFile    string
+	AddressesFile string
+}
+
 // Config defines the configuration
 type Config struct {
-	Web Web
+	Web  Web
+	Data Data
 }
 
 // New generates a new Configuration type
@@ -33,6 +44,14 @@ func New(ctx context.Context) *Config {
 			DebugHost:          getEnv("GEO_API_DEBUG_HOST", "0.0.0.0:4000"),
 			DebugMode:          getEnvAsBool("DEBUG_MODE", true),
 		},
+		Data: Data{
+			DatasetsDir:   getEnv("GEO_API_DATASETS_DIR", ""),
+			RoadsFile:     getEnv("GEO_API_ROADS_FILE", ""),
+			CountriesFile: getEnv("GEO_API_COUNTRIES_FILE", ""),
+			RegionsFile:   getEnv("GEO_API_REGIONS_FILE", ""),
+			PlacesFile:    getEnv("GEO_API_PLACES_FILE", ""),
+			AddressesFile: getEnv("GEO_API_ADDRESSES_FILE", ""),
+		},
 	}
 
 	return cfg
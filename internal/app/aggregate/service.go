@@ -0,0 +1,12 @@
+package aggregate
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/aggregate"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	Aggregate(zones [][][][]geometry.Point, points []aggregate.Point, properties []string) ([]aggregate.Stats, error)
+	AggregateHexGrid(points []aggregate.Point, cellSize float64, units string, properties []string) ([]aggregate.Cell, error)
+}
@@ -0,0 +1,13 @@
+package cell
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/s2"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	GetCellID(p geometry.Point, level int) (*s2.CellID, error)
+	GetCellPolygon(id s2.CellID) (*geometry.Polygon, error)
+	GetCellCovering(polygons [][][]geometry.Point, minLevel, maxLevel, maxCells int) ([]s2.CellID, error)
+}
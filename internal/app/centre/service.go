@@ -0,0 +1,12 @@
+package centre
+
+import "github.com/tomchavakis/geojson/geometry"
+
+// Service ...
+type Service interface {
+	GetCentroid(gs []geometry.Geometry) (*geometry.Point, error)
+	GetCenterOfMass(gs []geometry.Geometry) (*geometry.Point, error)
+	GetBBoxCenter(gs []geometry.Geometry) (*geometry.Point, error)
+	GetPointOnSurface(gs []geometry.Geometry) (*geometry.Point, error)
+	GetPoleOfInaccessibility(gs []geometry.Geometry, tolerance float64) (*geometry.Point, error)
+}
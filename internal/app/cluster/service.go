@@ -0,0 +1,12 @@
+package cluster
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/cluster"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	ClusterDBSCAN(points []geometry.Point, eps float64, units string, minPoints int) (*cluster.Result, error)
+	ClusterKMeans(points []geometry.Point, k int) (*cluster.Result, error)
+}
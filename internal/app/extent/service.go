@@ -0,0 +1,15 @@
+package extent
+
+import (
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	GetBBox(gs []geometry.Geometry) (*geojson.BBOX, error)
+	GetBBoxPolygon(b geojson.BBOX) (*geometry.Geometry, error)
+	ExpandBBox(b geojson.BBOX, distance float64, units string) (*geojson.BBOX, error)
+	IntersectBBox(a, b geojson.BBOX) (*geojson.BBOX, error)
+	UnionBBox(bs []geojson.BBOX) (*geojson.BBOX, error)
+}
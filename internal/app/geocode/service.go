@@ -0,0 +1,12 @@
+package geocode
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/gazetteer"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	ReverseGeocode(p geometry.Point) (*gazetteer.Location, error)
+	Geocode(q gazetteer.Query) ([]gazetteer.Candidate, error)
+}
@@ -0,0 +1,12 @@
+package grid
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/grid"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	GetGrid(kind grid.Kind, b *geojson.BBOX, cellSize float64, units string, mask [][][]geometry.Point) (*grid.Grid, error)
+}
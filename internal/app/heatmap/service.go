@@ -0,0 +1,16 @@
+package heatmap
+
+import (
+	"context"
+	"image"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/density"
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+	"github.com/tomchavakis/geojson"
+)
+
+// Service ...
+type Service interface {
+	GetHeatmapTile(ctx context.Context, points []density.Point, kernel density.Kernel, bandwidth float64, t tile.Tile, scale float64) (*image.NRGBA, error)
+	GetHeatmapGrid(points []density.Point, kernel density.Kernel, bandwidth float64, b geojson.BBOX, cellSize float64, units string) (*density.Cells, error)
+}
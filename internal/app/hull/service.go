@@ -0,0 +1,10 @@
+package hull
+
+import "github.com/tomchavakis/geojson/geometry"
+
+// Service ...
+type Service interface {
+	GetConvexHull(points []geometry.Point) (*geometry.Geometry, error)
+	GetConcaveHull(points []geometry.Point, maxEdge float64, units string) (*geometry.Geometry, error)
+	GetConcaveHullByConcavity(points []geometry.Point, concavity float64) (*geometry.Geometry, error)
+}
@@ -0,0 +1,13 @@
+package isochrone
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/isochrone"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	GetIsochrones(origin geometry.Point, profile *roads.Profile, seconds []float64) ([]isochrone.Contour, error)
+	GetIsodistances(origin geometry.Point, profile *roads.Profile, meters []float64) ([]isochrone.Contour, error)
+}
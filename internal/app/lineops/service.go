@@ -0,0 +1,14 @@
+package lineops
+
+import "github.com/tomchavakis/geojson/geometry"
+
+// Service ...
+type Service interface {
+	SplitLine(line, points []geometry.Point) ([][]geometry.Point, error)
+	SplitLineByLine(line, splitter []geometry.Point) ([][]geometry.Point, error)
+	SliceLine(line []geometry.Point, start, stop geometry.Point) ([]geometry.Point, error)
+	SliceLineAlong(line []geometry.Point, start, stop float64, units string) ([]geometry.Point, error)
+	OffsetLine(line []geometry.Point, distance float64, units string) ([]geometry.Point, error)
+	ChunkLine(line []geometry.Point, length float64, units string) ([][]geometry.Point, error)
+	GetLineIntersections(a, b []geometry.Point) ([]geometry.Point, error)
+}
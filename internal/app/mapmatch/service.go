@@ -0,0 +1,11 @@
+package mapmatch
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/mapmatch"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	MatchTrace(trace []geometry.Point, opts mapmatch.Options) (*mapmatch.Result, error)
+}
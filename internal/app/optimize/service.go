@@ -0,0 +1,10 @@
+package optimize
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/optimize"
+)
+
+// Service ...
+type Service interface {
+	OptimizeRoute(req optimize.Request) (*optimize.Tour, error)
+}
@@ -0,0 +1,11 @@
+package overlay
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/overlay"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	Overlay(subject, clip [][][]geometry.Point, op overlay.Op) (*geometry.Geometry, error)
+}
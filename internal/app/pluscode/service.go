@@ -0,0 +1,14 @@
+package pluscode
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/olc"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	EncodePlusCode(p geometry.Point, length int) (*string, error)
+	DecodePlusCode(code string) (*olc.CodeArea, error)
+	ShortenPlusCode(code string, ref geometry.Point) (*string, error)
+	RecoverPlusCode(code string, ref geometry.Point) (*string, error)
+}
@@ -0,0 +1,12 @@
+package routing
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geo-api/internal/spatial/routing"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	GetRoute(a, b geometry.Point, profile *roads.Profile) (*routing.Result, error)
+}
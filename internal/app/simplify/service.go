@@ -0,0 +1,11 @@
+package simplify
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/simplify"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	Simplify(gs []geometry.Geometry, tolerance float64, units string, algorithm simplify.Algorithm, preserveTopology bool) ([]geometry.Geometry, error)
+}
@@ -0,0 +1,14 @@
+package tilemath
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	GetTileAt(p geometry.Point, z int) (*tile.Tile, error)
+	GetTileFromTMS(z, x, y int) (*tile.Tile, error)
+	GetTileFromQuadkey(quadkey string) (*tile.Tile, error)
+	GetTileCover(polygons [][][]geometry.Point, z int) ([]tile.Tile, error)
+}
@@ -0,0 +1,16 @@
+package tiles
+
+import (
+	"errors"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+)
+
+// ErrDatasetNotFound is returned for the datasets that aren't stored.
+var ErrDatasetNotFound = errors.New("dataset not found")
+
+// Service ...
+type Service interface {
+	GetTileETag(dataset string, t tile.Tile) (string, error)
+	GetVectorTile(dataset string, t tile.Tile) ([]byte, error)
+}
@@ -0,0 +1,11 @@
+package track
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/track"
+)
+
+// Service ...
+type Service interface {
+	AnalyzeTrack(points []track.Point, opts track.StopOptions) (*track.Analysis, error)
+	CleanTrack(points []track.Point, opts track.CleanOptions) (*track.Cleaned, error)
+}
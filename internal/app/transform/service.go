@@ -0,0 +1,10 @@
+package transform
+
+import "github.com/tomchavakis/geojson/geometry"
+
+// Service ...
+type Service interface {
+	Translate(gs []geometry.Geometry, distance float64, bearing float64, units string) ([]geometry.Geometry, error)
+	Rotate(gs []geometry.Geometry, angle float64, pivot *geometry.Point) ([]geometry.Geometry, error)
+	Scale(gs []geometry.Geometry, factor float64, origin *geometry.Point) ([]geometry.Geometry, error)
+}
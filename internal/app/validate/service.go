@@ -0,0 +1,12 @@
+package validate
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/validate"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Service ...
+type Service interface {
+	Validate(gs []geometry.Geometry) ([][]validate.Problem, error)
+	MakeValid(gs []geometry.Geometry) ([]geometry.Geometry, error)
+}
@@ -0,0 +1,163 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/tomchavakis/geo-api/internal/app/aggregate"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/aggregate"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// AggregateHandler struct
+type AggregateHandler struct {
+	aggregateSvc aggregate.Service
+}
+
+// NewAggregateHandler handler
+func NewAggregateHandler(aSvc aggregate.Service) *AggregateHandler {
+	ah := &AggregateHandler{
+		aggregateSvc: aSvc,
+	}
+	return ah
+}
+
+// AggregateMessage holds the points to aggregate, as any GeoJSON object made of Points and
+// MultiPoints, and either the polygons to aggregate them into or the hexagon grid to bin them
+// into. Properties are the numeric properties of the points to summarize.
+type AggregateMessage struct {
+	Points     json.RawMessage `json:"points"`
+	Polygons   json.RawMessage `json:"polygons,omitempty"`
+	Grid       *AggregateGrid  `json:"grid,omitempty"`
+	Properties []string        `json:"properties"`
+}
+
+// AggregateGrid holds the size in units of the hexagons binning the points.
+type AggregateGrid struct {
+	CellSize *float64 `json:"cellSize,omitempty"`
+	Units    string   `json:"units"`
+}
+
+// aggregateRoute returns the polygons with the number of points inside them and the sum, mean,
+// min and max of the chosen properties, as <property>_sum, <property>_mean and so on. Polygons
+// keep their properties and are all returned, while only the hexagons holding points are.
+func (ah *AggregateHandler) aggregateRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	var am AggregateMessage
+	if err := decodeBody(r, &am); err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	if len(am.Points) == 0 {
+		err := errors.New("points can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	points, err := decodeAggregatePoints(am.Points, am.Properties)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	switch {
+	case len(am.Polygons) > 0 && am.Grid != nil:
+		err := errors.New("only one of polygons or grid can be set")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	case am.Grid != nil:
+		if am.Grid.CellSize == nil {
+			err := errors.New("grid cellSize can't be empty")
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		if len(points) == 0 {
+			err := errors.New("points can't be empty")
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		cells, err := ah.aggregateSvc.AggregateHexGrid(points, *am.Grid.CellSize, am.Grid.Units, am.Properties)
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+
+		fs := make([]feature.Feature, 0, len(cells))
+		for _, c := range cells {
+			fs = append(fs, geom.NewFeature(c.Geometry, statsProperties(nil, c.Stats, am.Properties)))
+		}
+		return NewResponse(geom.NewFeatureCollection(fs), http.StatusOK), nil
+	case len(am.Polygons) > 0:
+		fs, err := geom.Decode(am.Polygons)
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		zones := make([][][][]geometry.Point, 0, len(fs))
+		for _, f := range fs {
+			z, err := geom.Polygons(f.Geometry)
+			if err != nil {
+				err := errors.New("polygons must be Polygon or MultiPolygon geometries")
+				return nil, NewResponseError(err, http.StatusBadRequest)
+			}
+			zones = append(zones, z)
+		}
+
+		stats, err := ah.aggregateSvc.Aggregate(zones, points, am.Properties)
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		if len(stats) != len(fs) {
+			err := errors.New("unexpected number of aggregates")
+			return nil, NewResponseError(err, http.StatusInternalServerError)
+		}
+		for i := range fs {
+			fs[i].Properties = statsProperties(fs[i].Properties, stats[i], am.Properties)
+		}
+		return NewResponse(geom.NewFeatureCollection(fs), http.StatusOK), nil
+	default:
+		err := errors.New("polygons or grid is required")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+}
+
+// decodeAggregatePoints returns the positions of the points with the numeric values of the
+// properties. Missing or non-numeric values are left out of the statistics of the property.
+func decodeAggregatePoints(data json.RawMessage, properties []string) ([]algo.Point, error) {
+	fs, err := geom.Decode(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var points []algo.Point
+	for _, f := range fs {
+		if f.Geometry.GeoJSONType != geojson.Point && f.Geometry.GeoJSONType != geojson.MultiPoint {
+			return nil, errors.New("points must be Point or MultiPoint geometries")
+		}
+		ps, err := geom.Coords(f.Geometry)
+		if err != nil {
+			return nil, err
+		}
+		values := make(map[string]float64, len(properties))
+		for _, name := range properties {
+			if v, ok := f.Properties[name].(float64); ok {
+				values[name] = v
+			}
+		}
+		for _, p := range ps {
+			points = append(points, algo.Point{Point: p, Values: values})
+		}
+	}
+	return points, nil
+}
+
+// statsProperties adds the statistics to the properties of a feature.
+func statsProperties(props map[string]interface{}, s algo.Stats, properties []string) map[string]interface{} {
+	if props == nil {
+		props = map[string]interface{}{}
+	}
+	props["count"] = s.Count
+	for _, name := range properties {
+		sum := s.Properties[name]
+		props[name+"_sum"] = sum.Sum
+		props[name+"_mean"] = sum.Mean
+		props[name+"_min"] = sum.Min
+		props[name+"_max"] = sum.Max
+	}
+	return props
+}
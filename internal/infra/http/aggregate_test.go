@@ -0,0 +1,128 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/aggregate"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestAggregate(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	mean := 2.0
+	lo := 1.0
+	hi := 3.0
+	district := `{"type": "Feature", "properties": {"name": "north"}, "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [2, 0], [2, 2], [0, 0]]]}}`
+
+	tests := map[string]struct {
+		mockAggregate func(zones [][][][]geometry.Point, points []aggregate.Point, properties []string) ([]aggregate.Stats, error)
+		mockHexGrid   func(points []aggregate.Point, cellSize float64, units string, properties []string) ([]aggregate.Cell, error)
+		want          []map[string]interface{}
+		payload       string
+		wantErr       bool
+		err           error
+		args          args
+	}{
+		"polygons": {
+			mockAggregate: func(zones [][][][]geometry.Point, points []aggregate.Point, properties []string) ([]aggregate.Stats, error) {
+				if len(zones) != 1 || len(points) != 2 || points[0].Values["severity"] != 1 || len(points[1].Values) != 0 {
+					return nil, errors.New("unexpected arguments")
+				}
+				return []aggregate.Stats{{Count: 2, Properties: map[string]aggregate.Summary{
+					"severity": {Count: 2, Sum: 4, Mean: &mean, Min: &lo, Max: &hi},
+				}}}, nil
+			},
+			payload: `{"points": {"type": "FeatureCollection", "features": [
+				{"type": "Feature", "properties": {"severity": 1}, "geometry": {"type": "Point", "coordinates": [1, 0.5]}},
+				{"type": "Feature", "properties": {"severity": "high"}, "geometry": {"type": "Point", "coordinates": [1.5, 0.5]}}
+			]}, "polygons": ` + district + `, "properties": ["severity"]}`,
+			want: []map[string]interface{}{{
+				"name": "north", "count": 2, "severity_sum": 4.0, "severity_mean": &mean, "severity_min": &lo, "severity_max": &hi,
+			}},
+		},
+		"empty points": {
+			payload: `{"polygons": ` + district + `}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("points can't be empty"), http.StatusBadRequest),
+		},
+		"invalid points": {
+			payload: `{"points": ` + district + `, "polygons": ` + district + `}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("points must be Point or MultiPoint geometries"), http.StatusBadRequest),
+		},
+		"invalid polygons": {
+			payload: `{"points": {"type": "Point", "coordinates": [1, 1]}, "polygons": {"type": "Point", "coordinates": [1, 1]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("polygons must be Polygon or MultiPolygon geometries"), http.StatusBadRequest),
+		},
+		"polygons and grid": {
+			payload: `{"points": {"type": "Point", "coordinates": [1, 1]}, "polygons": ` + district + `, "grid": {"cellSize": 1}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("only one of polygons or grid can be set"), http.StatusBadRequest),
+		},
+		"no zones": {
+			payload: `{"points": {"type": "Point", "coordinates": [1, 1]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("polygons or grid is required"), http.StatusBadRequest),
+		},
+		"empty grid cell size": {
+			payload: `{"points": {"type": "Point", "coordinates": [1, 1]}, "grid": {"units": "kilometers"}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("grid cellSize can't be empty"), http.StatusBadRequest),
+		},
+		"hex grid": {
+			mockHexGrid: func(points []aggregate.Point, cellSize float64, units string, properties []string) ([]aggregate.Cell, error) {
+				if len(points) != 2 || cellSize != 5 || units != "kilometers" {
+					return nil, errors.New("unexpected arguments")
+				}
+				return []aggregate.Cell{{Geometry: geom.PointGeometry(geometry.Point{Lng: 1, Lat: 1}), Stats: aggregate.Stats{Count: 2}}}, nil
+			},
+			payload: `{"points": {"type": "MultiPoint", "coordinates": [[1, 1], [1.01, 1]]}, "grid": {"cellSize": 5, "units": "kilometers"}}`,
+			want:    []map[string]interface{}{{"count": 2}},
+		},
+		"service error": {
+			mockHexGrid: func(points []aggregate.Point, cellSize float64, units string, properties []string) ([]aggregate.Cell, error) {
+				return nil, errors.New("cell size must be a positive number")
+			},
+			payload: `{"points": {"type": "Point", "coordinates": [1, 1]}, "grid": {"cellSize": -1}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("cell size must be a positive number"), http.StatusBadRequest),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/aggregate", strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockAggregateRepository()
+			MockSvc.AggregateFn = tt.mockAggregate
+			MockSvc.AggregateHexGridFn = tt.mockHexGrid
+			h := NewAggregateHandler(MockSvc)
+			resp, err := h.aggregateRoute(tt.args.w, tt.args.r)
+			if tt.wantErr {
+				assert.Equal(t, tt.err, err, "aggregate() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			fc, ok := resp.Payload.(feature.Collection)
+			assert.True(t, ok)
+			assert.Len(t, fc.Features, len(tt.want))
+			for i, f := range fc.Features {
+				assert.Equal(t, tt.want[i], f.Properties)
+			}
+		})
+	}
+}
@@ -0,0 +1,177 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/tomchavakis/geo-api/internal/app/cell"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/s2"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// CellHandler struct
+type CellHandler struct {
+	cellSvc cell.Service
+}
+
+// NewCellHandler handler
+func NewCellHandler(cellSvc cell.Service) *CellHandler {
+	ch := &CellHandler{
+		cellSvc: cellSvc,
+	}
+	return ch
+}
+
+// CellMessage describes an S2 cell. The ID is the signed 64-bit representation used by BigQuery,
+// encoded as a string to avoid losing precision in JSON clients.
+type CellMessage struct {
+	ID    string `json:"id"`
+	Token string `json:"token"`
+	Level int    `json:"level"`
+	Face  int    `json:"face"`
+}
+
+// CoveringMessage ...
+type CoveringMessage struct {
+	Geometry json.RawMessage `json:"geometry"`
+	MinLevel *int            `json:"minLevel,omitempty"`
+	MaxLevel *int            `json:"maxLevel,omitempty"`
+	MaxCells *int            `json:"maxCells,omitempty"`
+}
+
+func newCellMessage(id s2.CellID) CellMessage {
+	return CellMessage{
+		ID:    strconv.FormatInt(id.Int64(), 10),
+		Token: id.Token(),
+		Level: id.Level(),
+		Face:  id.Face(),
+	}
+}
+
+func (ch *CellHandler) cellIDRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	lat, lon, err := getLatLon(r, "lat", "lon")
+
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	level, err := getInt(r, "level", s2.MaxLevel)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	p := geometry.Point{
+		Lat: *lat,
+		Lng: *lon,
+	}
+
+	id, err := ch.cellSvc.GetCellID(p, level)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(newCellMessage(*id), http.StatusOK), nil
+}
+
+func (ch *CellHandler) cellPolygonRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	v := r.URL.Query().Get("id")
+	if v == "" {
+		return nil, NewResponseError(errors.New("id can't be empty"), http.StatusBadRequest)
+	}
+
+	id, err := s2.CellIDFromString(v)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	poly, err := ch.cellSvc.GetCellPolygon(id)
+	if err != nil {
+		return nil, NewResponseError(errors.New(err.Error()), http.StatusInternalServerError)
+	}
+
+	c := newCellMessage(id)
+	f := geom.NewFeature(geom.PolygonGeometry(geom.Rings(*poly)), map[string]interface{}{
+		"id":    c.ID,
+		"token": c.Token,
+		"level": c.Level,
+	})
+
+	return NewResponse(f, http.StatusOK), nil
+}
+
+func (ch *CellHandler) coveringRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	if r.Body == nil {
+		err := errors.New("invalid Body")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	var cm CoveringMessage
+	err := json.NewDecoder(r.Body).Decode(&cm)
+	if err != nil {
+		return nil, NewResponseError(errors.New("invalid input"), http.StatusBadRequest)
+	}
+
+	if len(cm.Geometry) == 0 {
+		err := errors.New("geometry can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	polygons, err := decodePolygons(cm.Geometry)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	minLevel, maxLevel, maxCells := 0, s2.MaxLevel, s2.DefaultMaxCells
+	if cm.MinLevel != nil {
+		minLevel = *cm.MinLevel
+	}
+	if cm.MaxLevel != nil {
+		maxLevel = *cm.MaxLevel
+	}
+	if cm.MaxCells != nil {
+		maxCells = *cm.MaxCells
+	}
+	if minLevel < 0 || minLevel > s2.MaxLevel || maxLevel < 0 || maxLevel > s2.MaxLevel {
+		return nil, NewResponseError(fmt.Errorf("levels must be between 0 and %d", s2.MaxLevel), http.StatusBadRequest)
+	}
+	if maxCells < 1 || maxCells > s2.MaxCoveringCells {
+		return nil, NewResponseError(fmt.Errorf("maxCells must be between 1 and %d", s2.MaxCoveringCells), http.StatusBadRequest)
+	}
+
+	ids, err := ch.cellSvc.GetCellCovering(polygons, minLevel, maxLevel, maxCells)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	cells := make([]CellMessage, 0, len(ids))
+	for _, id := range ids {
+		cells = append(cells, newCellMessage(id))
+	}
+
+	return NewResponse(cells, http.StatusOK), nil
+}
+
+// decodePolygons returns the polygons of a GeoJSON object made of Polygons and MultiPolygons.
+func decodePolygons(data []byte) ([][][]geometry.Point, error) {
+	gs, err := geom.DecodeGeometries(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var polygons [][][]geometry.Point
+	for _, g := range gs {
+		ps, err := geom.Polygons(g)
+		if err != nil {
+			return nil, errors.New("only Polygon and MultiPolygon geometries are supported")
+		}
+		polygons = append(polygons, ps...)
+	}
+	if len(polygons) == 0 {
+		return nil, errors.New("geometry can't be empty")
+	}
+
+	return polygons, nil
+}
@@ -0,0 +1,171 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/s2"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestCellID(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	id, err := s2.CellIDFromToken("89c25a3")
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		mockGetCellID func(p geometry.Point, level int) (*s2.CellID, error)
+		want          *Response
+		request       string
+		wantErr       bool
+		err           error
+		args          args
+	}{
+		"invalid input": {
+			want: nil,
+			mockGetCellID: func(p geometry.Point, level int) (*s2.CellID, error) {
+				return &id, nil
+			},
+			request: "/api/v1/s2/cellid?lat=a&lon=-74.006&level=12",
+			wantErr: true,
+			err:     NewResponseError(errors.New(`invalid lat: "a" is not a valid coordinate`), http.StatusBadRequest),
+		},
+		"invalid level": {
+			want: nil,
+			mockGetCellID: func(p geometry.Point, level int) (*s2.CellID, error) {
+				return &id, nil
+			},
+			request: "/api/v1/s2/cellid?lat=40.7128&lon=-74.006&level=a",
+			wantErr: true,
+			err:     NewResponseError(errors.New("invalid level"), http.StatusBadRequest),
+		},
+		"get cell id error": {
+			want: nil,
+			mockGetCellID: func(p geometry.Point, level int) (*s2.CellID, error) {
+				return nil, errors.New("level must be between 0 and 30")
+			},
+			request: "/api/v1/s2/cellid?lat=40.7128&lon=-74.006&level=31",
+			wantErr: true,
+			err:     NewResponseError(errors.New("level must be between 0 and 30"), http.StatusBadRequest),
+		},
+		"happy path": {
+			want: NewResponse(CellMessage{
+				ID:    "-8520148382826627072",
+				Token: "89c25a3",
+				Level: 12,
+				Face:  4,
+			}, http.StatusOK),
+			mockGetCellID: func(p geometry.Point, level int) (*s2.CellID, error) {
+				return &id, nil
+			},
+			request: "/api/v1/s2/cellid?lat=40.7128&lon=-74.006&level=12",
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", tt.request, nil)
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockCellRepository()
+			MockSvc.GetCellIDFn = tt.mockGetCellID
+			h := NewCellHandler(MockSvc)
+			got, err := h.cellIDRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "cellID() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "cellID() got = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestCovering(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	id, err := s2.CellIDFromToken("89c25a3")
+	assert.NoError(t, err)
+
+	tests := map[string]struct {
+		mockGetCellCovering func(polygons [][][]geometry.Point, minLevel, maxLevel, maxCells int) ([]s2.CellID, error)
+		want                *Response
+		payload             string
+		wantErr             bool
+		err                 error
+		args                args
+	}{
+		"empty geometry": {
+			want:    nil,
+			payload: `{"maxCells": 4}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("geometry can't be empty"), http.StatusBadRequest),
+		},
+		"unsupported geometry": {
+			want:    nil,
+			payload: `{"geometry": {"type": "Point", "coordinates": [-74.006, 40.7128]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("only Polygon and MultiPolygon geometries are supported"), http.StatusBadRequest),
+		},
+		"level out of range": {
+			want:    nil,
+			payload: `{"minLevel": 31, "geometry": {"type": "Polygon", "coordinates": [[[-74.01, 40.70], [-74.00, 40.70], [-74.00, 40.71], [-74.01, 40.70]]]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("levels must be between 0 and 30"), http.StatusBadRequest),
+		},
+		"too many cells": {
+			want:    nil,
+			payload: `{"maxCells": 1000000, "geometry": {"type": "Polygon", "coordinates": [[[-74.01, 40.70], [-74.00, 40.70], [-74.00, 40.71], [-74.01, 40.70]]]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("maxCells must be between 1 and 10000"), http.StatusBadRequest),
+		},
+		"happy path": {
+			want: NewResponse([]CellMessage{{
+				ID:    "-8520148382826627072",
+				Token: "89c25a3",
+				Level: 12,
+				Face:  4,
+			}}, http.StatusOK),
+			mockGetCellCovering: func(polygons [][][]geometry.Point, minLevel, maxLevel, maxCells int) ([]s2.CellID, error) {
+				if len(polygons) != 1 || minLevel != 0 || maxLevel != 12 || maxCells != 4 {
+					return nil, errors.New("unexpected arguments")
+				}
+				return []s2.CellID{id}, nil
+			},
+			payload: `{"maxLevel": 12, "maxCells": 4, "geometry": {"type": "Polygon", "coordinates": [[[-74.01, 40.70], [-74.00, 40.70], [-74.00, 40.71], [-74.01, 40.70]]]}}`,
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/s2/covering", strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockCellRepository()
+			MockSvc.GetCellCoveringFn = tt.mockGetCellCovering
+			h := NewCellHandler(MockSvc)
+			got, err := h.coveringRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "covering() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "covering() got = %v, want %v", got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/tomchavakis/geo-api/internal/app/centre"
+	"github.com/tomchavakis/geo-api/internal/spatial/coord"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// defaultPoleTolerance is the precision in degrees of the pole of inaccessibility, about 11 meters.
+const defaultPoleTolerance = 0.0001
+
+// CentreHandler struct
+type CentreHandler struct {
+	centreSvc centre.Service
+}
+
+// NewCentreHandler handler
+func NewCentreHandler(cSvc centre.Service) *CentreHandler {
+	ch := &CentreHandler{
+		centreSvc: cSvc,
+	}
+	return ch
+}
+
+func (ch *CentreHandler) centroidRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	return centreResponse(r, ch.centreSvc.GetCentroid)
+}
+
+func (ch *CentreHandler) centerOfMassRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	return centreResponse(r, ch.centreSvc.GetCenterOfMass)
+}
+
+func (ch *CentreHandler) bboxCenterRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	return centreResponse(r, ch.centreSvc.GetBBoxCenter)
+}
+
+// pointOnSurfaceRoute returns a point guaranteed to lie on the geometry. With pole=true the pole of
+// inaccessibility of the polygons is returned instead, found to within tolerance degrees.
+func (ch *CentreHandler) pointOnSurfaceRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	pole, err := getBool(r, "pole")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if !pole {
+		return centreResponse(r, ch.centreSvc.GetPointOnSurface)
+	}
+
+	tolerance, err := getFloat(r, "tolerance", defaultPoleTolerance)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return centreResponse(r, func(gs []geometry.Geometry) (*geometry.Point, error) {
+		return ch.centreSvc.GetPoleOfInaccessibility(gs, tolerance)
+	})
+}
+
+func centreResponse(r *http.Request, fn func(gs []geometry.Geometry) (*geometry.Point, error)) (*Response, error) {
+	format, err := getFormat(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	gs, err := decodeGeometries(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	p, err := fn(gs)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	if format != coord.Decimal {
+		return NewResponse(formatPoint(*p, format), http.StatusOK), nil
+	}
+
+	return NewResponse(*p, http.StatusOK), nil
+}
@@ -0,0 +1,116 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestPointOnSurface(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	polygon := `{"type": "Polygon", "coordinates": [[[0, 0], [10, 0], [10, 1], [1, 1], [1, 10], [0, 10], [0, 0]]]}`
+
+	tests := map[string]struct {
+		mockGetPointOnSurface        func(gs []geometry.Geometry) (*geometry.Point, error)
+		mockGetPoleOfInaccessibility func(gs []geometry.Geometry, tolerance float64) (*geometry.Point, error)
+		want                         *Response
+		request                      string
+		payload                      string
+		wantErr                      bool
+		err                          error
+		args                         args
+	}{
+		"invalid input": {
+			want:    nil,
+			request: "/api/v1/centre/surface",
+			payload: `{"type": "Polygon"`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("cannot decode the input value"), http.StatusBadRequest),
+		},
+		"invalid pole": {
+			want:    nil,
+			request: "/api/v1/centre/surface?pole=a",
+			payload: polygon,
+			wantErr: true,
+			err:     NewResponseError(errors.New("invalid pole"), http.StatusBadRequest),
+		},
+		"invalid tolerance": {
+			want:    nil,
+			request: "/api/v1/centre/surface?pole=true&tolerance=NaN",
+			payload: polygon,
+			wantErr: true,
+			err:     NewResponseError(errors.New("invalid tolerance"), http.StatusBadRequest),
+		},
+		"pole error": {
+			want: nil,
+			mockGetPoleOfInaccessibility: func(gs []geometry.Geometry, tolerance float64) (*geometry.Point, error) {
+				return nil, errors.New("only Polygon and MultiPolygon geometries are supported")
+			},
+			request: "/api/v1/centre/surface?pole=true",
+			payload: `{"type": "Point", "coordinates": [0, 0]}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("only Polygon and MultiPolygon geometries are supported"), http.StatusBadRequest),
+		},
+		"point on surface": {
+			want: NewResponse(geometry.Point{Lat: 0.5, Lng: 5.5}, http.StatusOK),
+			mockGetPointOnSurface: func(gs []geometry.Geometry) (*geometry.Point, error) {
+				return &geometry.Point{Lat: 0.5, Lng: 5.5}, nil
+			},
+			request: "/api/v1/centre/surface",
+			payload: polygon,
+			wantErr: false,
+			err:     nil,
+		},
+		"pole of inaccessibility": {
+			want: NewResponse(geometry.Point{Lat: 0.5858, Lng: 0.5858}, http.StatusOK),
+			mockGetPoleOfInaccessibility: func(gs []geometry.Geometry, tolerance float64) (*geometry.Point, error) {
+				if len(gs) != 1 || tolerance != 0.001 {
+					return nil, errors.New("unexpected arguments")
+				}
+				return &geometry.Point{Lat: 0.5858, Lng: 0.5858}, nil
+			},
+			request: "/api/v1/centre/surface?pole=true&tolerance=0.001",
+			payload: polygon,
+			wantErr: false,
+			err:     nil,
+		},
+		"dms format": {
+			want: NewResponse(FormattedPoint{Lat: `0°30'0"N`, Lng: `5°30'0"E`}, http.StatusOK),
+			mockGetPointOnSurface: func(gs []geometry.Geometry) (*geometry.Point, error) {
+				return &geometry.Point{Lat: 0.5, Lng: 5.5}, nil
+			},
+			request: "/api/v1/centre/surface?format=dms",
+			payload: polygon,
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", tt.request, strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockCentreRepository()
+			MockSvc.GetPointOnSurfaceFn = tt.mockGetPointOnSurface
+			MockSvc.GetPoleOfInaccessibilityFn = tt.mockGetPoleOfInaccessibility
+			h := NewCentreHandler(MockSvc)
+			got, err := h.pointOnSurfaceRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "pointOnSurface() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "pointOnSurface() got = %v, want %v", got, tt.want)
+		})
+	}
+}
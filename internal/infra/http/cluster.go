@@ -0,0 +1,139 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tomchavakis/geo-api/internal/app/cluster"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/cluster"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// defaultMinPoints is the DBSCAN density used when the request doesn't set one.
+const defaultMinPoints = 3
+
+// ClusterHandler struct
+type ClusterHandler struct {
+	clusterSvc cluster.Service
+}
+
+// NewClusterHandler handler
+func NewClusterHandler(cSvc cluster.Service) *ClusterHandler {
+	ch := &ClusterHandler{
+		clusterSvc: cSvc,
+	}
+	return ch
+}
+
+// ClusterMessage holds the points to cluster, as any GeoJSON object made of Points. DBSCAN uses
+// eps in units and minPoints, 3 by default, while k-means uses k, by default the square root of
+// half the number of points.
+type ClusterMessage struct {
+	Points    json.RawMessage `json:"points"`
+	Eps       *float64        `json:"eps,omitempty"`
+	Units     string          `json:"units"`
+	MinPoints *int            `json:"minPoints,omitempty"`
+	K         *int            `json:"k,omitempty"`
+}
+
+// ClusterResponse holds the points with the cluster they belong to, null for the DBSCAN noise,
+// and the centroids and convex hulls of the clusters with their number of points.
+type ClusterResponse struct {
+	Points    feature.Collection `json:"points"`
+	Centroids feature.Collection `json:"centroids"`
+	Hulls     feature.Collection `json:"hulls"`
+}
+
+func (ch *ClusterHandler) clusterRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	method := chi.URLParam(r, "method")
+	if method != "dbscan" && method != "kmeans" {
+		err := fmt.Errorf("unsupported clustering %q, expected one of dbscan or kmeans", method)
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	var cm ClusterMessage
+	if err := decodeBody(r, &cm); err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	if len(cm.Points) == 0 {
+		err := errors.New("points can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	fs, err := geom.Decode(cm.Points)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	points := make([]geometry.Point, 0, len(fs))
+	for _, f := range fs {
+		if f.Geometry.GeoJSONType != geojson.Point {
+			err := errors.New("points must be Point geometries")
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		p, err := geom.Point(f.Geometry)
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		points = append(points, *p)
+	}
+
+	var res *algo.Result
+	if method == "dbscan" {
+		if cm.Eps == nil {
+			err := errors.New("eps can't be empty")
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		minPoints := defaultMinPoints
+		if cm.MinPoints != nil {
+			minPoints = *cm.MinPoints
+		}
+		res, err = ch.clusterSvc.ClusterDBSCAN(points, *cm.Eps, cm.Units, minPoints)
+	} else {
+		k := int(math.Round(math.Sqrt(float64(len(points)) / 2)))
+		if k < 1 {
+			k = 1
+		}
+		if cm.K != nil {
+			k = *cm.K
+		}
+		res, err = ch.clusterSvc.ClusterKMeans(points, k)
+	}
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if len(res.Labels) != len(fs) {
+		err := errors.New("unexpected number of labels")
+		return nil, NewResponseError(err, http.StatusInternalServerError)
+	}
+
+	for i := range fs {
+		if fs[i].Properties == nil {
+			fs[i].Properties = map[string]interface{}{}
+		}
+		if l := res.Labels[i]; l == algo.Noise {
+			fs[i].Properties["cluster"] = nil
+		} else {
+			fs[i].Properties["cluster"] = l
+		}
+	}
+	centroids := make([]feature.Feature, 0, len(res.Clusters))
+	hulls := make([]feature.Feature, 0, len(res.Clusters))
+	for _, c := range res.Clusters {
+		centroids = append(centroids, geom.NewFeature(geom.PointGeometry(c.Centroid), map[string]interface{}{"cluster": c.ID, "count": c.Count}))
+		hulls = append(hulls, geom.NewFeature(c.Hull, map[string]interface{}{"cluster": c.ID, "count": c.Count}))
+	}
+
+	cr := ClusterResponse{
+		Points:    geom.NewFeatureCollection(fs),
+		Centroids: geom.NewFeatureCollection(centroids),
+		Hulls:     geom.NewFeatureCollection(hulls),
+	}
+	return NewResponse(cr, http.StatusOK), nil
+}
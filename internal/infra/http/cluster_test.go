@@ -0,0 +1,131 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/cluster"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestCluster(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	points := `{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "properties": {"id": "a"}, "geometry": {"type": "Point", "coordinates": [10, 50]}},
+		{"type": "Feature", "properties": {"id": "b"}, "geometry": {"type": "Point", "coordinates": [10.001, 50]}},
+		{"type": "Feature", "properties": {"id": "c"}, "geometry": {"type": "Point", "coordinates": [12, 50]}}
+	]}`
+	result := &cluster.Result{
+		Labels: []int{0, 0, cluster.Noise},
+		Clusters: []cluster.Cluster{{
+			ID: 0, Count: 2, Centroid: geometry.Point{Lng: 10.0005, Lat: 50},
+			Hull: geom.LineStringGeometry([]geometry.Point{{Lng: 10, Lat: 50}, {Lng: 10.001, Lat: 50}}),
+		}},
+	}
+
+	tests := map[string]struct {
+		mockDBSCAN func(points []geometry.Point, eps float64, units string, minPoints int) (*cluster.Result, error)
+		mockKMeans func(points []geometry.Point, k int) (*cluster.Result, error)
+		method     string
+		payload    string
+		want       []interface{}
+		wantErr    bool
+		err        error
+		args       args
+	}{
+		"dbscan": {
+			mockDBSCAN: func(ps []geometry.Point, eps float64, units string, minPoints int) (*cluster.Result, error) {
+				if len(ps) != 3 || eps != 0.5 || units != "kilometres" || minPoints != 3 {
+					return nil, errors.New("unexpected arguments")
+				}
+				return result, nil
+			},
+			method:  "dbscan",
+			payload: `{"points": ` + points + `, "eps": 0.5, "units": "kilometres"}`,
+			want:    []interface{}{0, 0, nil},
+		},
+		"kmeans with the default k": {
+			mockKMeans: func(ps []geometry.Point, k int) (*cluster.Result, error) {
+				if len(ps) != 3 || k != 1 {
+					return nil, errors.New("unexpected arguments")
+				}
+				return &cluster.Result{Labels: []int{0, 0, 0}}, nil
+			},
+			method:  "kmeans",
+			payload: `{"points": ` + points + `}`,
+			want:    []interface{}{0, 0, 0},
+		},
+		"invalid method": {
+			method:  "optics",
+			payload: `{"points": ` + points + `}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New(`unsupported clustering "optics", expected one of dbscan or kmeans`), http.StatusBadRequest),
+		},
+		"empty points": {
+			method:  "kmeans",
+			payload: `{"k": 2}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("points can't be empty"), http.StatusBadRequest),
+		},
+		"invalid points": {
+			method:  "kmeans",
+			payload: `{"points": {"type": "MultiPoint", "coordinates": [[1, 1], [2, 2]]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("points must be Point geometries"), http.StatusBadRequest),
+		},
+		"empty eps": {
+			method:  "dbscan",
+			payload: `{"points": ` + points + `}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("eps can't be empty"), http.StatusBadRequest),
+		},
+		"service error": {
+			mockKMeans: func(ps []geometry.Point, k int) (*cluster.Result, error) {
+				return nil, errors.New("k must be between 1 and the number of points")
+			},
+			method:  "kmeans",
+			payload: `{"points": ` + points + `, "k": 4}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("k must be between 1 and the number of points"), http.StatusBadRequest),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/cluster/"+tt.method, strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("method", tt.method)
+			tt.args.r = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			MockSvc := mock.NewMockClusterRepository()
+			MockSvc.ClusterDBSCANFn = tt.mockDBSCAN
+			MockSvc.ClusterKMeansFn = tt.mockKMeans
+			h := NewClusterHandler(MockSvc)
+			resp, err := h.clusterRoute(tt.args.w, tt.args.r)
+			if tt.wantErr {
+				assert.Equal(t, tt.err, err, "cluster() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			cr, ok := resp.Payload.(ClusterResponse)
+			assert.True(t, ok)
+			assert.Len(t, cr.Points.Features, len(tt.want))
+			for i, f := range cr.Points.Features {
+				assert.Equal(t, tt.want[i], f.Properties["cluster"])
+				assert.NotEmpty(t, f.Properties["id"])
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/tomchavakis/geo-api/internal/app/extent"
+	"github.com/tomchavakis/geo-api/internal/spatial/bbox"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+)
+
+// ExtentHandler struct
+type ExtentHandler struct {
+	extentSvc extent.Service
+}
+
+// NewExtentHandler handler
+func NewExtentHandler(extSvc extent.Service) *ExtentHandler {
+	eh := &ExtentHandler{
+		extentSvc: extSvc,
+	}
+	return eh
+}
+
+// BBoxMessage ...
+type BBoxMessage struct {
+	BBox     []float64 `json:"bbox"`
+	Distance *float64  `json:"distance,omitempty"`
+	Units    string    `json:"units"`
+}
+
+// BBoxesMessage ...
+type BBoxesMessage struct {
+	BBoxes [][]float64 `json:"bboxes"`
+}
+
+func (eh *ExtentHandler) bboxRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	gs, err := decodeGeometries(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	b, err := eh.extentSvc.GetBBox(gs)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(bbox.ToSlice(*b), http.StatusOK), nil
+}
+
+func (eh *ExtentHandler) bboxPolygonRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	bm, b, err := decodeBBox(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	g, err := eh.extentSvc.GetBBoxPolygon(*b)
+	if err != nil {
+		return nil, NewResponseError(errors.New(err.Error()), http.StatusInternalServerError)
+	}
+
+	f := geom.NewFeature(*g, nil)
+	f.Bbox = bm.BBox
+
+	return NewResponse(f, http.StatusOK), nil
+}
+
+func (eh *ExtentHandler) expandRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	bm, b, err := decodeBBox(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	if bm.Distance == nil {
+		err := errors.New("distance can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	res, err := eh.extentSvc.ExpandBBox(*b, *bm.Distance, bm.Units)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(bbox.ToSlice(*res), http.StatusOK), nil
+}
+
+func (eh *ExtentHandler) intersectRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	bs, err := decodeBBoxes(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	if len(bs) != 2 {
+		err := errors.New("exactly two bboxes are required")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	res, err := eh.extentSvc.IntersectBBox(bs[0], bs[1])
+	if err != nil {
+		return nil, NewResponseError(errors.New(err.Error()), http.StatusInternalServerError)
+	}
+
+	if res == nil {
+		return NewResponse(nil, http.StatusOK), nil
+	}
+
+	return NewResponse(bbox.ToSlice(*res), http.StatusOK), nil
+}
+
+func (eh *ExtentHandler) unionRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	bs, err := decodeBBoxes(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	res, err := eh.extentSvc.UnionBBox(bs)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(bbox.ToSlice(*res), http.StatusOK), nil
+}
+
+func decodeBBox(r *http.Request) (*BBoxMessage, *geojson.BBOX, error) {
+	if r.Body == nil {
+		return nil, nil, errors.New("invalid Body")
+	}
+	var bm BBoxMessage
+	err := json.NewDecoder(r.Body).Decode(&bm)
+	if err != nil {
+		return nil, nil, errors.New("invalid input")
+	}
+
+	if bm.BBox == nil {
+		return nil, nil, errors.New("bbox can't be empty")
+	}
+
+	b, err := bbox.FromSlice(bm.BBox)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return &bm, b, nil
+}
+
+func decodeBBoxes(r *http.Request) ([]geojson.BBOX, error) {
+	if r.Body == nil {
+		return nil, errors.New("invalid Body")
+	}
+	var bm BBoxesMessage
+	err := json.NewDecoder(r.Body).Decode(&bm)
+	if err != nil {
+		return nil, errors.New("invalid input")
+	}
+
+	if len(bm.BBoxes) == 0 {
+		return nil, errors.New("bboxes can't be empty")
+	}
+
+	bs := make([]geojson.BBOX, 0, len(bm.BBoxes))
+	for _, s := range bm.BBoxes {
+		b, err := bbox.FromSlice(s)
+		if err != nil {
+			return nil, err
+		}
+		bs = append(bs, *b)
+	}
+
+	return bs, nil
+}
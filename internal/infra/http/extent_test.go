@@ -0,0 +1,140 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestBBox(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	tests := map[string]struct {
+		mockGetBBox func(gs []geometry.Geometry) (*geojson.BBOX, error)
+		want        *Response
+		payload     string
+		wantErr     bool
+		err         error
+		args        args
+	}{
+		"invalid input": {
+			want:    nil,
+			payload: `{"type": "Point"`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("cannot decode the input value"), http.StatusBadRequest),
+		},
+		"bbox error": {
+			want: nil,
+			mockGetBBox: func(gs []geometry.Geometry) (*geojson.BBOX, error) {
+				return nil, errors.New("geometry can't be empty")
+			},
+			payload: `{"type": "FeatureCollection", "features": []}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("geometry can't be empty"), http.StatusBadRequest),
+		},
+		"happy path": {
+			want: NewResponse([]float64{179, -17, -179, -16}, http.StatusOK),
+			mockGetBBox: func(gs []geometry.Geometry) (*geojson.BBOX, error) {
+				if len(gs) != 1 {
+					return nil, errors.New("unexpected arguments")
+				}
+				return geojson.NewBBox(179, -17, -179, -16), nil
+			},
+			payload: `{"type": "LineString", "coordinates": [[179, -17], [-179, -16]]}`,
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/bbox", strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockExtentRepository()
+			MockSvc.GetBBoxFn = tt.mockGetBBox
+			h := NewExtentHandler(MockSvc)
+			got, err := h.bboxRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "bbox() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "bbox() got = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestBBoxIntersect(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	tests := map[string]struct {
+		mockIntersectBBox func(a, b geojson.BBOX) (*geojson.BBOX, error)
+		want              *Response
+		payload           string
+		wantErr           bool
+		err               error
+		args              args
+	}{
+		"one bbox": {
+			want:    nil,
+			payload: `{"bboxes": [[0, 0, 10, 10]]}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("exactly two bboxes are required"), http.StatusBadRequest),
+		},
+		"invalid bbox": {
+			want:    nil,
+			payload: `{"bboxes": [[0, 0, 10, 10], [0, 10, 10]]}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("a bbox must have four elements [west, south, east, north]"), http.StatusBadRequest),
+		},
+		"disjoint": {
+			want: NewResponse(nil, http.StatusOK),
+			mockIntersectBBox: func(a, b geojson.BBOX) (*geojson.BBOX, error) {
+				return nil, nil
+			},
+			payload: `{"bboxes": [[0, 0, 10, 10], [20, 0, 30, 10]]}`,
+			wantErr: false,
+			err:     nil,
+		},
+		"happy path": {
+			want: NewResponse([]float64{5, 5, 10, 10}, http.StatusOK),
+			mockIntersectBBox: func(a, b geojson.BBOX) (*geojson.BBOX, error) {
+				return geojson.NewBBox(5, 5, 10, 10), nil
+			},
+			payload: `{"bboxes": [[0, 0, 10, 10], [5, 5, 15, 15]]}`,
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/bbox/intersect", strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockExtentRepository()
+			MockSvc.IntersectBBoxFn = tt.mockIntersectBBox
+			h := NewExtentHandler(MockSvc)
+			got, err := h.intersectRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "intersect() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "intersect() got = %v, want %v", got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,170 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/tomchavakis/geo-api/internal/app/geocode"
+	"github.com/tomchavakis/geo-api/internal/spatial/gazetteer"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// GeocodeHandler struct
+type GeocodeHandler struct {
+	geocodeSvc geocode.Service
+}
+
+// NewGeocodeHandler handler
+func NewGeocodeHandler(gSvc geocode.Service) *GeocodeHandler {
+	gh := &GeocodeHandler{
+		geocodeSvc: gSvc,
+	}
+	return gh
+}
+
+// RegionResponse is a country with its ISO 3166-1 alpha-2 code, or a first level division with its
+// ISO 3166-2 code when the dataset has one.
+type RegionResponse struct {
+	Code    string `json:"code"`
+	Country string `json:"country"`
+	Name    string `json:"name"`
+}
+
+// PlaceResponse is a place of the gazetteer with its distance in meters from the point geocoded.
+type PlaceResponse struct {
+	ID         string            `json:"id"`
+	Name       string            `json:"name"`
+	Point      geometry.Geometry `json:"point"`
+	Country    string            `json:"country"`
+	Population int64             `json:"population"`
+	Distance   float64           `json:"distance"`
+}
+
+// ReverseResponse holds the country and the first level division containing a point and its
+// nearest populated place, null when there's none.
+type ReverseResponse struct {
+	Country *RegionResponse `json:"country"`
+	Admin1  *RegionResponse `json:"admin1"`
+	Place   *PlaceResponse  `json:"place"`
+}
+
+// reverseRoute reverse geocodes lat, lon with the boundaries and the places of the gazetteer
+// datasets loaded, without calling external services.
+func (gh *GeocodeHandler) reverseRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	lat, lon, err := getLatLon(r, "lat", "lon")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	loc, err := gh.geocodeSvc.ReverseGeocode(geometry.Point{Lat: *lat, Lng: *lon})
+	if err != nil {
+		return nil, gazetteerError(err)
+	}
+
+	res := ReverseResponse{Country: regionResponse(loc.Country), Admin1: regionResponse(loc.Admin1)}
+	if loc.Place != nil {
+		res.Place = placeResponse(loc.Place, loc.Distance)
+	}
+	return NewResponse(res, http.StatusOK), nil
+}
+
+// geocodeRoute geocodes the text q with the places and the addresses of the gazetteer datasets
+// loaded, matching their names with typos and the last word typed partly. The candidates are
+// Point features with their bbox, the best first, at most limit of them, 10 by default. When lat
+// and lon are set, the candidates near them rank higher and have their distance in meters.
+func (gh *GeocodeHandler) geocodeRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	q := r.URL.Query()
+	text := q.Get("q")
+	if text == "" {
+		return nil, NewResponseError(errors.New("q can't be empty"), http.StatusBadRequest)
+	}
+	limit, err := getInt(r, "limit", gazetteer.DefaultLimit)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	query := gazetteer.Query{Text: text, Limit: limit}
+	if q.Get("lat") != "" || q.Get("lon") != "" {
+		lat, lon, err := getLatLon(r, "lat", "lon")
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		query.Focus = &geometry.Point{Lat: *lat, Lng: *lon}
+	}
+
+	cs, err := gh.geocodeSvc.Geocode(query)
+	if err != nil {
+		return nil, gazetteerError(err)
+	}
+
+	fs := make([]feature.Feature, 0, len(cs))
+	for _, c := range cs {
+		p := c.Place
+		props := map[string]interface{}{
+			"name":    p.Name,
+			"country": p.Country,
+			"kind":    kind(p),
+			"score":   c.Score,
+		}
+		if p.Population > 0 {
+			props["population"] = p.Population
+		}
+		if len(p.Context) > 0 {
+			props["context"] = p.Context
+		}
+		if query.Focus != nil {
+			props["distance"] = c.Distance
+		}
+		f := geom.NewFeature(geom.PointGeometry(p.Point), props)
+		f.ID = p.ID
+		bbox := c.BBox
+		f.Bbox = bbox[:]
+		fs = append(fs, f)
+	}
+	return NewResponse(geom.NewFeatureCollection(fs), http.StatusOK), nil
+}
+
+// kind names the kind of a place: address, place for the populated places, region for the
+// administrative areas and feature for the others.
+func kind(p *gazetteer.Place) string {
+	switch {
+	case p.Code == gazetteer.AddressCode:
+		return "address"
+	case p.Populated():
+		return "place"
+	case p.Class == "A":
+		return "region"
+	}
+	return "feature"
+}
+
+func regionResponse(r *gazetteer.Region) *RegionResponse {
+	if r == nil {
+		return nil
+	}
+	code := r.Code
+	if r.Level == gazetteer.Country {
+		code = r.CountryCode
+	}
+	return &RegionResponse{Code: code, Country: r.CountryCode, Name: r.Name}
+}
+
+func placeResponse(p *gazetteer.Place, distance float64) *PlaceResponse {
+	return &PlaceResponse{
+		ID:         p.ID,
+		Name:       p.Name,
+		Point:      geom.PointGeometry(p.Point),
+		Country:    p.Country,
+		Population: p.Population,
+		Distance:   distance,
+	}
+}
+
+// gazetteerError maps the errors of the geocoding services to their status.
+func gazetteerError(err error) error {
+	if errors.Is(err, gazetteer.ErrNoGazetteer) {
+		return NewResponseError(err, http.StatusServiceUnavailable)
+	}
+	return NewResponseError(err, http.StatusBadRequest)
+}
@@ -0,0 +1,203 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/gazetteer"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestReverse(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	athens := &gazetteer.Place{ID: "264371", Name: "Athens", Point: geometry.Point{Lat: 37.98376, Lng: 23.72784}, Country: "GR", Population: 664046}
+	greece := &gazetteer.Region{Level: gazetteer.Country, CountryCode: "GR", Name: "Greece"}
+	attica := &gazetteer.Region{Level: gazetteer.Admin1, CountryCode: "GR", Code: "GR-I", Name: "Attica"}
+
+	tests := map[string]struct {
+		mockReverse func(p geometry.Point) (*gazetteer.Location, error)
+		query       string
+		want        ReverseResponse
+		wantErr     bool
+		err         error
+		args        args
+	}{
+		"happy path": {
+			mockReverse: func(p geometry.Point) (*gazetteer.Location, error) {
+				if p != (geometry.Point{Lat: 37.981, Lng: 23.741}) {
+					return nil, errors.New("unexpected point")
+				}
+				return &gazetteer.Location{Country: greece, Admin1: attica, Place: athens, Distance: 1193.5}, nil
+			},
+			query: "?lat=37.981&lon=23.741",
+			want: ReverseResponse{
+				Country: &RegionResponse{Code: "GR", Country: "GR", Name: "Greece"},
+				Admin1:  &RegionResponse{Code: "GR-I", Country: "GR", Name: "Attica"},
+				Place:   &PlaceResponse{ID: "264371", Name: "Athens", Point: geom.PointGeometry(athens.Point), Country: "GR", Population: 664046, Distance: 1193.5},
+			},
+		},
+		"at sea": {
+			mockReverse: func(p geometry.Point) (*gazetteer.Location, error) {
+				return &gazetteer.Location{}, nil
+			},
+			query: "?lat=36.5&lon=30.5",
+			want:  ReverseResponse{},
+		},
+		"missing lon": {
+			query:   "?lat=36.5",
+			wantErr: true,
+			err:     NewResponseError(errors.New("lon can't be empty"), http.StatusBadRequest),
+		},
+		"no gazetteer": {
+			mockReverse: func(p geometry.Point) (*gazetteer.Location, error) {
+				return nil, gazetteer.ErrNoGazetteer
+			},
+			query:   "?lat=36.5&lon=30.5",
+			wantErr: true,
+			err:     NewResponseError(gazetteer.ErrNoGazetteer, http.StatusServiceUnavailable),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/api/v1/reverse"+tt.query, nil)
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockGeocodeRepository()
+			MockSvc.ReverseGeocodeFn = tt.mockReverse
+			h := NewGeocodeHandler(MockSvc)
+			got, err := h.reverseRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "reverse() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got.Payload)
+			if tt.want.Place != nil {
+				b, err := json.Marshal(got.Payload)
+				assert.NoError(t, err)
+				assert.Contains(t, string(b), `"point":{"type":"Point","coordinates":[23.72784,37.98376]}`)
+			}
+		})
+	}
+}
+
+func TestGeocode(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	athens := &gazetteer.Place{ID: "264371", Name: "Athens", Point: geometry.Point{Lat: 37.98376, Lng: 23.72784}, Class: "P", Country: "GR", Population: 664046}
+	ermou := &gazetteer.Place{ID: "a1b2", Name: "12 Ermou", Point: geometry.Point{Lat: 37.9838, Lng: 23.7275}, Code: gazetteer.AddressCode, Context: []string{"Athina"}}
+	candidates := []gazetteer.Candidate{
+		{Place: athens, BBox: [4]float64{23.5, 37.8, 23.9, 38.1}, Score: 1.2, Distance: 120},
+		{Place: ermou, BBox: [4]float64{23.7, 37.98, 23.73, 37.99}, Score: 0.8, Distance: 10},
+	}
+
+	tests := map[string]struct {
+		mockGeocode func(q gazetteer.Query) ([]gazetteer.Candidate, error)
+		query       string
+		distance    bool
+		wantErr     bool
+		err         error
+		args        args
+	}{
+		"happy path": {
+			mockGeocode: func(q gazetteer.Query) ([]gazetteer.Candidate, error) {
+				if q.Text != "athens ermou" || q.Limit != 10 || q.Focus != nil {
+					return nil, errors.New("unexpected query")
+				}
+				return candidates, nil
+			},
+			query: "?q=athens+ermou",
+		},
+		"focus point": {
+			mockGeocode: func(q gazetteer.Query) ([]gazetteer.Candidate, error) {
+				if q.Limit != 2 || *q.Focus != (geometry.Point{Lat: 37.98, Lng: 23.72}) {
+					return nil, errors.New("unexpected query")
+				}
+				return candidates, nil
+			},
+			query:    "?q=athens&lat=37.98&lon=23.72&limit=2",
+			distance: true,
+		},
+		"missing q": {
+			query:   "?lat=37.98&lon=23.72",
+			wantErr: true,
+			err:     NewResponseError(errors.New("q can't be empty"), http.StatusBadRequest),
+		},
+		"half focus": {
+			query:   "?q=athens&lat=37.98",
+			wantErr: true,
+			err:     NewResponseError(errors.New("lon can't be empty"), http.StatusBadRequest),
+		},
+		"invalid limit": {
+			query:   "?q=athens&limit=ten",
+			wantErr: true,
+			err:     NewResponseError(errors.New("invalid limit"), http.StatusBadRequest),
+		},
+		"service error": {
+			mockGeocode: func(q gazetteer.Query) ([]gazetteer.Candidate, error) {
+				return nil, errors.New("the limit must be between 1 and 50")
+			},
+			query:   "?q=athens&limit=100",
+			wantErr: true,
+			err:     NewResponseError(errors.New("the limit must be between 1 and 50"), http.StatusBadRequest),
+		},
+		"no gazetteer": {
+			mockGeocode: func(q gazetteer.Query) ([]gazetteer.Candidate, error) {
+				return nil, gazetteer.ErrNoGazetteer
+			},
+			query:   "?q=athens",
+			wantErr: true,
+			err:     NewResponseError(gazetteer.ErrNoGazetteer, http.StatusServiceUnavailable),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/api/v1/geocode"+tt.query, nil)
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockGeocodeRepository()
+			MockSvc.GeocodeFn = tt.mockGeocode
+			h := NewGeocodeHandler(MockSvc)
+			got, err := h.geocodeRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "geocode() error = %v,expected = %v", err, tt.err)
+				return
+			}
+
+			fc := got.Payload.(feature.Collection)
+			assert.Len(t, fc.Features, 2)
+			f := fc.Features[0]
+			assert.Equal(t, "264371", f.ID)
+			assert.Equal(t, []float64{23.5, 37.8, 23.9, 38.1}, f.Bbox)
+			assert.Equal(t, []float64{23.7, 37.98, 23.73, 37.99}, fc.Features[1].Bbox)
+			assert.Equal(t, "place", f.Properties["kind"])
+			assert.Equal(t, int64(664046), f.Properties["population"])
+			assert.Equal(t, "address", fc.Features[1].Properties["kind"])
+			assert.Equal(t, []string{"Athina"}, fc.Features[1].Properties["context"])
+			p, err := geom.Point(f.Geometry)
+			assert.NoError(t, err)
+			assert.Equal(t, athens.Point, *p)
+			if tt.distance {
+				assert.Equal(t, 120.0, f.Properties["distance"])
+			} else {
+				assert.NotContains(t, f.Properties, "distance")
+			}
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tomchavakis/geo-api/internal/app/grid"
+	"github.com/tomchavakis/geo-api/internal/spatial/bbox"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/grid"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// GridHandler struct
+type GridHandler struct {
+	gridSvc grid.Service
+}
+
+// NewGridHandler handler
+func NewGridHandler(gSvc grid.Service) *GridHandler {
+	gh := &GridHandler{
+		gridSvc: gSvc,
+	}
+	return gh
+}
+
+// GridMessage requests a grid of cells of a size in units over a bbox, or over the bbox of the
+// mask when it is missing. The mask, any GeoJSON object made of Polygons and MultiPolygons,
+// keeps the points inside it and the cells intersecting it.
+type GridMessage struct {
+	BBox     []float64       `json:"bbox,omitempty"`
+	Mask     json.RawMessage `json:"mask,omitempty"`
+	CellSize *float64        `json:"cellSize,omitempty"`
+	Units    string          `json:"units"`
+}
+
+func (gh *GridHandler) gridRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	kind, err := algo.ParseKind(chi.URLParam(r, "kind"))
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	var gm GridMessage
+	if err := decodeBody(r, &gm); err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	if gm.CellSize == nil {
+		err := errors.New("cellSize can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if gm.BBox == nil && len(gm.Mask) == 0 {
+		err := errors.New("bbox or mask is required")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	var b *geojson.BBOX
+	if gm.BBox != nil {
+		b, err = bbox.FromSlice(gm.BBox)
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+	}
+	var mask [][][]geometry.Point
+	if len(gm.Mask) > 0 {
+		mask, err = decodePolygons(gm.Mask)
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+	}
+
+	g, err := gh.gridSvc.GetGrid(kind, b, *gm.CellSize, gm.Units, mask)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(gridCollection{grid: g}, http.StatusOK), nil
+}
+
+// gridCollection streams the cells of a grid as a FeatureCollection.
+type gridCollection struct {
+	grid *algo.Grid
+}
+
+// Stream implements Streamer.
+func (gc gridCollection) Stream(w io.Writer) error {
+	if _, err := io.WriteString(w, `{"type":"FeatureCollection","features":[`); err != nil {
+		return err
+	}
+	first := true
+	err := gc.grid.Each(func(g geometry.Geometry) error {
+		b, err := json.Marshal(geom.NewFeature(g, nil))
+		if err != nil {
+			return err
+		}
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		_, err = w.Write(b)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "]}")
+	return err
+}
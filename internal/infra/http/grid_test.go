@@ -0,0 +1,113 @@
+package http
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/grid"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestGrid(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	tests := map[string]struct {
+		mockGrid func(kind grid.Kind, b *geojson.BBOX, cellSize float64, units string, mask [][][]geometry.Point) (*grid.Grid, error)
+		kind     string
+		payload  string
+		wantErr  bool
+		err      error
+		args     args
+	}{
+		"invalid kind": {
+			kind:    "circle",
+			payload: `{"bbox": [0, 0, 1, 1], "cellSize": 10}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New(`unsupported grid "circle", expected one of point, square, hex or triangle`), http.StatusBadRequest),
+		},
+		"empty cell size": {
+			kind:    "square",
+			payload: `{"bbox": [0, 0, 1, 1]}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("cellSize can't be empty"), http.StatusBadRequest),
+		},
+		"empty area": {
+			kind:    "square",
+			payload: `{"cellSize": 10}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("bbox or mask is required"), http.StatusBadRequest),
+		},
+		"invalid bbox": {
+			kind:    "hex",
+			payload: `{"bbox": [0, 1, 1, 0], "cellSize": 10}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("bbox south can't be greater than north"), http.StatusBadRequest),
+		},
+		"service error": {
+			mockGrid: func(kind grid.Kind, b *geojson.BBOX, cellSize float64, units string, mask [][][]geometry.Point) (*grid.Grid, error) {
+				return nil, errors.New("cell size must be a positive number")
+			},
+			kind:    "point",
+			payload: `{"mask": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}, "cellSize": -1}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("cell size must be a positive number"), http.StatusBadRequest),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/grid/"+tt.kind, strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("kind", tt.kind)
+			tt.args.r = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			MockSvc := mock.NewMockGridRepository()
+			MockSvc.GetGridFn = tt.mockGrid
+			h := NewGridHandler(MockSvc)
+			_, err = h.gridRoute(tt.args.w, tt.args.r)
+			assert.Equal(t, tt.err, err, "grid() error = %v,expected = %v", err, tt.err)
+		})
+	}
+}
+
+func TestGridStream(t *testing.T) {
+	MockSvc := mock.NewMockGridRepository()
+	MockSvc.GetGridFn = func(kind grid.Kind, b *geojson.BBOX, cellSize float64, units string, mask [][][]geometry.Point) (*grid.Grid, error) {
+		if kind != grid.Squares || b == nil || cellSize != 25 || units != "kilometers" {
+			return nil, errors.New("unexpected arguments")
+		}
+		return grid.New(grid.Options{Kind: kind, BBox: *b, CellSize: 0.2499 * math.Pi / 180})
+	}
+	h := NewGridHandler(MockSvc)
+
+	req, err := http.NewRequest("POST", "/api/v1/grid/square", strings.NewReader(`{"bbox": [-0.5, -0.5, 0.5, 0.5], "cellSize": 25, "units": "kilometers"}`))
+	assert.NoError(t, err)
+	rctx := chi.NewRouteContext()
+	rctx.URLParams.Add("kind", "square")
+	req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+	rec := httptest.NewRecorder()
+	handle(h.gridRoute)(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	var fc feature.Collection
+	assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &fc))
+	assert.Len(t, fc.Features, 16)
+	assert.Equal(t, geojson.Polygon, fc.Features[0].Geometry.GeoJSONType)
+}
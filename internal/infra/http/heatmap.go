@@ -0,0 +1,191 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"net/http"
+
+	"github.com/tomchavakis/geo-api/internal/app/heatmap"
+	"github.com/tomchavakis/geo-api/internal/spatial/bbox"
+	"github.com/tomchavakis/geo-api/internal/spatial/density"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// HeatmapHandler struct
+type HeatmapHandler struct {
+	heatmapSvc heatmap.Service
+}
+
+// NewHeatmapHandler handler
+func NewHeatmapHandler(hSvc heatmap.Service) *HeatmapHandler {
+	hh := &HeatmapHandler{
+		heatmapSvc: hSvc,
+	}
+	return hh
+}
+
+// HeatmapMessage holds the points of a heatmap, as any GeoJSON object made of Points and
+// MultiPoints weighted by their numeric Weight property, 1 when it is missing or not set. The
+// kernel, gaussian by default, spreads every point over a bandwidth in meters. The grid of
+// intensities covers the bbox with cells of a size in units, and the raster tiles reach red at
+// the scale intensity, by default the highest intensity of the tile.
+type HeatmapMessage struct {
+	Points    json.RawMessage `json:"points"`
+	Weight    string          `json:"weight"`
+	Kernel    string          `json:"kernel"`
+	Bandwidth *float64        `json:"bandwidth,omitempty"`
+	BBox      []float64       `json:"bbox,omitempty"`
+	CellSize  *float64        `json:"cellSize,omitempty"`
+	Units     string          `json:"units"`
+	Scale     float64         `json:"scale"`
+}
+
+// heatmapRoute returns the intensities of the heatmap on a grid of square cells, leaving out the
+// cells where it is 0.
+func (hh *HeatmapHandler) heatmapRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	var hm HeatmapMessage
+	if err := decodeBody(r, &hm); err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	points, kernel, err := decodeHeatmap(hm)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if hm.BBox == nil {
+		err := errors.New("bbox can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	b, err := bbox.FromSlice(hm.BBox)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if hm.CellSize == nil {
+		err := errors.New("cellSize can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	cells, err := hh.heatmapSvc.GetHeatmapGrid(points, kernel, *hm.Bandwidth, *b, *hm.CellSize, hm.Units)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(heatmapCollection{cells: cells}, http.StatusOK), nil
+}
+
+// heatmapTileRoute renders the heatmap on the PNG raster tile of the z, x and y URL parameters.
+func (hh *HeatmapHandler) heatmapTileRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	t, err := getTile(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	var hm HeatmapMessage
+	if err := decodeBody(r, &hm); err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	points, kernel, err := decodeHeatmap(hm)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	img, err := hh.heatmapSvc.GetHeatmapTile(r.Context(), points, kernel, *hm.Bandwidth, *t, hm.Scale)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	resp := NewResponse(pngImage{img: img}, http.StatusOK)
+	resp.Header["Content-Type"] = "image/png"
+	return resp, nil
+}
+
+// decodeHeatmap returns the weighted points and the kernel of a heatmap request.
+func decodeHeatmap(hm HeatmapMessage) ([]density.Point, density.Kernel, error) {
+	if len(hm.Points) == 0 {
+		return nil, "", errors.New("points can't be empty")
+	}
+	if hm.Bandwidth == nil {
+		return nil, "", errors.New("bandwidth can't be empty")
+	}
+	kernel := density.Gaussian
+	if hm.Kernel != "" {
+		k, err := density.ParseKernel(hm.Kernel)
+		if err != nil {
+			return nil, "", err
+		}
+		kernel = k
+	}
+
+	fs, err := geom.Decode(hm.Points)
+	if err != nil {
+		return nil, "", err
+	}
+	var points []density.Point
+	for _, f := range fs {
+		if f.Geometry.GeoJSONType != geojson.Point && f.Geometry.GeoJSONType != geojson.MultiPoint {
+			return nil, "", errors.New("points must be Point or MultiPoint geometries")
+		}
+		ps, err := geom.Coords(f.Geometry)
+		if err != nil {
+			return nil, "", err
+		}
+		weight := 1.0
+		if hm.Weight != "" {
+			if v, ok := f.Properties[hm.Weight].(float64); ok {
+				weight = v
+			}
+		}
+		for _, p := range ps {
+			points = append(points, density.Point{Point: p, Weight: weight})
+		}
+	}
+
+	return points, kernel, nil
+}
+
+// heatmapCollection streams the cells of a heatmap as a FeatureCollection with their intensity.
+type heatmapCollection struct {
+	cells *density.Cells
+}
+
+// Stream implements Streamer.
+func (hc heatmapCollection) Stream(w io.Writer) error {
+	if _, err := io.WriteString(w, `{"type":"FeatureCollection","features":[`); err != nil {
+		return err
+	}
+	first := true
+	err := hc.cells.Each(func(g geometry.Geometry, intensity float64) error {
+		b, err := json.Marshal(geom.NewFeature(g, map[string]interface{}{"intensity": intensity}))
+		if err != nil {
+			return err
+		}
+		if !first {
+			if _, err := io.WriteString(w, ","); err != nil {
+				return err
+			}
+		}
+		first = false
+		_, err = w.Write(b)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "]}")
+	return err
+}
+
+// pngImage streams an image as PNG.
+type pngImage struct {
+	img image.Image
+}
+
+// Stream implements Streamer.
+func (pi pngImage) Stream(w io.Writer) error {
+	return png.Encode(w, pi.img)
+}
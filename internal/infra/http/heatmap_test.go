@@ -0,0 +1,130 @@
+package http
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/density"
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson"
+)
+
+func TestHeatmap(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	points := `{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "properties": {"customers": 4}, "geometry": {"type": "Point", "coordinates": [10, 50]}},
+		{"type": "Feature", "properties": {}, "geometry": {"type": "MultiPoint", "coordinates": [[10.1, 50], [10.2, 50]]}}
+	]}`
+
+	tests := map[string]struct {
+		mockGrid func(points []density.Point, kernel density.Kernel, bandwidth float64, b geojson.BBOX, cellSize float64, units string) (*density.Cells, error)
+		payload  string
+		wantErr  bool
+		err      error
+		args     args
+	}{
+		"grid": {
+			mockGrid: func(points []density.Point, kernel density.Kernel, bandwidth float64, b geojson.BBOX, cellSize float64, units string) (*density.Cells, error) {
+				if len(points) != 3 || points[0].Weight != 4 || points[2].Weight != 1 || kernel != density.Quartic || bandwidth != 500 || cellSize != 100 {
+					return nil, errors.New("unexpected arguments")
+				}
+				return nil, nil
+			},
+			payload: `{"points": ` + points + `, "weight": "customers", "kernel": "quartic", "bandwidth": 500, "bbox": [9, 49, 11, 51], "cellSize": 100, "units": "meters"}`,
+		},
+		"empty points": {
+			payload: `{"bandwidth": 500, "bbox": [9, 49, 11, 51], "cellSize": 100}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("points can't be empty"), http.StatusBadRequest),
+		},
+		"empty bandwidth": {
+			payload: `{"points": ` + points + `, "bbox": [9, 49, 11, 51], "cellSize": 100}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("bandwidth can't be empty"), http.StatusBadRequest),
+		},
+		"invalid kernel": {
+			payload: `{"points": ` + points + `, "kernel": "cosine", "bandwidth": 500, "bbox": [9, 49, 11, 51], "cellSize": 100}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New(`unsupported kernel "cosine", expected one of gaussian, epanechnikov, quartic, triangular or uniform`), http.StatusBadRequest),
+		},
+		"invalid points": {
+			payload: `{"points": {"type": "LineString", "coordinates": [[1, 1], [2, 2]]}, "bandwidth": 500, "bbox": [9, 49, 11, 51], "cellSize": 100}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("points must be Point or MultiPoint geometries"), http.StatusBadRequest),
+		},
+		"empty bbox": {
+			payload: `{"points": ` + points + `, "bandwidth": 500, "cellSize": 100}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("bbox can't be empty"), http.StatusBadRequest),
+		},
+		"empty cell size": {
+			payload: `{"points": ` + points + `, "bandwidth": 500, "bbox": [9, 49, 11, 51]}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("cellSize can't be empty"), http.StatusBadRequest),
+		},
+		"service error": {
+			mockGrid: func(points []density.Point, kernel density.Kernel, bandwidth float64, b geojson.BBOX, cellSize float64, units string) (*density.Cells, error) {
+				return nil, errors.New("bandwidth must be a positive number")
+			},
+			payload: `{"points": ` + points + `, "bandwidth": -1, "bbox": [9, 49, 11, 51], "cellSize": 100}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("bandwidth must be a positive number"), http.StatusBadRequest),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/heatmap", strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockHeatmapRepository()
+			MockSvc.GetHeatmapGridFn = tt.mockGrid
+			h := NewHeatmapHandler(MockSvc)
+			_, err = h.heatmapRoute(tt.args.w, tt.args.r)
+			assert.Equal(t, tt.err, err, "heatmap() error = %v,expected = %v", err, tt.err)
+		})
+	}
+}
+
+func TestHeatmapTile(t *testing.T) {
+	MockSvc := mock.NewMockHeatmapRepository()
+	MockSvc.GetHeatmapTileFn = func(ctx context.Context, points []density.Point, kernel density.Kernel, bandwidth float64, tl tile.Tile, scale float64) (*image.NRGBA, error) {
+		if tl != (tile.Tile{Z: 12, X: 2161, Y: 1399}) || kernel != density.Gaussian || scale != 10 {
+			return nil, errors.New("unexpected arguments")
+		}
+		return density.Image([]float64{0, 5, 10, 20}, 2, 2, scale), nil
+	}
+	h := NewHeatmapHandler(MockSvc)
+	router := chi.NewRouter()
+	router.Post("/api/v1/heatmap/{z}/{x}/{y}.png", handle(h.heatmapTileRoute))
+
+	body := `{"points": {"type": "Point", "coordinates": [10, 50]}, "bandwidth": 500, "scale": 10}`
+	rec := httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("POST", "/api/v1/heatmap/12/2161/1399.png", strings.NewReader(body)))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "image/png", rec.Header().Get("Content-Type"))
+	img, err := png.Decode(bytes.NewReader(rec.Body.Bytes()))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 2, 2), img.Bounds())
+
+	rec = httptest.NewRecorder()
+	router.ServeHTTP(rec, httptest.NewRequest("POST", "/api/v1/heatmap/2/4/0.png", strings.NewReader(body)))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.Contains(t, rec.Body.String(), "x and y must be between 0 and 3 at zoom 2")
+}
@@ -1,7 +1,9 @@
 package http
 
 import (
+	"bufio"
 	"encoding/json"
+	"io"
 	"log"
 	"net/http"
 
@@ -10,17 +12,88 @@ import (
 	"github.com/go-chi/render"
 	"github.com/pkg/errors"
 
+	"github.com/tomchavakis/geo-api/internal/app/aggregate"
+	"github.com/tomchavakis/geo-api/internal/app/cell"
+	"github.com/tomchavakis/geo-api/internal/app/centre"
+	"github.com/tomchavakis/geo-api/internal/app/cluster"
+	"github.com/tomchavakis/geo-api/internal/app/extent"
+	"github.com/tomchavakis/geo-api/internal/app/geocode"
+	"github.com/tomchavakis/geo-api/internal/app/grid"
+	"github.com/tomchavakis/geo-api/internal/app/heatmap"
+	"github.com/tomchavakis/geo-api/internal/app/hull"
+	"github.com/tomchavakis/geo-api/internal/app/isochrone"
+	"github.com/tomchavakis/geo-api/internal/app/lineops"
+	"github.com/tomchavakis/geo-api/internal/app/mapmatch"
 	"github.com/tomchavakis/geo-api/internal/app/measurement"
+	"github.com/tomchavakis/geo-api/internal/app/optimize"
+	"github.com/tomchavakis/geo-api/internal/app/overlay"
+	"github.com/tomchavakis/geo-api/internal/app/pluscode"
+	"github.com/tomchavakis/geo-api/internal/app/routing"
+	"github.com/tomchavakis/geo-api/internal/app/simplify"
+	"github.com/tomchavakis/geo-api/internal/app/tilemath"
+	"github.com/tomchavakis/geo-api/internal/app/tiles"
+	"github.com/tomchavakis/geo-api/internal/app/track"
+	"github.com/tomchavakis/geo-api/internal/app/transform"
+	"github.com/tomchavakis/geo-api/internal/app/validate"
 )
 
+// Services groups the application services exposed by the API.
+type Services struct {
+	Measurement measurement.Service
+	Cell        cell.Service
+	PlusCode    pluscode.Service
+	Extent      extent.Service
+	Centre      centre.Service
+	Simplify    simplify.Service
+	Hull        hull.Service
+	Overlay     overlay.Service
+	Validate    validate.Service
+	Transform   transform.Service
+	LineOps     lineops.Service
+	Grid        grid.Service
+	Aggregate   aggregate.Service
+	Cluster     cluster.Service
+	Heatmap     heatmap.Service
+	Tiles       tiles.Service
+	TileMath    tilemath.Service
+	Track       track.Service
+	MapMatch    mapmatch.Service
+	Routing     routing.Service
+	Isochrone   isochrone.Service
+	Optimize    optimize.Service
+	Geocode     geocode.Service
+}
+
 // HTTP ...
 type HTTP struct {
 	Router *chi.Mux
 	s      *MeasurementHandler
+	cell   *CellHandler
+	pc     *PlusCodeHandler
+	ext    *ExtentHandler
+	centre *CentreHandler
+	simp   *SimplifyHandler
+	hull   *HullHandler
+	ovl    *OverlayHandler
+	valid  *ValidateHandler
+	trans  *TransformHandler
+	line   *LineOpsHandler
+	grid   *GridHandler
+	agg    *AggregateHandler
+	clust  *ClusterHandler
+	heat   *HeatmapHandler
+	tiles  *TilesHandler
+	tmath  *TileMathHandler
+	track  *TrackHandler
+	match  *MapMatchHandler
+	route  *RoutingHandler
+	iso    *IsochroneHandler
+	opt    *OptimizeHandler
+	geo    *GeocodeHandler
 }
 
 // New constructs a new HTTP
-func New(msrSvc measurement.Service) *HTTP {
+func New(svc Services) *HTTP {
 	r := chi.NewRouter()
 	r.Use(middleware.RequestID)
 	r.Use(middleware.RealIP)
@@ -31,7 +104,29 @@ func New(msrSvc measurement.Service) *HTTP {
 	r.Use(render.SetContentType(render.ContentTypeJSON))
 	return &HTTP{
 		Router: r,
-		s:      NewMeasurementHandler(msrSvc),
+		s:      NewMeasurementHandler(svc.Measurement),
+		cell:   NewCellHandler(svc.Cell),
+		pc:     NewPlusCodeHandler(svc.PlusCode),
+		ext:    NewExtentHandler(svc.Extent),
+		centre: NewCentreHandler(svc.Centre),
+		simp:   NewSimplifyHandler(svc.Simplify),
+		hull:   NewHullHandler(svc.Hull),
+		ovl:    NewOverlayHandler(svc.Overlay),
+		valid:  NewValidateHandler(svc.Validate),
+		trans:  NewTransformHandler(svc.Transform),
+		line:   NewLineOpsHandler(svc.LineOps),
+		grid:   NewGridHandler(svc.Grid),
+		agg:    NewAggregateHandler(svc.Aggregate),
+		clust:  NewClusterHandler(svc.Cluster),
+		heat:   NewHeatmapHandler(svc.Heatmap),
+		tiles:  NewTilesHandler(svc.Tiles),
+		tmath:  NewTileMathHandler(svc.TileMath),
+		track:  NewTrackHandler(svc.Track),
+		match:  NewMapMatchHandler(svc.MapMatch),
+		route:  NewRoutingHandler(svc.Routing),
+		iso:    NewIsochroneHandler(svc.Isochrone),
+		opt:    NewOptimizeHandler(svc.Optimize),
+		geo:    NewGeocodeHandler(svc.Geocode),
 	}
 }
 
@@ -47,10 +142,41 @@ func handle(fn func(w http.ResponseWriter, r *http.Request) (*Response, error))
 			_, _ = RespondError(w, status, err)
 			return
 		}
+		for k, v := range resp.Header {
+			w.Header().Set(k, v)
+		}
+		if s, ok := resp.Payload.(Streamer); ok {
+			RespondStream(w, resp.Status, s)
+			return
+		}
 		_, _ = Respond(w, resp.Status, resp.Payload)
 	}
 }
 
+// Streamer is a payload written to the client while it is produced, instead of being built in
+// memory first.
+type Streamer interface {
+	Stream(w io.Writer) error
+}
+
+// RespondStream streams a payload to the client, as JSON unless the content type is set already.
+// The status is sent before the payload is produced, so errors happening while streaming can only
+// be logged and end the response early.
+func RespondStream(w http.ResponseWriter, code int, s Streamer) {
+	if w.Header().Get("Content-Type") == "" {
+		w.Header().Set("Content-Type", "application/json")
+	}
+	w.WriteHeader(code)
+
+	bw := bufio.NewWriterSize(w, 32*1024)
+	if err := s.Stream(bw); err != nil {
+		log.Printf("error %v", err)
+	}
+	if err := bw.Flush(); err != nil {
+		log.Printf("error %v", err)
+	}
+}
+
 // RespondError sends an error response back to the client.
 func RespondError(w http.ResponseWriter, code int, err error) (int, error) {
 	if webErr, ok := errors.Cause(err).(*Error); ok {
@@ -0,0 +1,72 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/tomchavakis/geo-api/internal/app/hull"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// HullHandler struct
+type HullHandler struct {
+	hullSvc hull.Service
+}
+
+// NewHullHandler handler
+func NewHullHandler(hSvc hull.Service) *HullHandler {
+	hh := &HullHandler{
+		hullSvc: hSvc,
+	}
+	return hh
+}
+
+// HullMessage requests the convex hull of the points, or a concave hull when concave is set. The
+// concave hull takes either a maximum edge length in units or a concavity between 0 (the most
+// concave) and 1 (the convex hull).
+type HullMessage struct {
+	Points    []geometry.Point `json:"points"`
+	Concave   bool             `json:"concave"`
+	Concavity *float64         `json:"concavity,omitempty"`
+	MaxEdge   *float64         `json:"maxEdge,omitempty"`
+	Units     string           `json:"units"`
+}
+
+func (hh *HullHandler) hullRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	if r.Body == nil {
+		err := errors.New("invalid Body")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	var hm HullMessage
+	err := json.NewDecoder(r.Body).Decode(&hm)
+	if err != nil {
+		return nil, NewResponseError(errors.New("invalid input"), http.StatusBadRequest)
+	}
+
+	if len(hm.Points) == 0 {
+		err := errors.New("points can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	var g *geometry.Geometry
+	switch {
+	case !hm.Concave:
+		g, err = hh.hullSvc.GetConvexHull(hm.Points)
+	case hm.MaxEdge != nil && hm.Concavity != nil:
+		err := errors.New("only one of maxEdge or concavity can be set")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	case hm.MaxEdge != nil:
+		g, err = hh.hullSvc.GetConcaveHull(hm.Points, *hm.MaxEdge, hm.Units)
+	case hm.Concavity != nil:
+		g, err = hh.hullSvc.GetConcaveHullByConcavity(hm.Points, *hm.Concavity)
+	default:
+		err := errors.New("maxEdge or concavity is required for a concave hull")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(g, http.StatusOK), nil
+}
@@ -0,0 +1,112 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestHull(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	points := `[{"Lat": 0, "Lng": 0}, {"Lat": 0, "Lng": 2}, {"Lat": 1, "Lng": 1}, {"Lat": 2, "Lng": 2}]`
+	triangle := geom.PolygonGeometry([][]geometry.Point{{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 2}, {Lat: 2, Lng: 2}, {Lat: 0, Lng: 0}}})
+
+	tests := map[string]struct {
+		mockGetConvexHull             func(points []geometry.Point) (*geometry.Geometry, error)
+		mockGetConcaveHull            func(points []geometry.Point, maxEdge float64, units string) (*geometry.Geometry, error)
+		mockGetConcaveHullByConcavity func(points []geometry.Point, concavity float64) (*geometry.Geometry, error)
+		want                          *Response
+		payload                       string
+		wantErr                       bool
+		err                           error
+		args                          args
+	}{
+		"invalid input": {
+			want:    nil,
+			payload: `{"points": "a"}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("invalid input"), http.StatusBadRequest),
+		},
+		"empty points": {
+			want:    nil,
+			payload: `{"points": []}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("points can't be empty"), http.StatusBadRequest),
+		},
+		"concave without parameters": {
+			want:    nil,
+			payload: `{"concave": true, "points": ` + points + `}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("maxEdge or concavity is required for a concave hull"), http.StatusBadRequest),
+		},
+		"concave with both parameters": {
+			want:    nil,
+			payload: `{"concave": true, "maxEdge": 1, "concavity": 0.5, "points": ` + points + `}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("only one of maxEdge or concavity can be set"), http.StatusBadRequest),
+		},
+		"concavity error": {
+			want: nil,
+			mockGetConcaveHullByConcavity: func(points []geometry.Point, concavity float64) (*geometry.Geometry, error) {
+				return nil, errors.New("concavity must be between 0 and 1")
+			},
+			payload: `{"concave": true, "concavity": 2, "points": ` + points + `}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("concavity must be between 0 and 1"), http.StatusBadRequest),
+		},
+		"convex": {
+			want: NewResponse(&triangle, http.StatusOK),
+			mockGetConvexHull: func(points []geometry.Point) (*geometry.Geometry, error) {
+				if len(points) != 4 {
+					return nil, errors.New("unexpected arguments")
+				}
+				return &triangle, nil
+			},
+			payload: `{"points": ` + points + `}`,
+			wantErr: false,
+			err:     nil,
+		},
+		"concave by max edge": {
+			want: NewResponse(&triangle, http.StatusOK),
+			mockGetConcaveHull: func(points []geometry.Point, maxEdge float64, units string) (*geometry.Geometry, error) {
+				if maxEdge != 500 || units != "meters" {
+					return nil, errors.New("unexpected arguments")
+				}
+				return &triangle, nil
+			},
+			payload: `{"concave": true, "maxEdge": 500, "units": "meters", "points": ` + points + `}`,
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/hull", strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockHullRepository()
+			MockSvc.GetConvexHullFn = tt.mockGetConvexHull
+			MockSvc.GetConcaveHullFn = tt.mockGetConcaveHull
+			MockSvc.GetConcaveHullByConcavityFn = tt.mockGetConcaveHullByConcavity
+			h := NewHullHandler(MockSvc)
+			got, err := h.hullRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "hull() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "hull() got = %v, want %v", got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/tomchavakis/geo-api/internal/app/isochrone"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/isochrone"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// IsochroneHandler struct
+type IsochroneHandler struct {
+	isochroneSvc isochrone.Service
+}
+
+// NewIsochroneHandler handler
+func NewIsochroneHandler(iSvc isochrone.Service) *IsochroneHandler {
+	ih := &IsochroneHandler{
+		isochroneSvc: iSvc,
+	}
+	return ih
+}
+
+// isochroneRoute returns the areas reached from lat, lon over the road network within the comma
+// separated travel times in minutes, or within the travel distances in meters when meters is set
+// instead, for the car, bike or foot profile. The areas are Polygon or MultiPolygon features with
+// their minutes or meters and the profile as properties, the largest first so that they draw
+// nested in each other.
+func (ih *IsochroneHandler) isochroneRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	lat, lon, err := getLatLon(r, "lat", "lon")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	profile, err := getProfile(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	minutes, err := getFloats(r, "minutes")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	meters, err := getFloats(r, "meters")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if (minutes == nil) == (meters == nil) {
+		return nil, NewResponseError(errors.New("either minutes or meters must be set"), http.StatusBadRequest)
+	}
+
+	origin := geometry.Point{Lat: *lat, Lng: *lon}
+	var cs []algo.Contour
+	name, scale := "meters", 1.0
+	if minutes != nil {
+		name, scale = "minutes", 60
+		seconds := make([]float64, len(minutes))
+		for i, m := range minutes {
+			seconds[i] = m * 60
+		}
+		cs, err = ih.isochroneSvc.GetIsochrones(origin, profile, seconds)
+	} else {
+		cs, err = ih.isochroneSvc.GetIsodistances(origin, profile, meters)
+	}
+	if err != nil {
+		return nil, roadsError(err)
+	}
+
+	fs := make([]feature.Feature, 0, len(cs))
+	for i := len(cs) - 1; i >= 0; i-- {
+		c := cs[i]
+		g := geom.MultiPolygonGeometry(c.Polygons)
+		if len(c.Polygons) == 1 {
+			g = geom.PolygonGeometry(c.Polygons[0])
+		}
+		fs = append(fs, geom.NewFeature(g, map[string]interface{}{
+			name:      c.Value / scale,
+			"profile": profile.Name,
+		}))
+	}
+	return NewResponse(geom.NewFeatureCollection(fs), http.StatusOK), nil
+}
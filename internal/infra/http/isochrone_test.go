@@ -0,0 +1,133 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/isochrone"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestIsochrone(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	square := func(d float64) [][]geometry.Point {
+		return [][]geometry.Point{{{Lng: -d, Lat: -d}, {Lng: d, Lat: -d}, {Lng: d, Lat: d}, {Lng: -d, Lat: d}, {Lng: -d, Lat: -d}}}
+	}
+	contours := func(values []float64) []algo.Contour {
+		var res []algo.Contour
+		for i, v := range values {
+			c := algo.Contour{Value: v, Polygons: [][][]geometry.Point{square(float64(i + 1))}}
+			if i == 1 {
+				c.Polygons = append(c.Polygons, square(0.1))
+			}
+			res = append(res, c)
+		}
+		return res
+	}
+
+	tests := map[string]struct {
+		mockIsochrones   func(origin geometry.Point, profile *roads.Profile, seconds []float64) ([]algo.Contour, error)
+		mockIsodistances func(origin geometry.Point, profile *roads.Profile, meters []float64) ([]algo.Contour, error)
+		query            string
+		property         string
+		values           []float64
+		wantErr          bool
+		err              error
+		args             args
+	}{
+		"minutes": {
+			mockIsochrones: func(origin geometry.Point, profile *roads.Profile, seconds []float64) ([]algo.Contour, error) {
+				if origin != (geometry.Point{Lat: 37.9, Lng: 23.7}) || profile != roads.Bike {
+					return nil, errors.New("unexpected request")
+				}
+				return contours(seconds), nil
+			},
+			query:    "?lat=37.9&lon=23.7&minutes=5,10&profile=bike",
+			property: "minutes",
+			values:   []float64{10, 5},
+		},
+		"meters": {
+			mockIsodistances: func(origin geometry.Point, profile *roads.Profile, meters []float64) ([]algo.Contour, error) {
+				if profile != roads.Car {
+					return nil, errors.New("unexpected profile")
+				}
+				return contours(meters), nil
+			},
+			query:    "?lat=37.9&lon=23.7&meters=500,1000",
+			property: "meters",
+			values:   []float64{1000, 500},
+		},
+		"missing contours": {
+			query:   "?lat=37.9&lon=23.7",
+			wantErr: true,
+			err:     NewResponseError(errors.New("either minutes or meters must be set"), http.StatusBadRequest),
+		},
+		"both contours": {
+			query:   "?lat=37.9&lon=23.7&minutes=5&meters=500",
+			wantErr: true,
+			err:     NewResponseError(errors.New("either minutes or meters must be set"), http.StatusBadRequest),
+		},
+		"invalid minutes": {
+			query:   "?lat=37.9&lon=23.7&minutes=5,ten",
+			wantErr: true,
+			err:     NewResponseError(errors.New("invalid minutes"), http.StatusBadRequest),
+		},
+		"missing origin": {
+			query:   "?minutes=5",
+			wantErr: true,
+			err:     NewResponseError(errors.New("lat can't be empty"), http.StatusBadRequest),
+		},
+		"service error": {
+			mockIsochrones: func(origin geometry.Point, profile *roads.Profile, seconds []float64) ([]algo.Contour, error) {
+				return nil, errors.New("the isochrones can't be longer than 2 hours")
+			},
+			query:   "?lat=37.9&lon=23.7&minutes=180",
+			wantErr: true,
+			err:     NewResponseError(errors.New("the isochrones can't be longer than 2 hours"), http.StatusBadRequest),
+		},
+		"no network": {
+			mockIsochrones: func(origin geometry.Point, profile *roads.Profile, seconds []float64) ([]algo.Contour, error) {
+				return nil, roads.ErrNoNetwork
+			},
+			query:   "?lat=37.9&lon=23.7&minutes=5",
+			wantErr: true,
+			err:     NewResponseError(roads.ErrNoNetwork, http.StatusServiceUnavailable),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/api/v1/isochrone"+tt.query, nil)
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockIsochroneRepository()
+			MockSvc.GetIsochronesFn = tt.mockIsochrones
+			MockSvc.GetIsodistancesFn = tt.mockIsodistances
+			h := NewIsochroneHandler(MockSvc)
+			got, err := h.isochroneRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "isochrone() error = %v,expected = %v", err, tt.err)
+				return
+			}
+
+			fc := got.Payload.(feature.Collection)
+			assert.Len(t, fc.Features, 2)
+			for i, f := range fc.Features {
+				assert.Equal(t, tt.values[i], f.Properties[tt.property])
+			}
+			assert.Equal(t, geojson.MultiPolygon, fc.Features[0].Geometry.GeoJSONType)
+			assert.Equal(t, geojson.Polygon, fc.Features[1].Geometry.GeoJSONType)
+		})
+	}
+}
@@ -0,0 +1,249 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/tomchavakis/geo-api/internal/app/lineops"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// LineOpsHandler struct
+type LineOpsHandler struct {
+	lineOpsSvc lineops.Service
+}
+
+// NewLineOpsHandler handler
+func NewLineOpsHandler(lSvc lineops.Service) *LineOpsHandler {
+	lh := &LineOpsHandler{
+		lineOpsSvc: lSvc,
+	}
+	return lh
+}
+
+// SplitMessage holds a LineString and the Point, MultiPoint or LineString splitting it, as any GeoJSON
+// object.
+type SplitMessage struct {
+	Line     json.RawMessage `json:"line"`
+	Splitter json.RawMessage `json:"splitter"`
+}
+
+// SliceMessage holds a LineString sliced either between the positions closest to two points or
+// between two distances in units from its start.
+type SliceMessage struct {
+	Line          json.RawMessage `json:"line"`
+	Start         *geometry.Point `json:"start,omitempty"`
+	Stop          *geometry.Point `json:"stop,omitempty"`
+	StartDistance *float64        `json:"startDistance,omitempty"`
+	StopDistance  *float64        `json:"stopDistance,omitempty"`
+	Units         string          `json:"units"`
+}
+
+// OffsetMessage holds a LineString offset by a distance in units, on its right for a positive
+// distance and on its left for a negative one.
+type OffsetMessage struct {
+	Line     json.RawMessage `json:"line"`
+	Distance *float64        `json:"distance,omitempty"`
+	Units    string          `json:"units"`
+}
+
+// ChunkMessage holds a LineString cut into pieces of a length in units.
+type ChunkMessage struct {
+	Line   json.RawMessage `json:"line"`
+	Length *float64        `json:"length,omitempty"`
+	Units  string          `json:"units"`
+}
+
+// LineIntersectMessage holds the two LineStrings to intersect.
+type LineIntersectMessage struct {
+	Line  json.RawMessage `json:"line"`
+	Other json.RawMessage `json:"other"`
+}
+
+func (lh *LineOpsHandler) splitRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	var sm SplitMessage
+	if err := decodeBody(r, &sm); err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	line, err := decodeLine(sm.Line, "line")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if len(sm.Splitter) == 0 {
+		err := errors.New("splitter can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	gs, err := geom.DecodeGeometries(sm.Splitter)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if len(gs) != 1 {
+		err := errors.New("splitter must be a single geometry")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	var parts [][]geometry.Point
+	switch gs[0].GeoJSONType {
+	case geojson.Point, geojson.MultiPoint:
+		ps, err := geom.Coords(gs[0])
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		parts, err = lh.lineOpsSvc.SplitLine(line, ps)
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+	case geojson.LineString:
+		splitter, err := geom.Line(gs[0])
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		parts, err = lh.lineOpsSvc.SplitLineByLine(line, splitter)
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+	default:
+		err := errors.New("splitter must be a Point, MultiPoint or LineString")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(linesCollection(parts), http.StatusOK), nil
+}
+
+func (lh *LineOpsHandler) sliceRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	var sm SliceMessage
+	if err := decodeBody(r, &sm); err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	line, err := decodeLine(sm.Line, "line")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	byPoints := sm.Start != nil && sm.Stop != nil
+	byDistances := sm.StartDistance != nil && sm.StopDistance != nil
+	var res []geometry.Point
+	switch {
+	case byPoints && byDistances:
+		err := errors.New("only one of start and stop or startDistance and stopDistance can be set")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	case byPoints:
+		res, err = lh.lineOpsSvc.SliceLine(line, *sm.Start, *sm.Stop)
+	case byDistances:
+		res, err = lh.lineOpsSvc.SliceLineAlong(line, *sm.StartDistance, *sm.StopDistance, sm.Units)
+	default:
+		err := errors.New("start and stop or startDistance and stopDistance are required")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	g := geom.LineStringGeometry(res)
+	return NewResponse(&g, http.StatusOK), nil
+}
+
+func (lh *LineOpsHandler) offsetRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	var om OffsetMessage
+	if err := decodeBody(r, &om); err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	line, err := decodeLine(om.Line, "line")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if om.Distance == nil {
+		err := errors.New("distance can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	res, err := lh.lineOpsSvc.OffsetLine(line, *om.Distance, om.Units)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	g := geom.LineStringGeometry(res)
+	return NewResponse(&g, http.StatusOK), nil
+}
+
+func (lh *LineOpsHandler) chunkRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	var cm ChunkMessage
+	if err := decodeBody(r, &cm); err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	line, err := decodeLine(cm.Line, "line")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if cm.Length == nil {
+		err := errors.New("length can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	chunks, err := lh.lineOpsSvc.ChunkLine(line, *cm.Length, cm.Units)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(linesCollection(chunks), http.StatusOK), nil
+}
+
+func (lh *LineOpsHandler) intersectRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	var im LineIntersectMessage
+	if err := decodeBody(r, &im); err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	a, err := decodeLine(im.Line, "line")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	b, err := decodeLine(im.Other, "other")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	ps, err := lh.lineOpsSvc.GetLineIntersections(a, b)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	fs := make([]feature.Feature, 0, len(ps))
+	for _, p := range ps {
+		fs = append(fs, geom.NewFeature(geom.PointGeometry(p), nil))
+	}
+	return NewResponse(geom.NewFeatureCollection(fs), http.StatusOK), nil
+}
+
+// decodeLine returns the positions of a GeoJSON object holding a single LineString.
+func decodeLine(data json.RawMessage, name string) ([]geometry.Point, error) {
+	if len(data) == 0 {
+		return nil, errors.New(name + " can't be empty")
+	}
+	gs, err := geom.DecodeGeometries(data)
+	if err != nil {
+		return nil, err
+	}
+	if len(gs) != 1 || gs[0].GeoJSONType != geojson.LineString {
+		return nil, errors.New(name + " must be a single LineString")
+	}
+
+	return geom.Line(gs[0])
+}
+
+// linesCollection returns the lines as a FeatureCollection of LineStrings with their index.
+func linesCollection(ls [][]geometry.Point) feature.Collection {
+	fs := make([]feature.Feature, 0, len(ls))
+	for i, l := range ls {
+		fs = append(fs, geom.NewFeature(geom.LineStringGeometry(l), map[string]interface{}{"index": i}))
+	}
+	return geom.NewFeatureCollection(fs)
+}
@@ -0,0 +1,156 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestLineSplit(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	line := `{"type": "LineString", "coordinates": [[0, 0], [2, 0]]}`
+	parts := [][]geometry.Point{{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}}, {{Lat: 0, Lng: 1}, {Lat: 0, Lng: 2}}}
+
+	tests := map[string]struct {
+		mockSplit       func(line, points []geometry.Point) ([][]geometry.Point, error)
+		mockSplitByLine func(line, splitter []geometry.Point) ([][]geometry.Point, error)
+		want            *Response
+		payload         string
+		wantErr         bool
+		err             error
+		args            args
+	}{
+		"empty line": {
+			want:    nil,
+			payload: `{"splitter": {"type": "Point", "coordinates": [1, 0]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("line can't be empty"), http.StatusBadRequest),
+		},
+		"not a line": {
+			want:    nil,
+			payload: `{"line": {"type": "Point", "coordinates": [1, 0]}, "splitter": {"type": "Point", "coordinates": [1, 0]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("line must be a single LineString"), http.StatusBadRequest),
+		},
+		"invalid splitter": {
+			want:    nil,
+			payload: `{"line": ` + line + `, "splitter": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("splitter must be a Point, MultiPoint or LineString"), http.StatusBadRequest),
+		},
+		"split by point": {
+			want: NewResponse(linesCollection(parts), http.StatusOK),
+			mockSplit: func(line, points []geometry.Point) ([][]geometry.Point, error) {
+				if len(line) != 2 || len(points) != 1 || points[0] != (geometry.Point{Lat: 0, Lng: 1}) {
+					return nil, errors.New("unexpected arguments")
+				}
+				return parts, nil
+			},
+			payload: `{"line": ` + line + `, "splitter": {"type": "Point", "coordinates": [1, 0]}}`,
+			wantErr: false,
+			err:     nil,
+		},
+		"split by line": {
+			want: NewResponse(linesCollection(parts), http.StatusOK),
+			mockSplitByLine: func(line, splitter []geometry.Point) ([][]geometry.Point, error) {
+				return parts, nil
+			},
+			payload: `{"line": ` + line + `, "splitter": {"type": "LineString", "coordinates": [[1, -1], [1, 1]]}}`,
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/line/split", strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockLineOpsRepository()
+			MockSvc.SplitLineFn = tt.mockSplit
+			MockSvc.SplitLineByLineFn = tt.mockSplitByLine
+			h := NewLineOpsHandler(MockSvc)
+			got, err := h.splitRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "split() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "split() got = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestLineChunk(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	line := `{"type": "Feature", "properties": {}, "geometry": {"type": "LineString", "coordinates": [[0, 0], [0.002, 0]]}}`
+	chunks := [][]geometry.Point{{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 0.0009}}, {{Lat: 0, Lng: 0.0009}, {Lat: 0, Lng: 0.0018}}, {{Lat: 0, Lng: 0.0018}, {Lat: 0, Lng: 0.002}}}
+
+	tests := map[string]struct {
+		mockChunk func(line []geometry.Point, length float64, units string) ([][]geometry.Point, error)
+		want      *Response
+		payload   string
+		wantErr   bool
+		err       error
+		args      args
+	}{
+		"empty length": {
+			want:    nil,
+			payload: `{"line": ` + line + `}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("length can't be empty"), http.StatusBadRequest),
+		},
+		"service error": {
+			want: nil,
+			mockChunk: func(line []geometry.Point, length float64, units string) ([][]geometry.Point, error) {
+				return nil, errors.New("length must be a positive number")
+			},
+			payload: `{"line": ` + line + `, "length": -100, "units": "meters"}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("length must be a positive number"), http.StatusBadRequest),
+		},
+		"happy path": {
+			want: NewResponse(linesCollection(chunks), http.StatusOK),
+			mockChunk: func(line []geometry.Point, length float64, units string) ([][]geometry.Point, error) {
+				if length != 100 || units != "meters" {
+					return nil, errors.New("unexpected arguments")
+				}
+				return chunks, nil
+			},
+			payload: `{"line": ` + line + `, "length": 100, "units": "meters"}`,
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/line/chunk", strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockLineOpsRepository()
+			MockSvc.ChunkLineFn = tt.mockChunk
+			h := NewLineOpsHandler(MockSvc)
+			got, err := h.chunkRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "chunk() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "chunk() got = %v, want %v", got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,121 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/tomchavakis/geo-api/internal/app/mapmatch"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/mapmatch"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+const (
+	// defaultMatchRadius is the distance in meters within which the roads of a point are searched
+	// when the request doesn't set one.
+	defaultMatchRadius = 50
+	// defaultMatchSigma is the standard deviation of the GPS positions in meters used when the
+	// request doesn't set one.
+	defaultMatchSigma = 5
+	// defaultMatchBeta is the scale in meters of the difference between the route and the distance
+	// between two points used when the request doesn't set one.
+	defaultMatchBeta = 3
+	// defaultMatchCandidates is the number of roads kept for each point when the request doesn't
+	// set one.
+	defaultMatchCandidates = 5
+)
+
+// MapMatchHandler struct
+type MapMatchHandler struct {
+	mapMatchSvc mapmatch.Service
+}
+
+// NewMapMatchHandler handler
+func NewMapMatchHandler(mSvc mapmatch.Service) *MapMatchHandler {
+	mh := &MapMatchHandler{
+		mapMatchSvc: mSvc,
+	}
+	return mh
+}
+
+// MapMatchResponse holds the matched path of a trace, a LineString or a MultiLineString when the
+// trace had to be split, the IDs of the road edges travelled in order, the points snapped to the
+// roads and the indexes of the points too far from any road.
+type MapMatchResponse struct {
+	Geometry  geometry.Geometry  `json:"geometry"`
+	Edges     []string           `json:"edges"`
+	Points    feature.Collection `json:"points"`
+	Unmatched []int              `json:"unmatched"`
+}
+
+// mapMatchRoute snaps the trace sent as the body, a LineString or a MultiPoint, to the most likely
+// path over the road network. The roads are searched within radius meters of each point, the
+// nearest candidates kept, the GPS positions being off by sigma meters and beta scaling how far the
+// length of the routes between points may differ from the distance between them.
+func (mh *MapMatchHandler) mapMatchRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	var opts algo.Options
+	params := []struct {
+		name       string
+		v          *float64
+		defaultVal float64
+	}{
+		{name: "radius", v: &opts.Radius, defaultVal: defaultMatchRadius},
+		{name: "sigma", v: &opts.Sigma, defaultVal: defaultMatchSigma},
+		{name: "beta", v: &opts.Beta, defaultVal: defaultMatchBeta},
+	}
+	for _, p := range params {
+		v, err := getFloat(r, p.name, p.defaultVal)
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		*p.v = v
+	}
+	k, err := getInt(r, "candidates", defaultMatchCandidates)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	opts.Candidates = k
+
+	gs, err := decodeGeometries(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if len(gs) != 1 || (gs[0].GeoJSONType != geojson.LineString && gs[0].GeoJSONType != geojson.MultiPoint) {
+		return nil, NewResponseError(errors.New("the trace must be a single LineString or MultiPoint"), http.StatusBadRequest)
+	}
+	trace, err := geom.Coords(gs[0])
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	res, err := mh.mapMatchSvc.MatchTrace(trace, opts)
+	if err != nil {
+		return nil, roadsError(err)
+	}
+
+	g := geom.MultiLineStringGeometry(res.Lines)
+	if len(res.Lines) == 1 {
+		g = geom.LineStringGeometry(res.Lines[0])
+	}
+	points := make([]feature.Feature, 0, len(res.Points))
+	for _, p := range res.Points {
+		points = append(points, geom.NewFeature(geom.PointGeometry(p.Point), map[string]interface{}{
+			"index":    p.Index,
+			"edge":     p.Edge,
+			"distance": p.Distance,
+		}))
+	}
+	unmatched := res.Unmatched
+	if unmatched == nil {
+		unmatched = []int{}
+	}
+
+	return NewResponse(MapMatchResponse{
+		Geometry:  g,
+		Edges:     res.Edges,
+		Points:    geom.NewFeatureCollection(points),
+		Unmatched: unmatched,
+	}, http.StatusOK), nil
+}
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/mapmatch"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestMapMatch(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	network, err := roads.Build([]roads.Way{
+		{ID: "a", Points: []geometry.Point{{Lng: 0, Lat: 0}, {Lng: 0.002, Lat: 0}}},
+		{ID: "b", Points: []geometry.Point{{Lng: 0.003, Lat: 0}, {Lng: 0.005, Lat: 0}}},
+	})
+	assert.NoError(t, err)
+	match := func(trace []geometry.Point, opts algo.Options) (*algo.Result, error) {
+		return algo.Match(network, trace, opts)
+	}
+	trace := `{"type": "LineString", "coordinates": [[0.0005, 0.0001], [0.0015, 0], [0.0035, 0], [0.0045, 0], [0.0045, 0.01]]}`
+
+	tests := map[string]struct {
+		mockMatch func(trace []geometry.Point, opts algo.Options) (*algo.Result, error)
+		query     string
+		payload   string
+		lines     int
+		wantErr   bool
+		err       error
+		args      args
+	}{
+		"line string": {
+			mockMatch: func(trace []geometry.Point, opts algo.Options) (*algo.Result, error) {
+				if opts != (algo.Options{Radius: 50, Sigma: 5, Beta: 3, Candidates: 5}) {
+					return nil, errors.New("unexpected options")
+				}
+				return match(trace, opts)
+			},
+			payload: trace,
+			lines:   2,
+		},
+		"multi point with options": {
+			mockMatch: func(trace []geometry.Point, opts algo.Options) (*algo.Result, error) {
+				if opts != (algo.Options{Radius: 20, Sigma: 4, Beta: 2, Candidates: 3}) {
+					return nil, errors.New("unexpected options")
+				}
+				return match(trace, opts)
+			},
+			query:   "?radius=20&sigma=4&beta=2&candidates=3",
+			payload: `{"type": "Feature", "properties": {}, "geometry": {"type": "MultiPoint", "coordinates": [[0.0005, 0.0001], [0.0015, 0]]}}`,
+			lines:   1,
+		},
+		"invalid radius": {
+			query:   "?radius=far",
+			payload: trace,
+			wantErr: true,
+			err:     NewResponseError(errors.New("invalid radius"), http.StatusBadRequest),
+		},
+		"invalid candidates": {
+			query:   "?candidates=1.5",
+			payload: trace,
+			wantErr: true,
+			err:     NewResponseError(errors.New("invalid candidates"), http.StatusBadRequest),
+		},
+		"polygon": {
+			payload: `{"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 0]]]}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("the trace must be a single LineString or MultiPoint"), http.StatusBadRequest),
+		},
+		"no network": {
+			mockMatch: func(trace []geometry.Point, opts algo.Options) (*algo.Result, error) {
+				return nil, roads.ErrNoNetwork
+			},
+			payload: trace,
+			wantErr: true,
+			err:     NewResponseError(roads.ErrNoNetwork, http.StatusServiceUnavailable),
+		},
+		"service error": {
+			mockMatch: match,
+			payload:   `{"type": "LineString", "coordinates": [[1, 1], [1.001, 1]]}`,
+			wantErr:   true,
+			err:       NewResponseError(errors.New("no point of the trace is near a road"), http.StatusBadRequest),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/mapmatch"+tt.query, strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockMapMatchRepository()
+			MockSvc.MatchTraceFn = tt.mockMatch
+			h := NewMapMatchHandler(MockSvc)
+			got, err := h.mapMatchRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "mapMatch() error = %v,expected = %v", err, tt.err)
+				return
+			}
+
+			res := got.Payload.(MapMatchResponse)
+			if tt.lines == 1 {
+				assert.Equal(t, geojson.LineString, res.Geometry.GeoJSONType)
+				assert.Equal(t, []string{"a:0"}, res.Edges)
+				assert.Empty(t, res.Unmatched)
+				return
+			}
+			assert.Equal(t, geojson.MultiLineString, res.Geometry.GeoJSONType)
+			assert.Equal(t, []string{"a:0", "b:0"}, res.Edges)
+			assert.Equal(t, []int{4}, res.Unmatched)
+			assert.Len(t, res.Points.Features, 4)
+			assert.Equal(t, "b:0", res.Points.Features[2].Properties["edge"])
+			assert.InDelta(t, 11.1, res.Points.Features[0].Properties["distance"], 0.1)
+		})
+	}
+}
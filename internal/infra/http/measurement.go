@@ -4,10 +4,12 @@ import (
 	"encoding/json"
 	"errors"
 	"log"
+	"math"
 	"net/http"
 	"strconv"
 
 	"github.com/tomchavakis/geo-api/internal/app/measurement"
+	"github.com/tomchavakis/geo-api/internal/spatial/coord"
 	"github.com/tomchavakis/geojson/geometry"
 )
 
@@ -35,13 +37,13 @@ func (sh *MeasurementHandler) distanceRoute(w http.ResponseWriter, r *http.Reque
 	latA, lonA, err := getLatLon(r, "latA", "lonA")
 
 	if err != nil {
-		return nil, NewResponseError(errors.New("invalid input"), http.StatusBadRequest)
+		return nil, NewResponseError(err, http.StatusBadRequest)
 	}
 
 	latB, lonB, err := getLatLon(r, "latB", "lonB")
 
 	if err != nil {
-		return nil, NewResponseError(errors.New("invalid input"), http.StatusBadRequest)
+		return nil, NewResponseError(err, http.StatusBadRequest)
 	}
 
 	p1 := geometry.Point{
@@ -67,13 +69,13 @@ func (sh *MeasurementHandler) bearingRoute(w http.ResponseWriter, r *http.Reques
 	latA, lonA, err := getLatLon(r, "latA", "lonA")
 
 	if err != nil {
-		return nil, NewResponseError(errors.New("invalid input"), http.StatusBadRequest)
+		return nil, NewResponseError(err, http.StatusBadRequest)
 	}
 
 	latB, lonB, err := getLatLon(r, "latB", "lonB")
 
 	if err != nil {
-		return nil, NewResponseError(errors.New("invalid input"), http.StatusBadRequest)
+		return nil, NewResponseError(err, http.StatusBadRequest)
 	}
 
 	p1 := geometry.Point{
@@ -100,13 +102,13 @@ func (sh *MeasurementHandler) midpointRoute(w http.ResponseWriter, r *http.Reque
 	latA, lonA, err := getLatLon(r, "latA", "lonA")
 
 	if err != nil {
-		return nil, NewResponseError(errors.New("invalid input"), http.StatusBadRequest)
+		return nil, NewResponseError(err, http.StatusBadRequest)
 	}
 
 	latB, lonB, err := getLatLon(r, "latB", "lonB")
 
 	if err != nil {
-		return nil, NewResponseError(errors.New("invalid input"), http.StatusBadRequest)
+		return nil, NewResponseError(err, http.StatusBadRequest)
 	}
 
 	p1 := geometry.Point{
@@ -119,8 +121,17 @@ func (sh *MeasurementHandler) midpointRoute(w http.ResponseWriter, r *http.Reque
 		Lng: *lonB,
 	}
 
+	format, err := getFormat(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
 	midpoint := sh.measurementSvc.GetMidPoint(p1, p2)
 
+	if format != coord.Decimal {
+		return NewResponse(formatPoint(*midpoint, format), http.StatusOK), nil
+	}
+
 	return NewResponse(*midpoint, http.StatusOK), nil
 }
 
@@ -145,12 +156,21 @@ func (sh *MeasurementHandler) nearestPointRoute(w http.ResponseWriter, r *http.R
 		return nil, NewResponseError(err, http.StatusBadRequest)
 	}
 
+	format, err := getFormat(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
 	nearestPoint, err := sh.measurementSvc.GetNearestPoint(*np.ReferencePoint, np.Points, np.Units)
 
 	if err != nil {
 		return nil, NewResponseError(err, http.StatusBadRequest)
 	}
 
+	if format != coord.Decimal && nearestPoint != nil {
+		return NewResponse(formatPoint(*nearestPoint, format), http.StatusOK), nil
+	}
+
 	return NewResponse(nearestPoint, http.StatusOK), nil
 }
 
@@ -158,7 +178,7 @@ func (sh *MeasurementHandler) destinationRoute(w http.ResponseWriter, r *http.Re
 	lat, lon, err := getLatLon(r, "lat", "lon")
 
 	if err != nil {
-		return nil, NewResponseError(errors.New("invalid input"), http.StatusBadRequest)
+		return nil, NewResponseError(err, http.StatusBadRequest)
 	}
 
 	p := geometry.Point{
@@ -172,7 +192,7 @@ func (sh *MeasurementHandler) destinationRoute(w http.ResponseWriter, r *http.Re
 	}
 	distance, err := strconv.ParseFloat(d, 64)
 
-	if err != nil {
+	if err != nil || math.IsNaN(distance) || math.IsInf(distance, 0) {
 		return nil, NewResponseError(errors.New("invalid distance"), http.StatusBadRequest)
 	}
 
@@ -182,32 +202,26 @@ func (sh *MeasurementHandler) destinationRoute(w http.ResponseWriter, r *http.Re
 	}
 	bearing, err := strconv.ParseFloat(b, 64)
 
-	if err != nil {
+	if err != nil || math.IsNaN(bearing) || math.IsInf(bearing, 0) {
 		return nil, NewResponseError(errors.New("invalid bearing"), http.StatusBadRequest)
 	}
 
 	units := r.URL.Query().Get("units")
 
+	format, err := getFormat(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
 	dp, err := sh.measurementSvc.GetDestination(p, distance, bearing, units)
 	if err != nil {
 		log.Printf("error %v", err)
 		return nil, NewResponseError(errors.New(err.Error()), http.StatusInternalServerError)
 	}
 
-	return NewResponse(dp, http.StatusOK), nil
-}
-
-func getLatLon(r *http.Request, lat, lon string) (*float64, *float64, error) {
-	lat0 := r.URL.Query().Get(lat)
-	latA, err := strconv.ParseFloat(lat0, 64)
-	if err != nil {
-		return nil, nil, errors.New("invalid point")
-	}
-	lon0 := r.URL.Query().Get(lon)
-	lonA, err := strconv.ParseFloat(lon0, 64)
-	if err != nil {
-		return nil, nil, errors.New("invalid point")
+	if format != coord.Decimal && dp != nil {
+		return NewResponse(formatPoint(*dp, format), http.StatusOK), nil
 	}
 
-	return &latA, &lonA, nil
+	return NewResponse(dp, http.StatusOK), nil
 }
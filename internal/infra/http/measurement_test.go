@@ -34,7 +34,7 @@ func TestDistance(t *testing.T) {
 			},
 			request: "/api/v1/distance?latA=a&lonA=34.44&latB=23.44&lonB=34.42",
 			wantErr: true,
-			err:     NewResponseError(errors.New("invalid input"), http.StatusBadRequest),
+			err:     NewResponseError(errors.New(`invalid latA: "a" is not a valid coordinate`), http.StatusBadRequest),
 			args: args{
 				w: nil,
 				r: nil,
@@ -47,7 +47,49 @@ func TestDistance(t *testing.T) {
 			},
 			request: "/api/v1/distance?latA=23.33&lonA=34.44&latB=b&lonB=34.42",
 			wantErr: true,
-			err:     NewResponseError(errors.New("invalid input"), http.StatusBadRequest),
+			err:     NewResponseError(errors.New(`invalid latB: "b" is not a valid coordinate`), http.StatusBadRequest),
+			args: args{
+				w: nil,
+				r: nil,
+			},
+		},
+		"latitude out of range": {
+			want: nil,
+			mockGetDistance: func(x, y geometry.Point) (*float64, error) {
+				return common.Float64Ptr(10.0), nil
+			},
+			request: "/api/v1/distance?latA=95&lonA=34.44&latB=23.44&lonB=34.42",
+			wantErr: true,
+			err:     NewResponseError(errors.New("invalid latA: 95 is out of range [-90, 90]"), http.StatusBadRequest),
+			args: args{
+				w: nil,
+				r: nil,
+			},
+		},
+		"not finite longitude": {
+			want: nil,
+			mockGetDistance: func(x, y geometry.Point) (*float64, error) {
+				return common.Float64Ptr(10.0), nil
+			},
+			request: "/api/v1/distance?latA=23.33&lonA=34.44&latB=23.44&lonB=NaN",
+			wantErr: true,
+			err:     NewResponseError(errors.New(`invalid lonB: "NaN" is not a finite number`), http.StatusBadRequest),
+			args: args{
+				w: nil,
+				r: nil,
+			},
+		},
+		"degrees minutes seconds": {
+			want: NewResponse(common.Float64Ptr(10.0), http.StatusOK),
+			mockGetDistance: func(x, y geometry.Point) (*float64, error) {
+				if x.Lat != 23.5 || x.Lng != -34.25 {
+					return nil, errors.New("unexpected point")
+				}
+				return common.Float64Ptr(10.0), nil
+			},
+			request: "/api/v1/distance?latA=23%C2%B030%2700%22N&lonA=34%C2%B015%27W&latB=23.44&lonB=34.42",
+			wantErr: false,
+			err:     nil,
 			args: args{
 				w: nil,
 				r: nil,
@@ -121,7 +163,7 @@ func TestBearing(t *testing.T) {
 			},
 			request: "/api/v1/bearing?latA=a&lonA=34.44&latB=23.44&lonB=34.42",
 			wantErr: true,
-			err:     NewResponseError(errors.New("invalid input"), http.StatusBadRequest),
+			err:     NewResponseError(errors.New(`invalid latA: "a" is not a valid coordinate`), http.StatusBadRequest),
 			args: args{
 				w: nil,
 				r: nil,
@@ -134,7 +176,7 @@ func TestBearing(t *testing.T) {
 			},
 			request: "/api/v1/bearing?latA=23.33&lonA=34.44&latB=b&lonB=34.42",
 			wantErr: true,
-			err:     NewResponseError(errors.New("invalid input"), http.StatusBadRequest),
+			err:     NewResponseError(errors.New(`invalid latB: "b" is not a valid coordinate`), http.StatusBadRequest),
 			args: args{
 				w: nil,
 				r: nil,
@@ -208,7 +250,7 @@ func TestMidPoint(t *testing.T) {
 			},
 			request: "/api/v1/midpoint?latA=a&lonA=34.44&latB=23.44&lonB=34.42",
 			wantErr: true,
-			err:     NewResponseError(errors.New("invalid input"), http.StatusBadRequest),
+			err:     NewResponseError(errors.New(`invalid latA: "a" is not a valid coordinate`), http.StatusBadRequest),
 			args: args{
 				w: nil,
 				r: nil,
@@ -221,7 +263,7 @@ func TestMidPoint(t *testing.T) {
 			},
 			request: "/api/v1/midpoint?latA=23.33&lonA=34.44&latB=b&lonB=34.42",
 			wantErr: true,
-			err:     NewResponseError(errors.New("invalid input"), http.StatusBadRequest),
+			err:     NewResponseError(errors.New(`invalid latB: "b" is not a valid coordinate`), http.StatusBadRequest),
 			args: args{
 				w: nil,
 				r: nil,
@@ -240,6 +282,32 @@ func TestMidPoint(t *testing.T) {
 				r: nil,
 			},
 		},
+		"dms format": {
+			want: NewResponse(FormattedPoint{Lat: "23°23'6.001\"N", Lng: "34°25'48.015\"E"}, http.StatusOK),
+			mockGetMidPoint: func(x, y geometry.Point) *geometry.Point {
+				return geometry.NewPoint(23.38500031791607, 34.430004151010706)
+			},
+			request: "/api/v1/midpoint?latA=23.33&lonA=34.44&latB=23.44&lonB=34.42&format=dms",
+			wantErr: false,
+			err:     nil,
+			args: args{
+				w: nil,
+				r: nil,
+			},
+		},
+		"invalid format": {
+			want: nil,
+			mockGetMidPoint: func(x, y geometry.Point) *geometry.Point {
+				return nil
+			},
+			request: "/api/v1/midpoint?latA=23.33&lonA=34.44&latB=23.44&lonB=34.42&format=utm",
+			wantErr: true,
+			err:     NewResponseError(errors.New(`unsupported format "utm", expected one of decimal, dms or ddm`), http.StatusBadRequest),
+			args: args{
+				w: nil,
+				r: nil,
+			},
+		},
 	}
 
 	for name, tt := range tests {
@@ -282,7 +350,7 @@ func TestDestinationPoint(t *testing.T) {
 			},
 			request: "/api/v1/destination?lat=a&lon=34.44&distance=10&bearing=10&units=m",
 			wantErr: true,
-			err:     NewResponseError(errors.New("invalid input"), http.StatusBadRequest),
+			err:     NewResponseError(errors.New(`invalid lat: "a" is not a valid coordinate`), http.StatusBadRequest),
 			args: args{
 				w: nil,
 				r: nil,
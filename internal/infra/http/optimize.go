@@ -0,0 +1,157 @@
+package http
+
+import (
+	"errors"
+	"math"
+	"net/http"
+
+	"github.com/tomchavakis/geo-api/internal/app/optimize"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/optimize"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/constants"
+	"github.com/tomchavakis/turf-go/conversions"
+)
+
+// defaultSpeed is the speed in km/h of the geodesic metric when none is set.
+const defaultSpeed = 30
+
+// OptimizeHandler struct
+type OptimizeHandler struct {
+	optimizeSvc optimize.Service
+}
+
+// NewOptimizeHandler handler
+func NewOptimizeHandler(oSvc optimize.Service) *OptimizeHandler {
+	oh := &OptimizeHandler{
+		optimizeSvc: oSvc,
+	}
+	return oh
+}
+
+// TimeWindow is the time a stop can be visited, in seconds after the departure. A stop can be
+// visited from the departure when earliest isn't set and any time later when latest isn't set.
+type TimeWindow struct {
+	Earliest *float64 `json:"earliest"`
+	Latest   *float64 `json:"latest"`
+}
+
+// OptimizeMessage holds the stops visited from a start, ending at the last stop or at an end when
+// set. Windows holds a time window or null for each stop, and ServiceTime the seconds spent at each
+// of them. The geodesic metric, the default one, travels the great circles at a speed in km/h, 30
+// by default, and the road metric the fastest routes of the car, bike or foot profile.
+type OptimizeMessage struct {
+	Start       *geometry.Point  `json:"start"`
+	Stops       []geometry.Point `json:"stops"`
+	End         *geometry.Point  `json:"end,omitempty"`
+	Windows     []*TimeWindow    `json:"windows,omitempty"`
+	ServiceTime float64          `json:"serviceTime"`
+	Metric      string           `json:"metric"`
+	Profile     string           `json:"profile"`
+	Speed       *float64         `json:"speed"`
+	Units       string           `json:"units"`
+}
+
+// OptimizeResponse holds the order of the stops by their index in the request, the stops in that
+// order and the LineString joining them from the start to the end. The distance is in units, the
+// duration and the arrivals at the stops in seconds after the departure, and late sums the seconds
+// the stops are reached after their window.
+type OptimizeResponse struct {
+	Order    []int             `json:"order"`
+	Stops    []geometry.Point  `json:"stops"`
+	Geometry geometry.Geometry `json:"geometry"`
+	Distance float64           `json:"distance"`
+	Duration float64           `json:"duration"`
+	Arrivals []float64         `json:"arrivals"`
+	Late     float64           `json:"late"`
+	Metric   string            `json:"metric"`
+}
+
+// optimizeRoute orders the stops of a route with a nearest neighbour tour improved with 2-opt and
+// Or-opt moves, keeping the time windows first and shortening the route next.
+func (oh *OptimizeHandler) optimizeRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	var om OptimizeMessage
+	if err := decodeBody(r, &om); err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if om.Start == nil {
+		return nil, NewResponseError(errors.New("start can't be empty"), http.StatusBadRequest)
+	}
+	if len(om.Stops) == 0 {
+		return nil, NewResponseError(errors.New("stops can't be empty"), http.StatusBadRequest)
+	}
+
+	req := algo.Request{
+		Start:   *om.Start,
+		Stops:   om.Stops,
+		End:     om.End,
+		Service: om.ServiceTime,
+		Metric:  algo.Geodesic,
+		Speed:   defaultSpeed,
+	}
+	if om.Metric != "" {
+		m, err := algo.ParseMetric(om.Metric)
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		req.Metric = m
+	}
+	if req.Metric == algo.Road {
+		req.Profile = roads.Car
+		if om.Profile != "" {
+			p, err := roads.ParseProfile(om.Profile)
+			if err != nil {
+				return nil, NewResponseError(err, http.StatusBadRequest)
+			}
+			req.Profile = p
+		}
+	}
+	if om.Speed != nil {
+		req.Speed = *om.Speed
+	}
+	if om.Windows != nil {
+		req.Windows = make([]*algo.Window, len(om.Windows))
+		for i, tw := range om.Windows {
+			if tw == nil {
+				continue
+			}
+			win := &algo.Window{Latest: math.Inf(1)}
+			if tw.Earliest != nil {
+				win.Earliest = *tw.Earliest
+			}
+			if tw.Latest != nil {
+				win.Latest = *tw.Latest
+			}
+			req.Windows[i] = win
+		}
+	}
+
+	tour, err := oh.optimizeSvc.OptimizeRoute(req)
+	if err != nil {
+		return nil, roadsError(err)
+	}
+	distance, err := conversions.ConvertLength(tour.Distance, constants.UnitMeters, om.Units)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	stops := make([]geometry.Point, len(tour.Order))
+	for i, s := range tour.Order {
+		stops[i] = om.Stops[s]
+	}
+	line := append([]geometry.Point{*om.Start}, stops...)
+	if om.End != nil {
+		line = append(line, *om.End)
+	}
+	return NewResponse(OptimizeResponse{
+		Order:    tour.Order,
+		Stops:    stops,
+		Geometry: geom.LineStringGeometry(line),
+		Distance: distance,
+		Duration: tour.Duration,
+		Arrivals: tour.Arrivals,
+		Late:     tour.Late,
+		Metric:   string(req.Metric),
+	}, http.StatusOK), nil
+}
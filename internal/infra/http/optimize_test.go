@@ -0,0 +1,145 @@
+package http
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/optimize"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestOptimize(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	optimize := func(req algo.Request) (*algo.Tour, error) {
+		if req.Metric == algo.Road {
+			return nil, roads.ErrNoNetwork
+		}
+		return algo.Optimize(nil, req)
+	}
+	stops := `"start": {"Lat": 0, "Lng": 0}, "stops": [{"Lat": 0, "Lng": 0.03}, {"Lat": 0, "Lng": 0.01}, {"Lat": 0, "Lng": 0.02}]`
+
+	tests := map[string]struct {
+		mockOptimize func(req algo.Request) (*algo.Tour, error)
+		payload      string
+		order        []int
+		distance     float64
+		points       int
+		wantErr      bool
+		err          error
+		args         args
+	}{
+		"geodesic": {
+			mockOptimize: func(req algo.Request) (*algo.Tour, error) {
+				if req.Speed != 30 || req.Windows != nil {
+					return nil, errors.New("unexpected request")
+				}
+				return optimize(req)
+			},
+			payload:  `{` + stops + `}`,
+			order:    []int{1, 2, 0},
+			distance: 3.336,
+			points:   4,
+		},
+		"round trip in meters": {
+			mockOptimize: optimize,
+			payload:      `{` + stops + `, "end": {"Lat": 0, "Lng": 0}, "units": "meters", "speed": 50}`,
+			distance:     6671.7,
+			points:       5,
+		},
+		"time windows": {
+			mockOptimize: func(req algo.Request) (*algo.Tour, error) {
+				if req.Windows[2] != nil || req.Windows[0].Earliest != 0 || !math.IsInf(req.Windows[1].Latest, 1) {
+					return nil, errors.New("unexpected windows")
+				}
+				return optimize(req)
+			},
+			payload:  `{` + stops + `, "windows": [{"latest": 500}, {"earliest": 1200}, null]}`,
+			distance: 5.560,
+			points:   4,
+		},
+		"missing start": {
+			payload: `{"stops": [{"Lat": 0, "Lng": 0.03}]}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("start can't be empty"), http.StatusBadRequest),
+		},
+		"missing stops": {
+			payload: `{"start": {"Lat": 0, "Lng": 0}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("stops can't be empty"), http.StatusBadRequest),
+		},
+		"invalid body": {
+			payload: `{"start": 1}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("invalid input"), http.StatusBadRequest),
+		},
+		"unknown metric": {
+			payload: `{` + stops + `, "metric": "flight"}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New(`unsupported metric "flight", expected geodesic or road`), http.StatusBadRequest),
+		},
+		"unknown profile": {
+			payload: `{` + stops + `, "metric": "road", "profile": "boat"}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New(`unsupported profile "boat", expected one of car, bike or foot`), http.StatusBadRequest),
+		},
+		"invalid units": {
+			mockOptimize: optimize,
+			payload:      `{` + stops + `, "units": "parsecs"}`,
+			wantErr:      true,
+			err:          NewResponseError(errors.New("invalid unit"), http.StatusBadRequest),
+		},
+		"service error": {
+			mockOptimize: optimize,
+			payload:      `{` + stops + `, "windows": [null]}`,
+			wantErr:      true,
+			err:          NewResponseError(errors.New("the time windows must be as many as the stops"), http.StatusBadRequest),
+		},
+		"no network": {
+			mockOptimize: optimize,
+			payload:      `{` + stops + `, "metric": "road"}`,
+			wantErr:      true,
+			err:          NewResponseError(roads.ErrNoNetwork, http.StatusServiceUnavailable),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/optimize", strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockOptimizeRepository()
+			MockSvc.OptimizeRouteFn = tt.mockOptimize
+			h := NewOptimizeHandler(MockSvc)
+			got, err := h.optimizeRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "optimize() error = %v,expected = %v", err, tt.err)
+				return
+			}
+
+			res := got.Payload.(OptimizeResponse)
+			if tt.order != nil {
+				assert.Equal(t, tt.order, res.Order)
+			}
+			assert.Len(t, res.Stops, 3)
+			assert.Equal(t, res.Stops[0], geometry.Point{Lng: []float64{0.03, 0.01, 0.02}[res.Order[0]]})
+			assert.Equal(t, geojson.LineString, res.Geometry.GeoJSONType)
+			assert.Len(t, res.Geometry.Coordinates, tt.points)
+			assert.InDelta(t, tt.distance, res.Distance, tt.distance/1000)
+			assert.Len(t, res.Arrivals, 3)
+			assert.Equal(t, 0.0, res.Late)
+			assert.Equal(t, "geodesic", res.Metric)
+		})
+	}
+}
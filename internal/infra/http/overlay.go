@@ -0,0 +1,73 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tomchavakis/geo-api/internal/app/overlay"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/overlay"
+)
+
+// OverlayHandler struct
+type OverlayHandler struct {
+	overlaySvc overlay.Service
+}
+
+// NewOverlayHandler handler
+func NewOverlayHandler(oSvc overlay.Service) *OverlayHandler {
+	oh := &OverlayHandler{
+		overlaySvc: oSvc,
+	}
+	return oh
+}
+
+// OverlayMessage holds the subject and the clip of an overlay, as any GeoJSON object containing
+// Polygons or MultiPolygons.
+type OverlayMessage struct {
+	Subject json.RawMessage `json:"subject"`
+	Clip    json.RawMessage `json:"clip"`
+}
+
+func (oh *OverlayHandler) overlayRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	op, err := algo.ParseOp(chi.URLParam(r, "op"))
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	if r.Body == nil {
+		err := errors.New("invalid Body")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	var om OverlayMessage
+	err = json.NewDecoder(r.Body).Decode(&om)
+	if err != nil {
+		return nil, NewResponseError(errors.New("invalid input"), http.StatusBadRequest)
+	}
+
+	if len(om.Subject) == 0 {
+		err := errors.New("subject can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if len(om.Clip) == 0 {
+		err := errors.New("clip can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	subject, err := decodePolygons(om.Subject)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	clip, err := decodePolygons(om.Clip)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	g, err := oh.overlaySvc.Overlay(subject, clip, op)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(g, http.StatusOK), nil
+}
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/overlay"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestOverlay(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	square := `{"type": "Polygon", "coordinates": [[[0, 0], [2, 0], [2, 2], [0, 2], [0, 0]]]}`
+	clip := `{"type": "Feature", "properties": {}, "geometry": {"type": "Polygon", "coordinates": [[[1, 1], [3, 1], [3, 3], [1, 3], [1, 1]]]}}`
+	res := geom.PolygonGeometry([][]geometry.Point{{{Lat: 1, Lng: 1}, {Lat: 1, Lng: 2}, {Lat: 2, Lng: 2}, {Lat: 2, Lng: 1}, {Lat: 1, Lng: 1}}})
+
+	tests := map[string]struct {
+		mockOverlay func(subject, clip [][][]geometry.Point, op overlay.Op) (*geometry.Geometry, error)
+		want        *Response
+		op          string
+		payload     string
+		wantErr     bool
+		err         error
+		args        args
+	}{
+		"invalid operation": {
+			want:    nil,
+			op:      "merge",
+			payload: `{"subject": ` + square + `, "clip": ` + clip + `}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New(`unsupported operation "merge", expected one of union, intersection, difference or xor`), http.StatusBadRequest),
+		},
+		"empty clip": {
+			want:    nil,
+			op:      "union",
+			payload: `{"subject": ` + square + `}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("clip can't be empty"), http.StatusBadRequest),
+		},
+		"unsupported geometry": {
+			want:    nil,
+			op:      "union",
+			payload: `{"subject": ` + square + `, "clip": {"type": "Point", "coordinates": [0, 0]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("only Polygon and MultiPolygon geometries are supported"), http.StatusBadRequest),
+		},
+		"happy path": {
+			want: NewResponse(&res, http.StatusOK),
+			mockOverlay: func(subject, clip [][][]geometry.Point, op overlay.Op) (*geometry.Geometry, error) {
+				if len(subject) != 1 || len(clip) != 1 || op != overlay.Intersection {
+					return nil, errors.New("unexpected arguments")
+				}
+				return &res, nil
+			},
+			op:      "intersection",
+			payload: `{"subject": ` + square + `, "clip": ` + clip + `}`,
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/overlay/"+tt.op, strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("op", tt.op)
+			tt.args.r = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			MockSvc := mock.NewMockOverlayRepository()
+			MockSvc.OverlayFn = tt.mockOverlay
+			h := NewOverlayHandler(MockSvc)
+			got, err := h.overlayRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "overlay() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "overlay() got = %v, want %v", got, tt.want)
+		})
+	}
+}
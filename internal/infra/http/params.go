@@ -0,0 +1,174 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tomchavakis/geo-api/internal/spatial/coord"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// FormattedPoint is a point with its coordinates written as strings in the requested format.
+type FormattedPoint struct {
+	Lat string
+	Lng string
+}
+
+// getLatLon parses a latitude and a longitude given in decimal degrees, degrees and decimal minutes
+// or degrees, minutes and seconds. Values given in the opposite order are swapped when their
+// hemisphere letters show it, e.g. lat=74°0'21.6"W&lon=40°42'46"N.
+func getLatLon(r *http.Request, lat, lon string) (*float64, *float64, error) {
+	q := r.URL.Query()
+	lat0 := q.Get(lat)
+	if lat0 == "" {
+		return nil, nil, fmt.Errorf("%s can't be empty", lat)
+	}
+	lon0 := q.Get(lon)
+	if lon0 == "" {
+		return nil, nil, fmt.Errorf("%s can't be empty", lon)
+	}
+
+	latA, lonA, err := coord.ParsePair(lat0, lon0)
+	if err != nil {
+		var ce *coord.Error
+		if errors.As(err, &ce) {
+			name := lat
+			if ce.Axis == coord.Longitude {
+				name = lon
+			}
+			return nil, nil, fmt.Errorf("invalid %s: %v", name, ce.Err)
+		}
+		return nil, nil, err
+	}
+
+	return &latA, &lonA, nil
+}
+
+// getFormat returns the coordinate format requested with the format query parameter.
+func getFormat(r *http.Request) (coord.Format, error) {
+	return coord.ParseFormat(r.URL.Query().Get("format"))
+}
+
+// formatPoint writes the coordinates of the point in the given format.
+func formatPoint(p geometry.Point, f coord.Format) FormattedPoint {
+	return FormattedPoint{
+		Lat: coord.Write(p.Lat, coord.Latitude, f),
+		Lng: coord.Write(p.Lng, coord.Longitude, f),
+	}
+}
+
+// getInt returns the integer value of a query parameter or the default value when it is missing.
+func getInt(r *http.Request, name string, defaultVal int) (int, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return defaultVal, nil
+	}
+	i, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s", name)
+	}
+
+	return i, nil
+}
+
+// getFloat returns the finite float value of a query parameter or the default value when it is missing.
+func getFloat(r *http.Request, name string, defaultVal float64) (float64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return defaultVal, nil
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, fmt.Errorf("invalid %s", name)
+	}
+
+	return f, nil
+}
+
+// getFloats parses a comma separated list of numbers, nil when the parameter is missing.
+func getFloats(r *http.Request, name string) ([]float64, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return nil, nil
+	}
+	var res []float64
+	for _, s := range strings.Split(v, ",") {
+		f, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+		if err != nil || math.IsNaN(f) || math.IsInf(f, 0) {
+			return nil, fmt.Errorf("invalid %s", name)
+		}
+		res = append(res, f)
+	}
+	return res, nil
+}
+
+// getBool returns the boolean value of a query parameter or false when it is missing.
+func getBool(r *http.Request, name string) (bool, error) {
+	v := r.URL.Query().Get(name)
+	if v == "" {
+		return false, nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return false, fmt.Errorf("invalid %s", name)
+	}
+
+	return b, nil
+}
+
+// getTile returns the tile given by the z, x and y URL parameters.
+func getTile(r *http.Request) (*tile.Tile, error) {
+	zxy, err := getZXY(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return tile.New(zxy[0], zxy[1], zxy[2])
+}
+
+// getZXY returns the integers of the z, x and y URL parameters.
+func getZXY(r *http.Request) ([3]int, error) {
+	var zxy [3]int
+	for i, name := range []string{"z", "x", "y"} {
+		v, err := strconv.Atoi(chi.URLParam(r, name))
+		if err != nil {
+			return zxy, fmt.Errorf("invalid %s", name)
+		}
+		zxy[i] = v
+	}
+
+	return zxy, nil
+}
+
+// decodeGeometries reads the geometries of any GeoJSON object sent as the request body.
+func decodeGeometries(r *http.Request) ([]geometry.Geometry, error) {
+	if r.Body == nil {
+		return nil, errors.New("invalid Body")
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.New("invalid input")
+	}
+
+	return geom.DecodeGeometries(body)
+}
+
+// decodeBody decodes the JSON request body into v.
+func decodeBody(r *http.Request, v interface{}) error {
+	if r.Body == nil {
+		return errors.New("invalid Body")
+	}
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil {
+		return errors.New("invalid input")
+	}
+	return nil
+}
@@ -0,0 +1,130 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/tomchavakis/geo-api/internal/app/pluscode"
+	"github.com/tomchavakis/geo-api/internal/spatial/olc"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// PlusCodeHandler struct
+type PlusCodeHandler struct {
+	plusCodeSvc pluscode.Service
+}
+
+// NewPlusCodeHandler handler
+func NewPlusCodeHandler(pcSvc pluscode.Service) *PlusCodeHandler {
+	ph := &PlusCodeHandler{
+		plusCodeSvc: pcSvc,
+	}
+	return ph
+}
+
+// CodeAreaMessage is the area represented by a decoded code.
+type CodeAreaMessage struct {
+	olc.CodeArea
+	Center geometry.Point `json:"center"`
+}
+
+func (ph *PlusCodeHandler) encodeRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	lat, lon, err := getLatLon(r, "lat", "lon")
+
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	length, err := getInt(r, "length", olc.PairCodeLength)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	p := geometry.Point{
+		Lat: *lat,
+		Lng: *lon,
+	}
+
+	code, err := ph.plusCodeSvc.EncodePlusCode(p, length)
+	if err != nil {
+		return nil, NewResponseError(errors.New(err.Error()), http.StatusInternalServerError)
+	}
+
+	return NewResponse(code, http.StatusOK), nil
+}
+
+func (ph *PlusCodeHandler) decodeRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	code, err := getCode(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	area, err := ph.plusCodeSvc.DecodePlusCode(code)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(CodeAreaMessage{CodeArea: *area, Center: area.Center()}, http.StatusOK), nil
+}
+
+func (ph *PlusCodeHandler) shortenRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	code, err := getCode(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	lat, lon, err := getLatLon(r, "lat", "lon")
+
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	ref := geometry.Point{
+		Lat: *lat,
+		Lng: *lon,
+	}
+
+	short, err := ph.plusCodeSvc.ShortenPlusCode(code, ref)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(short, http.StatusOK), nil
+}
+
+func (ph *PlusCodeHandler) recoverRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	code, err := getCode(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	lat, lon, err := getLatLon(r, "lat", "lon")
+
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	ref := geometry.Point{
+		Lat: *lat,
+		Lng: *lon,
+	}
+
+	full, err := ph.plusCodeSvc.RecoverPlusCode(code, ref)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(full, http.StatusOK), nil
+}
+
+// getCode returns the code query parameter. An unescaped '+' is decoded as a space in query strings,
+// so spaces are turned back to separators.
+func getCode(r *http.Request) (string, error) {
+	code := strings.TrimSpace(strings.ReplaceAll(r.URL.Query().Get("code"), " ", "+"))
+	if code == "" {
+		return "", errors.New("code can't be empty")
+	}
+
+	return code, nil
+}
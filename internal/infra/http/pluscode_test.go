@@ -0,0 +1,89 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/common"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestPlusCodeRecover(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	tests := map[string]struct {
+		mockRecoverPlusCode func(code string, ref geometry.Point) (*string, error)
+		want                *Response
+		request             string
+		wantErr             bool
+		err                 error
+		args                args
+	}{
+		"empty code": {
+			want:    nil,
+			request: "/api/v1/pluscode/recover?code=&lat=47.4&lon=8.6",
+			wantErr: true,
+			err:     NewResponseError(errors.New("code can't be empty"), http.StatusBadRequest),
+		},
+		"invalid input": {
+			want:    nil,
+			request: "/api/v1/pluscode/recover?code=9G8F%2B6X&lat=a&lon=8.6",
+			wantErr: true,
+			err:     NewResponseError(errors.New(`invalid lat: "a" is not a valid coordinate`), http.StatusBadRequest),
+		},
+		"recover error": {
+			want: nil,
+			mockRecoverPlusCode: func(code string, ref geometry.Point) (*string, error) {
+				return nil, errors.New("code is not a valid short code")
+			},
+			request: "/api/v1/pluscode/recover?code=9G8F6X&lat=47.4&lon=8.6",
+			wantErr: true,
+			err:     NewResponseError(errors.New("code is not a valid short code"), http.StatusBadRequest),
+		},
+		"unescaped separator": {
+			want: NewResponse(common.StringPtr("8FVC9G8F+6X"), http.StatusOK),
+			mockRecoverPlusCode: func(code string, ref geometry.Point) (*string, error) {
+				if code != "9G8F+6X" {
+					return nil, errors.New("unexpected code")
+				}
+				return common.StringPtr("8FVC9G8F+6X"), nil
+			},
+			request: "/api/v1/pluscode/recover?code=9G8F+6X&lat=47.4&lon=8.6",
+			wantErr: false,
+			err:     nil,
+		},
+		"happy path": {
+			want: NewResponse(common.StringPtr("8FVC9G8F+6X"), http.StatusOK),
+			mockRecoverPlusCode: func(code string, ref geometry.Point) (*string, error) {
+				return common.StringPtr("8FVC9G8F+6X"), nil
+			},
+			request: "/api/v1/pluscode/recover?code=9G8F%2B6X&lat=47.4&lon=8.6",
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", tt.request, nil)
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockPlusCodeRepository()
+			MockSvc.RecoverPlusCodeFn = tt.mockRecoverPlusCode
+			h := NewPlusCodeHandler(MockSvc)
+			got, err := h.recoverRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "recover() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "recover() got = %v, want %v", got, tt.want)
+		})
+	}
+}
@@ -22,5 +22,51 @@ func (h *HTTP) RouteBuilder() {
 		h.Router.Get("/api/v1/destination", handle(h.s.destinationRoute))
 		h.Router.Get("/api/v1/midpoint", handle(h.s.midpointRoute))
 		h.Router.Post("/api/v1/nearestpoint", handle(h.s.nearestPointRoute))
+		h.Router.Get("/api/v1/s2/cellid", handle(h.cell.cellIDRoute))
+		h.Router.Get("/api/v1/s2/polygon", handle(h.cell.cellPolygonRoute))
+		h.Router.Post("/api/v1/s2/covering", handle(h.cell.coveringRoute))
+		h.Router.Get("/api/v1/pluscode/encode", handle(h.pc.encodeRoute))
+		h.Router.Get("/api/v1/pluscode/decode", handle(h.pc.decodeRoute))
+		h.Router.Get("/api/v1/pluscode/shorten", handle(h.pc.shortenRoute))
+		h.Router.Get("/api/v1/pluscode/recover", handle(h.pc.recoverRoute))
+		h.Router.Post("/api/v1/bbox", handle(h.ext.bboxRoute))
+		h.Router.Post("/api/v1/bbox/polygon", handle(h.ext.bboxPolygonRoute))
+		h.Router.Post("/api/v1/bbox/expand", handle(h.ext.expandRoute))
+		h.Router.Post("/api/v1/bbox/intersect", handle(h.ext.intersectRoute))
+		h.Router.Post("/api/v1/bbox/union", handle(h.ext.unionRoute))
+		h.Router.Post("/api/v1/centre/centroid", handle(h.centre.centroidRoute))
+		h.Router.Post("/api/v1/centre/mass", handle(h.centre.centerOfMassRoute))
+		h.Router.Post("/api/v1/centre/bbox", handle(h.centre.bboxCenterRoute))
+		h.Router.Post("/api/v1/centre/surface", handle(h.centre.pointOnSurfaceRoute))
+		h.Router.Post("/api/v1/simplify", handle(h.simp.simplifyRoute))
+		h.Router.Post("/api/v1/hull", handle(h.hull.hullRoute))
+		h.Router.Post("/api/v1/overlay/{op}", handle(h.ovl.overlayRoute))
+		h.Router.Post("/api/v1/validate", handle(h.valid.validateRoute))
+		h.Router.Post("/api/v1/makevalid", handle(h.valid.makeValidRoute))
+		h.Router.Post("/api/v1/transform/{op}", handle(h.trans.transformRoute))
+		h.Router.Post("/api/v1/line/split", handle(h.line.splitRoute))
+		h.Router.Post("/api/v1/line/slice", handle(h.line.sliceRoute))
+		h.Router.Post("/api/v1/line/offset", handle(h.line.offsetRoute))
+		h.Router.Post("/api/v1/line/chunk", handle(h.line.chunkRoute))
+		h.Router.Post("/api/v1/line/intersect", handle(h.line.intersectRoute))
+		h.Router.Post("/api/v1/grid/{kind}", handle(h.grid.gridRoute))
+		h.Router.Post("/api/v1/aggregate", handle(h.agg.aggregateRoute))
+		h.Router.Post("/api/v1/cluster/{method}", handle(h.clust.clusterRoute))
+		h.Router.Post("/api/v1/heatmap", handle(h.heat.heatmapRoute))
+		h.Router.Post("/api/v1/heatmap/{z}/{x}/{y}.png", handle(h.heat.heatmapTileRoute))
+		h.Router.Get("/api/v1/tiles/{dataset}/{z}/{x}/{y}.mvt", handle(h.tiles.vectorTileRoute))
+		h.Router.Get("/api/v1/tile", handle(h.tmath.tileRoute))
+		h.Router.Get("/api/v1/tile/xyz/{z}/{x}/{y}", handle(h.tmath.xyzRoute))
+		h.Router.Get("/api/v1/tile/tms/{z}/{x}/{y}", handle(h.tmath.tmsRoute))
+		h.Router.Get("/api/v1/tile/quadkey/{quadkey}", handle(h.tmath.quadkeyRoute))
+		h.Router.Post("/api/v1/tile/cover", handle(h.tmath.coverRoute))
+		h.Router.Post("/api/v1/track/analyze", handle(h.track.analyzeRoute))
+		h.Router.Post("/api/v1/track/clean", handle(h.track.cleanRoute))
+		h.Router.Post("/api/v1/mapmatch", handle(h.match.mapMatchRoute))
+		h.Router.Get("/api/v1/route", handle(h.route.pathRoute))
+		h.Router.Get("/api/v1/isochrone", handle(h.iso.isochroneRoute))
+		h.Router.Post("/api/v1/optimize", handle(h.opt.optimizeRoute))
+		h.Router.Get("/api/v1/reverse", handle(h.geo.reverseRoute))
+		h.Router.Get("/api/v1/geocode", handle(h.geo.geocodeRoute))
 	})
 }
@@ -0,0 +1,92 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/tomchavakis/geo-api/internal/app/routing"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/routing"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// RoutingHandler struct
+type RoutingHandler struct {
+	routingSvc routing.Service
+}
+
+// NewRoutingHandler handler
+func NewRoutingHandler(rSvc routing.Service) *RoutingHandler {
+	rh := &RoutingHandler{
+		routingSvc: rSvc,
+	}
+	return rh
+}
+
+// RouteResponse holds a route over the roads as a LineString, with its distance in meters, its
+// duration in seconds and the IDs of the road edges travelled in order.
+type RouteResponse struct {
+	Geometry geometry.Geometry `json:"geometry"`
+	Distance float64           `json:"distance"`
+	Duration float64           `json:"duration"`
+	Profile  string            `json:"profile"`
+	Edges    []string          `json:"edges"`
+}
+
+// pathRoute returns the fastest route over the road network from latA, lonA to latB, lonB for the
+// car, bike or foot profile, car by default. The points are snapped to the nearest road open to the
+// profile.
+func (rh *RoutingHandler) pathRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	latA, lonA, err := getLatLon(r, "latA", "lonA")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	latB, lonB, err := getLatLon(r, "latB", "lonB")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	profile, err := getProfile(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	a := geometry.Point{Lat: *latA, Lng: *lonA}
+	b := geometry.Point{Lat: *latB, Lng: *lonB}
+	res, err := rh.routingSvc.GetRoute(a, b, profile)
+	if err != nil {
+		return nil, roadsError(err)
+	}
+
+	edges := res.Edges
+	if edges == nil {
+		edges = []string{}
+	}
+	return NewResponse(RouteResponse{
+		Geometry: geom.LineStringGeometry(res.Points),
+		Distance: res.Distance,
+		Duration: res.Duration,
+		Profile:  profile.Name,
+		Edges:    edges,
+	}, http.StatusOK), nil
+}
+
+// getProfile returns the travel profile requested with the profile query parameter, car by default.
+func getProfile(r *http.Request) (*roads.Profile, error) {
+	p := r.URL.Query().Get("profile")
+	if p == "" {
+		return roads.Car, nil
+	}
+	return roads.ParseProfile(p)
+}
+
+// roadsError maps the errors of the services working over the road network to their status.
+func roadsError(err error) error {
+	switch {
+	case errors.Is(err, roads.ErrNoNetwork):
+		return NewResponseError(err, http.StatusServiceUnavailable)
+	case errors.Is(err, algo.ErrNoRoute):
+		return NewResponseError(err, http.StatusNotFound)
+	}
+	return NewResponseError(err, http.StatusBadRequest)
+}
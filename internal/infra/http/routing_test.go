@@ -0,0 +1,109 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/routing"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestRoute(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	network, err := roads.Build([]roads.Way{
+		{ID: "a", Points: []geometry.Point{{Lng: 0, Lat: 0}, {Lng: 0.002, Lat: 0}}, Tags: map[string]string{"highway": "residential"}},
+	})
+	assert.NoError(t, err)
+	route := func(a, b geometry.Point, profile *roads.Profile) (*algo.Result, error) {
+		return algo.Route(network, a, b, profile)
+	}
+
+	tests := map[string]struct {
+		mockRoute func(a, b geometry.Point, profile *roads.Profile) (*algo.Result, error)
+		query     string
+		duration  float64
+		wantErr   bool
+		err       error
+		args      args
+	}{
+		"car by default": {
+			mockRoute: route,
+			query:     "?latA=0&lonA=0.0005&latB=0&lonB=0.0015",
+			duration:  111.2 / (30 / 3.6),
+		},
+		"foot": {
+			mockRoute: func(a, b geometry.Point, profile *roads.Profile) (*algo.Result, error) {
+				if a != (geometry.Point{Lng: 0.0005}) || b != (geometry.Point{Lng: 0.0015}) {
+					return nil, errors.New("unexpected points")
+				}
+				return route(a, b, profile)
+			},
+			query:    "?latA=0&lonA=0.0005&latB=0&lonB=0.0015&profile=foot",
+			duration: 111.2 / (5 / 3.6),
+		},
+		"missing point": {
+			query:   "?latA=0&lonA=0.0005",
+			wantErr: true,
+			err:     NewResponseError(errors.New("latB can't be empty"), http.StatusBadRequest),
+		},
+		"unsupported profile": {
+			query:   "?latA=0&lonA=0.0005&latB=0&lonB=0.0015&profile=boat",
+			wantErr: true,
+			err:     NewResponseError(errors.New(`unsupported profile "boat", expected one of car, bike or foot`), http.StatusBadRequest),
+		},
+		"no road near": {
+			mockRoute: route,
+			query:     "?latA=0&lonA=0.0005&latB=1&lonB=1",
+			wantErr:   true,
+			err:       NewResponseError(errors.New("point B is farther than 1000 meters from the roads open to car"), http.StatusBadRequest),
+		},
+		"no route": {
+			mockRoute: func(a, b geometry.Point, profile *roads.Profile) (*algo.Result, error) {
+				return nil, algo.ErrNoRoute
+			},
+			query:   "?latA=0&lonA=0.0005&latB=0&lonB=0.0015",
+			wantErr: true,
+			err:     NewResponseError(algo.ErrNoRoute, http.StatusNotFound),
+		},
+		"no network": {
+			mockRoute: func(a, b geometry.Point, profile *roads.Profile) (*algo.Result, error) {
+				return nil, roads.ErrNoNetwork
+			},
+			query:   "?latA=0&lonA=0.0005&latB=0&lonB=0.0015",
+			wantErr: true,
+			err:     NewResponseError(roads.ErrNoNetwork, http.StatusServiceUnavailable),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("GET", "/api/v1/route"+tt.query, nil)
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockRoutingRepository()
+			MockSvc.GetRouteFn = tt.mockRoute
+			h := NewRoutingHandler(MockSvc)
+			got, err := h.pathRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "route() error = %v,expected = %v", err, tt.err)
+				return
+			}
+
+			res := got.Payload.(RouteResponse)
+			assert.Equal(t, geojson.LineString, res.Geometry.GeoJSONType)
+			assert.InDelta(t, 111.2, res.Distance, 0.1)
+			assert.InDelta(t, tt.duration, res.Duration, 0.1)
+			assert.Equal(t, []string{"a:0"}, res.Edges)
+		})
+	}
+}
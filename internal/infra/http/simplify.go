@@ -0,0 +1,115 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/tomchavakis/geo-api/internal/app/simplify"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/simplify"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// SimplifyHandler struct
+type SimplifyHandler struct {
+	simplifySvc simplify.Service
+}
+
+// NewSimplifyHandler handler
+func NewSimplifyHandler(sSvc simplify.Service) *SimplifyHandler {
+	sh := &SimplifyHandler{
+		simplifySvc: sSvc,
+	}
+	return sh
+}
+
+// SimplifyMessage ...
+type SimplifyMessage struct {
+	Geometry         json.RawMessage `json:"geometry"`
+	Tolerance        *float64        `json:"tolerance,omitempty"`
+	Units            string          `json:"units"`
+	Algorithm        string          `json:"algorithm"`
+	PreserveTopology bool            `json:"preserveTopology"`
+}
+
+// SimplifiedMessage is the simplified input as a FeatureCollection, whatever the type of the input,
+// with the vertex counts before and after the simplification.
+type SimplifiedMessage struct {
+	Geometry       feature.Collection `json:"geometry"`
+	VerticesBefore int                `json:"verticesBefore"`
+	VerticesAfter  int                `json:"verticesAfter"`
+}
+
+func (sh *SimplifyHandler) simplifyRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	if r.Body == nil {
+		err := errors.New("invalid Body")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	var sm SimplifyMessage
+	err := json.NewDecoder(r.Body).Decode(&sm)
+	if err != nil {
+		return nil, NewResponseError(errors.New("invalid input"), http.StatusBadRequest)
+	}
+
+	if len(sm.Geometry) == 0 {
+		err := errors.New("geometry can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	if sm.Tolerance == nil {
+		err := errors.New("tolerance can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	algorithm, err := algo.ParseAlgorithm(sm.Algorithm)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	fs, err := geom.Decode(sm.Geometry)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	gs := make([]geometry.Geometry, 0, len(fs))
+	for i := range fs {
+		gs = append(gs, fs[i].Geometry)
+	}
+	before, err := countVertices(gs)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	res, err := sh.simplifySvc.Simplify(gs, *sm.Tolerance, sm.Units, algorithm, sm.PreserveTopology)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	after, err := countVertices(res)
+	if err != nil {
+		return nil, NewResponseError(errors.New(err.Error()), http.StatusInternalServerError)
+	}
+	for i := range fs {
+		fs[i].Geometry = res[i]
+	}
+
+	return NewResponse(SimplifiedMessage{
+		Geometry:       geom.NewFeatureCollection(fs),
+		VerticesBefore: before,
+		VerticesAfter:  after,
+	}, http.StatusOK), nil
+}
+
+func countVertices(gs []geometry.Geometry) (int, error) {
+	n := 0
+	for _, g := range gs {
+		ps, err := geom.Coords(g)
+		if err != nil {
+			return 0, err
+		}
+		n += len(ps)
+	}
+	return n, nil
+}
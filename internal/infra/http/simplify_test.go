@@ -0,0 +1,97 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/simplify"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestSimplify(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	simplified := []geometry.Point{{Lat: 0, Lng: 0}, {Lat: 9, Lng: 7}}
+
+	tests := map[string]struct {
+		mockSimplify func(gs []geometry.Geometry, tolerance float64, units string, algorithm simplify.Algorithm, preserveTopology bool) ([]geometry.Geometry, error)
+		want         *Response
+		payload      string
+		wantErr      bool
+		err          error
+		args         args
+	}{
+		"empty geometry": {
+			want:    nil,
+			payload: `{"tolerance": 10}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("geometry can't be empty"), http.StatusBadRequest),
+		},
+		"empty tolerance": {
+			want:    nil,
+			payload: `{"geometry": {"type": "LineString", "coordinates": [[0, 0], [3, 5], [7, 9]]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("tolerance can't be empty"), http.StatusBadRequest),
+		},
+		"invalid algorithm": {
+			want:    nil,
+			payload: `{"tolerance": 10, "algorithm": "radial", "geometry": {"type": "LineString", "coordinates": [[0, 0], [3, 5], [7, 9]]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New(`unsupported algorithm "radial", expected one of douglas-peucker or visvalingam`), http.StatusBadRequest),
+		},
+		"simplify error": {
+			want: nil,
+			mockSimplify: func(gs []geometry.Geometry, tolerance float64, units string, algorithm simplify.Algorithm, preserveTopology bool) ([]geometry.Geometry, error) {
+				return nil, errors.New("tolerance must be a positive number")
+			},
+			payload: `{"tolerance": -1, "geometry": {"type": "LineString", "coordinates": [[0, 0], [3, 5], [7, 9]]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("tolerance must be a positive number"), http.StatusBadRequest),
+		},
+		"happy path": {
+			want: NewResponse(SimplifiedMessage{
+				Geometry: geom.NewFeatureCollection([]feature.Feature{
+					geom.NewFeature(geom.LineStringGeometry(simplified), nil),
+				}),
+				VerticesBefore: 3,
+				VerticesAfter:  2,
+			}, http.StatusOK),
+			mockSimplify: func(gs []geometry.Geometry, tolerance float64, units string, algorithm simplify.Algorithm, preserveTopology bool) ([]geometry.Geometry, error) {
+				if len(gs) != 1 || tolerance != 10 || units != "meters" || algorithm != simplify.Visvalingam || !preserveTopology {
+					return nil, errors.New("unexpected arguments")
+				}
+				return []geometry.Geometry{geom.LineStringGeometry(simplified)}, nil
+			},
+			payload: `{"tolerance": 10, "units": "meters", "algorithm": "visvalingam", "preserveTopology": true, "geometry": {"type": "LineString", "coordinates": [[0, 0], [3, 5], [7, 9]]}}`,
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/simplify", strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockSimplifyRepository()
+			MockSvc.SimplifyFn = tt.mockSimplify
+			h := NewSimplifyHandler(MockSvc)
+			got, err := h.simplifyRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "simplify() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "simplify() got = %v, want %v", got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tomchavakis/geo-api/internal/app/tilemath"
+	"github.com/tomchavakis/geo-api/internal/spatial/bbox"
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// TileMathHandler struct
+type TileMathHandler struct {
+	tileMathSvc tilemath.Service
+}
+
+// NewTileMathHandler handler
+func NewTileMathHandler(tmSvc tilemath.Service) *TileMathHandler {
+	th := &TileMathHandler{
+		tileMathSvc: tmSvc,
+	}
+	return th
+}
+
+// TileMessage is a tile with its coordinates in the XYZ and TMS schemes, its Bing Maps quadkey
+// and its bounding box.
+type TileMessage struct {
+	Z       int       `json:"z"`
+	X       int       `json:"x"`
+	Y       int       `json:"y"`
+	TMSY    int       `json:"tmsY"`
+	Quadkey string    `json:"quadkey"`
+	BBox    []float64 `json:"bbox"`
+}
+
+// TileCoverMessage holds the Polygons and MultiPolygons to cover with the tiles of a zoom level.
+type TileCoverMessage struct {
+	Geometry json.RawMessage `json:"geometry"`
+	Zoom     *int            `json:"zoom,omitempty"`
+}
+
+func newTileMessage(t tile.Tile) TileMessage {
+	return TileMessage{Z: t.Z, X: t.X, Y: t.Y, TMSY: t.TMS(), Quadkey: t.Quadkey(), BBox: bbox.ToSlice(t.BBox())}
+}
+
+// tileRoute returns the tile holding the lat and lon point at the z zoom level.
+func (th *TileMathHandler) tileRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	lat, lon, err := getLatLon(r, "lat", "lon")
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if r.URL.Query().Get("z") == "" {
+		err := errors.New("z can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	z, err := getInt(r, "z", 0)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	t, err := th.tileMathSvc.GetTileAt(geometry.Point{Lat: *lat, Lng: *lon}, z)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(newTileMessage(*t), http.StatusOK), nil
+}
+
+// xyzRoute returns the tile of the z, x and y URL parameters in the XYZ scheme.
+func (th *TileMathHandler) xyzRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	t, err := getTile(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(newTileMessage(*t), http.StatusOK), nil
+}
+
+// tmsRoute returns the tile of the z, x and y URL parameters in the TMS scheme.
+func (th *TileMathHandler) tmsRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	zxy, err := getZXY(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	t, err := th.tileMathSvc.GetTileFromTMS(zxy[0], zxy[1], zxy[2])
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(newTileMessage(*t), http.StatusOK), nil
+}
+
+// quadkeyRoute returns the tile of the quadkey URL parameter.
+func (th *TileMathHandler) quadkeyRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	t, err := th.tileMathSvc.GetTileFromQuadkey(chi.URLParam(r, "quadkey"))
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(newTileMessage(*t), http.StatusOK), nil
+}
+
+// coverRoute returns the tiles at a zoom level that intersect Polygons and MultiPolygons.
+func (th *TileMathHandler) coverRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	var tm TileCoverMessage
+	if err := decodeBody(r, &tm); err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if len(tm.Geometry) == 0 {
+		err := errors.New("geometry can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if tm.Zoom == nil {
+		err := errors.New("zoom can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	polygons, err := decodePolygons(tm.Geometry)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	ts, err := th.tileMathSvc.GetTileCover(polygons, *tm.Zoom)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	res := make([]TileMessage, 0, len(ts))
+	for _, t := range ts {
+		res = append(res, newTileMessage(t))
+	}
+	return NewResponse(res, http.StatusOK), nil
+}
@@ -0,0 +1,137 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestTileRoutes(t *testing.T) {
+	MockSvc := mock.NewMockTileMathRepository()
+	MockSvc.GetTileAtFn = func(p geometry.Point, z int) (*tile.Tile, error) {
+		return tile.At(p, z)
+	}
+	MockSvc.GetTileFromTMSFn = tile.FromTMS
+	MockSvc.GetTileFromQuadkeyFn = tile.ParseQuadkey
+	h := NewTileMathHandler(MockSvc)
+	router := chi.NewRouter()
+	router.Get("/api/v1/tile", handle(h.tileRoute))
+	router.Get("/api/v1/tile/xyz/{z}/{x}/{y}", handle(h.xyzRoute))
+	router.Get("/api/v1/tile/tms/{z}/{x}/{y}", handle(h.tmsRoute))
+	router.Get("/api/v1/tile/quadkey/{quadkey}", handle(h.quadkeyRoute))
+
+	want := TileMessage{Z: 1, X: 1, Y: 0, TMSY: 1, Quadkey: "1", BBox: []float64{0, 0, 180, 85.0511287798066}}
+	tests := map[string]struct {
+		url    string
+		status int
+		err    string
+	}{
+		"lat lon":           {url: "/api/v1/tile?lat=45&lon=90&z=1", status: http.StatusOK},
+		"xyz":               {url: "/api/v1/tile/xyz/1/1/0", status: http.StatusOK},
+		"tms":               {url: "/api/v1/tile/tms/1/1/1", status: http.StatusOK},
+		"quadkey":           {url: "/api/v1/tile/quadkey/1", status: http.StatusOK},
+		"empty zoom":        {url: "/api/v1/tile?lat=45&lon=90", status: http.StatusBadRequest, err: "z can't be empty"},
+		"invalid zoom":      {url: "/api/v1/tile?lat=45&lon=90&z=a", status: http.StatusBadRequest, err: "invalid z"},
+		"zoom out of range": {url: "/api/v1/tile?lat=45&lon=90&z=30", status: http.StatusBadRequest, err: "zoom must be between 0 and 24"},
+		"xyz out of range":  {url: "/api/v1/tile/xyz/1/2/0", status: http.StatusBadRequest, err: "x and y must be between 0 and 1 at zoom 1"},
+		"invalid tms":       {url: "/api/v1/tile/tms/1/x/0", status: http.StatusBadRequest, err: "invalid x"},
+		"invalid quadkey":   {url: "/api/v1/tile/quadkey/14", status: http.StatusBadRequest, err: "quadkey can only hold the digits 0 to 3"},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, httptest.NewRequest("GET", tt.url, nil))
+			assert.Equal(t, tt.status, rec.Code)
+			if tt.err != "" {
+				assert.Contains(t, rec.Body.String(), tt.err)
+				return
+			}
+			var got TileMessage
+			assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+			assert.Equal(t, want.Quadkey, got.Quadkey)
+			assert.Equal(t, [3]int{want.Z, want.X, want.Y}, [3]int{got.Z, got.X, got.Y})
+			assert.Equal(t, want.TMSY, got.TMSY)
+			assert.InDeltaSlice(t, want.BBox, got.BBox, 1e-9)
+		})
+	}
+}
+
+func TestTileCover(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	polygon := `{"type": "Polygon", "coordinates": [[[10, 10], [11, 10], [11, 11], [10, 10]]]}`
+
+	tests := map[string]struct {
+		mockCover func(polygons [][][]geometry.Point, z int) ([]tile.Tile, error)
+		want      *Response
+		payload   string
+		wantErr   bool
+		err       error
+		args      args
+	}{
+		"happy path": {
+			mockCover: func(polygons [][][]geometry.Point, z int) ([]tile.Tile, error) {
+				if len(polygons) != 1 || z != 0 {
+					return nil, errors.New("unexpected arguments")
+				}
+				return []tile.Tile{{}}, nil
+			},
+			want:    NewResponse([]TileMessage{newTileMessage(tile.Tile{})}, http.StatusOK),
+			payload: `{"zoom": 0, "geometry": ` + polygon + `}`,
+		},
+		"empty geometry": {
+			payload: `{"zoom": 3}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("geometry can't be empty"), http.StatusBadRequest),
+		},
+		"empty zoom": {
+			payload: `{"geometry": ` + polygon + `}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("zoom can't be empty"), http.StatusBadRequest),
+		},
+		"unsupported geometry": {
+			payload: `{"zoom": 3, "geometry": {"type": "Point", "coordinates": [10, 10]}}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("only Polygon and MultiPolygon geometries are supported"), http.StatusBadRequest),
+		},
+		"service error": {
+			mockCover: func(polygons [][][]geometry.Point, z int) ([]tile.Tile, error) {
+				return nil, errors.New("too many tiles, the limit is 100000")
+			},
+			payload: `{"zoom": 24, "geometry": ` + polygon + `}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("too many tiles, the limit is 100000"), http.StatusBadRequest),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/tile/cover", strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockTileMathRepository()
+			MockSvc.GetTileCoverFn = tt.mockCover
+			h := NewTileMathHandler(MockSvc)
+			got, err := h.coverRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "cover() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "cover() got = %v, want %v", got, tt.want)
+		})
+	}
+}
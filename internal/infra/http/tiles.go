@@ -0,0 +1,80 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tomchavakis/geo-api/internal/app/tiles"
+)
+
+// TilesHandler struct
+type TilesHandler struct {
+	tilesSvc tiles.Service
+}
+
+// NewTilesHandler handler
+func NewTilesHandler(tSvc tiles.Service) *TilesHandler {
+	th := &TilesHandler{
+		tilesSvc: tSvc,
+	}
+	return th
+}
+
+// vectorTileRoute returns the Mapbox Vector Tile of the z, x and y URL parameters of a stored
+// dataset. The tiles carry an ETag, so that the clients sending it back in If-None-Match get a 304
+// Not Modified instead of the tile while the dataset is unchanged.
+func (th *TilesHandler) vectorTileRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	dataset := chi.URLParam(r, "dataset")
+	t, err := getTile(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	etag, err := th.tilesSvc.GetTileETag(dataset, *t)
+	if err != nil {
+		return nil, tilesError(err)
+	}
+	var resp *Response
+	if etagMatch(r.Header.Get("If-None-Match"), etag) {
+		resp = NewResponse(rawBytes(nil), http.StatusNotModified)
+	} else {
+		b, err := th.tilesSvc.GetVectorTile(dataset, *t)
+		if err != nil {
+			return nil, tilesError(err)
+		}
+		resp = NewResponse(rawBytes(b), http.StatusOK)
+	}
+	resp.Header["Content-Type"] = "application/vnd.mapbox-vector-tile"
+	resp.Header["ETag"] = etag
+	return resp, nil
+}
+
+func tilesError(err error) error {
+	if errors.Is(err, tiles.ErrDatasetNotFound) {
+		return NewResponseError(err, http.StatusNotFound)
+	}
+	return NewResponseError(err, http.StatusInternalServerError)
+}
+
+// etagMatch reports whether an If-None-Match header holds an entity tag, comparing them weakly.
+func etagMatch(header, etag string) bool {
+	for _, v := range strings.Split(header, ",") {
+		v = strings.TrimSpace(v)
+		if v == "*" || strings.TrimPrefix(v, "W/") == strings.TrimPrefix(etag, "W/") {
+			return true
+		}
+	}
+	return false
+}
+
+// rawBytes streams bytes as they are.
+type rawBytes []byte
+
+// Stream implements Streamer.
+func (rb rawBytes) Stream(w io.Writer) error {
+	_, err := w.Write(rb)
+	return err
+}
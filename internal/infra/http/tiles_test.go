@@ -0,0 +1,90 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/app/tiles"
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+	"github.com/tomchavakis/geo-api/test/mock"
+)
+
+func TestVectorTile(t *testing.T) {
+	MockSvc := mock.NewMockTilesRepository()
+	MockSvc.GetTileETagFn = func(dataset string, tl tile.Tile) (string, error) {
+		if dataset != "roads" {
+			return "", tiles.ErrDatasetNotFound
+		}
+		return `"v1"`, nil
+	}
+	MockSvc.GetVectorTileFn = func(dataset string, tl tile.Tile) ([]byte, error) {
+		if tl != (tile.Tile{Z: 12, X: 2161, Y: 1399}) {
+			return nil, errors.New("unexpected arguments")
+		}
+		return []byte{0x1a, 0x00}, nil
+	}
+	h := NewTilesHandler(MockSvc)
+	router := chi.NewRouter()
+	router.Get("/api/v1/tiles/{dataset}/{z}/{x}/{y}.mvt", handle(h.vectorTileRoute))
+
+	tests := map[string]struct {
+		url         string
+		ifNoneMatch string
+		status      int
+		body        string
+	}{
+		"tile": {
+			url:    "/api/v1/tiles/roads/12/2161/1399.mvt",
+			status: http.StatusOK,
+			body:   "\x1a\x00",
+		},
+		"etag matches": {
+			url:         "/api/v1/tiles/roads/12/2161/1399.mvt",
+			ifNoneMatch: `"v0", W/"v1"`,
+			status:      http.StatusNotModified,
+		},
+		"etag changed": {
+			url:         "/api/v1/tiles/roads/12/2161/1399.mvt",
+			ifNoneMatch: `"v0"`,
+			status:      http.StatusOK,
+			body:        "\x1a\x00",
+		},
+		"unknown dataset": {
+			url:    "/api/v1/tiles/rivers/12/2161/1399.mvt",
+			status: http.StatusNotFound,
+			body:   `{"Error":"dataset not found"}`,
+		},
+		"invalid tile": {
+			url:    "/api/v1/tiles/roads/30/0/0.mvt",
+			status: http.StatusBadRequest,
+			body:   `{"Error":"zoom must be between 0 and 24"}`,
+		},
+		"service error": {
+			url:    "/api/v1/tiles/roads/1/0/0.mvt",
+			status: http.StatusInternalServerError,
+			body:   `{"Error":"unexpected arguments"}`,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", tt.url, nil)
+			if tt.ifNoneMatch != "" {
+				req.Header.Set("If-None-Match", tt.ifNoneMatch)
+			}
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.status, rec.Code)
+			assert.Equal(t, tt.body, rec.Body.String())
+			if tt.status == http.StatusOK || tt.status == http.StatusNotModified {
+				assert.Equal(t, `"v1"`, rec.Header().Get("ETag"))
+				assert.Equal(t, "application/vnd.mapbox-vector-tile", rec.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
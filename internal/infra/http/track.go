@@ -0,0 +1,250 @@
+package http
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/tomchavakis/geo-api/internal/app/track"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/track"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+const (
+	// defaultStopRadius is the radius of the stops in meters used when the request doesn't set one.
+	defaultStopRadius = 50
+	// defaultStopDuration is the minimum duration of the stops in seconds used when the request
+	// doesn't set one.
+	defaultStopDuration = 300
+	// defaultMaxSpeed is the speed in meters per second, about 250 km/h, beyond which the points
+	// are dropped when the request doesn't set one.
+	defaultMaxSpeed = 70
+	// defaultJitterRadius is the distance in meters within which the points are dropped as
+	// stationary when the request doesn't set one.
+	defaultJitterRadius = 3
+	// defaultProcessNoise is the standard deviation of the acceleration in meters per second
+	// squared used when the request doesn't set one.
+	defaultProcessNoise = 1
+	// defaultMeasurementNoise is the standard deviation of the GPS positions in meters used when
+	// the request doesn't set one.
+	defaultMeasurementNoise = 10
+)
+
+// TrackHandler struct
+type TrackHandler struct {
+	trackSvc track.Service
+}
+
+// NewTrackHandler handler
+func NewTrackHandler(tSvc track.Service) *TrackHandler {
+	th := &TrackHandler{
+		trackSvc: tSvc,
+	}
+	return th
+}
+
+// TrackAnalysisResponse holds the totals of a track, with its distance in meters, its durations in
+// seconds and its speeds in meters per second, and its segments, stops and moves as features.
+type TrackAnalysisResponse struct {
+	Distance       float64            `json:"distance"`
+	Duration       float64            `json:"duration"`
+	MovingDuration float64            `json:"movingDuration"`
+	AverageSpeed   float64            `json:"averageSpeed"`
+	MaxSpeed       float64            `json:"maxSpeed"`
+	Segments       feature.Collection `json:"segments"`
+	Stops          feature.Collection `json:"stops"`
+	Moves          feature.Collection `json:"moves"`
+}
+
+// TrackCleanResponse holds a cleaned track, as a LineString timed by its coordTimes property, and
+// the points dropped from it with their index in the original track and the reason.
+type TrackCleanResponse struct {
+	Track   feature.Feature    `json:"track"`
+	Dropped feature.Collection `json:"dropped"`
+	Report  TrackCleanReport   `json:"report"`
+}
+
+// TrackCleanReport counts the points of a track before and after cleaning it and compares its
+// lengths in meters.
+type TrackCleanReport struct {
+	Input          int     `json:"input"`
+	Output         int     `json:"output"`
+	Dropped        int     `json:"dropped"`
+	DistanceBefore float64 `json:"distanceBefore"`
+	DistanceAfter  float64 `json:"distanceAfter"`
+}
+
+// analyzeRoute returns the speed and heading of every segment of a track, its stops of at least
+// stopDuration seconds within stopRadius meters, and the moves between them. The track is sent as
+// the body, either GeoJSON LineStrings timed by their coordTimes property or a GPX document.
+func (th *TrackHandler) analyzeRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	radius, err := getFloat(r, "stopRadius", defaultStopRadius)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	duration, err := getFloat(r, "stopDuration", defaultStopDuration)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	points, err := decodeTrack(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	opts := algo.StopOptions{Radius: radius, Duration: time.Duration(duration * float64(time.Second))}
+	a, err := th.trackSvc.AnalyzeTrack(points, opts)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	return NewResponse(trackAnalysisResponse(points, a), http.StatusOK), nil
+}
+
+// cleanRoute drops the points of a track reached faster than maxSpeed meters per second and the
+// ones within jitterRadius meters of the previous kept point, then smooths it with the kalman or
+// rts smoother, rts by default, or none. The smoother models a constant velocity with
+// processNoise, the deviation of the acceleration in meters per second squared, and GPS
+// positions off by measurementNoise meters. The track is sent as the body as for analyzeRoute.
+func (th *TrackHandler) cleanRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	opts := algo.CleanOptions{Smoother: algo.RTS}
+	params := []struct {
+		name       string
+		v          *float64
+		defaultVal float64
+	}{
+		{name: "maxSpeed", v: &opts.MaxSpeed, defaultVal: defaultMaxSpeed},
+		{name: "jitterRadius", v: &opts.JitterRadius, defaultVal: defaultJitterRadius},
+		{name: "processNoise", v: &opts.ProcessNoise, defaultVal: defaultProcessNoise},
+		{name: "measurementNoise", v: &opts.MeasurementNoise, defaultVal: defaultMeasurementNoise},
+	}
+	for _, p := range params {
+		v, err := getFloat(r, p.name, p.defaultVal)
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		*p.v = v
+	}
+	if s := r.URL.Query().Get("smoother"); s != "" {
+		sm, err := algo.ParseSmoother(s)
+		if err != nil {
+			return nil, NewResponseError(err, http.StatusBadRequest)
+		}
+		opts.Smoother = sm
+	}
+
+	points, err := decodeTrack(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	c, err := th.trackSvc.CleanTrack(points, opts)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	ps := make([]geometry.Point, 0, len(c.Points))
+	times := make([]interface{}, 0, len(c.Points))
+	for _, p := range c.Points {
+		ps = append(ps, p.Point)
+		times = append(times, p.Time.Format(time.RFC3339Nano))
+	}
+	dropped := make([]feature.Feature, 0, len(c.Dropped))
+	for _, d := range c.Dropped {
+		p := points[d.Index]
+		dropped = append(dropped, geom.NewFeature(geom.PointGeometry(p.Point), map[string]interface{}{
+			"index":  d.Index,
+			"time":   p.Time.Format(time.RFC3339Nano),
+			"reason": d.Reason,
+		}))
+	}
+
+	return NewResponse(TrackCleanResponse{
+		Track:   geom.NewFeature(geom.LineStringGeometry(ps), map[string]interface{}{"coordTimes": times}),
+		Dropped: geom.NewFeatureCollection(dropped),
+		Report: TrackCleanReport{
+			Input:          len(points),
+			Output:         len(c.Points),
+			Dropped:        len(c.Dropped),
+			DistanceBefore: algo.Length(points),
+			DistanceAfter:  algo.Length(c.Points),
+		},
+	}, http.StatusOK), nil
+}
+
+// decodeTrack reads the track sent as the request body, as GeoJSON or GPX.
+func decodeTrack(r *http.Request) ([]algo.Point, error) {
+	if r.Body == nil {
+		return nil, errors.New("invalid Body")
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.New("invalid input")
+	}
+
+	if bytes.HasPrefix(bytes.TrimSpace(body), []byte("<")) {
+		return algo.ParseGPX(body)
+	}
+	fs, err := geom.Decode(body)
+	if err != nil {
+		return nil, err
+	}
+	return algo.FromFeatures(fs)
+}
+
+func trackAnalysisResponse(points []algo.Point, a *algo.Analysis) TrackAnalysisResponse {
+	line := func(from, to int) geometry.Geometry {
+		ps := make([]geometry.Point, 0, to-from+1)
+		for _, p := range points[from : to+1] {
+			ps = append(ps, p.Point)
+		}
+		return geom.LineStringGeometry(ps)
+	}
+
+	segments := make([]feature.Feature, 0, len(a.Segments))
+	for _, s := range a.Segments {
+		segments = append(segments, geom.NewFeature(line(s.From, s.To), map[string]interface{}{
+			"start":    points[s.From].Time.Format(time.RFC3339Nano),
+			"end":      points[s.To].Time.Format(time.RFC3339Nano),
+			"distance": s.Distance,
+			"duration": s.Duration,
+			"speed":    s.Speed,
+			"heading":  s.Heading,
+		}))
+	}
+	stops := make([]feature.Feature, 0, len(a.Stops))
+	for _, s := range a.Stops {
+		stops = append(stops, geom.NewFeature(geom.PointGeometry(s.Centre), map[string]interface{}{
+			"start":    s.Start.Format(time.RFC3339Nano),
+			"end":      s.End.Format(time.RFC3339Nano),
+			"duration": s.Duration,
+			"count":    s.To - s.From + 1,
+		}))
+	}
+	moves := make([]feature.Feature, 0, len(a.Moves))
+	for _, m := range a.Moves {
+		moves = append(moves, geom.NewFeature(line(m.From, m.To), map[string]interface{}{
+			"start":        m.Start.Format(time.RFC3339Nano),
+			"end":          m.End.Format(time.RFC3339Nano),
+			"distance":     m.Distance,
+			"duration":     m.Duration,
+			"averageSpeed": m.AverageSpeed,
+			"maxSpeed":     m.MaxSpeed,
+		}))
+	}
+
+	return TrackAnalysisResponse{
+		Distance:       a.Distance,
+		Duration:       a.Duration,
+		MovingDuration: a.MovingDuration,
+		AverageSpeed:   a.AverageSpeed,
+		MaxSpeed:       a.MaxSpeed,
+		Segments:       geom.NewFeatureCollection(segments),
+		Stops:          geom.NewFeatureCollection(stops),
+		Moves:          geom.NewFeatureCollection(moves),
+	}
+}
@@ -0,0 +1,198 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/track"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestAnalyzeTrack(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	geojsonTrack := `{"type": "Feature", "properties": {"coordTimes": ["2024-05-01T08:00:00Z", "2024-05-01T08:00:10Z", "2024-05-01T08:10:10Z"]},
+		"geometry": {"type": "LineString", "coordinates": [[0, 0], [0.001, 0], [0.001, 0]]}}`
+	gpxTrack := `<gpx><trk><trkseg>
+		<trkpt lat="0" lon="0"><time>2024-05-01T08:00:00Z</time></trkpt>
+		<trkpt lat="0" lon="0.001"><time>2024-05-01T08:00:10Z</time></trkpt>
+		<trkpt lat="0" lon="0.001"><time>2024-05-01T08:10:10Z</time></trkpt>
+	</trkseg></trk></gpx>`
+	analyze := func(points []algo.Point, opts algo.StopOptions) (*algo.Analysis, error) {
+		if len(points) != 3 || points[1].Lng != 0.001 {
+			return nil, errors.New("unexpected points")
+		}
+		return algo.Analyze(points, opts)
+	}
+
+	tests := map[string]struct {
+		mockAnalyze func(points []algo.Point, opts algo.StopOptions) (*algo.Analysis, error)
+		query       string
+		payload     string
+		stops       int
+		wantErr     bool
+		err         error
+		args        args
+	}{
+		"geojson": {
+			mockAnalyze: analyze,
+			payload:     geojsonTrack,
+			stops:       1,
+		},
+		"gpx with options": {
+			mockAnalyze: func(points []algo.Point, opts algo.StopOptions) (*algo.Analysis, error) {
+				if opts.Radius != 10 || opts.Duration != 15*time.Minute {
+					return nil, errors.New("unexpected options")
+				}
+				return analyze(points, opts)
+			},
+			query:   "?stopRadius=10&stopDuration=900",
+			payload: gpxTrack,
+			stops:   0,
+		},
+		"invalid stop radius": {
+			query:   "?stopRadius=near",
+			payload: geojsonTrack,
+			wantErr: true,
+			err:     NewResponseError(errors.New("invalid stopRadius"), http.StatusBadRequest),
+		},
+		"untimed track": {
+			payload: `{"type": "LineString", "coordinates": [[0, 0], [1, 1]]}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("coordTimes can't be empty"), http.StatusBadRequest),
+		},
+		"invalid gpx": {
+			payload: `<gpx>`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("cannot decode the GPX document"), http.StatusBadRequest),
+		},
+		"service error": {
+			mockAnalyze: func(points []algo.Point, opts algo.StopOptions) (*algo.Analysis, error) {
+				return nil, errors.New("the times of the points must be increasing")
+			},
+			payload: geojsonTrack,
+			wantErr: true,
+			err:     NewResponseError(errors.New("the times of the points must be increasing"), http.StatusBadRequest),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/track/analyze"+tt.query, strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockTrackRepository()
+			MockSvc.AnalyzeTrackFn = tt.mockAnalyze
+			h := NewTrackHandler(MockSvc)
+			got, err := h.analyzeRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "analyze() error = %v,expected = %v", err, tt.err)
+				return
+			}
+
+			res := got.Payload.(TrackAnalysisResponse)
+			assert.InDelta(t, 111.2, res.Distance, 0.1)
+			assert.Equal(t, 610.0, res.Duration)
+			assert.Len(t, res.Segments.Features, 2)
+			assert.Equal(t, "2024-05-01T08:00:10Z", res.Segments.Features[1].Properties["start"])
+			assert.Len(t, res.Stops.Features, tt.stops)
+			if tt.stops > 0 {
+				assert.Equal(t, 2, res.Stops.Features[0].Properties["count"])
+				p, err := res.Stops.Features[0].Geometry.ToPoint()
+				assert.NoError(t, err)
+				assert.Equal(t, geometry.Point{Lng: 0.001}, *p)
+				assert.Len(t, res.Moves.Features, 1)
+			}
+		})
+	}
+}
+
+func TestCleanTrack(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	payload := `{"type": "Feature", "properties": {"coordTimes": ["2024-05-01T08:00:00Z", "2024-05-01T08:00:10Z", "2024-05-01T08:00:20Z", "2024-05-01T08:00:30Z"]},
+		"geometry": {"type": "LineString", "coordinates": [[0, 0], [0.001, 0], [0.05, 0], [0.002, 0]]}}`
+
+	tests := map[string]struct {
+		mockClean func(points []algo.Point, opts algo.CleanOptions) (*algo.Cleaned, error)
+		query     string
+		wantErr   bool
+		err       error
+		args      args
+	}{
+		"defaults": {
+			mockClean: func(points []algo.Point, opts algo.CleanOptions) (*algo.Cleaned, error) {
+				want := algo.CleanOptions{MaxSpeed: 70, JitterRadius: 3, Smoother: algo.RTS, ProcessNoise: 1, MeasurementNoise: 10}
+				if opts != want {
+					return nil, errors.New("unexpected options")
+				}
+				return algo.Clean(points, algo.CleanOptions{MaxSpeed: 70, Smoother: algo.NoSmoother})
+			},
+		},
+		"options": {
+			mockClean: func(points []algo.Point, opts algo.CleanOptions) (*algo.Cleaned, error) {
+				want := algo.CleanOptions{MaxSpeed: 40, JitterRadius: 0, Smoother: algo.Kalman, ProcessNoise: 0.5, MeasurementNoise: 5}
+				if opts != want {
+					return nil, errors.New("unexpected options")
+				}
+				return algo.Clean(points, algo.CleanOptions{MaxSpeed: 70, Smoother: algo.NoSmoother})
+			},
+			query: "?maxSpeed=40&jitterRadius=0&smoother=kalman&processNoise=0.5&measurementNoise=5",
+		},
+		"invalid smoother": {
+			query:   "?smoother=median",
+			wantErr: true,
+			err:     NewResponseError(errors.New(`unsupported smoother "median", expected one of none, kalman or rts`), http.StatusBadRequest),
+		},
+		"invalid max speed": {
+			query:   "?maxSpeed=fast",
+			wantErr: true,
+			err:     NewResponseError(errors.New("invalid maxSpeed"), http.StatusBadRequest),
+		},
+		"service error": {
+			mockClean: func(points []algo.Point, opts algo.CleanOptions) (*algo.Cleaned, error) {
+				return nil, errors.New("max speed must be a positive number")
+			},
+			query:   "?maxSpeed=-1",
+			wantErr: true,
+			err:     NewResponseError(errors.New("max speed must be a positive number"), http.StatusBadRequest),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/track/clean"+tt.query, strings.NewReader(payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockTrackRepository()
+			MockSvc.CleanTrackFn = tt.mockClean
+			h := NewTrackHandler(MockSvc)
+			got, err := h.cleanRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "clean() error = %v,expected = %v", err, tt.err)
+				return
+			}
+
+			res := got.Payload.(TrackCleanResponse)
+			assert.Equal(t, TrackCleanReport{Input: 4, Output: 3, Dropped: 1, DistanceBefore: res.Report.DistanceBefore, DistanceAfter: res.Report.DistanceAfter}, res.Report)
+			assert.InDelta(t, 222.4, res.Report.DistanceAfter, 0.1)
+			assert.Greater(t, res.Report.DistanceBefore, 10000.0)
+			assert.Equal(t, []interface{}{"2024-05-01T08:00:00Z", "2024-05-01T08:00:10Z", "2024-05-01T08:00:30Z"}, res.Track.Properties["coordTimes"])
+			assert.Len(t, res.Dropped.Features, 1)
+			assert.Equal(t, map[string]interface{}{"index": 2, "time": "2024-05-01T08:00:20Z", "reason": "speed"}, res.Dropped.Features[0].Properties)
+		})
+	}
+}
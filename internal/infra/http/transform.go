@@ -0,0 +1,107 @@
+package http
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/tomchavakis/geo-api/internal/app/transform"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// TransformHandler struct
+type TransformHandler struct {
+	transformSvc transform.Service
+}
+
+// NewTransformHandler handler
+func NewTransformHandler(tSvc transform.Service) *TransformHandler {
+	th := &TransformHandler{
+		transformSvc: tSvc,
+	}
+	return th
+}
+
+// TransformMessage holds the geometry to transform, as any GeoJSON object, and the parameters of the
+// transform: a distance in units and a bearing to translate, an angle in degrees to rotate clockwise
+// or a factor to scale. Rotations and scalings are made about the pivot, or about the centroid of the
+// geometry when it is missing.
+type TransformMessage struct {
+	Geometry json.RawMessage `json:"geometry"`
+	Distance *float64        `json:"distance,omitempty"`
+	Bearing  *float64        `json:"bearing,omitempty"`
+	Units    string          `json:"units"`
+	Angle    *float64        `json:"angle,omitempty"`
+	Factor   *float64        `json:"factor,omitempty"`
+	Pivot    *geometry.Point `json:"pivot,omitempty"`
+}
+
+func (th *TransformHandler) transformRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	op := chi.URLParam(r, "op")
+	if op != "translate" && op != "rotate" && op != "scale" {
+		err := fmt.Errorf("unsupported transform %q, expected one of translate, rotate or scale", op)
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	if r.Body == nil {
+		err := errors.New("invalid Body")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	var tm TransformMessage
+	err := json.NewDecoder(r.Body).Decode(&tm)
+	if err != nil {
+		return nil, NewResponseError(errors.New("invalid input"), http.StatusBadRequest)
+	}
+
+	if len(tm.Geometry) == 0 {
+		err := errors.New("geometry can't be empty")
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	fs, err := geom.Decode(tm.Geometry)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	gs := make([]geometry.Geometry, 0, len(fs))
+	for i := range fs {
+		gs = append(gs, fs[i].Geometry)
+	}
+
+	var res []geometry.Geometry
+	switch op {
+	case "translate":
+		if tm.Distance == nil {
+			return nil, NewResponseError(errors.New("distance can't be empty"), http.StatusBadRequest)
+		}
+		if tm.Bearing == nil {
+			return nil, NewResponseError(errors.New("bearing can't be empty"), http.StatusBadRequest)
+		}
+		res, err = th.transformSvc.Translate(gs, *tm.Distance, *tm.Bearing, tm.Units)
+	case "rotate":
+		if tm.Angle == nil {
+			return nil, NewResponseError(errors.New("angle can't be empty"), http.StatusBadRequest)
+		}
+		res, err = th.transformSvc.Rotate(gs, *tm.Angle, tm.Pivot)
+	default:
+		if tm.Factor == nil {
+			return nil, NewResponseError(errors.New("factor can't be empty"), http.StatusBadRequest)
+		}
+		res, err = th.transformSvc.Scale(gs, *tm.Factor, tm.Pivot)
+	}
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if len(res) != len(fs) {
+		err := errors.New("unexpected number of geometries")
+		return nil, NewResponseError(err, http.StatusInternalServerError)
+	}
+
+	for i := range fs {
+		fs[i].Geometry = res[i]
+	}
+
+	return NewResponse(geom.NewFeatureCollection(fs), http.StatusOK), nil
+}
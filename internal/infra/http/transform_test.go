@@ -0,0 +1,119 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestTransform(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	footprint := `{"type": "Feature", "properties": {"id": "b1"}, "geometry": {"type": "Polygon", "coordinates": [[[0, 0], [1, 0], [1, 1], [0, 1], [0, 0]]]}}`
+	res := geom.PolygonGeometry([][]geometry.Point{{{Lat: 0, Lng: 0}, {Lat: -1, Lng: 0}, {Lat: -1, Lng: 1}, {Lat: 0, Lng: 1}, {Lat: 0, Lng: 0}}})
+	fc := geom.NewFeatureCollection([]feature.Feature{geom.NewFeature(res, map[string]interface{}{"id": "b1"})})
+
+	tests := map[string]struct {
+		mockTranslate func(gs []geometry.Geometry, distance float64, bearing float64, units string) ([]geometry.Geometry, error)
+		mockRotate    func(gs []geometry.Geometry, angle float64, pivot *geometry.Point) ([]geometry.Geometry, error)
+		mockScale     func(gs []geometry.Geometry, factor float64, origin *geometry.Point) ([]geometry.Geometry, error)
+		want          *Response
+		op            string
+		payload       string
+		wantErr       bool
+		err           error
+		args          args
+	}{
+		"invalid transform": {
+			want:    nil,
+			op:      "skew",
+			payload: `{"geometry": ` + footprint + `}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New(`unsupported transform "skew", expected one of translate, rotate or scale`), http.StatusBadRequest),
+		},
+		"empty geometry": {
+			want:    nil,
+			op:      "rotate",
+			payload: `{"angle": 90}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("geometry can't be empty"), http.StatusBadRequest),
+		},
+		"empty bearing": {
+			want:    nil,
+			op:      "translate",
+			payload: `{"geometry": ` + footprint + `, "distance": 10}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("bearing can't be empty"), http.StatusBadRequest),
+		},
+		"service error": {
+			want: nil,
+			mockScale: func(gs []geometry.Geometry, factor float64, origin *geometry.Point) ([]geometry.Geometry, error) {
+				return nil, errors.New("factor must be a positive number")
+			},
+			op:      "scale",
+			payload: `{"geometry": ` + footprint + `, "factor": -1}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("factor must be a positive number"), http.StatusBadRequest),
+		},
+		"translate": {
+			want: NewResponse(fc, http.StatusOK),
+			mockTranslate: func(gs []geometry.Geometry, distance float64, bearing float64, units string) ([]geometry.Geometry, error) {
+				if len(gs) != 1 || distance != 111 || bearing != 180 || units != "kilometers" {
+					return nil, errors.New("unexpected arguments")
+				}
+				return []geometry.Geometry{res}, nil
+			},
+			op:      "translate",
+			payload: `{"geometry": ` + footprint + `, "distance": 111, "bearing": 180, "units": "kilometers"}`,
+			wantErr: false,
+			err:     nil,
+		},
+		"rotate about a pivot": {
+			want: NewResponse(fc, http.StatusOK),
+			mockRotate: func(gs []geometry.Geometry, angle float64, pivot *geometry.Point) ([]geometry.Geometry, error) {
+				if angle != 90 || pivot == nil || *pivot != (geometry.Point{Lat: 0, Lng: 0}) {
+					return nil, errors.New("unexpected arguments")
+				}
+				return []geometry.Geometry{res}, nil
+			},
+			op:      "rotate",
+			payload: `{"geometry": ` + footprint + `, "angle": 90, "pivot": {"Lat": 0, "Lng": 0}}`,
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/transform/"+tt.op, strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			rctx := chi.NewRouteContext()
+			rctx.URLParams.Add("op", tt.op)
+			tt.args.r = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+
+			MockSvc := mock.NewMockTransformRepository()
+			MockSvc.TranslateFn = tt.mockTranslate
+			MockSvc.RotateFn = tt.mockRotate
+			MockSvc.ScaleFn = tt.mockScale
+			h := NewTransformHandler(MockSvc)
+			got, err := h.transformRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "transform() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "transform() got = %v, want %v", got, tt.want)
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package http
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/tomchavakis/geo-api/internal/app/validate"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	algo "github.com/tomchavakis/geo-api/internal/spatial/validate"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// ValidateHandler struct
+type ValidateHandler struct {
+	validateSvc validate.Service
+}
+
+// NewValidateHandler handler
+func NewValidateHandler(vSvc validate.Service) *ValidateHandler {
+	vh := &ValidateHandler{
+		validateSvc: vSvc,
+	}
+	return vh
+}
+
+// ValidationMessage reports whether the input is valid and the problems of its features.
+type ValidationMessage struct {
+	Valid    bool             `json:"valid"`
+	Problems []FeatureProblem `json:"problems"`
+}
+
+// FeatureProblem is a validity problem of the feature at the given index of the input.
+type FeatureProblem struct {
+	Feature int `json:"feature"`
+	algo.Problem
+}
+
+func (vh *ValidateHandler) validateRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	gs, err := decodeGeometries(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	res, err := vh.validateSvc.Validate(gs)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	vm := ValidationMessage{Problems: []FeatureProblem{}}
+	for i, ps := range res {
+		for _, p := range ps {
+			vm.Problems = append(vm.Problems, FeatureProblem{Feature: i, Problem: p})
+		}
+	}
+	vm.Valid = len(vm.Problems) == 0
+
+	return NewResponse(vm, http.StatusOK), nil
+}
+
+// makeValidRoute repairs the geometries of any GeoJSON object and returns them as a
+// FeatureCollection, keeping the properties of the features.
+func (vh *ValidateHandler) makeValidRoute(w http.ResponseWriter, r *http.Request) (*Response, error) {
+	fs, err := decodeFeatures(r)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+
+	gs := make([]geometry.Geometry, 0, len(fs))
+	for i := range fs {
+		gs = append(gs, fs[i].Geometry)
+	}
+	res, err := vh.validateSvc.MakeValid(gs)
+	if err != nil {
+		return nil, NewResponseError(err, http.StatusBadRequest)
+	}
+	if len(res) != len(fs) {
+		err := errors.New("unexpected number of geometries")
+		return nil, NewResponseError(err, http.StatusInternalServerError)
+	}
+	for i := range fs {
+		fs[i].Geometry = res[i]
+	}
+
+	return NewResponse(geom.NewFeatureCollection(fs), http.StatusOK), nil
+}
+
+// decodeFeatures reads the features of any GeoJSON object sent as the request body.
+func decodeFeatures(r *http.Request) ([]feature.Feature, error) {
+	if r.Body == nil {
+		return nil, errors.New("invalid Body")
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, errors.New("invalid input")
+	}
+
+	return geom.Decode(body)
+}
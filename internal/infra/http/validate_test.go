@@ -0,0 +1,147 @@
+package http
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/validate"
+	"github.com/tomchavakis/geo-api/test/mock"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestValidate(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	bowtie := `{"type": "Polygon", "coordinates": [[[0, 0], [2, 2], [2, 0], [0, 2], [0, 0]]]}`
+	problem := validate.Problem{
+		Code:     validate.SelfIntersection,
+		Message:  "segment 0 intersects segment 2 of the same ring",
+		Location: &geometry.Point{Lat: 1, Lng: 1},
+		Path:     []int{0, 0},
+	}
+
+	tests := map[string]struct {
+		mockValidate func(gs []geometry.Geometry) ([][]validate.Problem, error)
+		want         *Response
+		payload      string
+		wantErr      bool
+		err          error
+		args         args
+	}{
+		"invalid input": {
+			want:    nil,
+			payload: `{"type": "Circle"}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New(`unsupported geojson type "Circle"`), http.StatusBadRequest),
+		},
+		"valid": {
+			want: NewResponse(ValidationMessage{Valid: true, Problems: []FeatureProblem{}}, http.StatusOK),
+			mockValidate: func(gs []geometry.Geometry) ([][]validate.Problem, error) {
+				return [][]validate.Problem{{}}, nil
+			},
+			payload: `{"type": "Point", "coordinates": [0, 0]}`,
+			wantErr: false,
+			err:     nil,
+		},
+		"invalid": {
+			want: NewResponse(ValidationMessage{Valid: false, Problems: []FeatureProblem{{Feature: 1, Problem: problem}}}, http.StatusOK),
+			mockValidate: func(gs []geometry.Geometry) ([][]validate.Problem, error) {
+				if len(gs) != 2 {
+					return nil, errors.New("unexpected arguments")
+				}
+				return [][]validate.Problem{{}, {problem}}, nil
+			},
+			payload: `{"type": "GeometryCollection", "geometries": [{"type": "Point", "coordinates": [0, 0]}, ` + bowtie + `]}`,
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/validate", strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockValidateRepository()
+			MockSvc.ValidateFn = tt.mockValidate
+			h := NewValidateHandler(MockSvc)
+			got, err := h.validateRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "validate() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "validate() got = %v, want %v", got, tt.want)
+		})
+	}
+}
+
+func TestMakeValid(t *testing.T) {
+	type args struct {
+		w http.ResponseWriter
+		r *http.Request
+	}
+
+	res := geom.PointGeometry(geometry.Point{Lat: 1, Lng: 1})
+	fc := geom.NewFeatureCollection([]feature.Feature{geom.NewFeature(res, map[string]interface{}{"name": "a"})})
+
+	tests := map[string]struct {
+		mockMakeValid func(gs []geometry.Geometry) ([]geometry.Geometry, error)
+		want          *Response
+		payload       string
+		wantErr       bool
+		err           error
+		args          args
+	}{
+		"empty body": {
+			want:    nil,
+			payload: ``,
+			wantErr: true,
+			err:     NewResponseError(errors.New("cannot decode the input value"), http.StatusBadRequest),
+		},
+		"service error": {
+			want: nil,
+			mockMakeValid: func(gs []geometry.Geometry) ([]geometry.Geometry, error) {
+				return nil, errors.New("the line has no valid position")
+			},
+			payload: `{"type": "LineString", "coordinates": []}`,
+			wantErr: true,
+			err:     NewResponseError(errors.New("the line has no valid position"), http.StatusBadRequest),
+		},
+		"happy path": {
+			want: NewResponse(fc, http.StatusOK),
+			mockMakeValid: func(gs []geometry.Geometry) ([]geometry.Geometry, error) {
+				return []geometry.Geometry{res}, nil
+			},
+			payload: `{"type": "Feature", "properties": {"name": "a"}, "geometry": {"type": "LineString", "coordinates": [[1, 1], [1, 1]]}}`,
+			wantErr: false,
+			err:     nil,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			req, err := http.NewRequest("POST", "/api/v1/makevalid", strings.NewReader(tt.payload))
+			assert.NoError(t, err)
+			tt.args.r = req
+
+			MockSvc := mock.NewMockValidateRepository()
+			MockSvc.MakeValidFn = tt.mockMakeValid
+			h := NewValidateHandler(MockSvc)
+			got, err := h.makeValidRoute(tt.args.w, tt.args.r)
+			if tt.wantErr || err != nil {
+				assert.Equal(t, tt.err, err, "makeValid() error = %v,expected = %v", err, tt.err)
+				return
+			}
+			assert.Equal(t, tt.want, got, "makeValid() got = %v, want %v", got, tt.want)
+		})
+	}
+}
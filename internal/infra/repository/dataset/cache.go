@@ -0,0 +1,59 @@
+package dataset
+
+import (
+	"container/list"
+	"sync"
+)
+
+// cache keeps the most recently used tiles up to a number of bytes.
+type cache struct {
+	mu      sync.Mutex
+	size    int
+	limit   int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+type entry struct {
+	key  string
+	data []byte
+}
+
+func newCache(limit int) *cache {
+	return &cache{limit: limit, order: list.New(), entries: map[string]*list.Element{}}
+}
+
+func (c *cache) get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*entry).data, true
+}
+
+// add stores a tile, evicting the least recently used ones beyond the limit. Tiles larger than the
+// limit aren't stored.
+func (c *cache) add(key string, data []byte) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if len(data) > c.limit {
+		return
+	}
+	if e, ok := c.entries[key]; ok {
+		c.size += len(data) - len(e.Value.(*entry).data)
+		e.Value.(*entry).data = data
+		c.order.MoveToFront(e)
+	} else {
+		c.entries[key] = c.order.PushFront(&entry{key: key, data: data})
+		c.size += len(data)
+	}
+	for c.size > c.limit {
+		e := c.order.Back()
+		c.order.Remove(e)
+		delete(c.entries, e.Value.(*entry).key)
+		c.size -= len(e.Value.(*entry).data)
+	}
+}
@@ -0,0 +1,117 @@
+package dataset
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tomchavakis/geo-api/internal/app/tiles"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/mvt"
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+)
+
+// cacheSize bounds the bytes of the encoded tiles kept in memory.
+const cacheSize = 64 << 20
+
+// dataset is a stored GeoJSON file with the version of its content.
+type dataset struct {
+	version  string
+	features []*mvt.Feature
+}
+
+// Repository serves the GeoJSON files of a directory as vector tiles. The name of a dataset is the
+// name of its file without the .geojson or .json extension. The files are read once, when the
+// repository is built.
+type Repository struct {
+	datasets map[string]*dataset
+	cache    *cache
+}
+
+// New reads the datasets of a directory, none when it's empty.
+func New(dir string) (*Repository, error) {
+	r := &Repository{datasets: map[string]*dataset{}, cache: newCache(cacheSize)}
+	if dir == "" {
+		return r, nil
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		ext := filepath.Ext(e.Name())
+		if e.IsDir() || (ext != ".geojson" && ext != ".json") {
+			continue
+		}
+		name := strings.TrimSuffix(e.Name(), ext)
+		if _, ok := r.datasets[name]; ok {
+			return nil, fmt.Errorf("dataset %q is stored twice", name)
+		}
+		ds, err := load(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, fmt.Errorf("dataset %q: %w", name, err)
+		}
+		r.datasets[name] = ds
+	}
+	return r, nil
+}
+
+func load(path string) (*dataset, error) {
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := geom.Decode(b)
+	if err != nil {
+		return nil, err
+	}
+	ds := &dataset{features: make([]*mvt.Feature, 0, len(fs))}
+	for _, f := range fs {
+		mf, err := mvt.NewFeature(f)
+		if err != nil {
+			return nil, err
+		}
+		ds.features = append(ds.features, mf)
+	}
+	sum := sha256.Sum256(b)
+	ds.version = hex.EncodeToString(sum[:8])
+	return ds, nil
+}
+
+// GetTileETag returns the entity tag of a tile, which changes with the content of the dataset.
+func (r *Repository) GetTileETag(name string, t tile.Tile) (string, error) {
+	ds, ok := r.datasets[name]
+	if !ok {
+		return "", tiles.ErrDatasetNotFound
+	}
+	return fmt.Sprintf(`"%s-%d-%d-%d"`, ds.version, t.Z, t.X, t.Y), nil
+}
+
+// GetVectorTile returns a tile of a dataset with its features in a layer of the dataset name,
+// empty when none of them reaches the tile.
+func (r *Repository) GetVectorTile(name string, t tile.Tile) ([]byte, error) {
+	ds, ok := r.datasets[name]
+	if !ok {
+		return nil, tiles.ErrDatasetNotFound
+	}
+
+	key := fmt.Sprintf("%s/%s/%d/%d/%d", name, ds.version, t.Z, t.X, t.Y)
+	if b, ok := r.cache.get(key); ok {
+		return b, nil
+	}
+
+	b := mvt.Bounds(t)
+	var fs []*mvt.Feature
+	for _, f := range ds.features {
+		if f.Intersects(b) {
+			fs = append(fs, f)
+		}
+	}
+	res := mvt.Encode(t, []mvt.Layer{{Name: name, Features: fs}})
+	r.cache.add(key, res)
+	return res, nil
+}
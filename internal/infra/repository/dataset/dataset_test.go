@@ -0,0 +1,79 @@
+package dataset
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/app/tiles"
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+)
+
+const roads = `{"type":"FeatureCollection","features":[
+	{"type":"Feature","id":"1","geometry":{"type":"LineString","coordinates":[[23.7,37.9],[23.8,38.0]]},"properties":{"name":"a"}}
+]}`
+
+func TestRepository(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "roads.geojson"), []byte(roads), 0o600))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "notes.txt"), []byte("not a dataset"), 0o600))
+
+	r, err := New(dir)
+	assert.NoError(t, err)
+	assert.Len(t, r.datasets, 1)
+
+	etag, err := r.GetTileETag("roads", tile.Tile{Z: 1, X: 1, Y: 0})
+	assert.NoError(t, err)
+	assert.Regexp(t, `^"[0-9a-f]{16}-1-1-0"$`, etag)
+
+	b, err := r.GetVectorTile("roads", tile.Tile{Z: 1, X: 1, Y: 0})
+	assert.NoError(t, err)
+	assert.NotEmpty(t, b)
+	cached, ok := r.cache.get("roads/" + r.datasets["roads"].version + "/1/1/0")
+	assert.True(t, ok)
+	assert.Equal(t, b, cached)
+
+	b, err = r.GetVectorTile("roads", tile.Tile{Z: 1, X: 0, Y: 1})
+	assert.NoError(t, err)
+	assert.Empty(t, b)
+
+	_, err = r.GetVectorTile("rivers", tile.Tile{})
+	assert.Equal(t, tiles.ErrDatasetNotFound, err)
+	_, err = r.GetTileETag("rivers", tile.Tile{})
+	assert.Equal(t, tiles.ErrDatasetNotFound, err)
+}
+
+func TestNewErrors(t *testing.T) {
+	r, err := New("")
+	assert.NoError(t, err)
+	assert.Empty(t, r.datasets)
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "bad.json"), []byte("{"), 0o600))
+	_, err = New(dir)
+	assert.EqualError(t, err, `dataset "bad": cannot decode the input value`)
+
+	_, err = New(filepath.Join(dir, "missing"))
+	assert.Error(t, err)
+}
+
+func TestCache(t *testing.T) {
+	c := newCache(10)
+	c.add("a", make([]byte, 4))
+	c.add("b", make([]byte, 4))
+	_, _ = c.get("a")
+	c.add("c", make([]byte, 4))
+
+	_, ok := c.get("b")
+	assert.False(t, ok)
+	_, ok = c.get("a")
+	assert.True(t, ok)
+	_, ok = c.get("c")
+	assert.True(t, ok)
+	assert.Equal(t, 8, c.size)
+
+	c.add("d", make([]byte, 11))
+	_, ok = c.get("d")
+	assert.False(t, ok)
+}
@@ -0,0 +1,102 @@
+package gazetteer
+
+import (
+	"bufio"
+	"io"
+	"os"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/gazetteer"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Files are the gazetteer datasets: the GeoJSON boundaries of the countries and of their first
+// level divisions, such as the Natural Earth admin 0 and admin 1 ones, a GeoNames dump of the
+// places and an OpenAddresses CSV file of the addresses.
+type Files struct {
+	Countries string
+	Regions   string
+	Places    string
+	Addresses string
+}
+
+// Repository geocodes offline with the gazetteer datasets, read once when the repository is built.
+// The places and the addresses are geocoded, the populated places only being reverse geocoded.
+type Repository struct {
+	reverser *gazetteer.Reverser
+	geocoder *gazetteer.Geocoder
+}
+
+// New reads the gazetteer datasets, leaving out the ones of an empty path. The repository returns
+// gazetteer.ErrNoGazetteer when none is loaded.
+func New(files Files) (*Repository, error) {
+	if files == (Files{}) {
+		return &Repository{}, nil
+	}
+	countries, err := loadRegions(files.Countries, gazetteer.Country)
+	if err != nil {
+		return nil, err
+	}
+	regions, err := loadRegions(files.Regions, gazetteer.Admin1)
+	if err != nil {
+		return nil, err
+	}
+	places, err := load(files.Places, gazetteer.ReadGeoNames)
+	if err != nil {
+		return nil, err
+	}
+	addresses, err := load(files.Addresses, gazetteer.ReadOpenAddresses)
+	if err != nil {
+		return nil, err
+	}
+
+	r := &Repository{reverser: gazetteer.NewReverser(countries, regions, places)}
+	if len(places)+len(addresses) > 0 {
+		r.geocoder = gazetteer.NewGeocoder(append(places, addresses...), countries, regions)
+	}
+	return r, nil
+}
+
+func loadRegions(path string, level gazetteer.Level) ([]gazetteer.Region, error) {
+	if path == "" {
+		return nil, nil
+	}
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := geom.Decode(b)
+	if err != nil {
+		return nil, err
+	}
+	return gazetteer.Regions(fs, level)
+}
+
+func load(path string, read func(io.Reader) ([]gazetteer.Place, error)) ([]gazetteer.Place, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return read(bufio.NewReader(f))
+}
+
+// ReverseGeocode returns the country, the first level division and the nearest populated place of
+// a point.
+func (r *Repository) ReverseGeocode(p geometry.Point) (*gazetteer.Location, error) {
+	if r.reverser == nil {
+		return nil, gazetteer.ErrNoGazetteer
+	}
+	return r.reverser.Reverse(p)
+}
+
+// Geocode returns the places and the addresses matching a query, the best first.
+func (r *Repository) Geocode(q gazetteer.Query) ([]gazetteer.Candidate, error) {
+	if r.geocoder == nil {
+		return nil, gazetteer.ErrNoGazetteer
+	}
+	return r.geocoder.Geocode(q)
+}
@@ -0,0 +1,69 @@
+package gazetteer
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/gazetteer"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+const regions = `{"type":"FeatureCollection","features":[
+	{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[23,37],[25,37],[25,39],[23,39],[23,37]]]},"properties":{"iso_a2":"GR","iso_3166_2":"GR-I","name":"Attica"}}
+]}`
+
+const places = "264371\tAthens\tAthens\t\t37.98376\t23.72784\tP\tPPLC\tGR\t\tESYE31\t\t\t\t664046\t\t70\tEurope/Athens\t2022-03-09\n"
+
+func TestReverseGeocode(t *testing.T) {
+	dir := t.TempDir()
+	files := Files{Regions: filepath.Join(dir, "admin1.geojson"), Places: filepath.Join(dir, "cities500.txt")}
+	assert.NoError(t, os.WriteFile(files.Regions, []byte(regions), 0o600))
+	assert.NoError(t, os.WriteFile(files.Places, []byte(places), 0o600))
+
+	r, err := New(files)
+	assert.NoError(t, err)
+	loc, err := r.ReverseGeocode(geometry.Point{Lat: 38, Lng: 23.7})
+	assert.NoError(t, err)
+	assert.Equal(t, "GR", loc.Country.CountryCode)
+	assert.Equal(t, "Attica", loc.Admin1.Name)
+	assert.Equal(t, "Athens", loc.Place.Name)
+
+	cs, err := r.Geocode(gazetteer.Query{Text: "athen"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Athens", cs[0].Place.Name)
+}
+
+func TestGeocode(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "addresses.csv")
+	assert.NoError(t, os.WriteFile(path, []byte("LON,LAT,NUMBER,STREET\n23.7275,37.9838,12,Ermou\n"), 0o600))
+
+	r, err := New(Files{Addresses: path})
+	assert.NoError(t, err)
+	cs, err := r.Geocode(gazetteer.Query{Text: "12 ermou"})
+	assert.NoError(t, err)
+	assert.Equal(t, "12 Ermou", cs[0].Place.Name)
+	// addresses aren't populated places
+	loc, err := r.ReverseGeocode(geometry.Point{Lat: 37.9838, Lng: 23.7275})
+	assert.NoError(t, err)
+	assert.Nil(t, loc.Place)
+}
+
+func TestNewErrors(t *testing.T) {
+	r, err := New(Files{})
+	assert.NoError(t, err)
+	_, err = r.ReverseGeocode(geometry.Point{})
+	assert.Equal(t, gazetteer.ErrNoGazetteer, err)
+	_, err = r.Geocode(gazetteer.Query{Text: "athens"})
+	assert.Equal(t, gazetteer.ErrNoGazetteer, err)
+
+	dir := t.TempDir()
+	_, err = New(Files{Places: filepath.Join(dir, "missing.txt")})
+	assert.Error(t, err)
+
+	path := filepath.Join(dir, "lines.geojson")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"type":"LineString","coordinates":[[0,0],[1,1]]}`), 0o600))
+	_, err = New(Files{Countries: path})
+	assert.EqualError(t, err, "boundaries must be Polygon or MultiPolygon features")
+}
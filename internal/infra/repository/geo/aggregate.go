@@ -0,0 +1,54 @@
+package measurement
+
+import (
+	"errors"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/aggregate"
+	"github.com/tomchavakis/geo-api/internal/spatial/bbox"
+	"github.com/tomchavakis/geo-api/internal/spatial/grid"
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/conversions"
+)
+
+// Aggregate returns the number of points inside each zone, given as a list of polygons, and the statistics of the
+// chosen properties.
+func (r *Repository) Aggregate(zones [][][][]geometry.Point, points []aggregate.Point, properties []string) ([]aggregate.Stats, error) {
+	ix := aggregate.NewIndex(points, properties)
+	res := make([]aggregate.Stats, 0, len(zones))
+	for _, z := range zones {
+		res = append(res, ix.Summarize(z))
+	}
+	return res, nil
+}
+
+// AggregateHexGrid bins the points into a grid of hexagons of a size in the given units over their bounding box and
+// returns the hexagons holding at least one point.
+func (r *Repository) AggregateHexGrid(points []aggregate.Point, cellSize float64, units string, properties []string) ([]aggregate.Cell, error) {
+	rad, err := conversions.LengthToRadians(cellSize, units)
+	if err != nil {
+		return nil, err
+	}
+	if !(rad > 0) {
+		return nil, errors.New("cell size must be a positive number")
+	}
+
+	ps := make([]geometry.Point, 0, len(points))
+	for _, p := range points {
+		ps = append(ps, p.Point)
+	}
+	b, err := bbox.Of(ps)
+	if err != nil {
+		return nil, err
+	}
+	// the hexagons are centred in the box and leave a margin narrower than two radii on its edges
+	b, err = bbox.Expand(*b, 2*cellSize, units)
+	if err != nil {
+		return nil, err
+	}
+
+	g, err := grid.New(grid.Options{Kind: grid.Hexagons, BBox: *b, CellSize: rad})
+	if err != nil {
+		return nil, err
+	}
+	return aggregate.NewIndex(points, properties).Bins(g)
+}
@@ -0,0 +1,37 @@
+package measurement
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/s2"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// GetCellID returns the S2 cell containing the point at the given level.
+func (r *Repository) GetCellID(p geometry.Point, level int) (*s2.CellID, error) {
+	id, err := s2.CellIDFromPoint(p, level)
+	if err != nil {
+		return nil, err
+	}
+
+	return &id, nil
+}
+
+// GetCellPolygon returns the boundary of an S2 cell as a polygon.
+func (r *Repository) GetCellPolygon(id s2.CellID) (*geometry.Polygon, error) {
+	v := id.Vertices()
+	ring, err := geometry.NewLineString([]geometry.Point{v[0], v[1], v[2], v[3], v[0]})
+	if err != nil {
+		return nil, err
+	}
+
+	return geometry.NewPolygon([]geometry.LineString{*ring})
+}
+
+// GetCellCovering returns the S2 cells covering the polygons.
+func (r *Repository) GetCellCovering(polygons [][][]geometry.Point, minLevel, maxLevel, maxCells int) ([]s2.CellID, error) {
+	rc, err := s2.NewCoverer(minLevel, maxLevel, maxCells)
+	if err != nil {
+		return nil, err
+	}
+
+	return rc.Covering(polygons)
+}
@@ -0,0 +1,99 @@
+package measurement
+
+import (
+	"errors"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/bbox"
+	"github.com/tomchavakis/geo-api/internal/spatial/centre"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// GetCentroid returns the arithmetic mean of the vertices of the geometries.
+func (r *Repository) GetCentroid(gs []geometry.Geometry) (*geometry.Point, error) {
+	parts, err := partsOf(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	return centre.Centroid(*parts)
+}
+
+// GetCenterOfMass returns the centroid of the geometries weighted by their area, or by their length for lines.
+func (r *Repository) GetCenterOfMass(gs []geometry.Geometry) (*geometry.Point, error) {
+	parts, err := partsOf(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	return centre.CenterOfMass(*parts)
+}
+
+// GetBBoxCenter returns the center of the bounding box of the geometries.
+func (r *Repository) GetBBoxCenter(gs []geometry.Geometry) (*geometry.Point, error) {
+	b, err := r.GetBBox(gs)
+	if err != nil {
+		return nil, err
+	}
+	c := bbox.Center(*b)
+
+	return &c, nil
+}
+
+// GetPointOnSurface returns a point guaranteed to lie on the geometries.
+func (r *Repository) GetPointOnSurface(gs []geometry.Geometry) (*geometry.Point, error) {
+	parts, err := partsOf(gs)
+	if err != nil {
+		return nil, err
+	}
+
+	return centre.PointOnSurface(*parts)
+}
+
+// GetPoleOfInaccessibility returns the interior point of the polygons farthest from their outline.
+func (r *Repository) GetPoleOfInaccessibility(gs []geometry.Geometry, tolerance float64) (*geometry.Point, error) {
+	parts, err := partsOf(gs)
+	if err != nil {
+		return nil, err
+	}
+	if len(parts.Polygons) == 0 {
+		return nil, errors.New("only Polygon and MultiPolygon geometries are supported")
+	}
+
+	return centre.PoleOfInaccessibility(parts.Polygons, tolerance)
+}
+
+// partsOf groups the components of the geometries by their dimension.
+func partsOf(gs []geometry.Geometry) (*centre.Parts, error) {
+	var parts centre.Parts
+	for _, g := range gs {
+		switch g.GeoJSONType {
+		case geojson.Point, geojson.MultiPoint:
+			ps, err := geom.Coords(g)
+			if err != nil {
+				return nil, err
+			}
+			parts.Points = append(parts.Points, ps...)
+		case geojson.LineString, geojson.MultiLineString:
+			ls, err := geom.Lines(g)
+			if err != nil {
+				return nil, err
+			}
+			parts.Lines = append(parts.Lines, ls...)
+		case geojson.Polygon, geojson.MultiPolygon:
+			polys, err := geom.Polygons(g)
+			if err != nil {
+				return nil, err
+			}
+			parts.Polygons = append(parts.Polygons, polys...)
+		default:
+			return nil, errors.New("invalid geometry type")
+		}
+	}
+	if parts.IsEmpty() {
+		return nil, errors.New("geometry can't be empty")
+	}
+
+	return &parts, nil
+}
@@ -0,0 +1,56 @@
+package measurement
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/cluster"
+	"github.com/tomchavakis/geo-api/internal/spatial/hull"
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/conversions"
+)
+
+// ClusterDBSCAN groups the points with at least minPoints points within eps, in the given units, and returns the cluster
+// of every point along with the centroid and convex hull of every cluster.
+func (r *Repository) ClusterDBSCAN(points []geometry.Point, eps float64, units string, minPoints int) (*cluster.Result, error) {
+	rad, err := conversions.LengthToRadians(eps, units)
+	if err != nil {
+		return nil, err
+	}
+	labels, err := cluster.DBSCAN(points, rad, minPoints)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterResult(points, labels)
+}
+
+// ClusterKMeans partitions the points into k clusters and returns the cluster of every point along with the centroid
+// and convex hull of every cluster.
+func (r *Repository) ClusterKMeans(points []geometry.Point, k int) (*cluster.Result, error) {
+	labels, err := cluster.KMeans(points, k)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterResult(points, labels)
+}
+
+func clusterResult(points []geometry.Point, labels []int) (*cluster.Result, error) {
+	members := cluster.Members(points, labels)
+	res := &cluster.Result{Labels: labels, Clusters: make([]cluster.Cluster, 0, len(members))}
+	for id, ps := range members {
+		if len(ps) == 0 {
+			// k-means can leave a cluster empty when there are fewer distinct points than clusters
+			continue
+		}
+		c, err := cluster.Centroid(ps)
+		if err != nil {
+			return nil, err
+		}
+		ring, err := hull.Convex(ps)
+		if err != nil {
+			return nil, err
+		}
+		res.Clusters = append(res.Clusters, cluster.Cluster{ID: id, Count: len(ps), Centroid: *c, Hull: *hullGeometry(ring)})
+	}
+
+	return res, nil
+}
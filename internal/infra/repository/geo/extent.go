@@ -0,0 +1,47 @@
+package measurement
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/bbox"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// GetBBox returns the bounding box of the geometries. Boxes crossing the antimeridian have West greater than East.
+func (r *Repository) GetBBox(gs []geometry.Geometry) (*geojson.BBOX, error) {
+	var points []geometry.Point
+	for _, g := range gs {
+		ps, err := geom.Coords(g)
+		if err != nil {
+			return nil, err
+		}
+		points = append(points, ps...)
+	}
+
+	return bbox.Of(points)
+}
+
+// GetBBoxPolygon returns the polygon of a bounding box. Boxes crossing the antimeridian return a MultiPolygon split on it.
+func (r *Repository) GetBBoxPolygon(b geojson.BBOX) (*geometry.Geometry, error) {
+	return polygonsGeometry(bbox.Polygon(b)), nil
+}
+
+// ExpandBBox grows a bounding box by a distance on every side.
+func (r *Repository) ExpandBBox(b geojson.BBOX, distance float64, units string) (*geojson.BBOX, error) {
+	return bbox.Expand(b, distance, units)
+}
+
+// IntersectBBox returns the intersection of two bounding boxes or nil when they don't intersect.
+func (r *Repository) IntersectBBox(a, b geojson.BBOX) (*geojson.BBOX, error) {
+	res, ok := bbox.Intersect(a, b)
+	if !ok {
+		return nil, nil
+	}
+
+	return res, nil
+}
+
+// UnionBBox returns the smallest bounding box containing all the boxes.
+func (r *Repository) UnionBBox(bs []geojson.BBOX) (*geojson.BBOX, error) {
+	return bbox.Union(bs)
+}
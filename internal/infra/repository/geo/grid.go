@@ -0,0 +1,38 @@
+package measurement
+
+import (
+	"errors"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/bbox"
+	"github.com/tomchavakis/geo-api/internal/spatial/grid"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/conversions"
+)
+
+// GetGrid lays out a grid of cells of a size in the given units over the bounding box, or over the bounding box of the
+// mask when it is nil. The cells are generated while the grid is iterated.
+func (r *Repository) GetGrid(kind grid.Kind, b *geojson.BBOX, cellSize float64, units string, mask [][][]geometry.Point) (*grid.Grid, error) {
+	rad, err := conversions.LengthToRadians(cellSize, units)
+	if err != nil {
+		return nil, err
+	}
+
+	if b == nil {
+		if len(mask) == 0 {
+			return nil, errors.New("bbox or mask is required")
+		}
+		var ps []geometry.Point
+		for _, rings := range mask {
+			if len(rings) > 0 {
+				ps = append(ps, rings[0]...)
+			}
+		}
+		b, err = bbox.Of(ps)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return grid.New(grid.Options{Kind: kind, BBox: *b, CellSize: rad, Mask: mask})
+}
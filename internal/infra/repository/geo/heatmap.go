@@ -0,0 +1,67 @@
+package measurement
+
+import (
+	"context"
+	"image"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/density"
+	"github.com/tomchavakis/geo-api/internal/spatial/grid"
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/turf-go/constants"
+	"github.com/tomchavakis/turf-go/conversions"
+)
+
+// GetHeatmapTile renders the kernel density of the points, with a bandwidth in meters, on a raster tile. The colours
+// reach red at the scale intensity, or at the highest intensity of the tile when the scale is 0. It stops when the
+// context is cancelled.
+func (r *Repository) GetHeatmapTile(ctx context.Context, points []density.Point, kernel density.Kernel, bandwidth float64, t tile.Tile, scale float64) (*image.NRGBA, error) {
+	// a quarter of the height of the pixels in degrees, which are at least as wide
+	b := t.BBox()
+	s, err := densitySurface(density.Merge(points, (b.North-b.South)/(4*tile.Size)), kernel, bandwidth)
+	if err != nil {
+		return nil, err
+	}
+
+	// the centres of the pixels
+	lngs := make([]float64, tile.Size)
+	lats := make([]float64, tile.Size)
+	for i := 0; i < tile.Size; i++ {
+		lngs[i] = t.Position(float64(i)+0.5, 0, tile.Size).Lng
+		lats[i] = t.Position(0, float64(i)+0.5, tile.Size).Lat
+	}
+	values, err := s.Raster(ctx, lngs, lats)
+	if err != nil {
+		return nil, err
+	}
+
+	return density.Image(values, tile.Size, tile.Size, scale), nil
+}
+
+// GetHeatmapGrid samples the kernel density of the points, with a bandwidth in meters, on a grid of square cells of a
+// size in the given units over the bounding box. The cells are generated while the grid is iterated.
+func (r *Repository) GetHeatmapGrid(points []density.Point, kernel density.Kernel, bandwidth float64, b geojson.BBOX, cellSize float64, units string) (*density.Cells, error) {
+	s, err := densitySurface(points, kernel, bandwidth)
+	if err != nil {
+		return nil, err
+	}
+	rad, err := conversions.LengthToRadians(cellSize, units)
+	if err != nil {
+		return nil, err
+	}
+	g, err := grid.New(grid.Options{Kind: grid.Squares, BBox: b, CellSize: rad})
+	if err != nil {
+		return nil, err
+	}
+
+	return density.NewCells(s, g), nil
+}
+
+func densitySurface(points []density.Point, kernel density.Kernel, bandwidth float64) (*density.Surface, error) {
+	rad, err := conversions.LengthToRadians(bandwidth, constants.UnitMeters)
+	if err != nil {
+		return nil, err
+	}
+
+	return density.New(points, kernel, rad)
+}
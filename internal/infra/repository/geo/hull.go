@@ -0,0 +1,57 @@
+package measurement
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/hull"
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/conversions"
+)
+
+// GetConvexHull returns the convex hull of the points.
+func (r *Repository) GetConvexHull(points []geometry.Point) (*geometry.Geometry, error) {
+	ring, err := hull.Convex(points)
+	if err != nil {
+		return nil, err
+	}
+
+	return hullGeometry(ring), nil
+}
+
+// GetConcaveHull returns the concave hull of the points whose boundary edges are at most maxEdge long, when the points allow it.
+func (r *Repository) GetConcaveHull(points []geometry.Point, maxEdge float64, units string) (*geometry.Geometry, error) {
+	rad, err := conversions.LengthToRadians(maxEdge, units)
+	if err != nil {
+		return nil, err
+	}
+	ring, err := hull.Concave(points, rad)
+	if err != nil {
+		return nil, err
+	}
+
+	return hullGeometry(ring), nil
+}
+
+// GetConcaveHullByConcavity returns the concave hull of the points, from the most concave with 0 to the convex hull with 1.
+func (r *Repository) GetConcaveHullByConcavity(points []geometry.Point, concavity float64) (*geometry.Geometry, error) {
+	ring, err := hull.ConcaveByConcavity(points, concavity)
+	if err != nil {
+		return nil, err
+	}
+
+	return hullGeometry(ring), nil
+}
+
+// hullGeometry returns a Polygon for a closed ring, or the LineString or Point the hull collapsed to.
+func hullGeometry(ring []geometry.Point) *geometry.Geometry {
+	var g geometry.Geometry
+	switch len(ring) {
+	case 1:
+		g = geom.PointGeometry(ring[0])
+	case 2:
+		g = geom.LineStringGeometry(ring)
+	default:
+		g = geom.PolygonGeometry([][]geometry.Point{ring})
+	}
+
+	return &g
+}
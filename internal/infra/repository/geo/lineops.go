@@ -0,0 +1,61 @@
+package measurement
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/lineops"
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/conversions"
+)
+
+// SplitLine splits the line at the positions closest to the points.
+func (r *Repository) SplitLine(line, points []geometry.Point) ([][]geometry.Point, error) {
+	return lineops.Split(line, points)
+}
+
+// SplitLineByLine splits the line where the splitter crosses or touches it.
+func (r *Repository) SplitLineByLine(line, splitter []geometry.Point) ([][]geometry.Point, error) {
+	return lineops.SplitByLine(line, splitter)
+}
+
+// SliceLine returns the part of the line between the positions closest to the start and stop points.
+func (r *Repository) SliceLine(line []geometry.Point, start, stop geometry.Point) ([]geometry.Point, error) {
+	return lineops.Slice(line, start, stop)
+}
+
+// SliceLineAlong returns the part of the line between two distances in the given units from its start.
+func (r *Repository) SliceLineAlong(line []geometry.Point, start, stop float64, units string) ([]geometry.Point, error) {
+	startRad, err := conversions.LengthToRadians(start, units)
+	if err != nil {
+		return nil, err
+	}
+	stopRad, err := conversions.LengthToRadians(stop, units)
+	if err != nil {
+		return nil, err
+	}
+
+	return lineops.SliceAlong(line, startRad, stopRad)
+}
+
+// OffsetLine returns the line parallel to the given one at a distance in the given units, on its right for a positive distance.
+func (r *Repository) OffsetLine(line []geometry.Point, distance float64, units string) ([]geometry.Point, error) {
+	rad, err := conversions.LengthToRadians(distance, units)
+	if err != nil {
+		return nil, err
+	}
+
+	return lineops.Offset(line, rad)
+}
+
+// ChunkLine cuts the line into pieces of a length in the given units, the last one being shorter.
+func (r *Repository) ChunkLine(line []geometry.Point, length float64, units string) ([][]geometry.Point, error) {
+	rad, err := conversions.LengthToRadians(length, units)
+	if err != nil {
+		return nil, err
+	}
+
+	return lineops.Chunk(line, rad)
+}
+
+// GetLineIntersections returns the positions where two lines cross or touch.
+func (r *Repository) GetLineIntersections(a, b []geometry.Point) ([]geometry.Point, error) {
+	return lineops.Intersect(a, b)
+}
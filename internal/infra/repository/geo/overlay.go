@@ -0,0 +1,28 @@
+package measurement
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/overlay"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Overlay computes a boolean operation between two sets of polygons. A single resulting polygon is returned as a Polygon,
+// anything else, including an empty result, as a MultiPolygon.
+func (r *Repository) Overlay(subject, clip [][][]geometry.Point, op overlay.Op) (*geometry.Geometry, error) {
+	polys, err := overlay.Overlay(subject, clip, op)
+	if err != nil {
+		return nil, err
+	}
+
+	return polygonsGeometry(polys), nil
+}
+
+func polygonsGeometry(polys [][][]geometry.Point) *geometry.Geometry {
+	if len(polys) == 1 {
+		g := geom.PolygonGeometry(polys[0])
+		return &g
+	}
+	g := geom.MultiPolygonGeometry(polys)
+
+	return &g
+}
@@ -0,0 +1,43 @@
+package measurement
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/olc"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// EncodePlusCode returns the Open Location Code of a point with the given number of digits.
+func (r *Repository) EncodePlusCode(p geometry.Point, length int) (*string, error) {
+	code := olc.Encode(p, length)
+
+	return &code, nil
+}
+
+// DecodePlusCode returns the area represented by a full Open Location Code.
+func (r *Repository) DecodePlusCode(code string) (*olc.CodeArea, error) {
+	area, err := olc.Decode(code)
+	if err != nil {
+		return nil, err
+	}
+
+	return area, nil
+}
+
+// ShortenPlusCode removes the leading digits of a full code that can be recovered from the reference point.
+func (r *Repository) ShortenPlusCode(code string, ref geometry.Point) (*string, error) {
+	short, err := olc.Shorten(code, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return &short, nil
+}
+
+// RecoverPlusCode returns the full code nearest to the reference point that matches a short code.
+func (r *Repository) RecoverPlusCode(code string, ref geometry.Point) (*string, error) {
+	full, err := olc.Recover(code, ref)
+	if err != nil {
+		return nil, err
+	}
+
+	return &full, nil
+}
@@ -0,0 +1,81 @@
+package measurement
+
+import (
+	"errors"
+	"math"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/simplify"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/conversions"
+)
+
+// Simplify reduces the vertices of the lines and polygons of the geometries. The tolerance is a
+// distance in the given units. Points and MultiPoints are returned unchanged.
+func (r *Repository) Simplify(gs []geometry.Geometry, tolerance float64, units string, algorithm simplify.Algorithm, preserveTopology bool) ([]geometry.Geometry, error) {
+	if tolerance < 0 || math.IsNaN(tolerance) || math.IsInf(tolerance, 0) {
+		return nil, errors.New("tolerance must be a positive number")
+	}
+	rad, err := conversions.LengthToRadians(tolerance, units)
+	if err != nil {
+		return nil, err
+	}
+	o := simplify.Options{Algorithm: algorithm, Tolerance: rad, PreserveTopology: preserveTopology}
+
+	res := make([]geometry.Geometry, 0, len(gs))
+	for _, g := range gs {
+		s, err := simplifyGeometry(g, o)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *s)
+	}
+
+	return res, nil
+}
+
+func simplifyGeometry(g geometry.Geometry, o simplify.Options) (*geometry.Geometry, error) {
+	switch g.GeoJSONType {
+	case geojson.Point, geojson.MultiPoint:
+		return &g, nil
+	case geojson.LineString, geojson.MultiLineString:
+		ls, err := geom.Lines(g)
+		if err != nil {
+			return nil, err
+		}
+		ls, err = simplify.Lines(ls, o)
+		if err != nil {
+			return nil, err
+		}
+		res := geom.MultiLineStringGeometry(ls)
+		if g.GeoJSONType == geojson.LineString {
+			res = geom.LineStringGeometry(ls[0])
+		}
+		return &res, nil
+	case geojson.Polygon, geojson.MultiPolygon:
+		polys, err := geom.Polygons(g)
+		if err != nil {
+			return nil, err
+		}
+		// the rings of all the polygons are simplified together to keep them from crossing each other
+		var rings [][]geometry.Point
+		for _, p := range polys {
+			rings = append(rings, p...)
+		}
+		rings, err = simplify.Rings(rings, o)
+		if err != nil {
+			return nil, err
+		}
+		for i := range polys {
+			polys[i], rings = rings[:len(polys[i])], rings[len(polys[i]):]
+		}
+		res := geom.MultiPolygonGeometry(polys)
+		if g.GeoJSONType == geojson.Polygon {
+			res = geom.PolygonGeometry(polys[0])
+		}
+		return &res, nil
+	default:
+		return nil, errors.New("invalid geometry type")
+	}
+}
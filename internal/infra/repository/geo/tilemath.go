@@ -0,0 +1,26 @@
+package measurement
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// GetTileAt returns the XYZ tile holding a point at a zoom level.
+func (r *Repository) GetTileAt(p geometry.Point, z int) (*tile.Tile, error) {
+	return tile.At(p, z)
+}
+
+// GetTileFromTMS returns the XYZ tile of TMS tile coordinates.
+func (r *Repository) GetTileFromTMS(z, x, y int) (*tile.Tile, error) {
+	return tile.FromTMS(z, x, y)
+}
+
+// GetTileFromQuadkey returns the XYZ tile of a Bing Maps quadkey.
+func (r *Repository) GetTileFromQuadkey(quadkey string) (*tile.Tile, error) {
+	return tile.ParseQuadkey(quadkey)
+}
+
+// GetTileCover returns the tiles at a zoom level intersecting the polygons.
+func (r *Repository) GetTileCover(polygons [][][]geometry.Point, z int) ([]tile.Tile, error) {
+	return tile.Cover(polygons, z)
+}
@@ -0,0 +1,15 @@
+package measurement
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/track"
+)
+
+// AnalyzeTrack returns the segments, the stops and the moves of a timed track.
+func (r *Repository) AnalyzeTrack(points []track.Point, opts track.StopOptions) (*track.Analysis, error) {
+	return track.Analyze(points, opts)
+}
+
+// CleanTrack drops the outliers and the stationary jitter of a timed track and smooths it.
+func (r *Repository) CleanTrack(points []track.Point, opts track.CleanOptions) (*track.Cleaned, error) {
+	return track.Clean(points, opts)
+}
@@ -0,0 +1,65 @@
+package measurement
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/transform"
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/conversions"
+)
+
+// Translate moves the geometries by a distance in the given units towards a bearing in degrees from true north.
+func (r *Repository) Translate(gs []geometry.Geometry, distance float64, bearing float64, units string) ([]geometry.Geometry, error) {
+	rad, err := conversions.LengthToRadians(distance, units)
+	if err != nil {
+		return nil, err
+	}
+
+	return transformGeometries(gs, func(g geometry.Geometry) (*geometry.Geometry, error) {
+		return transform.Translate(g, rad, bearing)
+	})
+}
+
+// Rotate turns the geometries clockwise by an angle in degrees around the pivot, or around their centroid when the pivot is nil.
+func (r *Repository) Rotate(gs []geometry.Geometry, angle float64, pivot *geometry.Point) ([]geometry.Geometry, error) {
+	pivot, err := r.pivot(gs, pivot)
+	if err != nil {
+		return nil, err
+	}
+
+	return transformGeometries(gs, func(g geometry.Geometry) (*geometry.Geometry, error) {
+		return transform.Rotate(g, angle, *pivot)
+	})
+}
+
+// Scale resizes the geometries by a factor about the origin, or about their centroid when the origin is nil.
+func (r *Repository) Scale(gs []geometry.Geometry, factor float64, origin *geometry.Point) ([]geometry.Geometry, error) {
+	origin, err := r.pivot(gs, origin)
+	if err != nil {
+		return nil, err
+	}
+
+	return transformGeometries(gs, func(g geometry.Geometry) (*geometry.Geometry, error) {
+		return transform.Scale(g, factor, *origin)
+	})
+}
+
+// pivot returns the given point, or the centroid of all the geometries so that they are transformed together.
+func (r *Repository) pivot(gs []geometry.Geometry, p *geometry.Point) (*geometry.Point, error) {
+	if p != nil {
+		return p, nil
+	}
+
+	return r.GetCentroid(gs)
+}
+
+func transformGeometries(gs []geometry.Geometry, fn func(geometry.Geometry) (*geometry.Geometry, error)) ([]geometry.Geometry, error) {
+	res := make([]geometry.Geometry, 0, len(gs))
+	for _, g := range gs {
+		t, err := fn(g)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *t)
+	}
+
+	return res, nil
+}
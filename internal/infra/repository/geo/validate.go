@@ -0,0 +1,35 @@
+package measurement
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/validate"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Validate returns the validity problems of every geometry, an empty list for a valid one.
+func (r *Repository) Validate(gs []geometry.Geometry) ([][]validate.Problem, error) {
+	res := make([][]validate.Problem, 0, len(gs))
+	for _, g := range gs {
+		ps, err := validate.Validate(g)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, ps)
+	}
+
+	return res, nil
+}
+
+// MakeValid repairs every geometry. Polygons may be split into MultiPolygons when their rings
+// intersect themselves.
+func (r *Repository) MakeValid(gs []geometry.Geometry) ([]geometry.Geometry, error) {
+	res := make([]geometry.Geometry, 0, len(gs))
+	for _, g := range gs {
+		v, err := validate.MakeValid(g)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, *v)
+	}
+
+	return res, nil
+}
@@ -0,0 +1,103 @@
+package network
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/isochrone"
+	"github.com/tomchavakis/geo-api/internal/spatial/mapmatch"
+	"github.com/tomchavakis/geo-api/internal/spatial/optimize"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geo-api/internal/spatial/routing"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Repository serves the algorithms working over a road network, read once when the repository is
+// built.
+type Repository struct {
+	network *roads.Network
+}
+
+// New reads the road network of a file, an OpenStreetMap PBF extract when its extension is .pbf
+// and GeoJSON lines otherwise. No network is loaded when the path is empty, the repository then
+// returning roads.ErrNoNetwork.
+func New(path string) (*Repository, error) {
+	if path == "" {
+		return &Repository{}, nil
+	}
+	ways, err := load(path)
+	if err != nil {
+		return nil, err
+	}
+	n, err := roads.Build(ways)
+	if err != nil {
+		return nil, err
+	}
+	return &Repository{network: n}, nil
+}
+
+func load(path string) ([]roads.Way, error) {
+	if strings.EqualFold(filepath.Ext(path), ".pbf") {
+		f, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer f.Close()
+		return roads.ReadPBF(bufio.NewReader(f))
+	}
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	fs, err := geom.Decode(b)
+	if err != nil {
+		return nil, err
+	}
+	return roads.FromFeatures(fs)
+}
+
+// MatchTrace snaps a trace to the most likely path over the road network.
+func (r *Repository) MatchTrace(trace []geometry.Point, opts mapmatch.Options) (*mapmatch.Result, error) {
+	if r.network == nil {
+		return nil, roads.ErrNoNetwork
+	}
+	return mapmatch.Match(r.network, trace, opts)
+}
+
+// GetRoute returns the fastest route between two points over the road network for a profile.
+func (r *Repository) GetRoute(a, b geometry.Point, profile *roads.Profile) (*routing.Result, error) {
+	if r.network == nil {
+		return nil, roads.ErrNoNetwork
+	}
+	return routing.Route(r.network, a, b, profile)
+}
+
+// GetIsochrones returns the areas reached from an origin over the road network within travel times
+// in seconds.
+func (r *Repository) GetIsochrones(origin geometry.Point, profile *roads.Profile, seconds []float64) ([]isochrone.Contour, error) {
+	if r.network == nil {
+		return nil, roads.ErrNoNetwork
+	}
+	return isochrone.Isochrones(r.network, origin, profile, seconds)
+}
+
+// GetIsodistances returns the areas reached from an origin over the road network within travel
+// distances in meters.
+func (r *Repository) GetIsodistances(origin geometry.Point, profile *roads.Profile, meters []float64) ([]isochrone.Contour, error) {
+	if r.network == nil {
+		return nil, roads.ErrNoNetwork
+	}
+	return isochrone.Isodistances(r.network, origin, profile, meters)
+}
+
+// OptimizeRoute orders the stops of a route, the road metric measuring them over the road network.
+func (r *Repository) OptimizeRoute(req optimize.Request) (*optimize.Tour, error) {
+	if req.Metric == optimize.Road && r.network == nil {
+		return nil, roads.ErrNoNetwork
+	}
+	return optimize.Optimize(r.network, req)
+}
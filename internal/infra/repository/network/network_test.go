@@ -0,0 +1,83 @@
+package network
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/mapmatch"
+	"github.com/tomchavakis/geo-api/internal/spatial/optimize"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+const streets = `{"type":"FeatureCollection","features":[
+	{"type":"Feature","id":"7","geometry":{"type":"LineString","coordinates":[[0,0],[0.002,0]]},"properties":{"highway":"residential"}}
+]}`
+
+var options = mapmatch.Options{Radius: 50, Sigma: 5, Beta: 5, Candidates: 5}
+
+func TestMatchTrace(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roads.geojson")
+	assert.NoError(t, os.WriteFile(path, []byte(streets), 0o600))
+
+	r, err := New(path)
+	assert.NoError(t, err)
+	res, err := r.MatchTrace([]geometry.Point{{Lng: 0.0005, Lat: 0.0001}, {Lng: 0.0015, Lat: -0.0001}}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"7:0"}, res.Edges)
+}
+
+func TestRouting(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "roads.geojson")
+	assert.NoError(t, os.WriteFile(path, []byte(streets), 0o600))
+
+	r, err := New(path)
+	assert.NoError(t, err)
+	res, err := r.GetRoute(geometry.Point{Lng: 0.0005}, geometry.Point{Lng: 0.0015}, roads.Car)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"7:0"}, res.Edges)
+	assert.InDelta(t, 111.2/(30/3.6), res.Duration, 0.1)
+
+	cs, err := r.GetIsochrones(geometry.Point{Lng: 0.001}, roads.Foot, []float64{30, 60})
+	assert.NoError(t, err)
+	assert.Len(t, cs, 2)
+	cs, err = r.GetIsodistances(geometry.Point{Lng: 0.001}, roads.Foot, []float64{50})
+	assert.NoError(t, err)
+	assert.Len(t, cs, 1)
+
+	stops := []geometry.Point{{Lng: 0.0015}, {Lng: 0.0005}}
+	tour, err := r.OptimizeRoute(optimize.Request{Stops: stops, Metric: optimize.Road, Profile: roads.Car})
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 0}, tour.Order)
+	assert.InDelta(t, 166.8, tour.Distance, 0.1)
+}
+
+func TestNewErrors(t *testing.T) {
+	r, err := New("")
+	assert.NoError(t, err)
+	_, err = r.MatchTrace([]geometry.Point{{}, {}}, options)
+	assert.Equal(t, roads.ErrNoNetwork, err)
+	_, err = r.GetRoute(geometry.Point{}, geometry.Point{}, roads.Car)
+	assert.Equal(t, roads.ErrNoNetwork, err)
+	_, err = r.GetIsochrones(geometry.Point{}, roads.Car, []float64{60})
+	assert.Equal(t, roads.ErrNoNetwork, err)
+	_, err = r.GetIsodistances(geometry.Point{}, roads.Car, []float64{60})
+	assert.Equal(t, roads.ErrNoNetwork, err)
+	stops := []geometry.Point{{Lng: 0.001}}
+	_, err = r.OptimizeRoute(optimize.Request{Stops: stops, Metric: optimize.Road, Profile: roads.Car})
+	assert.Equal(t, roads.ErrNoNetwork, err)
+	tour, err := r.OptimizeRoute(optimize.Request{Stops: stops, Metric: optimize.Geodesic, Speed: 30})
+	assert.NoError(t, err)
+	assert.InDelta(t, 111.2, tour.Distance, 0.1)
+
+	dir := t.TempDir()
+	_, err = New(filepath.Join(dir, "missing.pbf"))
+	assert.Error(t, err)
+
+	path := filepath.Join(dir, "points.geojson")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"type":"Point","coordinates":[0,0]}`), 0o600))
+	_, err = New(path)
+	assert.EqualError(t, err, "a road network must be made of LineString or MultiLineString features")
+}
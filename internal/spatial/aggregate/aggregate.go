@@ -0,0 +1,191 @@
+package aggregate
+
+import (
+	"math"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/grid"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Point is a position with the numeric values of its properties.
+type Point struct {
+	geometry.Point
+	Values map[string]float64
+}
+
+// Summary holds the statistics of a property over the points of a zone. Mean, Min and Max are nil
+// when none of the points has the property.
+type Summary struct {
+	Count int
+	Sum   float64
+	Mean  *float64
+	Min   *float64
+	Max   *float64
+}
+
+// Stats holds the number of points of a zone and the summaries of the chosen properties.
+type Stats struct {
+	Count      int
+	Properties map[string]Summary
+}
+
+// Cell is a cell of a grid with the statistics of the points inside it.
+type Cell struct {
+	Geometry geometry.Geometry
+	Stats
+}
+
+// Index groups the points in buckets of longitude and latitude so that a zone only tests the points
+// of the buckets its bounding box overlaps.
+type Index struct {
+	points      []Point
+	west, south float64
+	size        float64 // the side of the buckets in degrees
+	cols, rows  int
+	buckets     [][]int
+	properties  []string
+}
+
+// NewIndex indexes the points to summarize the given properties.
+func NewIndex(points []Point, properties []string) *Index {
+	ix := &Index{points: points, properties: properties}
+	if len(points) == 0 {
+		return ix
+	}
+
+	west, south, east, north := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range points {
+		west, east = math.Min(west, p.Lng), math.Max(east, p.Lng)
+		south, north = math.Min(south, p.Lat), math.Max(north, p.Lat)
+	}
+	// about one point per bucket
+	n := math.Sqrt(float64(len(points)))
+	size := math.Max(east-west, north-south) / n
+	if size == 0 {
+		size = 1
+	}
+	ix.west, ix.south, ix.size = west, south, size
+	ix.cols = int((east-west)/size) + 1
+	ix.rows = int((north-south)/size) + 1
+	ix.buckets = make([][]int, ix.cols*ix.rows)
+	for i, p := range points {
+		c, r := ix.cell(p.Lng, p.Lat)
+		ix.buckets[r*ix.cols+c] = append(ix.buckets[r*ix.cols+c], i)
+	}
+	return ix
+}
+
+func (ix *Index) cell(lng, lat float64) (int, int) {
+	c := int((lng - ix.west) / ix.size)
+	r := int((lat - ix.south) / ix.size)
+	return clamp(c, ix.cols), clamp(r, ix.rows)
+}
+
+func clamp(i, n int) int {
+	if i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
+
+// Summarize returns the statistics of the points inside the polygons, given as lists of rings where
+// the first ring is the exterior. Points on a boundary shared by two zones are counted in one of them.
+func (ix *Index) Summarize(polygons [][][]geometry.Point) Stats {
+	s := Stats{Properties: make(map[string]Summary, len(ix.properties))}
+	acc := make([]Summary, len(ix.properties))
+	if len(ix.buckets) > 0 {
+		for _, rings := range polygons {
+			if len(rings) == 0 || len(rings[0]) == 0 {
+				continue
+			}
+			west, south, east, north := bounds(rings[0])
+			c0, r0 := ix.cell(west, south)
+			c1, r1 := ix.cell(east, north)
+			for r := r0; r <= r1; r++ {
+				for c := c0; c <= c1; c++ {
+					for _, i := range ix.buckets[r*ix.cols+c] {
+						p := ix.points[i]
+						if p.Lng < west || p.Lng > east || p.Lat < south || p.Lat > north || !inside(p.Point, rings) {
+							continue
+						}
+						s.Count++
+						ix.add(acc, p)
+					}
+				}
+			}
+		}
+	}
+
+	for k, name := range ix.properties {
+		sum := acc[k]
+		if sum.Count > 0 {
+			mean := sum.Sum / float64(sum.Count)
+			sum.Mean = &mean
+		}
+		s.Properties[name] = sum
+	}
+	return s
+}
+
+// Bins returns the cells of a grid of polygons holding at least one point.
+func (ix *Index) Bins(g *grid.Grid) ([]Cell, error) {
+	var cs []Cell
+	err := g.Each(func(c geometry.Geometry) error {
+		polys, err := geom.Polygons(c)
+		if err != nil {
+			return err
+		}
+		if s := ix.Summarize(polys); s.Count > 0 {
+			cs = append(cs, Cell{Geometry: c, Stats: s})
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return cs, nil
+}
+
+func (ix *Index) add(acc []Summary, p Point) {
+	for k, name := range ix.properties {
+		v, ok := p.Values[name]
+		if !ok {
+			continue
+		}
+		a := &acc[k]
+		a.Count++
+		a.Sum += v
+		if a.Min == nil || v < *a.Min {
+			a.Min = &v
+		}
+		if a.Max == nil || v > *a.Max {
+			a.Max = &v
+		}
+	}
+}
+
+func bounds(r []geometry.Point) (float64, float64, float64, float64) {
+	west, south, east, north := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range r {
+		west, east = math.Min(west, p.Lng), math.Max(east, p.Lng)
+		south, north = math.Min(south, p.Lat), math.Max(north, p.Lat)
+	}
+	return west, south, east, north
+}
+
+// inside reports whether p is inside the exterior ring and outside the holes.
+func inside(p geometry.Point, rings [][]geometry.Point) bool {
+	if !geom.InRing(p, rings[0]) {
+		return false
+	}
+	for _, h := range rings[1:] {
+		if geom.InRing(p, h) {
+			return false
+		}
+	}
+	return true
+}
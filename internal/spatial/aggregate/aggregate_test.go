@@ -0,0 +1,112 @@
+package aggregate
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/grid"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func square(west, south, east, north float64) []geometry.Point {
+	return []geometry.Point{
+		{Lat: south, Lng: west}, {Lat: south, Lng: east}, {Lat: north, Lng: east}, {Lat: north, Lng: west}, {Lat: south, Lng: west},
+	}
+}
+
+func point(lng, lat float64, values map[string]float64) Point {
+	return Point{Point: geometry.Point{Lat: lat, Lng: lng}, Values: values}
+}
+
+func TestSummarize(t *testing.T) {
+	points := []Point{
+		point(0.5, 0.5, map[string]float64{"severity": 3}),
+		point(1.5, 0.5, map[string]float64{"severity": 1}),
+		point(1.5, 1.5, map[string]float64{"severity": 5, "casualties": 2}),
+		point(1.1, 1.1, nil),
+		point(5, 5, map[string]float64{"severity": 9}),
+	}
+	ix := NewIndex(points, []string{"severity", "casualties"})
+
+	tests := map[string]struct {
+		polygons   [][][]geometry.Point
+		count      int
+		severity   Summary
+		casualties Summary
+	}{
+		"polygon": {
+			polygons:   [][][]geometry.Point{{square(1, 0, 2, 2)}},
+			count:      3,
+			severity:   Summary{Count: 2, Sum: 6, Mean: f(3), Min: f(1), Max: f(5)},
+			casualties: Summary{Count: 1, Sum: 2, Mean: f(2), Min: f(2), Max: f(2)},
+		},
+		"multipolygon": {
+			polygons:   [][][]geometry.Point{{square(0, 0, 1, 1)}, {square(4, 4, 6, 6)}},
+			count:      2,
+			severity:   Summary{Count: 2, Sum: 12, Mean: f(6), Min: f(3), Max: f(9)},
+			casualties: Summary{},
+		},
+		"polygon with a hole": {
+			polygons:   [][][]geometry.Point{{square(0, 0, 2, 2), square(1, 1, 2, 2)}},
+			count:      2,
+			severity:   Summary{Count: 2, Sum: 4, Mean: f(2), Min: f(1), Max: f(3)},
+			casualties: Summary{},
+		},
+		"empty": {
+			polygons:   [][][]geometry.Point{{square(10, 10, 11, 11)}},
+			count:      0,
+			severity:   Summary{},
+			casualties: Summary{},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s := ix.Summarize(tt.polygons)
+			assert.Equal(t, tt.count, s.Count)
+			assert.Equal(t, tt.severity, s.Properties["severity"])
+			assert.Equal(t, tt.casualties, s.Properties["casualties"])
+		})
+	}
+}
+
+func TestSummarizeSharedBoundary(t *testing.T) {
+	ix := NewIndex([]Point{point(1, 0.5, nil), point(1, 1, nil)}, nil)
+
+	left := ix.Summarize([][][]geometry.Point{{square(0, 0, 1, 2)}})
+	right := ix.Summarize([][][]geometry.Point{{square(1, 0, 2, 2)}})
+	assert.Equal(t, 2, left.Count+right.Count)
+}
+
+func TestSummarizeNoPoints(t *testing.T) {
+	s := NewIndex(nil, []string{"severity"}).Summarize([][][]geometry.Point{{square(0, 0, 1, 1)}})
+	assert.Equal(t, 0, s.Count)
+	assert.Equal(t, Summary{}, s.Properties["severity"])
+}
+
+func TestBins(t *testing.T) {
+	points := []Point{
+		point(0.01, 0.01, map[string]float64{"severity": 1}),
+		point(0.02, 0.01, map[string]float64{"severity": 2}),
+		point(0.4, 0.4, map[string]float64{"severity": 4}),
+	}
+	g, err := grid.New(grid.Options{Kind: grid.Hexagons, BBox: *geojson.NewBBox(-0.5, -0.5, 0.5, 0.5), CellSize: 0.1 * math.Pi / 180})
+	assert.NoError(t, err)
+
+	cs, err := NewIndex(points, []string{"severity"}).Bins(g)
+	assert.NoError(t, err)
+	assert.Len(t, cs, 2)
+
+	total := 0
+	for _, c := range cs {
+		assert.Equal(t, geojson.Polygon, c.Geometry.GeoJSONType)
+		total += c.Count
+	}
+	assert.Equal(t, 3, total)
+}
+
+func f(v float64) *float64 {
+	return &v
+}
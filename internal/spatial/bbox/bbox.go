@@ -0,0 +1,247 @@
+package bbox
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/conversions"
+)
+
+// A bounding box crosses the antimeridian when its West edge is greater than its East edge,
+// as described in https://tools.ietf.org/html/rfc7946#section-5.2
+
+// FromSlice converts a GeoJSON [west, south, east, north] array to a bounding box.
+func FromSlice(b []float64) (*geojson.BBOX, error) {
+	if len(b) != 4 {
+		return nil, errors.New("a bbox must have four elements [west, south, east, north]")
+	}
+	for _, v := range b {
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return nil, errors.New("bbox values must be finite numbers")
+		}
+	}
+	if b[1] > b[3] {
+		return nil, errors.New("bbox south can't be greater than north")
+	}
+	if b[1] < -90 || b[3] > 90 {
+		return nil, errors.New("bbox latitudes must be between -90 and 90")
+	}
+	if b[0] < -180 || b[0] > 180 || b[2] < -180 || b[2] > 180 {
+		return nil, errors.New("bbox longitudes must be between -180 and 180")
+	}
+
+	return geojson.NewBBox(b[0], b[1], b[2], b[3]), nil
+}
+
+// ToSlice converts a bounding box to a GeoJSON [west, south, east, north] array.
+func ToSlice(b geojson.BBOX) []float64 {
+	return []float64{b.West, b.South, b.East, b.North}
+}
+
+// CrossesAntimeridian reports whether the bounding box crosses the antimeridian.
+func CrossesAntimeridian(b geojson.BBOX) bool {
+	return b.West > b.East
+}
+
+// Width returns the extent of the bounding box in degrees of longitude.
+func Width(b geojson.BBOX) float64 {
+	if CrossesAntimeridian(b) {
+		return b.East + 360 - b.West
+	}
+	return b.East - b.West
+}
+
+// Of returns the smallest bounding box that contains the points. The longitudes are treated as
+// positions on a circle, so points on both sides of the antimeridian give a box that crosses it
+// instead of one that wraps around the globe.
+func Of(points []geometry.Point) (*geojson.BBOX, error) {
+	if len(points) == 0 {
+		return nil, errors.New("points can't be empty")
+	}
+
+	south, north := math.Inf(1), math.Inf(-1)
+	lngs := make([]float64, 0, len(points))
+	for _, p := range points {
+		south = math.Min(south, p.Lat)
+		north = math.Max(north, p.Lat)
+		lngs = append(lngs, normalize(p.Lng))
+	}
+	sort.Float64s(lngs)
+
+	// the box is the complement of the largest gap between consecutive longitudes
+	gap := lngs[0] + 360 - lngs[len(lngs)-1]
+	west, east := lngs[0], lngs[len(lngs)-1]
+	for i := 1; i < len(lngs); i++ {
+		if d := lngs[i] - lngs[i-1]; d > gap {
+			gap = d
+			west, east = lngs[i], lngs[i-1]
+		}
+	}
+	if east == -180 && west > east {
+		east = 180
+	}
+
+	return geojson.NewBBox(west, south, east, north), nil
+}
+
+// Polygon returns the rings of the polygons covering the bounding box. Boxes crossing the
+// antimeridian are split in two polygons, one on each side.
+func Polygon(b geojson.BBOX) [][][]geometry.Point {
+	if !CrossesAntimeridian(b) {
+		return [][][]geometry.Point{rectangle(b.West, b.South, b.East, b.North)}
+	}
+	return [][][]geometry.Point{
+		rectangle(b.West, b.South, 180, b.North),
+		rectangle(-180, b.South, b.East, b.North),
+	}
+}
+
+// Expand grows the bounding box by a distance on every side. Boxes reaching a pole or
+// wider than the globe are expanded to the full range of longitudes.
+func Expand(b geojson.BBOX, distance float64, units string) (*geojson.BBOX, error) {
+	if distance < 0 || math.IsNaN(distance) || math.IsInf(distance, 0) {
+		return nil, errors.New("distance must be a positive number")
+	}
+	rad, err := conversions.LengthToRadians(distance, units)
+	if err != nil {
+		return nil, err
+	}
+	d := conversions.RadiansToDegrees(rad)
+
+	south := b.South - d
+	north := b.North + d
+	if south <= -90 || north >= 90 {
+		return geojson.NewBBox(-180, math.Max(south, -90), 180, math.Min(north, 90)), nil
+	}
+
+	// the longitude distance grows with the latitude, so use the edge closest to a pole
+	maxLat := math.Max(math.Abs(south), math.Abs(north))
+	dLng := conversions.RadiansToDegrees(math.Asin(math.Min(math.Sin(rad)/math.Cos(conversions.DegreesToRadians(maxLat)), 1)))
+	if Width(b)+2*dLng >= 360 {
+		return geojson.NewBBox(-180, south, 180, north), nil
+	}
+
+	return geojson.NewBBox(normalize(b.West-dLng), south, normalizeEast(b.East+dLng), north), nil
+}
+
+// Intersect returns the intersection of two bounding boxes, or false when they don't intersect.
+// When two boxes wider than half the globe intersect in two parts, the widest part is returned.
+func Intersect(a, b geojson.BBOX) (*geojson.BBOX, bool) {
+	south := math.Max(a.South, b.South)
+	north := math.Min(a.North, b.North)
+	if south > north {
+		return nil, false
+	}
+	if Width(a) >= 360 {
+		return geojson.NewBBox(b.West, south, b.East, north), true
+	}
+	if Width(b) >= 360 {
+		return geojson.NewBBox(a.West, south, a.East, north), true
+	}
+
+	aw, ae := unwrap(a)
+	bw, be := unwrap(b)
+	found := false
+	var west, east float64
+	for _, shift := range []float64{-360, 0, 360} {
+		w := math.Max(aw, bw+shift)
+		e := math.Min(ae, be+shift)
+		if w <= e && (!found || e-w > east-west) {
+			found = true
+			west, east = w, e
+		}
+	}
+	if !found {
+		return nil, false
+	}
+	if east-west >= 360 {
+		return geojson.NewBBox(-180, south, 180, north), true
+	}
+
+	return geojson.NewBBox(normalize(west), south, normalizeEast(east), north), true
+}
+
+// Union returns the smallest bounding box containing all the boxes.
+func Union(boxes []geojson.BBOX) (*geojson.BBOX, error) {
+	if len(boxes) == 0 {
+		return nil, errors.New("bboxes can't be empty")
+	}
+
+	res := boxes[0]
+	for _, b := range boxes[1:] {
+		res = union(res, b)
+	}
+
+	return &res, nil
+}
+
+func union(a, b geojson.BBOX) geojson.BBOX {
+	south := math.Min(a.South, b.South)
+	north := math.Max(a.North, b.North)
+
+	aw, ae := unwrap(a)
+	bw, be := unwrap(b)
+	best := math.Inf(1)
+	var west, east float64
+	for _, shift := range []float64{-360, 0, 360} {
+		w := math.Min(aw, bw+shift)
+		e := math.Max(ae, be+shift)
+		if e-w < best {
+			best = e - w
+			west, east = w, e
+		}
+	}
+	if east-west >= 360 {
+		return *geojson.NewBBox(-180, south, 180, north)
+	}
+
+	return *geojson.NewBBox(normalize(west), south, normalizeEast(east), north)
+}
+
+// unwrap returns the longitudes of the box with the east edge greater than the west edge.
+func unwrap(b geojson.BBOX) (float64, float64) {
+	if CrossesAntimeridian(b) {
+		return b.West, b.East + 360
+	}
+	return b.West, b.East
+}
+
+func rectangle(west, south, east, north float64) [][]geometry.Point {
+	return [][]geometry.Point{{
+		{Lat: south, Lng: west},
+		{Lat: south, Lng: east},
+		{Lat: north, Lng: east},
+		{Lat: north, Lng: west},
+		{Lat: south, Lng: west},
+	}}
+}
+
+// normalize returns the longitude in the [-180, 180) range.
+func normalize(lng float64) float64 {
+	lng = math.Mod(lng+180, 360)
+	if lng < 0 {
+		lng += 360
+	}
+	return lng - 180
+}
+
+// normalizeEast returns the longitude in the (-180, 180] range.
+func normalizeEast(lng float64) float64 {
+	n := normalize(lng)
+	if n == -180 {
+		return 180
+	}
+	return n
+}
+
+// Center returns the center of the bounding box. The center of a box crossing the antimeridian
+// lies between its edges and not on the opposite side of the globe.
+func Center(b geojson.BBOX) geometry.Point {
+	return geometry.Point{
+		Lat: (b.South + b.North) / 2,
+		Lng: normalize(b.West + Width(b)/2),
+	}
+}
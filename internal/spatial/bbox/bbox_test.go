@@ -0,0 +1,128 @@
+package bbox
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestOf(t *testing.T) {
+	tests := map[string]struct {
+		points []geometry.Point
+		want   geojson.BBOX
+	}{
+		"single point": {
+			points: []geometry.Point{{Lat: 10, Lng: 20}},
+			want:   geojson.BBOX{West: 20, South: 10, East: 20, North: 10},
+		},
+		"regular": {
+			points: []geometry.Point{{Lat: 10, Lng: 20}, {Lat: -5, Lng: 40}, {Lat: 3, Lng: 30}},
+			want:   geojson.BBOX{West: 20, South: -5, East: 40, North: 10},
+		},
+		"across the antimeridian": {
+			points: []geometry.Point{{Lat: -17, Lng: 178}, {Lat: -16, Lng: -179}, {Lat: -18, Lng: 179.5}},
+			want:   geojson.BBOX{West: 178, South: -18, East: -179, North: -16},
+		},
+		"ending on the antimeridian": {
+			points: []geometry.Point{{Lat: 0, Lng: 170}, {Lat: 1, Lng: 180}},
+			want:   geojson.BBOX{West: 170, South: 0, East: 180, North: 1},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Of(tt.points)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, *got)
+		})
+	}
+
+	_, err := Of(nil)
+	assert.Error(t, err)
+}
+
+func TestPolygon(t *testing.T) {
+	polys := Polygon(geojson.BBOX{West: 20, South: -5, East: 40, North: 10})
+	assert.Len(t, polys, 1)
+	assert.Len(t, polys[0][0], 5)
+
+	polys = Polygon(geojson.BBOX{West: 178, South: -18, East: -179, North: -16})
+	assert.Len(t, polys, 2)
+	assert.Equal(t, 180.0, polys[0][0][1].Lng)
+	assert.Equal(t, -180.0, polys[1][0][0].Lng)
+}
+
+func TestExpand(t *testing.T) {
+	b, err := Expand(geojson.BBOX{West: 179.9, South: 0, East: 179.95, North: 0.1}, 111.32, "kilometres")
+	assert.NoError(t, err)
+	assert.InDelta(t, -1.0, b.South, 1e-2)
+	assert.InDelta(t, 1.1, b.North, 1e-2)
+	assert.InDelta(t, 178.9, b.West, 1e-2)
+	assert.InDelta(t, -179.05, b.East, 1e-2)
+	assert.True(t, CrossesAntimeridian(*b))
+
+	b, err = Expand(geojson.BBOX{West: 0, South: 89, East: 1, North: 89.5}, 200, "kilometres")
+	assert.NoError(t, err)
+	assert.Equal(t, geojson.BBOX{West: -180, South: b.South, East: 180, North: 90}, *b)
+
+	_, err = Expand(geojson.BBOX{}, -1, "kilometres")
+	assert.Error(t, err)
+	_, err = Expand(geojson.BBOX{}, 1, "parsecs")
+	assert.Error(t, err)
+}
+
+func TestIntersect(t *testing.T) {
+	b, ok := Intersect(geojson.BBOX{West: 170, South: 0, East: -170, North: 10}, geojson.BBOX{West: -175, South: 5, East: -160, North: 20})
+	assert.True(t, ok)
+	assert.Equal(t, geojson.BBOX{West: -175, South: 5, East: -170, North: 10}, *b)
+
+	b, ok = Intersect(geojson.BBOX{West: -180, South: -90, East: 180, North: 90}, geojson.BBOX{West: 170, South: 0, East: -170, North: 10})
+	assert.True(t, ok)
+	assert.Equal(t, geojson.BBOX{West: 170, South: 0, East: -170, North: 10}, *b)
+
+	_, ok = Intersect(geojson.BBOX{West: 0, South: 0, East: 10, North: 10}, geojson.BBOX{West: 20, South: 0, East: 30, North: 10})
+	assert.False(t, ok)
+	_, ok = Intersect(geojson.BBOX{West: 0, South: 0, East: 10, North: 10}, geojson.BBOX{West: 0, South: 20, East: 10, North: 30})
+	assert.False(t, ok)
+}
+
+func TestUnion(t *testing.T) {
+	b, err := Union([]geojson.BBOX{
+		{West: 170, South: 0, East: 175, North: 10},
+		{West: -175, South: -5, East: -170, North: 5},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, geojson.BBOX{West: 170, South: -5, East: -170, North: 10}, *b)
+
+	b, err = Union([]geojson.BBOX{
+		{West: 0, South: 0, East: 10, North: 10},
+		{West: 20, South: 0, East: 30, North: 10},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, geojson.BBOX{West: 0, South: 0, East: 30, North: 10}, *b)
+
+	_, err = Union(nil)
+	assert.Error(t, err)
+}
+
+func TestFromSlice(t *testing.T) {
+	b, err := FromSlice([]float64{170, -10, -170, 10})
+	assert.NoError(t, err)
+	assert.True(t, CrossesAntimeridian(*b))
+	assert.Equal(t, 20.0, Width(*b))
+
+	_, err = FromSlice([]float64{1, 2, 3})
+	assert.Error(t, err)
+	_, err = FromSlice([]float64{0, 10, 1, 5})
+	assert.Error(t, err)
+	_, err = FromSlice([]float64{0, -91, 1, 5})
+	assert.Error(t, err)
+}
+
+func TestCenter(t *testing.T) {
+	assert.Equal(t, geometry.Point{Lat: 5, Lng: 15}, Center(geojson.BBOX{West: 10, South: 0, East: 20, North: 10}))
+	assert.Equal(t, geometry.Point{Lat: -17, Lng: -180}, Center(geojson.BBOX{West: 178, South: -18, East: -178, North: -16}))
+	assert.Equal(t, geometry.Point{Lat: 0, Lng: 179}, Center(geojson.BBOX{West: 176, South: -1, East: -178, North: 1}))
+}
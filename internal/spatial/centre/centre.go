@@ -0,0 +1,241 @@
+package centre
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// The functions of this package work on the plane of longitude and latitude, the same way
+// renderers place their labels on a map.
+
+// Parts holds the components of one or more geometries grouped by their dimension.
+type Parts struct {
+	Points   []geometry.Point
+	Lines    [][]geometry.Point
+	Polygons [][][]geometry.Point
+}
+
+// IsEmpty reports whether the parts have no positions.
+func (p Parts) IsEmpty() bool {
+	return len(p.Points) == 0 && len(p.Lines) == 0 && len(p.Polygons) == 0
+}
+
+// Centroid returns the arithmetic mean of all the vertices. The closing vertex of a ring is
+// counted once.
+func Centroid(parts Parts) (*geometry.Point, error) {
+	var sx, sy float64
+	n := 0
+	add := func(ps []geometry.Point) {
+		for _, p := range ps {
+			sx += p.Lng
+			sy += p.Lat
+			n++
+		}
+	}
+
+	add(parts.Points)
+	for _, l := range parts.Lines {
+		add(l)
+	}
+	for _, rings := range parts.Polygons {
+		for _, r := range rings {
+			add(open(r))
+		}
+	}
+	if n == 0 {
+		return nil, errors.New("geometry can't be empty")
+	}
+
+	return &geometry.Point{Lat: sy / float64(n), Lng: sx / float64(n)}, nil
+}
+
+// CenterOfMass returns the centroid of the components with the highest dimension: polygons
+// are weighted by their area, lines by their length and points count equally. Polygons or
+// lines collapsed to zero area or length fall back to the lower dimension.
+func CenterOfMass(parts Parts) (*geometry.Point, error) {
+	if parts.IsEmpty() {
+		return nil, errors.New("geometry can't be empty")
+	}
+
+	var area, ax, ay float64
+	for _, rings := range parts.Polygons {
+		for i, r := range rings {
+			a, cx, cy := ringCentroid(r)
+			a = math.Abs(a)
+			if i > 0 {
+				a = -a
+			}
+			area += a
+			ax += a * cx
+			ay += a * cy
+		}
+	}
+	if area > 0 {
+		return &geometry.Point{Lat: ay / area, Lng: ax / area}, nil
+	}
+
+	lines := parts.Lines
+	for _, rings := range parts.Polygons {
+		lines = append(lines, rings...)
+	}
+	var length, lx, ly float64
+	for _, l := range lines {
+		for i := 1; i < len(l); i++ {
+			d := math.Hypot(l[i].Lng-l[i-1].Lng, l[i].Lat-l[i-1].Lat)
+			length += d
+			lx += d * (l[i].Lng + l[i-1].Lng) / 2
+			ly += d * (l[i].Lat + l[i-1].Lat) / 2
+		}
+	}
+	if length > 0 {
+		return &geometry.Point{Lat: ly / length, Lng: lx / length}, nil
+	}
+
+	return Centroid(parts)
+}
+
+// PointOnSurface returns a point guaranteed to lie on the geometry. For polygons it is the
+// middle of the widest interior interval of a horizontal line crossing the polygons near the
+// middle of their extent, for lines the interior vertex closest to the centroid and for points
+// the point closest to the centroid.
+func PointOnSurface(parts Parts) (*geometry.Point, error) {
+	if parts.IsEmpty() {
+		return nil, errors.New("geometry can't be empty")
+	}
+
+	var best *geometry.Point
+	width := -1.0
+	for _, rings := range parts.Polygons {
+		if p, w, ok := scanline(rings); ok && w > width {
+			best, width = p, w
+		}
+	}
+	if best != nil {
+		return best, nil
+	}
+
+	c, err := CenterOfMass(parts)
+	if err != nil {
+		return nil, err
+	}
+
+	var candidates []geometry.Point
+	for _, l := range parts.Lines {
+		if len(l) > 2 {
+			candidates = append(candidates, l[1:len(l)-1]...)
+		}
+	}
+	if len(candidates) == 0 {
+		for _, l := range parts.Lines {
+			candidates = append(candidates, l...)
+		}
+		for _, rings := range parts.Polygons {
+			for _, r := range rings {
+				candidates = append(candidates, r...)
+			}
+		}
+	}
+	if len(candidates) == 0 {
+		candidates = parts.Points
+	}
+
+	return closest(*c, candidates), nil
+}
+
+// scanline returns the middle of the widest interval inside the polygon along a horizontal line
+// placed between the vertices closest to the middle of its latitude extent, so that the line
+// never passes through a vertex.
+func scanline(rings [][]geometry.Point) (*geometry.Point, float64, bool) {
+	if len(rings) == 0 || len(rings[0]) == 0 {
+		return nil, 0, false
+	}
+
+	lo, hi := math.Inf(1), math.Inf(-1)
+	for _, p := range rings[0] {
+		lo = math.Min(lo, p.Lat)
+		hi = math.Max(hi, p.Lat)
+	}
+	mid := (lo + hi) / 2
+	for _, r := range rings {
+		for _, p := range r {
+			if p.Lat <= mid && p.Lat > lo {
+				lo = p.Lat
+			} else if p.Lat > mid && p.Lat < hi {
+				hi = p.Lat
+			}
+		}
+	}
+	y := (lo + hi) / 2
+
+	var xs []float64
+	for _, r := range rings {
+		for i := range r {
+			a, b := r[i], r[(i+1)%len(r)]
+			if (a.Lat > y) != (b.Lat > y) {
+				xs = append(xs, a.Lng+(y-a.Lat)*(b.Lng-a.Lng)/(b.Lat-a.Lat))
+			}
+		}
+	}
+	sort.Float64s(xs)
+
+	width := -1.0
+	var x float64
+	for i := 0; i+1 < len(xs); i += 2 {
+		if w := xs[i+1] - xs[i]; w > width {
+			width = w
+			x = (xs[i] + xs[i+1]) / 2
+		}
+	}
+	if width < 0 {
+		return nil, 0, false
+	}
+
+	return &geometry.Point{Lat: y, Lng: x}, width, true
+}
+
+// ringCentroid returns the signed area and the centroid of a ring. The coordinates are shifted
+// to the first vertex to keep the precision of the products.
+func ringCentroid(r []geometry.Point) (float64, float64, float64) {
+	r = open(r)
+	if len(r) < 3 {
+		return 0, 0, 0
+	}
+
+	ox, oy := r[0].Lng, r[0].Lat
+	var a, cx, cy float64
+	for i := range r {
+		x0, y0 := r[i].Lng-ox, r[i].Lat-oy
+		x1, y1 := r[(i+1)%len(r)].Lng-ox, r[(i+1)%len(r)].Lat-oy
+		f := x0*y1 - x1*y0
+		a += f
+		cx += (x0 + x1) * f
+		cy += (y0 + y1) * f
+	}
+	if a == 0 {
+		return 0, 0, 0
+	}
+
+	return a / 2, cx/(3*a) + ox, cy/(3*a) + oy
+}
+
+// open returns the ring without its closing vertex.
+func open(r []geometry.Point) []geometry.Point {
+	if len(r) > 1 && r[0] == r[len(r)-1] {
+		return r[:len(r)-1]
+	}
+	return r
+}
+
+func closest(c geometry.Point, ps []geometry.Point) *geometry.Point {
+	best := ps[0]
+	dist := math.Inf(1)
+	for _, p := range ps {
+		if d := math.Hypot(p.Lng-c.Lng, p.Lat-c.Lat); d < dist {
+			best, dist = p, d
+		}
+	}
+	return &best
+}
@@ -0,0 +1,126 @@
+package centre
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// lShape is an L shaped polygon whose centroid lies outside of it.
+var lShape = [][]geometry.Point{{
+	{Lat: 0, Lng: 0}, {Lat: 0, Lng: 10}, {Lat: 1, Lng: 10}, {Lat: 1, Lng: 1},
+	{Lat: 10, Lng: 1}, {Lat: 10, Lng: 0}, {Lat: 0, Lng: 0},
+}}
+
+func square(x, y, size float64) [][]geometry.Point {
+	return [][]geometry.Point{{
+		{Lat: y, Lng: x}, {Lat: y, Lng: x + size}, {Lat: y + size, Lng: x + size},
+		{Lat: y + size, Lng: x}, {Lat: y, Lng: x},
+	}}
+}
+
+func TestCentroid(t *testing.T) {
+	c, err := Centroid(Parts{Polygons: [][][]geometry.Point{square(0, 0, 2)}})
+	assert.NoError(t, err)
+	assert.Equal(t, geometry.Point{Lat: 1, Lng: 1}, *c)
+
+	c, err = Centroid(Parts{Points: []geometry.Point{{Lat: 0, Lng: 0}, {Lat: 3, Lng: 6}}})
+	assert.NoError(t, err)
+	assert.Equal(t, geometry.Point{Lat: 1.5, Lng: 3}, *c)
+
+	_, err = Centroid(Parts{})
+	assert.EqualError(t, err, "geometry can't be empty")
+}
+
+func TestCenterOfMass(t *testing.T) {
+	tests := map[string]struct {
+		parts Parts
+		want  geometry.Point
+	}{
+		"square": {
+			parts: Parts{Polygons: [][][]geometry.Point{square(0, 0, 2)}},
+			want:  geometry.Point{Lat: 1, Lng: 1},
+		},
+		"weighted by area": {
+			// the vertices of the small square pull the arithmetic centroid but not the center of mass
+			parts: Parts{Polygons: [][][]geometry.Point{square(0, 0, 3), square(10, 0, 1)}},
+			want:  geometry.Point{Lat: (9*1.5 + 0.5) / 10, Lng: (9*1.5 + 10.5) / 10},
+		},
+		"with a hole": {
+			parts: Parts{Polygons: [][][]geometry.Point{{square(0, 0, 4)[0], square(2, 0, 2)[0]}}},
+			want:  geometry.Point{Lat: 7.0 / 3, Lng: 5.0 / 3},
+		},
+		"polygons win over points": {
+			parts: Parts{
+				Points:   []geometry.Point{{Lat: 50, Lng: 50}},
+				Polygons: [][][]geometry.Point{square(0, 0, 2)},
+			},
+			want: geometry.Point{Lat: 1, Lng: 1},
+		},
+		"lines weighted by length": {
+			parts: Parts{Lines: [][]geometry.Point{
+				{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 3}},
+				{{Lat: 1, Lng: 0}, {Lat: 1, Lng: 1}},
+			}},
+			want: geometry.Point{Lat: 0.25, Lng: (3*1.5 + 0.5) / 4},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := CenterOfMass(tt.parts)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want.Lat, got.Lat, 1e-9)
+			assert.InDelta(t, tt.want.Lng, got.Lng, 1e-9)
+		})
+	}
+}
+
+func TestPointOnSurface(t *testing.T) {
+	parts := Parts{Polygons: [][][]geometry.Point{lShape}}
+
+	c, err := CenterOfMass(parts)
+	assert.NoError(t, err)
+	assert.Less(t, signedDistance(c.Lng, c.Lat, lShape), 0.0)
+
+	p, err := PointOnSurface(parts)
+	assert.NoError(t, err)
+	assert.Greater(t, signedDistance(p.Lng, p.Lat, lShape), 0.0)
+
+	p, err = PointOnSurface(Parts{Lines: [][]geometry.Point{{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 0, Lng: 2}, {Lat: 0, Lng: 10}}}})
+	assert.NoError(t, err)
+	assert.Equal(t, geometry.Point{Lat: 0, Lng: 2}, *p)
+
+	p, err = PointOnSurface(Parts{Points: []geometry.Point{{Lat: 0, Lng: 0}, {Lat: 1, Lng: 1}, {Lat: 5, Lng: 5}}})
+	assert.NoError(t, err)
+	assert.Equal(t, geometry.Point{Lat: 1, Lng: 1}, *p)
+}
+
+func TestPoleOfInaccessibility(t *testing.T) {
+	p, err := PoleOfInaccessibility([][][]geometry.Point{square(0, 0, 10)}, 1e-3)
+	assert.NoError(t, err)
+	assert.InDelta(t, 5, p.Lat, 1e-2)
+	assert.InDelta(t, 5, p.Lng, 1e-2)
+
+	p, err = PoleOfInaccessibility([][][]geometry.Point{lShape}, 1e-3)
+	assert.NoError(t, err)
+	// the largest circle touches the outer corner walls and the inner corner
+	assert.InDelta(t, math.Sqrt2/(1+math.Sqrt2), signedDistance(p.Lng, p.Lat, lShape), 1e-3)
+
+	// the larger polygon of a MultiPolygon wins
+	p, err = PoleOfInaccessibility([][][]geometry.Point{square(0, 0, 1), square(10, 10, 4)}, 1e-3)
+	assert.NoError(t, err)
+	assert.InDelta(t, 12, p.Lat, 1e-2)
+	assert.InDelta(t, 12, p.Lng, 1e-2)
+
+	// a thin polygon isn't split into more cells than are probed
+	thin := []geometry.Point{{Lng: 0, Lat: 0}, {Lng: 10, Lat: 0}, {Lng: 10, Lat: 1e-9}, {Lng: 0, Lat: 1e-9}, {Lng: 0, Lat: 0}}
+	p, err = PoleOfInaccessibility([][][]geometry.Point{{thin}}, 1e-12)
+	assert.NoError(t, err)
+	assert.Greater(t, signedDistance(p.Lng, p.Lat, [][]geometry.Point{thin}), 0.0)
+
+	_, err = PoleOfInaccessibility([][][]geometry.Point{lShape}, 0)
+	assert.EqualError(t, err, "tolerance must be a positive number")
+}
@@ -0,0 +1,154 @@
+package centre
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// maxPoleCells bounds the number of cells probed while searching for the pole of
+// inaccessibility, so that tiny tolerances on large polygons can't run forever.
+const maxPoleCells = 100000
+
+// PoleOfInaccessibility returns the interior point farthest from the outline of the polygons,
+// found with the polylabel algorithm to within tolerance degrees. For a MultiPolygon the pole of
+// the polygon giving the largest distance is returned.
+func PoleOfInaccessibility(polygons [][][]geometry.Point, tolerance float64) (*geometry.Point, error) {
+	if tolerance <= 0 || math.IsNaN(tolerance) || math.IsInf(tolerance, 0) {
+		return nil, errors.New("tolerance must be a positive number")
+	}
+	if len(polygons) == 0 {
+		return nil, errors.New("only Polygon and MultiPolygon geometries are supported")
+	}
+
+	var best *geometry.Point
+	dist := math.Inf(-1)
+	for _, rings := range polygons {
+		if len(rings) == 0 || len(rings[0]) == 0 {
+			continue
+		}
+		p, d := polylabel(rings, tolerance)
+		if d > dist {
+			best, dist = &p, d
+		}
+	}
+	if best == nil {
+		return nil, errors.New("geometry can't be empty")
+	}
+
+	return best, nil
+}
+
+type poleCell struct {
+	x, y float64
+	h    float64 // half the cell size
+	d    float64 // distance from the cell center to the polygon, negative outside
+	max  float64 // the largest distance possible inside the cell
+}
+
+func newPoleCell(x, y, h float64, rings [][]geometry.Point) *poleCell {
+	d := signedDistance(x, y, rings)
+	return &poleCell{x: x, y: y, h: h, d: d, max: d + h*math.Sqrt2}
+}
+
+type cellQueue []*poleCell
+
+func (q cellQueue) Len() int            { return len(q) }
+func (q cellQueue) Less(i, j int) bool  { return q[i].max > q[j].max }
+func (q cellQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *cellQueue) Push(x interface{}) { *q = append(*q, x.(*poleCell)) }
+func (q *cellQueue) Pop() interface{} {
+	old := *q
+	c := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return c
+}
+
+func polylabel(rings [][]geometry.Point, tolerance float64) (geometry.Point, float64) {
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range rings[0] {
+		minX = math.Min(minX, p.Lng)
+		minY = math.Min(minY, p.Lat)
+		maxX = math.Max(maxX, p.Lng)
+		maxY = math.Max(maxY, p.Lat)
+	}
+
+	w, ht := maxX-minX, maxY-minY
+	if math.Min(w, ht) == 0 {
+		return geometry.Point{Lat: minY, Lng: minX}, 0
+	}
+	// the first cells are no smaller than the tolerance, and no more than maxPoleCells of them
+	// cover thin polygons
+	size := math.Max(math.Min(w, ht), math.Max(tolerance, math.Max(w, ht)/maxPoleCells))
+	h := size / 2
+
+	q := &cellQueue{}
+	for x := minX; x < maxX; x += size {
+		for y := minY; y < maxY; y += size {
+			heap.Push(q, newPoleCell(x+h, y+h, h, rings))
+		}
+	}
+
+	best := newPoleCell((minX+maxX)/2, (minY+maxY)/2, 0, rings)
+	if a, cx, cy := ringCentroid(rings[0]); a != 0 {
+		if c := newPoleCell(cx, cy, 0, rings); c.d > best.d {
+			best = c
+		}
+	}
+
+	for probed := 0; q.Len() > 0 && probed < maxPoleCells; probed++ {
+		c := heap.Pop(q).(*poleCell)
+		if c.d > best.d {
+			best = c
+		}
+		if c.max-best.d <= tolerance {
+			continue
+		}
+
+		h := c.h / 2
+		heap.Push(q, newPoleCell(c.x-h, c.y-h, h, rings))
+		heap.Push(q, newPoleCell(c.x+h, c.y-h, h, rings))
+		heap.Push(q, newPoleCell(c.x-h, c.y+h, h, rings))
+		heap.Push(q, newPoleCell(c.x+h, c.y+h, h, rings))
+	}
+
+	return geometry.Point{Lat: best.y, Lng: best.x}, best.d
+}
+
+// signedDistance returns the distance from the point to the closest ring, positive when the
+// point is inside the polygon and negative outside.
+func signedDistance(x, y float64, rings [][]geometry.Point) float64 {
+	inside := false
+	minDist := math.Inf(1)
+	for _, r := range rings {
+		for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+			a, b := r[i], r[j]
+			if (a.Lat > y) != (b.Lat > y) && x < (b.Lng-a.Lng)*(y-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+				inside = !inside
+			}
+			minDist = math.Min(minDist, segmentDistance(x, y, a, b))
+		}
+	}
+	if inside {
+		return minDist
+	}
+	return -minDist
+}
+
+func segmentDistance(x, y float64, a, b geometry.Point) float64 {
+	px, py := a.Lng, a.Lat
+	dx, dy := b.Lng-px, b.Lat-py
+	if dx != 0 || dy != 0 {
+		t := ((x-px)*dx + (y-py)*dy) / (dx*dx + dy*dy)
+		if t > 1 {
+			px, py = b.Lng, b.Lat
+		} else if t > 0 {
+			px += dx * t
+			py += dy * t
+		}
+	}
+	return math.Hypot(x-px, y-py)
+}
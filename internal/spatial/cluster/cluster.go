@@ -0,0 +1,374 @@
+package cluster
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/rand"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Noise labels the points DBSCAN leaves out of every cluster.
+const Noise = -1
+
+// maxPoints bounds the number of points clustered in one call.
+const maxPoints = 50000
+
+// The distances are the angular distances on the sphere, compared as the chord lengths between
+// unit vectors so that the clustering works the same near the poles and across the antimeridian.
+type vec struct {
+	x, y, z float64
+}
+
+func toVec(p geometry.Point) vec {
+	lat, lng := p.Lat*math.Pi/180, p.Lng*math.Pi/180
+	return vec{x: math.Cos(lat) * math.Cos(lng), y: math.Cos(lat) * math.Sin(lng), z: math.Sin(lat)}
+}
+
+func (v vec) point() geometry.Point {
+	return geometry.Point{
+		Lat: math.Atan2(v.z, math.Hypot(v.x, v.y)) * 180 / math.Pi,
+		Lng: math.Atan2(v.y, v.x) * 180 / math.Pi,
+	}
+}
+
+func (v vec) dot(o vec) float64 {
+	return v.x*o.x + v.y*o.y + v.z*o.z
+}
+
+func (v vec) add(o vec) vec {
+	return vec{x: v.x + o.x, y: v.y + o.y, z: v.z + o.z}
+}
+
+// chord2 returns the squared chord length between two unit vectors.
+func chord2(a, b vec) float64 {
+	dx, dy, dz := a.x-b.x, a.y-b.y, a.z-b.z
+	return dx*dx + dy*dy + dz*dz
+}
+
+func prepare(points []geometry.Point) ([]vec, error) {
+	if len(points) == 0 {
+		return nil, errors.New("points can't be empty")
+	}
+	if len(points) > maxPoints {
+		return nil, fmt.Errorf("too many points, the limit is %d", maxPoints)
+	}
+	vs := make([]vec, 0, len(points))
+	for _, p := range points {
+		if math.IsNaN(p.Lat) || math.IsNaN(p.Lng) || math.IsInf(p.Lat, 0) || math.IsInf(p.Lng, 0) {
+			return nil, errors.New("points must have finite coordinates")
+		}
+		vs = append(vs, toVec(p))
+	}
+	return vs, nil
+}
+
+// DBSCAN groups the points that have at least minPoints points, themselves included, within eps
+// radians, along with the points within eps of them. It returns the cluster of every point,
+// numbered from 0 in the order they are found, or Noise.
+func DBSCAN(points []geometry.Point, eps float64, minPoints int) ([]int, error) {
+	if !(eps > 0) || math.IsInf(eps, 0) {
+		return nil, errors.New("eps must be a positive number")
+	}
+	if minPoints < 1 {
+		return nil, errors.New("minPoints must be at least 1")
+	}
+	vs, err := prepare(points)
+	if err != nil {
+		return nil, err
+	}
+
+	// the points are in a grid of cubes of eps side, the neighbours of a point being in the cubes
+	// around its own, the side being kept large enough to number the cubes
+	limit := 4 * math.Pow(math.Sin(math.Min(eps, math.Pi)/2), 2)
+	g := newGrid(vs, math.Max(math.Sqrt(limit), 1e-12))
+
+	const unvisited = -2
+	labels := make([]int, len(points))
+	for i := range labels {
+		labels[i] = unvisited
+	}
+	id := 0
+	for i := range points {
+		if labels[i] != unvisited {
+			continue
+		}
+		if !g.dense(vs[i], limit, minPoints) {
+			labels[i] = Noise
+			continue
+		}
+
+		labels[i] = id
+		queue := []int{i}
+		for len(queue) > 0 {
+			j := queue[0]
+			queue = queue[1:]
+			if j != i && !g.dense(vs[j], limit, minPoints) {
+				// a border point, reachable but not dense enough to expand the cluster
+				continue
+			}
+			g.claim(vs[j], limit, func(k int) {
+				if labels[k] == unvisited {
+					queue = append(queue, k)
+				}
+				labels[k] = id
+			}, func(k int) bool { return labels[k] >= 0 })
+		}
+		id++
+	}
+	return labels, nil
+}
+
+// grid holds the points in cubes of the space of the unit vectors. Each cube also keeps the points
+// not in a cluster yet, dropped as they are claimed so that every point joins one cluster once.
+type grid struct {
+	vs    []vec
+	side  float64
+	cells map[[3]int][]int
+	free  map[[3]int][]int
+}
+
+func newGrid(vs []vec, side float64) *grid {
+	g := &grid{vs: vs, side: side, cells: map[[3]int][]int{}, free: map[[3]int][]int{}}
+	for i, v := range vs {
+		k := g.key(v)
+		g.cells[k] = append(g.cells[k], i)
+	}
+	for k, is := range g.cells {
+		g.free[k] = append([]int(nil), is...)
+	}
+	return g
+}
+
+func (g *grid) key(v vec) [3]int {
+	return [3]int{int(math.Floor(v.x / g.side)), int(math.Floor(v.y / g.side)), int(math.Floor(v.z / g.side))}
+}
+
+// around calls f with the keys of the cube of a point and of the cubes next to it.
+func (g *grid) around(v vec, f func(k [3]int)) {
+	c := g.key(v)
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			for dz := -1; dz <= 1; dz++ {
+				f([3]int{c[0] + dx, c[1] + dy, c[2] + dz})
+			}
+		}
+	}
+}
+
+// dense reports whether at least minPoints points are within the squared chord limit of a point,
+// counting no further than minPoints.
+func (g *grid) dense(v vec, limit float64, minPoints int) bool {
+	n := 0
+	g.around(v, func(k [3]int) {
+		for _, j := range g.cells[k] {
+			if n >= minPoints {
+				return
+			}
+			if chord2(v, g.vs[j]) <= limit {
+				n++
+			}
+		}
+	})
+	return n >= minPoints
+}
+
+// claim calls f with the points within the squared chord limit of a point that aren't in a
+// cluster yet, and drops them from the free points along with the ones clustered since.
+func (g *grid) claim(v vec, limit float64, f func(j int), clustered func(j int) bool) {
+	g.around(v, func(k [3]int) {
+		is, ok := g.free[k]
+		if !ok {
+			return
+		}
+		rest := is[:0]
+		for _, j := range is {
+			switch {
+			case clustered(j):
+			case chord2(v, g.vs[j]) <= limit:
+				f(j)
+			default:
+				rest = append(rest, j)
+			}
+		}
+		if len(rest) == 0 {
+			delete(g.free, k)
+		} else {
+			g.free[k] = rest
+		}
+	})
+}
+
+const (
+	// maxIterations bounds the refinements of the k-means centroids.
+	maxIterations = 100
+	// maxClusters bounds k, and maxAssignments the number of points times k measured at every
+	// refinement.
+	maxClusters    = 1000
+	maxAssignments = 5000000
+)
+
+// KMeans partitions the points into k clusters minimizing the distances to their centroids. The
+// centroids are seeded with k-means++ from a fixed seed, so the same input gives the same result.
+func KMeans(points []geometry.Point, k int) ([]int, error) {
+	vs, err := prepare(points)
+	if err != nil {
+		return nil, err
+	}
+	if k < 1 || k > len(points) {
+		return nil, errors.New("k must be between 1 and the number of points")
+	}
+	if k > maxClusters {
+		return nil, fmt.Errorf("k can't be greater than %d", maxClusters)
+	}
+	if len(points)*k > maxAssignments {
+		return nil, fmt.Errorf("too many points for %d clusters, the points times k can't exceed %d", k, maxAssignments)
+	}
+
+	cs := seed(vs, k)
+	labels := make([]int, len(vs))
+	for i := range labels {
+		labels[i] = -1
+	}
+	for it := 0; it < maxIterations; it++ {
+		changed := false
+		for i, v := range vs {
+			best, bestDot := 0, math.Inf(-1)
+			for c, cv := range cs {
+				if d := v.dot(cv); d > bestDot {
+					best, bestDot = c, d
+				}
+			}
+			if labels[i] != best {
+				labels[i] = best
+				changed = true
+			}
+		}
+		if !changed {
+			break
+		}
+
+		sums := make([]vec, k)
+		counts := make([]int, k)
+		for i, v := range vs {
+			sums[labels[i]] = sums[labels[i]].add(v)
+			counts[labels[i]]++
+		}
+		for c := range cs {
+			if counts[c] == 0 {
+				// an empty cluster takes the point farthest from its centroid
+				cs[c] = vs[farthest(vs, labels, cs)]
+				continue
+			}
+			if n, ok := normalize(sums[c]); ok {
+				cs[c] = n
+			}
+		}
+	}
+	return labels, nil
+}
+
+// seed picks k centroids among the points, each with a probability proportional to its squared
+// distance to the closest centroid already picked.
+func seed(vs []vec, k int) []vec {
+	rnd := rand.New(rand.NewSource(1))
+	cs := []vec{vs[rnd.Intn(len(vs))]}
+	dist := make([]float64, len(vs))
+	for i, v := range vs {
+		dist[i] = chord2(v, cs[0])
+	}
+	for len(cs) < k {
+		var total float64
+		for _, d := range dist {
+			total += d
+		}
+		next := -1
+		if total > 0 {
+			r := rnd.Float64() * total
+			for i, d := range dist {
+				if d == 0 {
+					continue
+				}
+				next = i
+				if r -= d; r <= 0 {
+					break
+				}
+			}
+		} else {
+			// all the points are picked already, the remaining centroids are duplicates
+			next = len(cs) % len(vs)
+		}
+		cs = append(cs, vs[next])
+		for i, v := range vs {
+			dist[i] = math.Min(dist[i], chord2(v, vs[next]))
+		}
+	}
+	return cs
+}
+
+func farthest(vs []vec, labels []int, cs []vec) int {
+	best, bestDist := 0, -1.0
+	for i, v := range vs {
+		if d := chord2(v, cs[labels[i]]); d > bestDist {
+			best, bestDist = i, d
+		}
+	}
+	return best
+}
+
+func normalize(v vec) (vec, bool) {
+	n := math.Sqrt(v.dot(v))
+	if n < 1e-12 {
+		return v, false
+	}
+	return vec{x: v.x / n, y: v.y / n, z: v.z / n}, true
+}
+
+// Centroid returns the mean position of the points on the sphere, or the first point when they
+// cancel each other out.
+func Centroid(points []geometry.Point) (*geometry.Point, error) {
+	if len(points) == 0 {
+		return nil, errors.New("points can't be empty")
+	}
+	var sum vec
+	for _, p := range points {
+		sum = sum.add(toVec(p))
+	}
+	n, ok := normalize(sum)
+	if !ok {
+		p := points[0]
+		return &p, nil
+	}
+	p := n.point()
+	return &p, nil
+}
+
+// Members groups the points by cluster, leaving out the noise.
+func Members(points []geometry.Point, labels []int) [][]geometry.Point {
+	var res [][]geometry.Point
+	for i, l := range labels {
+		if l < 0 {
+			continue
+		}
+		for len(res) <= l {
+			res = append(res, nil)
+		}
+		res[l] = append(res[l], points[i])
+	}
+	return res
+}
+
+// Result holds the cluster of every point and the clusters found.
+type Result struct {
+	Labels   []int
+	Clusters []Cluster
+}
+
+// Cluster describes a cluster by its number of points, centroid and hull.
+type Cluster struct {
+	ID       int
+	Count    int
+	Centroid geometry.Point
+	Hull     geometry.Geometry
+}
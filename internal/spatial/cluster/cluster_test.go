@@ -0,0 +1,168 @@
+package cluster
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// km is the angle of a kilometre on the earth in radians.
+const km = 1 / 6371.0088
+
+func blob(lng, lat float64, n int) []geometry.Point {
+	ps := make([]geometry.Point, 0, n)
+	for i := 0; i < n; i++ {
+		a := float64(i) * 2 * math.Pi / float64(n)
+		ps = append(ps, geometry.Point{Lng: lng + 0.001*math.Cos(a), Lat: lat + 0.001*math.Sin(a)})
+	}
+	return ps
+}
+
+func TestDBSCAN(t *testing.T) {
+	points := append(blob(10, 50, 5), blob(11, 50, 4)...)
+	points = append(points, geometry.Point{Lng: 12, Lat: 50})
+
+	labels, err := DBSCAN(points, 0.5*km, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 0, 0, 0, 0, 1, 1, 1, 1, Noise}, labels)
+
+	labels, err = DBSCAN(points, 0.5*km, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 0, 0, 0, 0, Noise, Noise, Noise, Noise, Noise}, labels)
+}
+
+func TestDBSCANBorder(t *testing.T) {
+	// the last point is within eps of the end of the dense chain only
+	points := []geometry.Point{{Lng: 0, Lat: 0}, {Lng: 0.001, Lat: 0}, {Lng: 0.002, Lat: 0}, {Lng: 0.0035, Lat: 0}}
+
+	labels, err := DBSCAN(points, 0.17*km, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 0, 0, 0}, labels)
+}
+
+func TestDBSCANAntimeridian(t *testing.T) {
+	points := []geometry.Point{{Lng: 179.9995, Lat: 0}, {Lng: -179.9995, Lat: 0}, {Lng: 180, Lat: 0.0005}}
+
+	labels, err := DBSCAN(points, 0.5*km, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{0, 0, 0}, labels)
+}
+
+// TestDBSCANBruteForce compares the clusters with the ones found by measuring every pair of points.
+func TestDBSCANBruteForce(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	points := make([]geometry.Point, 600)
+	for i := range points {
+		points[i] = geometry.Point{Lng: float64(i%3)*0.05 + rnd.NormFloat64()*0.01, Lat: 50 + rnd.NormFloat64()*0.01}
+	}
+	eps, minPoints := 0.3*km, 4
+
+	const unvisited = -2
+	limit := 4 * math.Pow(math.Sin(eps/2), 2)
+	neighbours := func(i int) []int {
+		var res []int
+		for j := range points {
+			if chord2(toVec(points[i]), toVec(points[j])) <= limit {
+				res = append(res, j)
+			}
+		}
+		return res
+	}
+	want := make([]int, len(points))
+	for i := range want {
+		want[i] = unvisited
+	}
+	id := 0
+	for i := range points {
+		if want[i] != unvisited {
+			continue
+		}
+		queue := neighbours(i)
+		if len(queue) < minPoints {
+			want[i] = Noise
+			continue
+		}
+		want[i] = id
+		for len(queue) > 0 {
+			j := queue[0]
+			queue = queue[1:]
+			if want[j] == Noise {
+				want[j] = id
+			}
+			if want[j] != unvisited {
+				continue
+			}
+			want[j] = id
+			if more := neighbours(j); len(more) >= minPoints {
+				queue = append(queue, more...)
+			}
+		}
+		id++
+	}
+
+	labels, err := DBSCAN(points, eps, minPoints)
+	assert.NoError(t, err)
+	assert.Equal(t, want, labels)
+	assert.Greater(t, id, 1)
+	assert.Contains(t, labels, Noise)
+}
+
+func TestKMeans(t *testing.T) {
+	points := append(blob(10, 50, 6), blob(20, 50, 6)...)
+	points = append(points, blob(15, 55, 6)...)
+
+	labels, err := KMeans(points, 3)
+	assert.NoError(t, err)
+	for g := 0; g < 3; g++ {
+		for i := 1; i < 6; i++ {
+			assert.Equal(t, labels[g*6], labels[g*6+i])
+		}
+	}
+	assert.NotEqual(t, labels[0], labels[6])
+	assert.NotEqual(t, labels[0], labels[12])
+	assert.NotEqual(t, labels[6], labels[12])
+
+	again, err := KMeans(points, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, labels, again)
+}
+
+func TestKMeansDuplicates(t *testing.T) {
+	points := []geometry.Point{{Lng: 1, Lat: 1}, {Lng: 1, Lat: 1}, {Lng: 1, Lat: 1}}
+
+	labels, err := KMeans(points, 2)
+	assert.NoError(t, err)
+	assert.Len(t, labels, 3)
+}
+
+func TestErrors(t *testing.T) {
+	points := blob(0, 0, 3)
+
+	_, err := DBSCAN(points, 0, 3)
+	assert.EqualError(t, err, "eps must be a positive number")
+	_, err = DBSCAN(points, km, 0)
+	assert.EqualError(t, err, "minPoints must be at least 1")
+	_, err = DBSCAN(nil, km, 3)
+	assert.EqualError(t, err, "points can't be empty")
+	_, err = KMeans(points, 4)
+	assert.EqualError(t, err, "k must be between 1 and the number of points")
+	_, err = KMeans(make([]geometry.Point, 2000), 1001)
+	assert.EqualError(t, err, "k can't be greater than 1000")
+	_, err = KMeans(make([]geometry.Point, 10000), 501)
+	assert.EqualError(t, err, "too many points for 501 clusters, the points times k can't exceed 5000000")
+	_, err = KMeans([]geometry.Point{{Lng: math.NaN()}}, 1)
+	assert.EqualError(t, err, "points must have finite coordinates")
+}
+
+func TestCentroid(t *testing.T) {
+	c, err := Centroid([]geometry.Point{{Lng: 179, Lat: 10}, {Lng: -179, Lat: 10}})
+	assert.NoError(t, err)
+	assert.InDelta(t, 180, math.Abs(c.Lng), 1e-9)
+	assert.InDelta(t, 10, c.Lat, 0.01)
+
+	ms := Members([]geometry.Point{{Lng: 1}, {Lng: 2}, {Lng: 3}}, []int{1, Noise, 0})
+	assert.Equal(t, [][]geometry.Point{{{Lng: 3}}, {{Lng: 1}}}, ms)
+}
@@ -0,0 +1,284 @@
+package coord
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// Axis identifies whether a value is a latitude or a longitude.
+type Axis int
+
+const (
+	// Unknown is used for values without a hemisphere letter.
+	Unknown Axis = iota
+	// Latitude values are north or south of the equator.
+	Latitude
+	// Longitude values are east or west of the prime meridian.
+	Longitude
+)
+
+// String returns the name of the axis.
+func (a Axis) String() string {
+	switch a {
+	case Latitude:
+		return "latitude"
+	case Longitude:
+		return "longitude"
+	default:
+		return "coordinate"
+	}
+}
+
+// Format defines how coordinates are written.
+type Format string
+
+const (
+	// Decimal writes coordinates as decimal degrees, e.g. 40.446195.
+	Decimal Format = "decimal"
+	// DMS writes coordinates as degrees, minutes and seconds, e.g. 40°26'46.302"N.
+	DMS Format = "dms"
+	// DDM writes coordinates as degrees and decimal minutes, e.g. 40°26.7717'N.
+	DDM Format = "ddm"
+)
+
+// ParseFormat validates a format name. An empty name is the decimal format.
+func ParseFormat(s string) (Format, error) {
+	switch f := Format(strings.ToLower(s)); f {
+	case "", Decimal:
+		return Decimal, nil
+	case DMS, DDM:
+		return f, nil
+	default:
+		return "", fmt.Errorf("unsupported format %q, expected one of decimal, dms or ddm", s)
+	}
+}
+
+// separators are the symbols accepted between degrees, minutes and seconds. The comma isn't one of
+// them, so that a decimal comma is rejected rather than read as minutes.
+var separators = strings.NewReplacer(
+	"°", " ", "º", " ", "˚", " ",
+	"''", " ", "′′", " ",
+	"'", " ", "′", " ", "’", " ",
+	"\"", " ", "″", " ", "”", " ",
+	":", " ",
+)
+
+// Parse reads a coordinate written as decimal degrees, degrees and decimal minutes or degrees,
+// minutes and seconds, with an optional sign or hemisphere letter. The axis of the hemisphere
+// letter is returned, or Unknown when there is none. The range of the value is not validated.
+func Parse(s string) (float64, Axis, error) {
+	in := strings.TrimSpace(s)
+	if in == "" {
+		return 0, Unknown, errors.New("can't be empty")
+	}
+	if v, err := strconv.ParseFloat(in, 64); err == nil && (math.IsNaN(v) || math.IsInf(v, 0)) {
+		return 0, Unknown, fmt.Errorf("%q is not a finite number", s)
+	}
+
+	rest, hemisphere := splitHemisphere(in)
+	axis := Unknown
+	sign := 1.0
+	switch hemisphere {
+	case 'N':
+		axis = Latitude
+	case 'S':
+		axis, sign = Latitude, -1
+	case 'E':
+		axis = Longitude
+	case 'W':
+		axis, sign = Longitude, -1
+	}
+
+	fields := strings.Fields(separators.Replace(rest))
+	if len(fields) == 0 || len(fields) > 3 {
+		return 0, Unknown, fmt.Errorf("%q is not a valid coordinate", s)
+	}
+
+	values := make([]float64, len(fields))
+	for i, f := range fields {
+		v, err := strconv.ParseFloat(f, 64)
+		if err != nil {
+			return 0, Unknown, fmt.Errorf("%q is not a valid coordinate", s)
+		}
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return 0, Unknown, fmt.Errorf("%q is not a finite number", s)
+		}
+		if i > 0 && (v < 0 || strings.HasPrefix(f, "+")) {
+			return 0, Unknown, fmt.Errorf("%q has a signed minutes or seconds component", s)
+		}
+		values[i] = v
+	}
+
+	negative := strings.HasPrefix(fields[0], "-")
+	if negative && hemisphere != 0 {
+		return 0, Unknown, fmt.Errorf("%q has both a sign and a hemisphere", s)
+	}
+	for i := 0; i < len(values)-1; i++ {
+		if values[i] != math.Trunc(values[i]) {
+			return 0, Unknown, fmt.Errorf("%q has a fractional component before the last one", s)
+		}
+	}
+
+	deg := math.Abs(values[0])
+	var minutes, seconds float64
+	if len(values) > 1 {
+		minutes = values[1]
+		if minutes >= 60 {
+			return 0, Unknown, fmt.Errorf("%q has minutes greater than or equal to 60", s)
+		}
+	}
+	if len(values) > 2 {
+		seconds = values[2]
+		if seconds >= 60 {
+			return 0, Unknown, fmt.Errorf("%q has seconds greater than or equal to 60", s)
+		}
+	}
+
+	v := deg + minutes/60 + seconds/3600
+	if negative {
+		v = -v
+	}
+
+	return sign * v, axis, nil
+}
+
+// Error describes why a latitude or a longitude is not valid.
+type Error struct {
+	Axis Axis
+	Err  error
+}
+
+// Error implements the error interface
+func (e *Error) Error() string {
+	return e.Axis.String() + " " + e.Err.Error()
+}
+
+// ParseLatitude parses a coordinate and validates it as a latitude.
+func ParseLatitude(s string) (float64, error) {
+	return parseAxis(s, Latitude)
+}
+
+// ParseLongitude parses a coordinate and validates it as a longitude.
+func ParseLongitude(s string) (float64, error) {
+	return parseAxis(s, Longitude)
+}
+
+// ParsePair parses a latitude and a longitude. When both values carry hemisphere letters that show
+// they were given in the opposite order, e.g. lat=74°W and lon=40°N, they are swapped.
+func ParsePair(lat, lon string) (float64, float64, error) {
+	la, laAxis, laErr := Parse(lat)
+	lo, loAxis, loErr := Parse(lon)
+	if laErr == nil && loErr == nil && laAxis == Longitude && loAxis == Latitude {
+		la, lo = lo, la
+		laAxis, loAxis = loAxis, laAxis
+	}
+
+	if laErr != nil {
+		return 0, 0, &Error{Axis: Latitude, Err: laErr}
+	}
+	if err := Validate(la, laAxis, Latitude); err != nil {
+		return 0, 0, err
+	}
+	if loErr != nil {
+		return 0, 0, &Error{Axis: Longitude, Err: loErr}
+	}
+	if err := Validate(lo, loAxis, Longitude); err != nil {
+		return 0, 0, err
+	}
+
+	return la, lo, nil
+}
+
+// Validate checks that the hemisphere matches the expected axis and that the value is within range.
+func Validate(v float64, hemisphere, want Axis) error {
+	if hemisphere != Unknown && hemisphere != want {
+		return &Error{Axis: want, Err: fmt.Errorf("can't have a %s hemisphere", hemisphere)}
+	}
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return &Error{Axis: want, Err: errors.New("must be a finite number")}
+	}
+	limit := 90.0
+	if want == Longitude {
+		limit = 180
+	}
+	if v < -limit || v > limit {
+		return &Error{Axis: want, Err: fmt.Errorf("%v is out of range [%v, %v]", v, -limit, limit)}
+	}
+
+	return nil
+}
+
+// Write formats the value of the axis in the given format.
+func Write(v float64, axis Axis, f Format) string {
+	if f == Decimal || f == "" {
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	}
+
+	h := "N"
+	switch {
+	case axis == Latitude && v < 0:
+		h = "S"
+	case axis == Longitude && v < 0:
+		h = "W"
+	case axis == Longitude:
+		h = "E"
+	}
+	a := math.Abs(v)
+	deg := math.Floor(a)
+
+	if f == DDM {
+		minutes := math.Round((a-deg)*60*1e4) / 1e4
+		if minutes >= 60 {
+			deg++
+			minutes = 0
+		}
+		return fmt.Sprintf("%d°%s'%s", int(deg), strconv.FormatFloat(minutes, 'f', -1, 64), h)
+	}
+
+	total := math.Round((a-deg)*3600*1e3) / 1e3
+	if total >= 3600 {
+		deg++
+		total = 0
+	}
+	minutes := math.Floor(total / 60)
+	seconds := math.Round((total-minutes*60)*1e3) / 1e3
+
+	return fmt.Sprintf("%d°%d'%s\"%s", int(deg), int(minutes), strconv.FormatFloat(seconds, 'f', -1, 64), h)
+}
+
+func parseAxis(s string, want Axis) (float64, error) {
+	v, axis, err := Parse(s)
+	if err != nil {
+		return 0, &Error{Axis: want, Err: err}
+	}
+	if err := Validate(v, axis, want); err != nil {
+		return 0, err
+	}
+
+	return v, nil
+}
+
+// splitHemisphere removes a leading or trailing hemisphere letter.
+func splitHemisphere(s string) (string, rune) {
+	isHemisphere := func(r rune) bool {
+		switch unicode.ToUpper(r) {
+		case 'N', 'S', 'E', 'W':
+			return true
+		}
+		return false
+	}
+
+	runes := []rune(s)
+	if isHemisphere(runes[len(runes)-1]) {
+		return strings.TrimSpace(string(runes[:len(runes)-1])), unicode.ToUpper(runes[len(runes)-1])
+	}
+	if isHemisphere(runes[0]) {
+		return strings.TrimSpace(string(runes[1:])), unicode.ToUpper(runes[0])
+	}
+
+	return s, 0
+}
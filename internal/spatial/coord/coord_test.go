@@ -0,0 +1,120 @@
+package coord
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParse(t *testing.T) {
+	tests := map[string]struct {
+		in   string
+		want float64
+		axis Axis
+		err  string
+	}{
+		"decimal":                {in: "40.446195", want: 40.446195},
+		"negative decimal":       {in: "-74.0060", want: -74.006},
+		"decimal with letter":    {in: "74.0060W", want: -74.006, axis: Longitude},
+		"leading letter":         {in: "S 33.8688", want: -33.8688, axis: Latitude},
+		"degrees symbol":         {in: "40.5°N", want: 40.5, axis: Latitude},
+		"dms":                    {in: `40°26'46"N`, want: 40 + 26.0/60 + 46.0/3600, axis: Latitude},
+		"dms with primes":        {in: "40°26′46.5″ S", want: -(40 + 26.0/60 + 46.5/3600), axis: Latitude},
+		"dms with spaces":        {in: "40 26 46 e", want: 40 + 26.0/60 + 46.0/3600, axis: Longitude},
+		"dms with two quotes":    {in: "40°26'46''N", want: 40 + 26.0/60 + 46.0/3600, axis: Latitude},
+		"ddm":                    {in: "40°26.767'N", want: 40 + 26.767/60, axis: Latitude},
+		"signed ddm":             {in: "-74 0.36", want: -(74 + 0.36/60)},
+		"empty":                  {in: " ", err: "can't be empty"},
+		"letters":                {in: "abc", err: `"abc" is not a valid coordinate`},
+		"nan":                    {in: "NaN", err: `"NaN" is not a finite number`},
+		"infinity":               {in: "-Inf", err: `"-Inf" is not a finite number`},
+		"minutes out of range":   {in: "40°60'N", err: `"40°60'N" has minutes greater than or equal to 60`},
+		"seconds out of range":   {in: "40°26'61\"N", err: `"40°26'61\"N" has seconds greater than or equal to 60`},
+		"sign and hemisphere":    {in: "-40N", err: `"-40N" has both a sign and a hemisphere`},
+		"fractional degrees dms": {in: "40.5°26'", err: `"40.5°26'" has a fractional component before the last one`},
+		"too many components":    {in: "1 2 3 4", err: `"1 2 3 4" is not a valid coordinate`},
+		"decimal comma":          {in: "40,5", err: `"40,5" is not a valid coordinate`},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			v, axis, err := Parse(tt.in)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, v, 1e-12)
+			assert.Equal(t, tt.axis, axis)
+		})
+	}
+}
+
+func TestParsePair(t *testing.T) {
+	lat, lon, err := ParsePair(`40°42'46"N`, `74°0'21.6"W`)
+	assert.NoError(t, err)
+	assert.InDelta(t, 40.712778, lat, 1e-6)
+	assert.InDelta(t, -74.006, lon, 1e-6)
+
+	// swapped order
+	lat, lon, err = ParsePair(`74°0'21.6"W`, `40°42'46"N`)
+	assert.NoError(t, err)
+	assert.InDelta(t, 40.712778, lat, 1e-6)
+	assert.InDelta(t, -74.006, lon, 1e-6)
+
+	_, _, err = ParsePair("40E", "74")
+	assert.EqualError(t, err, "latitude can't have a longitude hemisphere")
+
+	_, _, err = ParsePair("-90.5", "0")
+	assert.EqualError(t, err, "latitude -90.5 is out of range [-90, 90]")
+
+	_, _, err = ParsePair("0", "180.1")
+	assert.EqualError(t, err, "longitude 180.1 is out of range [-180, 180]")
+
+	_, _, err = ParsePair("0", "x")
+	assert.EqualError(t, err, `longitude "x" is not a valid coordinate`)
+}
+
+func TestParseLatitudeLongitude(t *testing.T) {
+	v, err := ParseLatitude("90S")
+	assert.NoError(t, err)
+	assert.Equal(t, -90.0, v)
+
+	_, err = ParseLatitude("10W")
+	assert.EqualError(t, err, "latitude can't have a longitude hemisphere")
+
+	v, err = ParseLongitude("180°W")
+	assert.NoError(t, err)
+	assert.Equal(t, -180.0, v)
+
+	_, err = ParseLongitude("")
+	assert.EqualError(t, err, "longitude can't be empty")
+}
+
+func TestWrite(t *testing.T) {
+	assert.Equal(t, `40°26'46.302"N`, Write(40.446195, Latitude, DMS))
+	assert.Equal(t, `74°0'21.6"W`, Write(-74.006, Longitude, DMS))
+	assert.Equal(t, `1°0'0"E`, Write(0.9999999999, Longitude, DMS))
+	assert.Equal(t, `40°26.7717'N`, Write(40.446195, Latitude, DDM))
+	assert.Equal(t, "-74.006", Write(-74.006, Longitude, Decimal))
+
+	for _, v := range []float64{40.446195, -12.5, 0.000123} {
+		s := Write(v, Latitude, DMS)
+		got, _, err := Parse(s)
+		assert.NoError(t, err)
+		assert.InDelta(t, v, got, 1e-6)
+	}
+}
+
+func TestParseFormat(t *testing.T) {
+	f, err := ParseFormat("")
+	assert.NoError(t, err)
+	assert.Equal(t, Decimal, f)
+
+	f, err = ParseFormat("DMS")
+	assert.NoError(t, err)
+	assert.Equal(t, DMS, f)
+
+	_, err = ParseFormat("utm")
+	assert.Error(t, err)
+}
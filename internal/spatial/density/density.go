@@ -0,0 +1,297 @@
+package density
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Kernel is the shape of the bump each point adds to the surface.
+type Kernel string
+
+const (
+	// Gaussian decreases smoothly, its bandwidth being the standard deviation. It is cut at three
+	// bandwidths.
+	Gaussian Kernel = "gaussian"
+	// Epanechnikov decreases with the square of the distance up to the bandwidth.
+	Epanechnikov Kernel = "epanechnikov"
+	// Quartic, or biweight, is the square of the Epanechnikov kernel and smoother at the bandwidth.
+	Quartic Kernel = "quartic"
+	// Triangular decreases linearly up to the bandwidth.
+	Triangular Kernel = "triangular"
+	// Uniform counts the points within the bandwidth.
+	Uniform Kernel = "uniform"
+)
+
+// ParseKernel returns the kernel of a name.
+func ParseKernel(s string) (Kernel, error) {
+	switch k := Kernel(s); k {
+	case Gaussian, Epanechnikov, Quartic, Triangular, Uniform:
+		return k, nil
+	default:
+		return "", fmt.Errorf("unsupported kernel %q, expected one of gaussian, epanechnikov, quartic, triangular or uniform", s)
+	}
+}
+
+// value returns the kernel at a distance of u bandwidths, 1 at the point itself.
+func (k Kernel) value(u float64) float64 {
+	switch k {
+	case Gaussian:
+		return math.Exp(-u * u / 2)
+	case Epanechnikov:
+		return 1 - u*u
+	case Quartic:
+		return (1 - u*u) * (1 - u*u)
+	case Triangular:
+		return 1 - u
+	default:
+		return 1
+	}
+}
+
+// support returns the distance in bandwidths beyond which the kernel is 0.
+func (k Kernel) support() float64 {
+	if k == Gaussian {
+		return 3
+	}
+	return 1
+}
+
+const (
+	// maxPoints bounds the number of points of a surface.
+	maxPoints = 100000
+	// maxSplats bounds the pixels reached by the points of a raster, added up.
+	maxSplats = 20000000
+)
+
+// Point is a position with its weight.
+type Point struct {
+	geometry.Point
+	Weight float64
+}
+
+// Surface is the kernel density of weighted points. Its intensity at a position is the sum of the
+// weights of the points, each multiplied by the kernel at its distance, so a lone point of weight
+// 1 gives an intensity of 1 on itself.
+type Surface struct {
+	kernel    Kernel
+	bandwidth float64 // radians
+	radius    float64 // radians
+	index     []indexed
+
+	// the points are indexed in cells of the radius in degrees of latitude and longitude
+	cell    float64
+	cols    int
+	buckets map[[2]int][]int
+	rows    map[int][]int // the columns of the buckets of every row
+}
+
+// New returns the density surface of the points with a bandwidth in radians.
+func New(points []Point, kernel Kernel, bandwidth float64) (*Surface, error) {
+	if _, err := ParseKernel(string(kernel)); err != nil {
+		return nil, err
+	}
+	if !(bandwidth > 0) || math.IsInf(bandwidth, 0) {
+		return nil, errors.New("bandwidth must be a positive number")
+	}
+	if len(points) > maxPoints {
+		return nil, fmt.Errorf("too many points, the limit is %d", maxPoints)
+	}
+	for _, p := range points {
+		if math.IsNaN(p.Lat) || math.IsNaN(p.Lng) || math.IsInf(p.Lat, 0) || math.IsInf(p.Lng, 0) {
+			return nil, errors.New("points must have finite coordinates")
+		}
+		if math.IsNaN(p.Weight) || math.IsInf(p.Weight, 0) {
+			return nil, errors.New("weights must be finite numbers")
+		}
+	}
+
+	s := &Surface{
+		kernel:    kernel,
+		bandwidth: bandwidth,
+		radius:    bandwidth * kernel.support(),
+		index:     make([]indexed, 0, len(points)),
+		buckets:   make(map[[2]int][]int),
+		rows:      make(map[int][]int),
+	}
+	// the cells are at least as large as the radius and split the longitudes evenly, so that
+	// the neighbours of a point are in the cells around its own, across the antimeridian too
+	s.cols = int(360 / math.Min(s.radius*180/math.Pi, 90))
+	s.cell = 360 / float64(s.cols)
+	for i, p := range points {
+		lat := p.Lat * math.Pi / 180
+		s.index = append(s.index, indexed{lat: lat, lng: p.Lng * math.Pi / 180, cosLat: math.Cos(lat), weight: p.Weight})
+		k := [2]int{int(math.Floor((p.Lat + 90) / s.cell)), mod(int(math.Floor((p.Lng+180)/s.cell)), s.cols)}
+		if _, ok := s.buckets[k]; !ok {
+			s.rows[k[0]] = append(s.rows[k[0]], k[1])
+		}
+		s.buckets[k] = append(s.buckets[k], i)
+	}
+	return s, nil
+}
+
+// indexed is a point in radians with the cosine of its latitude.
+type indexed struct {
+	lat, lng, cosLat, weight float64
+}
+
+func mod(a, n int) int {
+	return ((a % n) + n) % n
+}
+
+// At returns the intensity of the surface at a position.
+func (s *Surface) At(p geometry.Point) float64 {
+	row := int(math.Floor((p.Lat + 90) / s.cell))
+	// a circle of the radius spans more degrees of longitude away from the equator
+	n := s.cols
+	lo := 0
+	if c := math.Cos(math.Min(math.Abs(p.Lat)+s.cell, 90) * math.Pi / 180); c > 0 {
+		dLng := s.cell / c
+		lo = int(math.Floor((p.Lng - dLng + 180) / s.cell))
+		if m := int(math.Floor((p.Lng+dLng+180)/s.cell)) - lo + 1; m < n {
+			n = m
+		}
+	}
+
+	lat := p.Lat * math.Pi / 180
+	cosLat := math.Cos(lat)
+	lng := p.Lng * math.Pi / 180
+	// the haversine of the radius, to leave out the points beyond it before computing distances
+	hMax := math.Pow(math.Sin(s.radius/2), 2)
+	var sum float64
+	add := func(bucket []int) {
+		for _, i := range bucket {
+			q := &s.index[i]
+			sLat, sLng := math.Sin((q.lat-lat)/2), math.Sin((q.lng-lng)/2)
+			h := sLat*sLat + cosLat*q.cosLat*sLng*sLng
+			if h >= hMax {
+				continue
+			}
+			d := 2 * math.Asin(math.Sqrt(h))
+			sum += q.weight * s.kernel.value(d/s.bandwidth)
+		}
+	}
+	for r := row - 1; r <= row+1; r++ {
+		// near the poles the circle spans more columns than the row has buckets, which are
+		// visited instead
+		if cols := s.rows[r]; len(cols) < n {
+			for _, c := range cols {
+				if mod(c-lo, s.cols) < n {
+					add(s.buckets[[2]int{r, c}])
+				}
+			}
+			continue
+		}
+		for k := 0; k < n; k++ {
+			add(s.buckets[[2]int{r, mod(lo+k, s.cols)}])
+		}
+	}
+	return sum
+}
+
+// Raster returns the intensities of the surface at the pixels of a raster, row by row from the
+// top. The pixels are at the longitudes of the columns, increasing, and the latitudes of the rows,
+// decreasing. Each point only adds to the pixels within its radius, and the raster fails when they
+// add up to too many pixels or when the context is cancelled.
+func (s *Surface) Raster(ctx context.Context, lngs, lats []float64) ([]float64, error) {
+	w, h := len(lngs), len(lats)
+	values := make([]float64, w*h)
+	if w == 0 || h == 0 {
+		return values, nil
+	}
+	cosLats := make([]float64, h)
+	for y, lat := range lats {
+		cosLats[y] = math.Cos(lat * math.Pi / 180)
+	}
+	hMax := math.Pow(math.Sin(s.radius/2), 2)
+	rDeg := s.radius * 180 / math.Pi
+	// the haversine terms of the longitudes of the columns reached by a point
+	sLngs := make([]float64, w)
+
+	// the pixels reached by every point are counted before any is rendered
+	type footprint struct {
+		i, y0, y1, x0, x1 int
+	}
+	var fps []footprint
+	splats := 0
+	for i := range s.index {
+		q := &s.index[i]
+		lat, lng := q.lat*180/math.Pi, q.lng*180/math.Pi
+		y0 := sort.Search(h, func(y int) bool { return lats[y] <= lat+rDeg })
+		y1 := sort.Search(h, func(y int) bool { return lats[y] < lat-rDeg })
+		if y0 >= y1 {
+			continue
+		}
+
+		// the columns within the longitudes of the circle, on either side of the antimeridian
+		c := math.Cos(math.Min(math.Abs(lat)+rDeg, 90) * math.Pi / 180)
+		if dLng := rDeg / c; c <= 0 || dLng >= 180 {
+			fps = append(fps, footprint{i: i, y0: y0, y1: y1, x0: 0, x1: w})
+			splats += (y1 - y0) * w
+		} else {
+			for _, shift := range []float64{-360, 0, 360} {
+				x0 := sort.SearchFloat64s(lngs, lng+shift-dLng)
+				x1 := sort.Search(w, func(x int) bool { return lngs[x] > lng+shift+dLng })
+				if x0 < x1 {
+					fps = append(fps, footprint{i: i, y0: y0, y1: y1, x0: x0, x1: x1})
+					splats += (y1 - y0) * (x1 - x0)
+				}
+			}
+		}
+		if splats > maxSplats {
+			return nil, fmt.Errorf("the points reach more than %d pixels, lower the bandwidth or merge the points", maxSplats)
+		}
+	}
+
+	for _, fp := range fps {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		q := &s.index[fp.i]
+		for x := fp.x0; x < fp.x1; x++ {
+			sLng := math.Sin((q.lng - lngs[x]*math.Pi/180) / 2)
+			sLngs[x] = sLng * sLng
+		}
+		for y := fp.y0; y < fp.y1; y++ {
+			sLat := math.Sin((q.lat - lats[y]*math.Pi/180) / 2)
+			row, k := values[y*w:(y+1)*w], q.cosLat*cosLats[y]
+			for x := fp.x0; x < fp.x1; x++ {
+				hv := sLat*sLat + k*sLngs[x]
+				if hv >= hMax {
+					continue
+				}
+				d := 2 * math.Asin(math.Sqrt(hv))
+				row[x] += q.weight * s.kernel.value(d/s.bandwidth)
+			}
+		}
+	}
+	return values, nil
+}
+
+// Merge sums the weights of the points falling in the same cell of a size in degrees and places
+// them at the centre of the cell. Rendering a surface at a resolution coarser than the cells gives
+// the same picture in far less time when the points are dense.
+func Merge(points []Point, cell float64) []Point {
+	if !(cell > 0) {
+		return points
+	}
+	index := make(map[[2]int64]int)
+	res := make([]Point, 0, len(points))
+	for _, p := range points {
+		k := [2]int64{int64(math.Floor(p.Lat / cell)), int64(math.Floor(p.Lng / cell))}
+		if i, ok := index[k]; ok {
+			res[i].Weight += p.Weight
+			continue
+		}
+		index[k] = len(res)
+		res = append(res, Point{
+			Point:  geometry.Point{Lat: (float64(k[0]) + 0.5) * cell, Lng: (float64(k[1]) + 0.5) * cell},
+			Weight: p.Weight,
+		})
+	}
+	return res
+}
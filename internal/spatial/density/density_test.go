@@ -0,0 +1,178 @@
+package density
+
+import (
+	"context"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/grid"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// km is the angle of a kilometre on the earth in radians.
+const km = 1 / 6371.0088
+
+func TestSurface(t *testing.T) {
+	points := []Point{
+		{Point: geometry.Point{Lng: 0, Lat: 0}, Weight: 1},
+		{Point: geometry.Point{Lng: 0, Lat: 0}, Weight: 2},
+		{Point: geometry.Point{Lng: 1, Lat: 0}, Weight: 1},
+	}
+
+	tests := map[string]struct {
+		kernel Kernel
+		at     geometry.Point
+		want   float64
+	}{
+		"gaussian on the points":      {kernel: Gaussian, at: geometry.Point{}, want: 3},
+		"gaussian at one bandwidth":   {kernel: Gaussian, at: geometry.Point{Lat: 10 / 111.195}, want: 3 * math.Exp(-0.5)},
+		"gaussian beyond its support": {kernel: Gaussian, at: geometry.Point{Lat: 0.5}, want: 0},
+		"epanechnikov":                {kernel: Epanechnikov, at: geometry.Point{Lat: 5 / 111.195}, want: 3 * 0.75},
+		"quartic":                     {kernel: Quartic, at: geometry.Point{Lat: 5 / 111.195}, want: 3 * 0.5625},
+		"triangular":                  {kernel: Triangular, at: geometry.Point{Lat: 5 / 111.195}, want: 1.5},
+		"uniform":                     {kernel: Uniform, at: geometry.Point{Lat: 5 / 111.195}, want: 3},
+		"uniform beyond":              {kernel: Uniform, at: geometry.Point{Lat: 11 / 111.195}, want: 0},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := New(points, tt.kernel, 10*km)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.want, s.At(tt.at), 1e-3)
+		})
+	}
+}
+
+func TestSurfaceAntimeridianAndPoles(t *testing.T) {
+	s, err := New([]Point{
+		{Point: geometry.Point{Lng: 179.99, Lat: 0}, Weight: 1},
+		{Point: geometry.Point{Lng: 0, Lat: 89.99}, Weight: 1},
+	}, Uniform, 5*km)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1.0, s.At(geometry.Point{Lng: -179.99, Lat: 0}))
+	assert.Equal(t, 1.0, s.At(geometry.Point{Lng: 180, Lat: 89.99}))
+	assert.Equal(t, 0.0, s.At(geometry.Point{Lng: 0, Lat: 0}))
+
+	// a bandwidth of a metre splits the longitudes in millions of columns, which the positions
+	// next to the poles span entirely
+	s, err = New([]Point{
+		{Point: geometry.Point{Lng: 45, Lat: 89.999999}, Weight: 1},
+		{Point: geometry.Point{Lng: 90, Lat: -89.9999999}, Weight: 1},
+	}, Uniform, km/1000)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 1.0, s.At(geometry.Point{Lng: -135, Lat: 89.999999}))
+	assert.Equal(t, 1.0, s.At(geometry.Point{Lng: -90, Lat: -90}))
+	assert.Equal(t, 0.0, s.At(geometry.Point{Lng: 45, Lat: 89.9999}))
+}
+
+func TestSurfaceErrors(t *testing.T) {
+	_, err := New(nil, "cosine", km)
+	assert.EqualError(t, err, `unsupported kernel "cosine", expected one of gaussian, epanechnikov, quartic, triangular or uniform`)
+	_, err = New(nil, Gaussian, 0)
+	assert.EqualError(t, err, "bandwidth must be a positive number")
+	_, err = New([]Point{{Weight: math.Inf(1)}}, Gaussian, km)
+	assert.EqualError(t, err, "weights must be finite numbers")
+}
+
+func TestRaster(t *testing.T) {
+	points := []Point{
+		{Point: geometry.Point{Lng: 179.95, Lat: 10}, Weight: 1},
+		{Point: geometry.Point{Lng: -179.9, Lat: 10.05}, Weight: 2},
+		{Point: geometry.Point{Lng: 30, Lat: 89.95}, Weight: 1},
+		{Point: geometry.Point{Lng: 0, Lat: 0}, Weight: 1},
+	}
+	s, err := New(points, Quartic, 20*km)
+	assert.NoError(t, err)
+
+	// the rows and columns reach across the antimeridian and up to the north pole
+	var lngs, lats []float64
+	for x := -180.0; x <= -179.5; x += 0.01 {
+		lngs = append(lngs, x)
+	}
+	for x := 179.5; x <= 180; x += 0.01 {
+		lngs = append(lngs, x)
+	}
+	for y := 90.0; y >= 89.5; y -= 0.01 {
+		lats = append(lats, y)
+	}
+	for y := 10.5; y >= 9.5; y -= 0.01 {
+		lats = append(lats, y)
+	}
+	values, err := s.Raster(context.Background(), lngs, lats)
+	assert.NoError(t, err)
+	assert.Len(t, values, len(lngs)*len(lats))
+	positive := 0
+	for y, lat := range lats {
+		for x, lng := range lngs {
+			want := s.At(geometry.Point{Lng: lng, Lat: lat})
+			assert.InDelta(t, want, values[y*len(lngs)+x], 1e-9)
+			if want > 0 {
+				positive++
+			}
+		}
+	}
+	assert.Greater(t, positive, 100)
+}
+
+func TestRasterErrors(t *testing.T) {
+	points := make([]Point, 21)
+	for i := range points {
+		points[i].Weight = 1
+	}
+	s, err := New(points, Gaussian, 100*km)
+	assert.NoError(t, err)
+	lngs := make([]float64, 1000)
+	lats := make([]float64, 1000)
+	for i := range lngs {
+		lngs[i] = -0.5 + float64(i)/1000
+		lats[i] = 0.5 - float64(i)/1000
+	}
+	_, err = s.Raster(context.Background(), lngs, lats)
+	assert.EqualError(t, err, "the points reach more than 20000000 pixels, lower the bandwidth or merge the points")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = s.Raster(ctx, lngs[:10], lats[:10])
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestImage(t *testing.T) {
+	img := Image([]float64{0, 1, 2, 4}, 2, 2, 0)
+	assert.Equal(t, uint8(0), img.NRGBAAt(0, 0).A)
+	assert.Equal(t, ramp[len(ramp)-1].c, img.NRGBAAt(1, 1))
+	assert.Equal(t, colorAt(0.5), img.NRGBAAt(0, 1))
+
+	img = Image([]float64{0, 1, 2, 4}, 2, 2, 2)
+	assert.Equal(t, ramp[len(ramp)-1].c, img.NRGBAAt(0, 1))
+}
+
+func TestCells(t *testing.T) {
+	s, err := New([]Point{{Point: geometry.Point{Lng: 0.12495, Lat: 0.12495}, Weight: 1}}, Gaussian, 5*km)
+	assert.NoError(t, err)
+	g, err := grid.New(grid.Options{Kind: grid.Squares, BBox: *geojson.NewBBox(-0.5, -0.5, 0.5, 0.5), CellSize: 0.2499 * math.Pi / 180})
+	assert.NoError(t, err)
+
+	n := 0
+	assert.NoError(t, NewCells(s, g).Each(func(c geometry.Geometry, v float64) error {
+		n++
+		assert.InDelta(t, 1, v, 1e-6)
+		return nil
+	}))
+	assert.Equal(t, 1, n)
+}
+
+func TestMerge(t *testing.T) {
+	ps := Merge([]Point{
+		{Point: geometry.Point{Lng: 0.1, Lat: 0.1}, Weight: 1},
+		{Point: geometry.Point{Lng: 0.2, Lat: 0.3}, Weight: 2},
+		{Point: geometry.Point{Lng: 1.5, Lat: -0.5}, Weight: 1},
+	}, 1)
+	assert.Equal(t, []Point{
+		{Point: geometry.Point{Lng: 0.5, Lat: 0.5}, Weight: 3},
+		{Point: geometry.Point{Lng: 1.5, Lat: -0.5}, Weight: 1},
+	}, ps)
+}
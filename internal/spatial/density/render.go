@@ -0,0 +1,99 @@
+package density
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/grid"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// ramp is the colour scale of the heatmaps, from transparent blue for the lowest intensities to
+// red for the highest.
+var ramp = []struct {
+	at float64
+	c  color.NRGBA
+}{
+	{0, color.NRGBA{R: 0, G: 0, B: 255, A: 0}},
+	{0.2, color.NRGBA{R: 0, G: 0, B: 255, A: 160}},
+	{0.4, color.NRGBA{R: 0, G: 255, B: 255, A: 190}},
+	{0.6, color.NRGBA{R: 0, G: 255, B: 0, A: 210}},
+	{0.8, color.NRGBA{R: 255, G: 255, B: 0, A: 230}},
+	{1, color.NRGBA{R: 255, G: 0, B: 0, A: 250}},
+}
+
+// Image renders the intensities, given row by row from the top, with the colour scale reaching
+// red at scale. A scale of 0 or less scales the image to its highest intensity.
+func Image(values []float64, width, height int, scale float64) *image.NRGBA {
+	if scale <= 0 {
+		for _, v := range values {
+			scale = math.Max(scale, v)
+		}
+	}
+
+	img := image.NewNRGBA(image.Rect(0, 0, width, height))
+	for i, v := range values {
+		if v <= 0 || scale <= 0 {
+			continue
+		}
+		img.SetNRGBA(i%width, i/width, colorAt(math.Min(v/scale, 1)))
+	}
+	return img
+}
+
+func colorAt(f float64) color.NRGBA {
+	for i := 1; i < len(ramp); i++ {
+		if f > ramp[i].at {
+			continue
+		}
+		a, b := ramp[i-1], ramp[i]
+		t := (f - a.at) / (b.at - a.at)
+		mix := func(x, y uint8) uint8 {
+			return uint8(math.Round(float64(x) + t*(float64(y)-float64(x))))
+		}
+		return color.NRGBA{R: mix(a.c.R, b.c.R), G: mix(a.c.G, b.c.G), B: mix(a.c.B, b.c.B), A: mix(a.c.A, b.c.A)}
+	}
+	return ramp[len(ramp)-1].c
+}
+
+// Cells is a grid of polygons with the intensity of a surface at their centre.
+type Cells struct {
+	surface *Surface
+	grid    *grid.Grid
+}
+
+// NewCells samples the surface at the centres of the cells of the grid.
+func NewCells(s *Surface, g *grid.Grid) *Cells {
+	return &Cells{surface: s, grid: g}
+}
+
+// Each calls fn with every cell and its intensity, skipping the cells where it is 0. The cells are
+// generated one at a time, like the grid does.
+func (c *Cells) Each(fn func(cell geometry.Geometry, intensity float64) error) error {
+	return c.grid.Each(func(g geometry.Geometry) error {
+		polys, err := geom.Polygons(g)
+		if err != nil {
+			return err
+		}
+		if len(polys) == 0 || len(polys[0]) == 0 {
+			return nil
+		}
+		if v := c.surface.At(centre(polys[0][0])); v != 0 {
+			return fn(g, v)
+		}
+		return nil
+	})
+}
+
+// centre returns the mean of the vertices of a closed ring.
+func centre(r []geometry.Point) geometry.Point {
+	var lat, lng float64
+	n := len(r) - 1
+	for _, p := range r[:n] {
+		lat += p.Lat
+		lng += p.Lng
+	}
+	return geometry.Point{Lat: lat / float64(n), Lng: lng / float64(n)}
+}
@@ -0,0 +1,93 @@
+// Package gazetteer reads the place names and the administrative boundaries of offline datasets,
+// GeoNames dumps and Natural Earth boundaries, and indexes them to geocode without calling
+// external services.
+package gazetteer
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// ErrNoGazetteer is returned when no gazetteer dataset is loaded.
+var ErrNoGazetteer = errors.New("no gazetteer is loaded")
+
+// geonamesColumns is the number of tab separated columns of the GeoNames dumps.
+const geonamesColumns = 19
+
+// Place is a named place of a gazetteer. Class and Code are its GeoNames feature class and code,
+// P and PPL for a populated place, Country its ISO 3166-1 alpha-2 code and Admin1 the GeoNames code
+// of its first level administrative division. Context holds the names of the areas an address is
+// in.
+type Place struct {
+	ID         string
+	Name       string
+	ASCIIName  string
+	Alternates []string
+	Context    []string
+	Point      geometry.Point
+	Class      string
+	Code       string
+	Country    string
+	Admin1     string
+	Population int64
+}
+
+// Populated reports whether a place is a city, a town or a village.
+func (p *Place) Populated() bool {
+	return p.Class == "P"
+}
+
+// ReadGeoNames reads the places of a GeoNames dump, such as cities500.txt or allCountries.txt,
+// made of tab separated lines of 19 columns.
+func ReadGeoNames(r io.Reader) ([]Place, error) {
+	var res []Place
+	s := bufio.NewScanner(r)
+	s.Buffer(make([]byte, 0, 64<<10), 4<<20)
+	for line := 1; s.Scan(); line++ {
+		if strings.TrimSpace(s.Text()) == "" || strings.HasPrefix(s.Text(), "#") {
+			continue
+		}
+		cols := strings.Split(s.Text(), "\t")
+		if len(cols) != geonamesColumns {
+			return nil, fmt.Errorf("line %d of the GeoNames file has %d columns, expected %d", line, len(cols), geonamesColumns)
+		}
+		lat, err := strconv.ParseFloat(cols[4], 64)
+		if err != nil || math.Abs(lat) > 90 {
+			return nil, fmt.Errorf("line %d of the GeoNames file has an invalid latitude", line)
+		}
+		lng, err := strconv.ParseFloat(cols[5], 64)
+		if err != nil || math.Abs(lng) > 180 {
+			return nil, fmt.Errorf("line %d of the GeoNames file has an invalid longitude", line)
+		}
+		p := Place{
+			ID:        cols[0],
+			Name:      cols[1],
+			ASCIIName: cols[2],
+			Point:     geometry.Point{Lat: lat, Lng: lng},
+			Class:     cols[6],
+			Code:      cols[7],
+			Country:   cols[8],
+			Admin1:    cols[10],
+		}
+		if cols[3] != "" {
+			p.Alternates = strings.Split(cols[3], ",")
+		}
+		if cols[14] != "" {
+			if p.Population, err = strconv.ParseInt(cols[14], 10, 64); err != nil {
+				return nil, fmt.Errorf("line %d of the GeoNames file has an invalid population", line)
+			}
+		}
+		res = append(res, p)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,304 @@
+package gazetteer
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/constants"
+	"github.com/tomchavakis/turf-go/measurement"
+)
+
+const cities = "264371\tAthens\tAthens\tAthen,Atene,Athina\t37.98376\t23.72784\tP\tPPLC\tGR\t\tESYE31\t\t\t\t664046\t\t70\tEurope/Athens\t2022-03-09\n" +
+	"255274\tPiraeus\tPiraeus\t\t37.94745\t23.63708\tP\tPPLA3\tGR\t\tESYE31\t\t\t\t172357\t\t10\tEurope/Athens\t2019-10-23\n" +
+	"734077\tThessaloniki\tThessaloniki\tSalonica\t40.64361\t22.93086\tP\tPPLA\tGR\t\tESYE13\t\t\t\t354290\t\t10\tEurope/Athens\t2022-03-09\n" +
+	"257222\tMount Lycabettus\tMount Lycabettus\t\t37.98\t23.74\tT\tHLL\tGR\t\tESYE31\t\t\t\t0\t\t277\tEurope/Athens\t2019-10-23\n"
+
+const boundaries = `{"type":"FeatureCollection","features":[
+	{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[20,35],[28,35],[28,42],[20,42],[20,35]],[[25,39],[26,39],[26,40],[25,40],[25,39]]]},"properties":{"ISO_A2":"-99","ISO_A2_EH":"GR","NAME":"Greece"}}
+]}`
+
+const divisions = `{"type":"FeatureCollection","features":[
+	{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[23,37],[25,37],[25,39],[23,39],[23,37]]]},"properties":{"iso_a2":"GR","iso_3166_2":"GR-I","name":"Attica"}},
+	{"type":"Feature","geometry":{"type":"MultiPolygon","coordinates":[[[[22,40],[24,40],[24,41],[22,41],[22,40]]]]},"properties":{"iso_a2":"GR","iso_3166_2":"GR-B","name":"Central Macedonia"}},
+	{"type":"Feature","geometry":{"type":"Polygon","coordinates":[[[30,35],[31,35],[31,36],[30,36],[30,35]]]},"properties":{"iso_a2":"CY","name":"Island"}}
+]}`
+
+func regions(t *testing.T, s string, level Level) []Region {
+	fs, err := geom.Decode([]byte(s))
+	assert.NoError(t, err)
+	rs, err := Regions(fs, level)
+	assert.NoError(t, err)
+	return rs
+}
+
+func TestReadGeoNames(t *testing.T) {
+	ps, err := ReadGeoNames(strings.NewReader(cities))
+	assert.NoError(t, err)
+	assert.Len(t, ps, 4)
+	assert.Equal(t, Place{
+		ID:         "264371",
+		Name:       "Athens",
+		ASCIIName:  "Athens",
+		Alternates: []string{"Athen", "Atene", "Athina"},
+		Point:      geometry.Point{Lat: 37.98376, Lng: 23.72784},
+		Class:      "P",
+		Code:       "PPLC",
+		Country:    "GR",
+		Admin1:     "ESYE31",
+		Population: 664046,
+	}, ps[0])
+	assert.Nil(t, ps[1].Alternates)
+	assert.False(t, ps[3].Populated())
+
+	_, err = ReadGeoNames(strings.NewReader("1\tAthens\t37.9\t23.7\n"))
+	assert.EqualError(t, err, "line 1 of the GeoNames file has 4 columns, expected 19")
+	_, err = ReadGeoNames(strings.NewReader("\n" + strings.Replace(cities, "37.98376", "north", 1)))
+	assert.EqualError(t, err, "line 2 of the GeoNames file has an invalid latitude")
+}
+
+func TestRegions(t *testing.T) {
+	rs := regions(t, boundaries, Country)
+	assert.Equal(t, "GR", rs[0].CountryCode)
+	assert.Equal(t, "Greece", rs[0].Name)
+	assert.Empty(t, rs[0].Code)
+	assert.True(t, rs[0].Contains(geometry.Point{Lat: 38, Lng: 23.7}))
+	assert.False(t, rs[0].Contains(geometry.Point{Lat: 39.5, Lng: 25.5}))
+	assert.False(t, rs[0].Contains(geometry.Point{Lat: 45, Lng: 23.7}))
+
+	rs = regions(t, divisions, Admin1)
+	assert.Equal(t, "GR-B", rs[1].Code)
+	assert.Equal(t, "Central Macedonia", rs[1].Name)
+	assert.Empty(t, rs[2].Code)
+
+	rs = regions(t, `{"type":"MultiPolygon","coordinates":[[],[[[0,0],[1,0],[1,1],[0,0]]]]}`, Country)
+	assert.True(t, rs[0].Contains(geometry.Point{Lat: 0.2, Lng: 0.8}))
+	rs = regions(t, `{"type":"Polygon","coordinates":[]}`, Country)
+	assert.False(t, rs[0].Contains(geometry.Point{}))
+
+	fs, err := geom.Decode([]byte(`{"type":"Point","coordinates":[0,0]}`))
+	assert.NoError(t, err)
+	_, err = Regions(fs, Country)
+	assert.EqualError(t, err, "boundaries must be Polygon or MultiPolygon features")
+}
+
+func TestReverse(t *testing.T) {
+	ps, err := ReadGeoNames(strings.NewReader(cities))
+	assert.NoError(t, err)
+	rv := NewReverser(regions(t, boundaries, Country), regions(t, divisions, Admin1), ps)
+
+	loc, err := rv.Reverse(geometry.Point{Lat: 37.981, Lng: 23.741})
+	assert.NoError(t, err)
+	assert.Equal(t, "Greece", loc.Country.Name)
+	assert.Equal(t, "GR-I", loc.Admin1.Code)
+	// the hill is nearer but isn't a populated place
+	assert.Equal(t, "Athens", loc.Place.Name)
+	assert.InDelta(t, 1193.5, loc.Distance, 0.5)
+
+	loc, err = rv.Reverse(geometry.Point{Lat: 40.6, Lng: 22.9})
+	assert.NoError(t, err)
+	assert.Equal(t, "GR-B", loc.Admin1.Code)
+	assert.Equal(t, "Thessaloniki", loc.Place.Name)
+
+	// the division gives the country when the countries don't hold the point
+	loc, err = rv.Reverse(geometry.Point{Lat: 35.5, Lng: 30.5})
+	assert.NoError(t, err)
+	assert.Equal(t, "CY", loc.Country.CountryCode)
+	assert.Empty(t, loc.Country.Name)
+
+	// at sea
+	loc, err = rv.Reverse(geometry.Point{Lat: 36.5, Lng: 30.5})
+	assert.NoError(t, err)
+	assert.Nil(t, loc.Country)
+	assert.Nil(t, loc.Admin1)
+	assert.Equal(t, "Athens", loc.Place.Name)
+
+	_, err = rv.Reverse(geometry.Point{Lat: 91})
+	assert.EqualError(t, err, "the point must have a latitude between -90 and 90 and a longitude between -180 and 180")
+
+	loc, err = NewReverser(nil, nil, nil).Reverse(geometry.Point{})
+	assert.NoError(t, err)
+	assert.Nil(t, loc.Place)
+}
+
+// TestNearest compares the nearest places with the ones found by measuring every place.
+func TestNearest(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	places := make([]*Place, 500)
+	for i := range places {
+		places[i] = &Place{Point: geometry.Point{Lat: rnd.Float64()*180 - 90, Lng: rnd.Float64()*360 - 180}}
+	}
+	tree := newKDTree(append([]*Place(nil), places...))
+	for i := 0; i < 200; i++ {
+		q := geometry.Point{Lat: rnd.Float64()*180 - 90, Lng: rnd.Float64()*360 - 180}
+		want := math.Inf(1)
+		for _, p := range places {
+			d, err := measurement.PointDistance(q, p.Point, constants.UnitMeters)
+			assert.NoError(t, err)
+			want = math.Min(want, d)
+		}
+		_, got := tree.nearest(q)
+		assert.InDelta(t, want, got, 1e-3)
+	}
+}
+
+const addresses = "LON,LAT,NUMBER,STREET,UNIT,CITY,DISTRICT,REGION,POSTCODE,ID,HASH\n" +
+	"23.7275,37.9838,12,Odos Ermou,,Athina,,Attiki,10563,,a1b2\n" +
+	"-89.6501,39.7817,100,Main Street,2,Springfield,,IL,62701,,\n" +
+	"0,0,,,,Nowhere,,,,,\n"
+
+func geocoder(t *testing.T) *Geocoder {
+	ps, err := ReadGeoNames(strings.NewReader(cities +
+		"4250542\tSpringfield\tSpringfield\t\t39.80172\t-89.64371\tP\tPPLA\tUS\t\tIL\t\t\t\t116565\t\t180\tAmerica/Chicago\t2017-05-23\n" +
+		"4409896\tSpringfield\tSpringfield\t\t37.21533\t-93.29824\tP\tPPLA2\tUS\t\tMO\t\t\t\t166810\t\t396\tAmerica/Chicago\t2017-05-23\n" +
+		"390903\tHellenic Republic\tHellenic Republic\tGreece,Ellada,Ελλάδα\t39\t22\tA\tPCLI\tGR\t\t00\t\t\t\t10716322\t\t\tEurope/Athens\t2022-03-09\n"))
+	assert.NoError(t, err)
+	as, err := ReadOpenAddresses(strings.NewReader(addresses))
+	assert.NoError(t, err)
+	return NewGeocoder(append(ps, as...), regions(t, boundaries, Country), regions(t, divisions, Admin1))
+}
+
+func TestReadOpenAddresses(t *testing.T) {
+	as, err := ReadOpenAddresses(strings.NewReader(addresses))
+	assert.NoError(t, err)
+	assert.Len(t, as, 2)
+	assert.Equal(t, Place{
+		ID:      "a1b2",
+		Name:    "12 Odos Ermou",
+		Context: []string{"Athina", "Attiki", "10563"},
+		Point:   geometry.Point{Lat: 37.9838, Lng: 23.7275},
+		Code:    AddressCode,
+	}, as[0])
+	assert.Equal(t, "100 Main Street 2", as[1].Name)
+	assert.Equal(t, "3", as[1].ID)
+
+	_, err = ReadOpenAddresses(strings.NewReader("X,Y\n1,2\n"))
+	assert.EqualError(t, err, "the OpenAddresses file must have LON and LAT columns")
+	_, err = ReadOpenAddresses(strings.NewReader("LON,LAT,NUMBER,STREET\n200,0,1,Main\n"))
+	assert.EqualError(t, err, "line 2 of the OpenAddresses file has an invalid longitude")
+}
+
+func TestGeocode(t *testing.T) {
+	g := geocoder(t)
+	names := func(cs []Candidate) []string {
+		var res []string
+		for _, c := range cs {
+			res = append(res, c.Place.Name)
+		}
+		return res
+	}
+
+	cs, err := g.Geocode(Query{Text: "Athens"})
+	assert.NoError(t, err)
+	assert.Equal(t, "Athens", cs[0].Place.Name)
+	assert.Len(t, cs, 1)
+	assert.InDelta(t, 23.72784-0.2, cs[0].BBox[0], 0.05)
+	assert.InDelta(t, 37.98376+0.146, cs[0].BBox[3], 0.005)
+
+	// alternate names, accents and typos
+	for _, q := range []string{"athina", "ATHÈNS", "Athnes", "Salonica", "thessalonik", "Ελλαδα"} {
+		cs, err = g.Geocode(Query{Text: q})
+		assert.NoError(t, err)
+		assert.NotEmpty(t, cs, q)
+	}
+	cs, err = g.Geocode(Query{Text: "thesaloniki"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Thessaloniki"}, names(cs))
+
+	// the country takes the bbox of its boundary
+	cs, err = g.Geocode(Query{Text: "Greece"})
+	assert.NoError(t, err)
+	assert.Equal(t, [4]float64{20, 35, 28, 42}, cs[0].BBox)
+
+	// the most populated first, unless a focus point is nearer another one
+	cs, err = g.Geocode(Query{Text: "Springfield", Limit: 2})
+	assert.NoError(t, err)
+	assert.Equal(t, "MO", cs[0].Place.Admin1)
+	cs, err = g.Geocode(Query{Text: "Springfield", Focus: &geometry.Point{Lat: 39.8, Lng: -89.6}})
+	assert.NoError(t, err)
+	assert.Equal(t, "IL", cs[0].Place.Admin1)
+	assert.InDelta(t, 3700, cs[0].Distance, 100)
+
+	// addresses with their context
+	cs, err = g.Geocode(Query{Text: "100 main st springfield"})
+	assert.NoError(t, err)
+	assert.Equal(t, "100 Main Street 2", cs[0].Place.Name)
+	assert.InDelta(t, 39.7817-0.000225, cs[0].BBox[1], 1e-5)
+	cs, err = g.Geocode(Query{Text: "ermou 10563"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"12 Odos Ermou"}, names(cs))
+
+	// the last word may be typed partly
+	cs, err = g.Geocode(Query{Text: "pira"})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Piraeus"}, names(cs))
+
+	cs, err = g.Geocode(Query{Text: "Atlantis"})
+	assert.NoError(t, err)
+	assert.Empty(t, cs)
+}
+
+// TestGeocodeCommonWords geocodes over more places sharing their words than are scanned for a word.
+func TestGeocodeCommonWords(t *testing.T) {
+	var csv strings.Builder
+	csv.WriteString("LON,LAT,NUMBER,STREET,CITY,ID\n")
+	for i := 0; i < 2*maxPostings; i++ {
+		fmt.Fprintf(&csv, "%f,39.78,%d,Main Street,Springfield,%d\n", -89.65+float64(i%1000)*1e-4, i, i)
+	}
+	as, err := ReadOpenAddresses(strings.NewReader(csv.String()))
+	assert.NoError(t, err)
+	// the city comes after all the addresses naming it
+	as = append(as, Place{ID: "4409896", Name: "Springfield", ASCIIName: "Springfield", Point: geometry.Point{Lat: 37.21533, Lng: -93.29824}, Class: "P", Code: "PPLA2", Country: "US", Admin1: "MO", Population: 169176})
+	g := NewGeocoder(as, nil, nil)
+
+	cs, err := g.Geocode(Query{Text: "springfield"})
+	assert.NoError(t, err)
+	assert.Equal(t, "4409896", cs[0].Place.ID)
+
+	cs, err = g.Geocode(Query{Text: "19999 main street springfield"})
+	assert.NoError(t, err)
+	assert.Equal(t, "19999 Main Street", cs[0].Place.Name)
+	assert.Less(t, cs[1].Score, cs[0].Score)
+
+	cs, err = g.Geocode(Query{Text: "springfield main", Limit: 50})
+	assert.NoError(t, err)
+	assert.Len(t, cs, 50)
+	for i := 1; i < len(cs); i++ {
+		assert.False(t, better(cs[i], cs[i-1]))
+	}
+}
+
+func TestGeocodeErrors(t *testing.T) {
+	g := geocoder(t)
+	tests := map[string]struct {
+		query Query
+		err   string
+	}{
+		"empty":          {Query{Text: " ,- "}, "the query can't be empty"},
+		"too many words": {Query{Text: strings.Repeat("a ", 11)}, "the query can't have more than 10 words"},
+		"limit":          {Query{Text: "athens", Limit: 51}, "the limit must be between 1 and 50"},
+		"negative limit": {Query{Text: "athens", Limit: -1}, "the limit must be between 1 and 50"},
+		"invalid focus":  {Query{Text: "athens", Focus: &geometry.Point{Lng: 181}}, "the focus point must have a latitude between -90 and 90 and a longitude between -180 and 180"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := g.Geocode(tt.query)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestWithin(t *testing.T) {
+	assert.Equal(t, 0, within([]rune("athens"), []rune("athens"), 1))
+	assert.Equal(t, 1, within([]rune("athens"), []rune("athns"), 1))
+	assert.Equal(t, 1, within([]rune("athens"), []rune("ahtens"), 1))
+	assert.Equal(t, 2, within([]rune("athens"), []rune("ahtnes"), 1))
+	assert.Equal(t, 2, within([]rune("athens"), []rune("ahtnes"), 2))
+	assert.Equal(t, 3, within([]rune("athens"), []rune("at"), 2))
+	assert.Equal(t, []string{"athens", "ελλαδα", "1"}, tokens("Athéns, Ελλάδα-1"))
+}
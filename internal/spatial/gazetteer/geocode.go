@@ -0,0 +1,382 @@
+package gazetteer
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+const (
+	// DefaultLimit is the number of candidates returned when no limit is set.
+	DefaultLimit = 10
+	// maxLimit bounds the number of candidates returned.
+	maxLimit = 50
+	// maxWords bounds the number of words of a query.
+	maxWords = 10
+	// maxPrefixTerms bounds the number of words the last word of a query is a prefix of.
+	maxPrefixTerms = 200
+	// maxPostings bounds the places of a word of the index scanned for a query, the most populated
+	// first, the places of the more common words being looked up among the candidates found by the
+	// rarer ones.
+	maxPostings = 10000
+	// maxCandidates bounds the number of places scored for a query, the most populated first.
+	maxCandidates = 10000
+	// prefixScore, typoScore and phraseScore are the score of a word matched by its prefix, the
+	// score lost by each typo and the bonus of a name matching the whole query.
+	prefixScore = 0.75
+	typoScore   = 0.2
+	phraseScore = 0.25
+	// populationScore is the bonus of a place of 10 million people, less populated places earning
+	// its share of the log of their population.
+	populationScore = 0.2
+	// focusScore is the bonus of a place at the focus point, halving every focusHalving meters.
+	focusScore   = 0.5
+	focusHalving = 50000
+)
+
+// Query is a text geocoded to places, the ones near the focus point, when set, ranking higher.
+type Query struct {
+	Text  string
+	Focus *geometry.Point
+	Limit int
+}
+
+// Candidate is a place matching a query with its bounding box as west, south, east, north, its
+// score, the higher the better, and its distance in meters from the focus point when one is set.
+type Candidate struct {
+	Place    *Place
+	BBox     [4]float64
+	Score    float64
+	Distance float64
+}
+
+// Geocoder finds the places named in a text with an inverted index of the words of their names,
+// matched exactly, by their prefix for the last word typed and with a typo or two for the longer
+// words, the words of the index being found by their trigrams.
+type Geocoder struct {
+	places   []Place
+	bboxes   [][4]float64
+	ranks    []int32 // the places from the most populated, the postings holding their ranks
+	terms    []string
+	postings [][]int32
+	trigrams map[string][]int32
+}
+
+// NewGeocoder indexes the names of the places. The countries and the first level divisions of the
+// gazetteer, when set, are the bounding boxes of their places, the others being estimated from
+// the kind of place and its population.
+func NewGeocoder(places []Place, countries, admin1 []Region) *Geocoder {
+	g := &Geocoder{places: places, bboxes: make([][4]float64, len(places)), ranks: make([]int32, len(places)), trigrams: map[string][]int32{}}
+
+	// the places are indexed from the most populated, so that the ones scanned first for a common
+	// word are the most likely to be meant
+	for i := range g.ranks {
+		g.ranks[i] = int32(i)
+	}
+	sort.SliceStable(g.ranks, func(a, b int) bool { return places[g.ranks[a]].Population > places[g.ranks[b]].Population })
+
+	ids := map[string]int{}
+	var postings [][]int32
+	for r, i := range g.ranks {
+		p := &places[i]
+		names := append([]string{p.Name, p.ASCIIName}, p.Alternates...)
+		for _, name := range append(names, p.Context...) {
+			for _, t := range tokens(name) {
+				id, ok := ids[t]
+				if !ok {
+					id = len(g.terms)
+					ids[t] = id
+					g.terms = append(g.terms, t)
+					postings = append(postings, nil)
+				}
+				if ps := postings[id]; len(ps) == 0 || ps[len(ps)-1] != int32(r) {
+					postings[id] = append(ps, int32(r))
+				}
+			}
+		}
+	}
+
+	// the terms are sorted to find the ones of a prefix
+	order := make([]int, len(g.terms))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(a, b int) bool { return g.terms[order[a]] < g.terms[order[b]] })
+	terms := make([]string, len(order))
+	g.postings = make([][]int32, len(order))
+	for i, id := range order {
+		terms[i], g.postings[i] = g.terms[id], postings[id]
+		for _, tg := range trigrams(terms[i]) {
+			if ts := g.trigrams[tg]; len(ts) == 0 || ts[len(ts)-1] != int32(i) {
+				g.trigrams[tg] = append(ts, int32(i))
+			}
+		}
+	}
+	g.terms = terms
+
+	cs, as := newRegionIndex(countries), newRegionIndex(admin1)
+	for i := range places {
+		g.bboxes[i] = bounds(&places[i], cs, as)
+	}
+	return g
+}
+
+// bounds returns the bounding box of the region of a country or first level division place, and
+// otherwise a box around the place as large as its kind and population suggest.
+func bounds(p *Place, countries, admin1 *regionIndex) [4]float64 {
+	var r *Region
+	switch {
+	case p.Class == "A" && strings.HasPrefix(p.Code, "PCL"):
+		for i := range countries.regions {
+			if c := &countries.regions[i]; c.CountryCode == p.Country && c.CountryCode != "" {
+				r = c
+				break
+			}
+		}
+	case p.Class == "A" && p.Code == "ADM1":
+		if a := admin1.find(p.Point); a != nil && (a.CountryCode == p.Country || a.CountryCode == "") {
+			r = a
+		}
+	}
+	if r != nil {
+		return [4]float64{r.west, r.south, r.east, r.north}
+	}
+
+	radius := 1000.0
+	switch {
+	case p.Code == AddressCode:
+		radius = 25
+	case p.Class == "P":
+		radius = math.Max(1000, math.Min(30000, math.Sqrt(float64(p.Population))*20))
+	case p.Class == "A":
+		radius = 20000
+	}
+	dLat := radius / geom.MetersPerDegree
+	dLng := math.Min(180, dLat/math.Max(math.Cos(p.Point.Lat*math.Pi/180), 1e-6))
+	return [4]float64{
+		math.Max(-180, p.Point.Lng-dLng), math.Max(-90, p.Point.Lat-dLat),
+		math.Min(180, p.Point.Lng+dLng), math.Min(90, p.Point.Lat+dLat),
+	}
+}
+
+// Geocode returns the places matching a query, the best first. The places are scored by the share
+// of the words of the query their names hold, the typos and the prefixes scoring less, with a
+// bonus when one of their names is the whole query, another growing with their population and
+// another decreasing with their distance from the focus point.
+func (g *Geocoder) Geocode(q Query) ([]Candidate, error) {
+	words := tokens(q.Text)
+	if len(words) == 0 {
+		return nil, errors.New("the query can't be empty")
+	}
+	if len(words) > maxWords {
+		return nil, fmt.Errorf("the query can't have more than %d words", maxWords)
+	}
+	limit := q.Limit
+	if limit == 0 {
+		limit = DefaultLimit
+	}
+	if limit < 1 || limit > maxLimit {
+		return nil, fmt.Errorf("the limit must be between 1 and %d", maxLimit)
+	}
+	if f := q.Focus; f != nil && (math.IsNaN(f.Lat) || math.IsNaN(f.Lng) || math.Abs(f.Lat) > 90 || math.Abs(f.Lng) > 180) {
+		return nil, errors.New("the focus point must have a latitude between -90 and 90 and a longitude between -180 and 180")
+	}
+
+	// the words are matched from the rarest, so that the candidates are the places holding them
+	matches := make([][]termScore, len(words))
+	sizes := make([]int, len(words))
+	order := make([]int, len(words))
+	for w, word := range words {
+		matches[w] = g.match(word, w == len(words)-1)
+		for _, m := range matches[w] {
+			sizes[w] += len(g.postings[m.term])
+		}
+		order[w] = w
+	}
+	sort.SliceStable(order, func(a, b int) bool { return sizes[order[a]] < sizes[order[b]] })
+
+	// the best score of each word of the query in the names of each place, by rank
+	scores := map[int32][]float64{}
+	for _, w := range order {
+		for _, m := range matches[w] {
+			ps := g.postings[m.term]
+			if len(ps) > maxPostings && len(scores) > 0 {
+				for r, ws := range scores {
+					if k := sort.Search(len(ps), func(k int) bool { return ps[k] >= r }); k < len(ps) && ps[k] == r {
+						ws[w] = math.Max(ws[w], m.score)
+					}
+				}
+				continue
+			}
+			if len(ps) > maxPostings {
+				ps = ps[:maxPostings]
+			}
+			for _, r := range ps {
+				ws := scores[r]
+				if ws == nil {
+					if len(scores) >= maxCandidates {
+						continue
+					}
+					ws = make([]float64, len(words))
+					scores[r] = ws
+				}
+				ws[w] = math.Max(ws[w], m.score)
+			}
+		}
+	}
+
+	phrase := strings.Join(words, " ")
+	best := &candidates{}
+	for r, ws := range scores {
+		i := g.ranks[r]
+		p := &g.places[i]
+		c := Candidate{Place: p, BBox: g.bboxes[i]}
+		full := true
+		for _, s := range ws {
+			c.Score += s / float64(len(words))
+			full = full && s == 1
+		}
+		if full && g.named(p, phrase) {
+			c.Score += phraseScore
+		}
+		if p.Population > 0 {
+			c.Score += populationScore * math.Min(1, math.Log10(float64(p.Population))/7)
+		}
+		if q.Focus != nil {
+			c.Distance = distance(*q.Focus, p.Point)
+			c.Score += focusScore * math.Pow(0.5, c.Distance/focusHalving)
+		}
+		heap.Push(best, c)
+		if best.Len() > limit {
+			heap.Pop(best)
+		}
+	}
+
+	res := make([]Candidate, best.Len())
+	for i := len(res) - 1; i >= 0; i-- {
+		res[i] = heap.Pop(best).(Candidate)
+	}
+	return res, nil
+}
+
+// better reports whether a candidate ranks before another: the higher score first, then the
+// larger population and the lower ID.
+func better(a, b Candidate) bool {
+	if a.Score != b.Score {
+		return a.Score > b.Score
+	}
+	if a.Place.Population != b.Place.Population {
+		return a.Place.Population > b.Place.Population
+	}
+	return a.Place.ID < b.Place.ID
+}
+
+// candidates is a heap of the best candidates of a query, the worst one at its top.
+type candidates []Candidate
+
+func (h candidates) Len() int           { return len(h) }
+func (h candidates) Less(i, j int) bool { return better(h[j], h[i]) }
+func (h candidates) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+
+func (h *candidates) Push(x interface{}) {
+	*h = append(*h, x.(Candidate))
+}
+
+func (h *candidates) Pop() interface{} {
+	old := *h
+	c := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return c
+}
+
+// termScore is a term of the index matching a word of a query with its score.
+type termScore struct {
+	term  int
+	score float64
+}
+
+// match returns the terms of the index matching a word of a query, the best first: the word
+// itself, the words a typo away from a word of 4 to 7 letters or two typos away from a longer one,
+// and the words it's a prefix of when it's the last one of the query.
+func (g *Geocoder) match(word string, last bool) []termScore {
+	res := map[int]float64{}
+	if i := sort.SearchStrings(g.terms, word); i < len(g.terms) && g.terms[i] == word {
+		res[i] = 1
+	}
+	if last && len([]rune(word)) >= 2 {
+		for i, n := sort.SearchStrings(g.terms, word), 0; i < len(g.terms) && n < maxPrefixTerms && strings.HasPrefix(g.terms[i], word); i++ {
+			if _, ok := res[i]; !ok {
+				res[i] = prefixScore
+				n++
+			}
+		}
+	}
+
+	rs := []rune(word)
+	typos := 0
+	switch {
+	case len(rs) >= 8:
+		typos = 2
+	case len(rs) >= 4:
+		typos = 1
+	}
+	if typos == 0 {
+		return sorted(res)
+	}
+	// a typo changes 4 trigrams at most, when it swaps two letters
+	tgs := trigrams(word)
+	shared := map[int32]int{}
+	for _, tg := range tgs {
+		for _, t := range g.trigrams[tg] {
+			shared[t]++
+		}
+	}
+	for t, n := range shared {
+		if n < len(tgs)-4*typos {
+			continue
+		}
+		if _, ok := res[int(t)]; ok {
+			continue
+		}
+		if d := within(rs, []rune(g.terms[t]), typos); d > 0 && d <= typos {
+			res[int(t)] = 1 - typoScore*float64(d)
+		}
+	}
+	return sorted(res)
+}
+
+// sorted returns the terms matching a word, the best first.
+func sorted(scores map[int]float64) []termScore {
+	res := make([]termScore, 0, len(scores))
+	for t, s := range scores {
+		res = append(res, termScore{term: t, score: s})
+	}
+	sort.Slice(res, func(a, b int) bool {
+		if res[a].score != res[b].score {
+			return res[a].score > res[b].score
+		}
+		return res[a].term < res[b].term
+	})
+	return res
+}
+
+// named reports whether one of the names of a place is a phrase.
+func (g *Geocoder) named(p *Place, phrase string) bool {
+	for _, name := range append([]string{p.Name, p.ASCIIName}, p.Alternates...) {
+		if normalize(name) == phrase {
+			return true
+		}
+	}
+	return false
+}
+
+// distance returns the great circle distance in meters between two points.
+func distance(a, b geometry.Point) float64 {
+	return arc(chord2(unit(a), unit(b)))
+}
@@ -0,0 +1,145 @@
+package gazetteer
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// cellSize is the side in degrees of the cells of the region index.
+const cellSize = 1.0
+
+// regionIndex finds the regions containing a point, each region being in the cells its bounding
+// box overlaps.
+type regionIndex struct {
+	regions []Region
+	cells   map[[2]int][]int
+}
+
+func cell(lng, lat float64) [2]int {
+	return [2]int{int(math.Floor(lng / cellSize)), int(math.Floor(lat / cellSize))}
+}
+
+func newRegionIndex(regions []Region) *regionIndex {
+	ix := &regionIndex{regions: regions, cells: map[[2]int][]int{}}
+	for i, r := range regions {
+		if r.west > r.east {
+			continue
+		}
+		lo, hi := cell(r.west, r.south), cell(r.east, r.north)
+		for x := lo[0]; x <= hi[0]; x++ {
+			for y := lo[1]; y <= hi[1]; y++ {
+				k := [2]int{x, y}
+				ix.cells[k] = append(ix.cells[k], i)
+			}
+		}
+	}
+	return ix
+}
+
+// find returns the first region containing a point, nil when none does.
+func (ix *regionIndex) find(p geometry.Point) *Region {
+	for _, i := range ix.cells[cell(p.Lng, p.Lat)] {
+		if ix.regions[i].Contains(p) {
+			return &ix.regions[i]
+		}
+	}
+	return nil
+}
+
+// kdTree finds the nearest places to a point. The places are points of the unit sphere, the
+// chords between them growing with the great circle distances, stored as an implicit tree: the
+// median of a range splits it on the axis of its depth.
+type kdTree struct {
+	places []*Place
+	points [][3]float64
+}
+
+func unit(p geometry.Point) [3]float64 {
+	lat, lng := p.Lat*math.Pi/180, p.Lng*math.Pi/180
+	return [3]float64{math.Cos(lat) * math.Cos(lng), math.Cos(lat) * math.Sin(lng), math.Sin(lat)}
+}
+
+func newKDTree(places []*Place) *kdTree {
+	t := &kdTree{places: places, points: make([][3]float64, len(places))}
+	for i, p := range places {
+		t.points[i] = unit(p.Point)
+	}
+	t.build(0, len(places), 0)
+	return t
+}
+
+func (t *kdTree) build(lo, hi, axis int) {
+	if hi-lo < 2 {
+		return
+	}
+	sort.Sort(byAxis{t, lo, hi, axis})
+	mid := (lo + hi) / 2
+	t.build(lo, mid, (axis+1)%3)
+	t.build(mid+1, hi, (axis+1)%3)
+}
+
+// byAxis sorts a range of the tree on an axis.
+type byAxis struct {
+	t            *kdTree
+	lo, hi, axis int
+}
+
+func (s byAxis) Len() int { return s.hi - s.lo }
+
+func (s byAxis) Less(i, j int) bool {
+	return s.t.points[s.lo+i][s.axis] < s.t.points[s.lo+j][s.axis]
+}
+
+func (s byAxis) Swap(i, j int) {
+	i, j = s.lo+i, s.lo+j
+	s.t.points[i], s.t.points[j] = s.t.points[j], s.t.points[i]
+	s.t.places[i], s.t.places[j] = s.t.places[j], s.t.places[i]
+}
+
+// nearest returns the nearest place to a point and its great circle distance in meters, nil when
+// the tree is empty.
+func (t *kdTree) nearest(p geometry.Point) (*Place, float64) {
+	if len(t.places) == 0 {
+		return nil, 0
+	}
+	q := unit(p)
+	best, dist := -1, math.Inf(1)
+	t.search(q, 0, len(t.places), 0, &best, &dist)
+	return t.places[best], arc(dist)
+}
+
+// arc returns the great circle distance in meters of a squared chord of the unit sphere.
+func arc(d2 float64) float64 {
+	return 2 * math.Asin(math.Min(math.Sqrt(d2)/2, 1)) * geom.EarthRadius
+}
+
+// search looks for a place nearer than dist, the squared chord to best, in a range of the tree.
+func (t *kdTree) search(q [3]float64, lo, hi, axis int, best *int, dist *float64) {
+	if lo >= hi {
+		return
+	}
+	mid := (lo + hi) / 2
+	p := t.points[mid]
+	d := chord2(p, q)
+	if d < *dist || (d == *dist && t.places[mid].ID < t.places[*best].ID) {
+		*best, *dist = mid, d
+	}
+
+	next := (axis + 1) % 3
+	diff := q[axis] - p[axis]
+	near, far := [2]int{lo, mid}, [2]int{mid + 1, hi}
+	if diff > 0 {
+		near, far = far, near
+	}
+	t.search(q, near[0], near[1], next, best, dist)
+	if diff*diff <= *dist {
+		t.search(q, far[0], far[1], next, best, dist)
+	}
+}
+
+func chord2(a, b [3]float64) float64 {
+	return (a[0]-b[0])*(a[0]-b[0]) + (a[1]-b[1])*(a[1]-b[1]) + (a[2]-b[2])*(a[2]-b[2])
+}
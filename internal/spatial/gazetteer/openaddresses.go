@@ -0,0 +1,82 @@
+package gazetteer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// AddressCode is the feature code of the addresses, which have no feature class.
+const AddressCode = "ADDR"
+
+// ReadOpenAddresses reads the addresses of an OpenAddresses CSV file, with its LON, LAT, NUMBER,
+// STREET, UNIT, CITY, DISTRICT, REGION, POSTCODE, ID and HASH columns. An address is named after
+// its number, street and unit, and its city, district, region and postcode are its context.
+func ReadOpenAddresses(r io.Reader) ([]Place, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	cr.ReuseRecord = true
+	header, err := cr.Read()
+	if err != nil {
+		return nil, errors.New("cannot read the header of the OpenAddresses file")
+	}
+	cols := map[string]int{}
+	for i, h := range header {
+		cols[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))] = i
+	}
+	if _, ok := cols["LON"]; !ok {
+		return nil, errors.New("the OpenAddresses file must have LON and LAT columns")
+	}
+	if _, ok := cols["LAT"]; !ok {
+		return nil, errors.New("the OpenAddresses file must have LON and LAT columns")
+	}
+
+	var res []Place
+	for line := 2; ; line++ {
+		rec, err := cr.Read()
+		if err == io.EOF {
+			return res, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("cannot read line %d of the OpenAddresses file", line)
+		}
+		get := func(name string) string {
+			if i, ok := cols[name]; ok && i < len(rec) {
+				return strings.TrimSpace(rec[i])
+			}
+			return ""
+		}
+
+		lng, err := strconv.ParseFloat(get("LON"), 64)
+		if err != nil || math.Abs(lng) > 180 {
+			return nil, fmt.Errorf("line %d of the OpenAddresses file has an invalid longitude", line)
+		}
+		lat, err := strconv.ParseFloat(get("LAT"), 64)
+		if err != nil || math.Abs(lat) > 90 {
+			return nil, fmt.Errorf("line %d of the OpenAddresses file has an invalid latitude", line)
+		}
+		name := strings.Join(strings.Fields(get("NUMBER")+" "+get("STREET")+" "+get("UNIT")), " ")
+		if name == "" {
+			continue
+		}
+		p := Place{ID: get("HASH"), Name: name, Point: geometry.Point{Lat: lat, Lng: lng}, Code: AddressCode}
+		if p.ID == "" {
+			p.ID = get("ID")
+		}
+		if p.ID == "" {
+			p.ID = strconv.Itoa(line)
+		}
+		for _, c := range []string{"CITY", "DISTRICT", "REGION", "POSTCODE"} {
+			if v := get(c); v != "" {
+				p.Context = append(p.Context, v)
+			}
+		}
+		res = append(res, p)
+	}
+}
@@ -0,0 +1,116 @@
+package gazetteer
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Level is the administrative level of a region.
+type Level int
+
+const (
+	// Country is a country.
+	Country Level = iota
+	// Admin1 is a first level administrative division of a country, a state or a province.
+	Admin1
+)
+
+// property names of the codes and the names of the regions, the Natural Earth ones first, compared
+// without case.
+var (
+	countryCodeKeys = []string{"iso_a2_eh", "iso_a2", "country_code", "iso"}
+	admin1CodeKeys  = []string{"iso_3166_2", "code_hasc", "adm1_code", "code"}
+	nameKeys        = []string{"name", "name_en", "admin"}
+)
+
+// Region is a country or a first level administrative division. CountryCode is the ISO 3166-1
+// alpha-2 code of its country and Code, for the divisions only, their ISO 3166-2 code when the
+// dataset has one.
+type Region struct {
+	Level       Level
+	CountryCode string
+	Code        string
+	Name        string
+	Polygons    [][][]geometry.Point
+
+	west, south, east, north float64
+}
+
+// Regions returns the regions of a level of Polygon and MultiPolygon features, their codes and
+// names read from the properties of the Natural Earth admin 0 and admin 1 datasets, or from the
+// country_code, code and name properties.
+func Regions(fs []feature.Feature, level Level) ([]Region, error) {
+	res := make([]Region, 0, len(fs))
+	for _, f := range fs {
+		if f.Geometry.GeoJSONType != geojson.Polygon && f.Geometry.GeoJSONType != geojson.MultiPolygon {
+			return nil, errors.New("boundaries must be Polygon or MultiPolygon features")
+		}
+		polys, err := geom.Polygons(f.Geometry)
+		if err != nil {
+			return nil, err
+		}
+		r := Region{
+			Level:       level,
+			CountryCode: property(f.Properties, countryCodeKeys),
+			Name:        property(f.Properties, nameKeys),
+			Polygons:    polys,
+		}
+		if level == Admin1 {
+			r.Code = property(f.Properties, admin1CodeKeys)
+		}
+		r.west, r.south, r.east, r.north = math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+		for _, poly := range polys {
+			if len(poly) == 0 {
+				continue
+			}
+			for _, p := range poly[0] {
+				r.west, r.east = math.Min(r.west, p.Lng), math.Max(r.east, p.Lng)
+				r.south, r.north = math.Min(r.south, p.Lat), math.Max(r.north, p.Lat)
+			}
+		}
+		res = append(res, r)
+	}
+	return res, nil
+}
+
+// property returns the first string property of the keys set, Natural Earth marking the missing
+// codes with -99.
+func property(props map[string]interface{}, keys []string) string {
+	for _, k := range keys {
+		for pk, v := range props {
+			if s, ok := v.(string); ok && strings.EqualFold(pk, k) && s != "" && s != "-99" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+// Contains reports whether a point is inside the region, outside its holes.
+func (r *Region) Contains(p geometry.Point) bool {
+	if p.Lng < r.west || p.Lng > r.east || p.Lat < r.south || p.Lat > r.north {
+		return false
+	}
+	for _, poly := range r.Polygons {
+		if len(poly) == 0 || !geom.InRing(p, poly[0]) {
+			continue
+		}
+		hole := false
+		for _, h := range poly[1:] {
+			if geom.InRing(p, h) {
+				hole = true
+				break
+			}
+		}
+		if !hole {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,58 @@
+package gazetteer
+
+import (
+	"errors"
+	"math"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Location is what a point is reverse geocoded to: the country and the first level division
+// containing it, nil when none does, and the nearest populated place with its distance in meters.
+type Location struct {
+	Country  *Region
+	Admin1   *Region
+	Place    *Place
+	Distance float64
+}
+
+// Reverser reverse geocodes points with the boundaries of the countries and their first level
+// divisions and the populated places of a gazetteer.
+type Reverser struct {
+	countries *regionIndex
+	admin1    *regionIndex
+	places    *kdTree
+}
+
+// NewReverser indexes the regions and the populated places, leaving out the other places.
+func NewReverser(countries, admin1 []Region, places []Place) *Reverser {
+	var populated []*Place
+	for i := range places {
+		if places[i].Populated() {
+			populated = append(populated, &places[i])
+		}
+	}
+	return &Reverser{
+		countries: newRegionIndex(countries),
+		admin1:    newRegionIndex(admin1),
+		places:    newKDTree(populated),
+	}
+}
+
+// Reverse returns the location of a point. The country is the division's one when the country
+// boundaries don't hold the point but the division ones do, so that the first level divisions are
+// enough to find both.
+func (rv *Reverser) Reverse(p geometry.Point) (*Location, error) {
+	if math.IsNaN(p.Lat) || math.IsNaN(p.Lng) || math.Abs(p.Lat) > 90 || math.Abs(p.Lng) > 180 {
+		return nil, errors.New("the point must have a latitude between -90 and 90 and a longitude between -180 and 180")
+	}
+	loc := &Location{
+		Country: rv.countries.find(p),
+		Admin1:  rv.admin1.find(p),
+	}
+	if loc.Country == nil && loc.Admin1 != nil && loc.Admin1.CountryCode != "" {
+		loc.Country = &Region{Level: Country, CountryCode: loc.Admin1.CountryCode}
+	}
+	loc.Place, loc.Distance = rv.places.nearest(p)
+	return loc, nil
+}
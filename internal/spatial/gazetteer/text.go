@@ -0,0 +1,97 @@
+package gazetteer
+
+import (
+	"strings"
+	"unicode"
+)
+
+// folds maps the accented letters to their base letters, so that the names match whether they are
+// typed with their accents or not.
+var folds = func() map[rune]string {
+	m := map[rune]string{}
+	for base, letters := range map[string]string{
+		"a": "àáâãäåāăą", "c": "çćčĉċ", "d": "ďđð", "e": "èéêëēĕėęě", "g": "ĝğġģ", "h": "ĥħ",
+		"i": "ìíîïĩīĭįı", "j": "ĵ", "k": "ķ", "l": "ĺļľŀł", "n": "ñńņňŉ", "o": "òóôõöøōŏő",
+		"r": "ŕŗř", "s": "śŝşšș", "t": "ţťŧț", "u": "ùúûüũūŭůűų", "w": "ŵ", "y": "ýÿŷ", "z": "źżž",
+		"ae": "æ", "oe": "œ", "ss": "ß", "th": "þ",
+		"α": "ά", "ε": "έ", "η": "ή", "ι": "ίϊΐ", "ο": "ό", "υ": "ύϋΰ", "ω": "ώ", "σ": "ς",
+	} {
+		for _, l := range letters {
+			m[l] = base
+		}
+	}
+	return m
+}()
+
+// tokens returns the words of a text, lower cased and without accents.
+func tokens(s string) []string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if f, ok := folds[r]; ok {
+			b.WriteString(f)
+		} else if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else if !unicode.Is(unicode.Mn, r) {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Fields(b.String())
+}
+
+// normalize returns the words of a text joined by spaces.
+func normalize(s string) string {
+	return strings.Join(tokens(s), " ")
+}
+
+// trigrams returns the trigrams of a word padded with a $ at both ends, as many as its letters.
+func trigrams(w string) []string {
+	rs := []rune("$" + w + "$")
+	res := make([]string, 0, len(rs)-2)
+	for i := 0; i+3 <= len(rs); i++ {
+		res = append(res, string(rs[i:i+3]))
+	}
+	return res
+}
+
+// within returns the edit distance between two words when it's at most limit, limit+1 otherwise,
+// swapping two letters being a single edit.
+func within(a, b []rune, limit int) int {
+	if d := len(a) - len(b); d > limit || -d > limit {
+		return limit + 1
+	}
+	prev2, prev, cur := make([]int, len(b)+1), make([]int, len(b)+1), make([]int, len(b)+1)
+	for j := range prev {
+		prev[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		cur[0] = i
+		best := cur[0]
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			cur[j] = prev[j-1] + cost
+			if prev[j]+1 < cur[j] {
+				cur[j] = prev[j] + 1
+			}
+			if cur[j-1]+1 < cur[j] {
+				cur[j] = cur[j-1] + 1
+			}
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] && prev2[j-2]+1 < cur[j] {
+				cur[j] = prev2[j-2] + 1
+			}
+			if cur[j] < best {
+				best = cur[j]
+			}
+		}
+		if best > limit {
+			return limit + 1
+		}
+		prev2, prev, cur = prev, cur, prev2
+	}
+	if prev[len(b)] > limit {
+		return limit + 1
+	}
+	return prev[len(b)]
+}
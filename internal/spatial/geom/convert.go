@@ -0,0 +1,246 @@
+package geom
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Point returns the position of a Point geometry.
+func Point(g geometry.Geometry) (*geometry.Point, error) {
+	if g.GeoJSONType != geojson.Point {
+		return nil, errors.New("invalid geometry type")
+	}
+	var coords []float64
+	if err := remarshal(g.Coordinates, &coords); err != nil {
+		return nil, err
+	}
+	if len(coords) < 2 {
+		return nil, errors.New("a position must have at least two elements")
+	}
+	return geometry.NewPoint(coords[1], coords[0]), nil
+}
+
+// Line returns the positions of a LineString or the positions of a MultiPoint geometry.
+func Line(g geometry.Geometry) ([]geometry.Point, error) {
+	if g.GeoJSONType != geojson.LineString && g.GeoJSONType != geojson.MultiPoint {
+		return nil, errors.New("invalid geometry type")
+	}
+	var coords [][]float64
+	if err := remarshal(g.Coordinates, &coords); err != nil {
+		return nil, err
+	}
+	return toPoints(coords)
+}
+
+// Lines returns the lines of a LineString or MultiLineString geometry.
+func Lines(g geometry.Geometry) ([][]geometry.Point, error) {
+	switch g.GeoJSONType {
+	case geojson.LineString:
+		l, err := Line(g)
+		if err != nil {
+			return nil, err
+		}
+		return [][]geometry.Point{l}, nil
+	case geojson.MultiLineString:
+		var coords [][][]float64
+		if err := remarshal(g.Coordinates, &coords); err != nil {
+			return nil, err
+		}
+		return toRings(coords)
+	default:
+		return nil, errors.New("invalid geometry type")
+	}
+}
+
+// Polygons returns the polygons of a Polygon or MultiPolygon geometry as lists of rings.
+// The rings are returned as they are, without checking whether they are closed.
+func Polygons(g geometry.Geometry) ([][][]geometry.Point, error) {
+	switch g.GeoJSONType {
+	case geojson.Polygon:
+		var coords [][][]float64
+		if err := remarshal(g.Coordinates, &coords); err != nil {
+			return nil, err
+		}
+		rings, err := toRings(coords)
+		if err != nil {
+			return nil, err
+		}
+		return [][][]geometry.Point{rings}, nil
+	case geojson.MultiPolygon:
+		var coords [][][][]float64
+		if err := remarshal(g.Coordinates, &coords); err != nil {
+			return nil, err
+		}
+		polys := make([][][]geometry.Point, 0, len(coords))
+		for _, c := range coords {
+			rings, err := toRings(c)
+			if err != nil {
+				return nil, err
+			}
+			polys = append(polys, rings)
+		}
+		return polys, nil
+	default:
+		return nil, errors.New("invalid geometry type")
+	}
+}
+
+// Coords returns all the positions of a geometry.
+func Coords(g geometry.Geometry) ([]geometry.Point, error) {
+	switch g.GeoJSONType {
+	case geojson.Point:
+		p, err := Point(g)
+		if err != nil {
+			return nil, err
+		}
+		return []geometry.Point{*p}, nil
+	case geojson.MultiPoint, geojson.LineString:
+		return Line(g)
+	case geojson.MultiLineString:
+		ls, err := Lines(g)
+		if err != nil {
+			return nil, err
+		}
+		var res []geometry.Point
+		for _, l := range ls {
+			res = append(res, l...)
+		}
+		return res, nil
+	case geojson.Polygon, geojson.MultiPolygon:
+		polys, err := Polygons(g)
+		if err != nil {
+			return nil, err
+		}
+		var res []geometry.Point
+		for _, rings := range polys {
+			for _, r := range rings {
+				res = append(res, r...)
+			}
+		}
+		return res, nil
+	default:
+		return nil, errors.New("invalid geometry type")
+	}
+}
+
+// PointGeometry converts a point to a GeoJSON Point geometry.
+func PointGeometry(p geometry.Point) geometry.Geometry {
+	return geometry.Geometry{
+		GeoJSONType: geojson.Point,
+		Coordinates: position(p),
+	}
+}
+
+// MultiPointGeometry converts a list of points to a GeoJSON MultiPoint geometry.
+func MultiPointGeometry(ps []geometry.Point) geometry.Geometry {
+	return geometry.Geometry{
+		GeoJSONType: geojson.MultiPoint,
+		Coordinates: positions(ps),
+	}
+}
+
+// LineStringGeometry converts a list of points to a GeoJSON LineString geometry.
+func LineStringGeometry(ps []geometry.Point) geometry.Geometry {
+	return geometry.Geometry{
+		GeoJSONType: geojson.LineString,
+		Coordinates: positions(ps),
+	}
+}
+
+// MultiLineStringGeometry converts a list of lines to a GeoJSON MultiLineString geometry.
+func MultiLineStringGeometry(ls [][]geometry.Point) geometry.Geometry {
+	coords := make([][][]float64, 0, len(ls))
+	for _, l := range ls {
+		coords = append(coords, positions(l))
+	}
+	return geometry.Geometry{
+		GeoJSONType: geojson.MultiLineString,
+		Coordinates: coords,
+	}
+}
+
+// PolygonGeometry converts a list of rings to a GeoJSON Polygon geometry.
+func PolygonGeometry(rings [][]geometry.Point) geometry.Geometry {
+	return geometry.Geometry{
+		GeoJSONType: geojson.Polygon,
+		Coordinates: ringPositions(rings),
+	}
+}
+
+// MultiPolygonGeometry converts a list of polygons to a GeoJSON MultiPolygon geometry.
+func MultiPolygonGeometry(polys [][][]geometry.Point) geometry.Geometry {
+	coords := make([][][][]float64, 0, len(polys))
+	for _, p := range polys {
+		coords = append(coords, ringPositions(p))
+	}
+	return geometry.Geometry{
+		GeoJSONType: geojson.MultiPolygon,
+		Coordinates: coords,
+	}
+}
+
+// Rings returns the rings of a polygon.
+func Rings(p geometry.Polygon) [][]geometry.Point {
+	rings := make([][]geometry.Point, 0, len(p.Coordinates))
+	for _, r := range p.Coordinates {
+		rings = append(rings, r.Coordinates)
+	}
+	return rings
+}
+
+func position(p geometry.Point) []float64 {
+	return []float64{p.Lng, p.Lat}
+}
+
+func positions(ps []geometry.Point) [][]float64 {
+	coords := make([][]float64, 0, len(ps))
+	for _, p := range ps {
+		coords = append(coords, position(p))
+	}
+	return coords
+}
+
+func ringPositions(rings [][]geometry.Point) [][][]float64 {
+	coords := make([][][]float64, 0, len(rings))
+	for _, r := range rings {
+		coords = append(coords, positions(r))
+	}
+	return coords
+}
+
+func toPoints(coords [][]float64) ([]geometry.Point, error) {
+	ps := make([]geometry.Point, 0, len(coords))
+	for _, c := range coords {
+		if len(c) < 2 {
+			return nil, errors.New("a position must have at least two elements")
+		}
+		ps = append(ps, geometry.Point{Lat: c[1], Lng: c[0]})
+	}
+	return ps, nil
+}
+
+func toRings(coords [][][]float64) ([][]geometry.Point, error) {
+	rings := make([][]geometry.Point, 0, len(coords))
+	for _, c := range coords {
+		r, err := toPoints(c)
+		if err != nil {
+			return nil, err
+		}
+		rings = append(rings, r)
+	}
+	return rings, nil
+}
+
+func remarshal(in, out interface{}) error {
+	b, err := json.Marshal(in)
+	if err != nil {
+		return errors.New("cannot marshal object")
+	}
+	if err := json.Unmarshal(b, out); err != nil {
+		return errors.New("cannot unmarshal object")
+	}
+	return nil
+}
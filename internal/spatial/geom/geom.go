@@ -0,0 +1,95 @@
+package geom
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// object is used to peek the type of a GeoJSON object before decoding it.
+type object struct {
+	Type geojson.OBjectType `json:"type"`
+}
+
+// Decode parses any GeoJSON object (Geometry, GeometryCollection, Feature or FeatureCollection)
+// and returns its content as a list of features. Bare geometries are wrapped in a feature
+// without properties and GeometryCollections are flattened.
+func Decode(data []byte) ([]feature.Feature, error) {
+	var o object
+	if err := json.Unmarshal(data, &o); err != nil {
+		return nil, errors.New("cannot decode the input value")
+	}
+
+	switch o.Type {
+	case geojson.FeatureCollection:
+		var fc feature.Collection
+		if err := json.Unmarshal(data, &fc); err != nil {
+			return nil, errors.New("cannot decode the feature collection")
+		}
+		return fc.Features, nil
+	case geojson.Feature:
+		var f feature.Feature
+		if err := json.Unmarshal(data, &f); err != nil {
+			return nil, errors.New("cannot decode the feature")
+		}
+		return []feature.Feature{f}, nil
+	case geojson.GeometryCollection:
+		var gc geometry.Collection
+		if err := json.Unmarshal(data, &gc); err != nil {
+			return nil, errors.New("cannot decode the geometry collection")
+		}
+		fs := make([]feature.Feature, 0, len(gc.Geometries))
+		for _, g := range gc.Geometries {
+			fs = append(fs, NewFeature(g, nil))
+		}
+		return fs, nil
+	case geojson.Point, geojson.MultiPoint, geojson.LineString, geojson.MultiLineString, geojson.Polygon, geojson.MultiPolygon:
+		var g geometry.Geometry
+		if err := json.Unmarshal(data, &g); err != nil {
+			return nil, errors.New("cannot decode the geometry")
+		}
+		return []feature.Feature{NewFeature(g, nil)}, nil
+	default:
+		return nil, fmt.Errorf("unsupported geojson type %q", o.Type)
+	}
+}
+
+// DecodeGeometries parses any GeoJSON object and returns the geometries it contains.
+func DecodeGeometries(data []byte) ([]geometry.Geometry, error) {
+	fs, err := Decode(data)
+	if err != nil {
+		return nil, err
+	}
+	gs := make([]geometry.Geometry, 0, len(fs))
+	for i := range fs {
+		gs = append(gs, fs[i].Geometry)
+	}
+	return gs, nil
+}
+
+// NewFeature wraps a geometry into a feature.
+func NewFeature(g geometry.Geometry, properties map[string]interface{}) feature.Feature {
+	if properties == nil {
+		properties = map[string]interface{}{}
+	}
+	return feature.Feature{
+		Type:       geojson.Feature,
+		Properties: properties,
+		Geometry:   g,
+	}
+}
+
+// NewFeatureCollection wraps a list of features into a feature collection.
+func NewFeatureCollection(fs []feature.Feature) feature.Collection {
+	if fs == nil {
+		fs = []feature.Feature{}
+	}
+	return feature.Collection{
+		Type:     geojson.FeatureCollection,
+		Features: fs,
+	}
+}
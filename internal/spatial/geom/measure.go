@@ -0,0 +1,35 @@
+package geom
+
+import (
+	"math"
+
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/constants"
+	"github.com/tomchavakis/turf-go/measurement"
+)
+
+const (
+	// EarthRadius is the mean radius of the Earth in meters.
+	EarthRadius = 6371008.8
+	// MetersPerDegree is the length of a degree of latitude in meters.
+	MetersPerDegree = math.Pi / 180 * EarthRadius
+)
+
+// Distance returns the great circle distance in meters between two positions.
+func Distance(a, b geometry.Point) float64 {
+	d, _ := measurement.PointDistance(a, b, constants.UnitMeters)
+	return d
+}
+
+// InRing reports whether a position is inside a ring. It casts a ray towards the east, counting
+// the positions on the west or south edges as inside.
+func InRing(p geometry.Point, r []geometry.Point) bool {
+	in := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		a, b := r[i], r[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) && p.Lng < (b.Lng-a.Lng)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			in = !in
+		}
+	}
+	return in
+}
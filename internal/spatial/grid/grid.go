@@ -0,0 +1,227 @@
+package grid
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// maxCells bounds the number of cells of a grid, before masking.
+const maxCells = 1000000
+
+// Kind is the shape of the cells of a grid.
+type Kind string
+
+const (
+	// Points places a point at every vertex of a square grid.
+	Points Kind = "point"
+	// Squares tiles the box with squares.
+	Squares Kind = "square"
+	// Hexagons tiles the box with flat-topped hexagons.
+	Hexagons Kind = "hex"
+	// Triangles tiles the box with squares split in two triangles along alternating diagonals.
+	Triangles Kind = "triangle"
+)
+
+// ParseKind returns the kind of grid with the given name.
+func ParseKind(s string) (Kind, error) {
+	switch k := Kind(strings.ToLower(s)); k {
+	case Points, Squares, Hexagons, Triangles:
+		return k, nil
+	default:
+		return "", fmt.Errorf("unsupported grid %q, expected one of point, square, hex or triangle", s)
+	}
+}
+
+// Options configures a grid.
+type Options struct {
+	Kind Kind
+	// BBox is the area covered by the grid. The cells are centred in it.
+	BBox geojson.BBOX
+	// CellSize is an angular distance in radians: the spacing of the points, the side of the
+	// squares and triangles, or the distance from the centre to the vertices of the hexagons.
+	CellSize float64
+	// Mask keeps only the points inside the polygons, or the cells intersecting them.
+	Mask [][][]geometry.Point
+}
+
+// Grid generates the cells of a grid one by one, so that large grids don't have to be held in
+// memory. Longitudes are scaled at the middle latitude of the box so that cells are square there.
+type Grid struct {
+	kind       Kind
+	x0, y0     float64 // the west and south edges of the cells
+	dx, dy     float64 // the size of the cells in degrees
+	cols, rows int
+	mask       *mask
+}
+
+// New checks the options and lays the grid out.
+func New(o Options) (*Grid, error) {
+	if _, err := ParseKind(string(o.Kind)); err != nil {
+		return nil, err
+	}
+	if o.CellSize <= 0 || math.IsNaN(o.CellSize) || math.IsInf(o.CellSize, 0) {
+		return nil, errors.New("cell size must be a positive number")
+	}
+
+	west, east := o.BBox.West, o.BBox.East
+	if west > east {
+		// the box crosses the antimeridian, the cells are shifted back when they are emitted
+		east += 360
+	}
+	south, north := o.BBox.South, o.BBox.North
+	width, height := east-west, north-south
+
+	g := &Grid{kind: o.Kind}
+	g.dy = o.CellSize * 180 / math.Pi
+	g.dx = g.dy / math.Max(math.Cos((south+north)/2*math.Pi/180), 1e-3)
+
+	var usedW, usedH float64
+	if o.Kind == Hexagons {
+		// flat-topped hexagons are 2 radii wide and sqrt(3) radii high, columns are 1.5 radii apart
+		// and odd columns are shifted up by half a hexagon
+		hexW, hexH := 2*g.dx, math.Sqrt(3)*g.dy
+		if width >= hexW {
+			g.cols = int((width-hexW)/(1.5*g.dx)) + 1
+		}
+		shift := 0.0
+		if g.cols > 1 {
+			shift = hexH / 2
+		}
+		if height >= hexH+shift {
+			g.rows = int((height - shift) / hexH)
+		}
+		usedW = hexW + float64(g.cols-1)*1.5*g.dx
+		usedH = float64(g.rows)*hexH + shift
+	} else {
+		g.cols = int(width / g.dx)
+		g.rows = int(height / g.dy)
+		usedW, usedH = float64(g.cols)*g.dx, float64(g.rows)*g.dy
+	}
+	if g.cols <= 0 || g.rows <= 0 {
+		g.cols, g.rows = 0, 0
+	}
+	cells := float64(g.cols+1) * float64(g.rows+1)
+	if o.Kind == Triangles {
+		cells *= 2
+	}
+	if cells > maxCells {
+		return nil, fmt.Errorf("the grid would have more than %d cells, the cell size must be larger", maxCells)
+	}
+	g.x0 = west + (width-usedW)/2
+	g.y0 = south + (height-usedH)/2
+
+	if len(o.Mask) > 0 {
+		m, err := newMask(o.Mask)
+		if err != nil {
+			return nil, err
+		}
+		g.mask = m
+	}
+
+	return g, nil
+}
+
+// Each calls fn with every cell of the grid, row by row from the south west corner, and stops at the
+// first error.
+func (g *Grid) Each(fn func(geometry.Geometry) error) error {
+	switch g.kind {
+	case Points:
+		return g.points(fn)
+	case Hexagons:
+		return g.hexagons(fn)
+	default:
+		return g.squares(fn)
+	}
+}
+
+func (g *Grid) points(fn func(geometry.Geometry) error) error {
+	if g.cols == 0 {
+		return nil
+	}
+	for j := 0; j <= g.rows; j++ {
+		for i := 0; i <= g.cols; i++ {
+			p := geometry.Point{Lat: g.y0 + float64(j)*g.dy, Lng: g.x0 + float64(i)*g.dx}
+			if p.Lng > 180 {
+				p.Lng -= 360
+			}
+			if g.mask != nil && !g.mask.contains(p) {
+				continue
+			}
+			if err := fn(geom.PointGeometry(p)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (g *Grid) squares(fn func(geometry.Geometry) error) error {
+	for j := 0; j < g.rows; j++ {
+		for i := 0; i < g.cols; i++ {
+			x, y := g.x0+float64(i)*g.dx, g.y0+float64(j)*g.dy
+			sw := geometry.Point{Lat: y, Lng: x}
+			se := geometry.Point{Lat: y, Lng: x + g.dx}
+			ne := geometry.Point{Lat: y + g.dy, Lng: x + g.dx}
+			nw := geometry.Point{Lat: y + g.dy, Lng: x}
+
+			var rings [][]geometry.Point
+			switch {
+			case g.kind == Squares:
+				rings = [][]geometry.Point{{sw, se, ne, nw, sw}}
+			case (i+j)%2 == 0:
+				rings = [][]geometry.Point{{sw, se, nw, sw}, {se, ne, nw, se}}
+			default:
+				rings = [][]geometry.Point{{sw, se, ne, sw}, {sw, ne, nw, sw}}
+			}
+			for _, r := range rings {
+				if err := g.emit(r, x+g.dx/2, fn); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func (g *Grid) hexagons(fn func(geometry.Geometry) error) error {
+	hexH := math.Sqrt(3) * g.dy
+	for j := 0; j < g.rows; j++ {
+		for i := 0; i < g.cols; i++ {
+			cx := g.x0 + g.dx + float64(i)*1.5*g.dx
+			cy := g.y0 + hexH/2 + float64(j)*hexH
+			if i%2 == 1 {
+				cy += hexH / 2
+			}
+			r := make([]geometry.Point, 0, 7)
+			for k := 0; k < 6; k++ {
+				a := float64(k) * math.Pi / 3
+				r = append(r, geometry.Point{Lat: cy + g.dy*math.Sin(a), Lng: cx + g.dx*math.Cos(a)})
+			}
+			r = append(r, r[0])
+			if err := g.emit(r, cx, fn); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// emit shifts the cell back into range when its centre is east of the antimeridian, masks it and
+// passes it to fn.
+func (g *Grid) emit(r []geometry.Point, cx float64, fn func(geometry.Geometry) error) error {
+	if cx > 180 {
+		for k := range r {
+			r[k].Lng -= 360
+		}
+	}
+	if g.mask != nil && !g.mask.intersects(r) {
+		return nil
+	}
+	return fn(geom.PolygonGeometry([][]geometry.Point{r}))
+}
@@ -0,0 +1,124 @@
+package grid
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+const degree = math.Pi / 180
+
+func square(west, south, east, north float64) [][]geometry.Point {
+	return [][]geometry.Point{{
+		{Lat: south, Lng: west}, {Lat: south, Lng: east}, {Lat: north, Lng: east}, {Lat: north, Lng: west}, {Lat: south, Lng: west},
+	}}
+}
+
+func collect(t *testing.T, o Options) []geometry.Geometry {
+	t.Helper()
+	g, err := New(o)
+	assert.NoError(t, err)
+	var gs []geometry.Geometry
+	assert.NoError(t, g.Each(func(c geometry.Geometry) error {
+		gs = append(gs, c)
+		return nil
+	}))
+	return gs
+}
+
+func TestGrid(t *testing.T) {
+	box := *geojson.NewBBox(-0.5, -0.5, 0.5, 0.5)
+
+	tests := map[string]struct {
+		o     Options
+		cells int
+		typ   geojson.OBjectType
+	}{
+		"points":       {o: Options{Kind: Points, BBox: box, CellSize: 0.2499 * degree}, cells: 25, typ: geojson.Point},
+		"squares":      {o: Options{Kind: Squares, BBox: box, CellSize: 0.2499 * degree}, cells: 16, typ: geojson.Polygon},
+		"triangles":    {o: Options{Kind: Triangles, BBox: box, CellSize: 0.2499 * degree}, cells: 32, typ: geojson.Polygon},
+		"hexagons":     {o: Options{Kind: Hexagons, BBox: box, CellSize: 0.1 * degree}, cells: 30, typ: geojson.Polygon},
+		"larger cells": {o: Options{Kind: Squares, BBox: box, CellSize: 2 * degree}, cells: 0},
+		"masked squares": {
+			o:     Options{Kind: Squares, BBox: box, CellSize: 0.2499 * degree, Mask: [][][]geometry.Point{square(0.1, 0.1, 0.2, 0.2)}},
+			cells: 1, typ: geojson.Polygon,
+		},
+		"masked points": {
+			o:     Options{Kind: Points, BBox: box, CellSize: 0.2499 * degree, Mask: [][][]geometry.Point{square(-0.3, -0.3, 0.3, 0.3)}},
+			cells: 9, typ: geojson.Point,
+		},
+		"masked with a hole": {
+			o: Options{Kind: Points, BBox: box, CellSize: 0.2499 * degree, Mask: [][][]geometry.Point{{
+				square(-0.3, -0.3, 0.3, 0.3)[0], square(-0.1, -0.1, 0.1, 0.1)[0],
+			}}},
+			cells: 8, typ: geojson.Point,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			gs := collect(t, tt.o)
+			assert.Len(t, gs, tt.cells)
+			for _, g := range gs {
+				assert.Equal(t, tt.typ, g.GeoJSONType)
+			}
+		})
+	}
+}
+
+func TestGridCellSize(t *testing.T) {
+	gs := collect(t, Options{Kind: Squares, BBox: *geojson.NewBBox(10, 59.5, 12, 60.5), CellSize: 0.25 * degree})
+	assert.NotEmpty(t, gs)
+
+	polys, err := geom.Polygons(gs[0])
+	assert.NoError(t, err)
+	r := polys[0][0]
+	// longitudes are scaled at 60 degrees north so that the cells are about square
+	assert.InDelta(t, 0.25, r[2].Lat-r[0].Lat, 1e-9)
+	assert.InDelta(t, 0.5, r[1].Lng-r[0].Lng, 1e-9)
+}
+
+func TestGridAntimeridian(t *testing.T) {
+	gs := collect(t, Options{Kind: Squares, BBox: *geojson.NewBBox(179.5, -0.5, -179.5, 0.5), CellSize: 0.2499 * degree})
+	assert.Len(t, gs, 16)
+
+	for _, g := range gs {
+		polys, err := geom.Polygons(g)
+		assert.NoError(t, err)
+		for _, p := range polys[0][0] {
+			assert.True(t, p.Lng >= -180.5 && p.Lng <= 180.5, "longitude %v", p.Lng)
+		}
+	}
+}
+
+func TestGridErrors(t *testing.T) {
+	box := *geojson.NewBBox(-10, -10, 10, 10)
+
+	_, err := New(Options{Kind: "circle", BBox: box, CellSize: degree})
+	assert.EqualError(t, err, `unsupported grid "circle", expected one of point, square, hex or triangle`)
+
+	_, err = New(Options{Kind: Squares, BBox: box, CellSize: 0})
+	assert.EqualError(t, err, "cell size must be a positive number")
+
+	_, err = New(Options{Kind: Squares, BBox: box, CellSize: 0.001 * degree})
+	assert.EqualError(t, err, "the grid would have more than 1000000 cells, the cell size must be larger")
+
+	g, err := New(Options{Kind: Squares, BBox: box, CellSize: degree})
+	assert.NoError(t, err)
+	n := 0
+	stop := errors.New("stop")
+	err = g.Each(func(geometry.Geometry) error {
+		n++
+		if n == 3 {
+			return stop
+		}
+		return nil
+	})
+	assert.Equal(t, stop, err)
+	assert.Equal(t, 3, n)
+}
@@ -0,0 +1,138 @@
+package grid
+
+import (
+	"errors"
+	"math"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// mask tests the cells against polygons on the plane of longitude and latitude.
+type mask struct {
+	polygons [][]ring
+}
+
+type ring struct {
+	points                   []geometry.Point
+	west, south, east, north float64
+}
+
+func newMask(polys [][][]geometry.Point) (*mask, error) {
+	m := &mask{}
+	for _, rings := range polys {
+		var rs []ring
+		for _, ps := range rings {
+			if len(ps) < 3 {
+				return nil, errors.New("mask rings must have at least three positions")
+			}
+			r := ring{points: ps, west: math.Inf(1), south: math.Inf(1), east: math.Inf(-1), north: math.Inf(-1)}
+			for _, p := range ps {
+				r.west, r.east = math.Min(r.west, p.Lng), math.Max(r.east, p.Lng)
+				r.south, r.north = math.Min(r.south, p.Lat), math.Max(r.north, p.Lat)
+			}
+			rs = append(rs, r)
+		}
+		if len(rs) > 0 {
+			m.polygons = append(m.polygons, rs)
+		}
+	}
+	return m, nil
+}
+
+// contains reports whether p is inside a polygon of the mask and outside its holes.
+func (m *mask) contains(p geometry.Point) bool {
+	for _, rs := range m.polygons {
+		if !rs[0].contains(p) {
+			continue
+		}
+		inHole := false
+		for _, h := range rs[1:] {
+			if h.contains(p) {
+				inHole = true
+				break
+			}
+		}
+		if !inHole {
+			return true
+		}
+	}
+	return false
+}
+
+// intersects reports whether the convex cell, given as a closed ring, shares some area or boundary
+// with the mask: a vertex of the cell is in the mask, a vertex of the mask is in the cell, or their
+// edges cross.
+func (m *mask) intersects(cell []geometry.Point) bool {
+	west, south, east, north := math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range cell {
+		west, east = math.Min(west, p.Lng), math.Max(east, p.Lng)
+		south, north = math.Min(south, p.Lat), math.Max(north, p.Lat)
+	}
+
+	for _, p := range cell[:len(cell)-1] {
+		if m.contains(p) {
+			return true
+		}
+	}
+	for _, rs := range m.polygons {
+		for _, r := range rs {
+			if r.west > east || r.east < west || r.south > north || r.north < south {
+				continue
+			}
+			for i, p := range r.points {
+				if inConvex(p, cell) {
+					return true
+				}
+				q := r.points[(i+1)%len(r.points)]
+				for k := 0; k+1 < len(cell); k++ {
+					if cross(p, q, cell[k], cell[k+1]) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+func (r ring) contains(p geometry.Point) bool {
+	if p.Lng < r.west || p.Lng > r.east || p.Lat < r.south || p.Lat > r.north {
+		return false
+	}
+	in := false
+	ps := r.points
+	for i, j := 0, len(ps)-1; i < len(ps); j, i = i, i+1 {
+		a, b := ps[i], ps[j]
+		if (a.Lat > p.Lat) != (b.Lat > p.Lat) && p.Lng < (b.Lng-a.Lng)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+			in = !in
+		}
+	}
+	return in
+}
+
+// inConvex reports whether p is inside or on the convex closed ring, whatever its winding.
+func inConvex(p geometry.Point, r []geometry.Point) bool {
+	sign := 0.0
+	for k := 0; k+1 < len(r); k++ {
+		o := orientation(r[k], r[k+1], p)
+		if o == 0 {
+			continue
+		}
+		if sign != 0 && (o > 0) != (sign > 0) {
+			return false
+		}
+		sign = o
+	}
+	return true
+}
+
+// cross reports whether the segments ab and cd properly cross.
+func cross(a, b, c, d geometry.Point) bool {
+	d1, d2 := orientation(c, d, a), orientation(c, d, b)
+	d3, d4 := orientation(a, b, c), orientation(a, b, d)
+	return ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0))
+}
+
+func orientation(a, b, c geometry.Point) float64 {
+	return (b.Lng-a.Lng)*(c.Lat-a.Lat) - (b.Lat-a.Lat)*(c.Lng-a.Lng)
+}
@@ -0,0 +1,120 @@
+package hull
+
+import (
+	"math"
+	"sort"
+)
+
+type vec struct {
+	x, y float64
+}
+
+type triangle struct {
+	a, b, c int // vertex indices in counterclockwise order
+	cx, cy  float64
+	r2      float64 // squared radius of the circumcircle
+}
+
+type edge struct {
+	a, b int
+}
+
+func newTriangle(ps []vec, a, b, c int) triangle {
+	if orientation(ps[a], ps[b], ps[c]) < 0 {
+		b, c = c, b
+	}
+	pa, pb, pc := ps[a], ps[b], ps[c]
+	bx, by := pb.x-pa.x, pb.y-pa.y
+	cx, cy := pc.x-pa.x, pc.y-pa.y
+	d := 2 * (bx*cy - by*cx)
+	t := triangle{a: a, b: b, c: c}
+	if d == 0 {
+		t.r2 = math.Inf(1)
+		return t
+	}
+	ux := (cy*(bx*bx+by*by) - by*(cx*cx+cy*cy)) / d
+	uy := (bx*(cx*cx+cy*cy) - cx*(bx*bx+by*by)) / d
+	t.cx, t.cy = pa.x+ux, pa.y+uy
+	t.r2 = ux*ux + uy*uy
+	return t
+}
+
+// delaunay triangulates the distinct points with the Bowyer-Watson algorithm. The points are
+// inserted from left to right, so triangles whose circumcircle is entirely on the left of the
+// current point can't change anymore and are moved out of the active list.
+func delaunay(points []vec) []triangle {
+	n := len(points)
+	order := make([]int, n)
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return points[order[i]].x < points[order[j]].x })
+
+	minX, minY := math.Inf(1), math.Inf(1)
+	maxX, maxY := math.Inf(-1), math.Inf(-1)
+	for _, p := range points {
+		minX, minY = math.Min(minX, p.x), math.Min(minY, p.y)
+		maxX, maxY = math.Max(maxX, p.x), math.Max(maxY, p.y)
+	}
+	d := math.Max(maxX-minX, maxY-minY)
+	if d == 0 {
+		d = 1
+	}
+	mx, my := (minX+maxX)/2, (minY+maxY)/2
+
+	// the super triangle containing every point takes the last three indices
+	ps := append(append([]vec{}, points...),
+		vec{mx - 100*d, my - 100*d}, vec{mx, my + 100*d}, vec{mx + 100*d, my - 100*d})
+	active := []triangle{newTriangle(ps, n, n+1, n+2)}
+	var done []triangle
+
+	for _, i := range order {
+		p := ps[i]
+		var edges []edge
+		keep := active[:0]
+		for _, t := range active {
+			dx := p.x - t.cx
+			if dx > 0 && dx*dx > t.r2 {
+				done = append(done, t)
+				continue
+			}
+			if dy := p.y - t.cy; dx*dx+dy*dy <= t.r2 {
+				edges = append(edges, edge{t.a, t.b}, edge{t.b, t.c}, edge{t.c, t.a})
+				continue
+			}
+			keep = append(keep, t)
+		}
+		active = keep
+
+		// the edges shared by two removed triangles are inside the cavity
+		count := make(map[edge]int, len(edges))
+		for _, e := range edges {
+			count[key(e)]++
+		}
+		for _, e := range edges {
+			if count[key(e)] == 1 {
+				active = append(active, newTriangle(ps, e.a, e.b, i))
+			}
+		}
+	}
+
+	res := make([]triangle, 0, len(done)+len(active))
+	for _, t := range append(done, active...) {
+		if t.a < n && t.b < n && t.c < n && orientation(ps[t.a], ps[t.b], ps[t.c]) > 0 {
+			res = append(res, t)
+		}
+	}
+	return res
+}
+
+// key returns the edge with its vertices sorted, so that both directions map to the same key.
+func key(e edge) edge {
+	if e.a > e.b {
+		return edge{e.b, e.a}
+	}
+	return e
+}
+
+func orientation(a, b, c vec) float64 {
+	return (b.x-a.x)*(c.y-a.y) - (b.y-a.y)*(c.x-a.x)
+}
@@ -0,0 +1,251 @@
+package hull
+
+import (
+	"container/heap"
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// The hulls are computed on an equirectangular plane centered on the mean latitude of the points,
+// where the distances are close to the angular distances on the sphere.
+
+// Convex returns the convex hull of the points as a closed counterclockwise ring. Fewer than three
+// distinct points, or points on a single line, return the distinct points forming the hull
+// without closing them.
+func Convex(points []geometry.Point) ([]geometry.Point, error) {
+	ps, vs, err := prepare(points)
+	if err != nil {
+		return nil, err
+	}
+
+	idx := convex(vs)
+	res := make([]geometry.Point, 0, len(idx)+1)
+	for _, i := range idx {
+		res = append(res, ps[i])
+	}
+	if len(res) > 2 {
+		res = append(res, res[0])
+	}
+	return res, nil
+}
+
+// Concave returns a concave hull of the points as a closed counterclockwise ring, using the
+// chi-shape algorithm: starting from the Delaunay triangulation of the points, the boundary
+// triangles are removed from the longest boundary edge down to maxEdge radians, as long as the
+// boundary stays a simple polygon. Degenerate inputs return the same result as Convex.
+func Concave(points []geometry.Point, maxEdge float64) ([]geometry.Point, error) {
+	if maxEdge <= 0 || math.IsNaN(maxEdge) || math.IsInf(maxEdge, 0) {
+		return nil, errors.New("max edge must be a positive number")
+	}
+	ps, vs, err := prepare(points)
+	if err != nil {
+		return nil, err
+	}
+
+	ring, ok := chiShape(vs, func([]triangle) float64 { return maxEdge })
+	if !ok {
+		return Convex(points)
+	}
+	return closeRing(ps, ring), nil
+}
+
+// ConcaveByConcavity returns a concave hull of the points where the maximum edge length is
+// relative to the edges of the triangulation: 0 gives the most concave hull and 1 the convex hull.
+func ConcaveByConcavity(points []geometry.Point, concavity float64) ([]geometry.Point, error) {
+	if concavity < 0 || concavity > 1 || math.IsNaN(concavity) {
+		return nil, errors.New("concavity must be between 0 and 1")
+	}
+	ps, vs, err := prepare(points)
+	if err != nil {
+		return nil, err
+	}
+
+	ring, ok := chiShape(vs, func(ts []triangle) float64 {
+		lo, hi := math.Inf(1), 0.0
+		for _, t := range ts {
+			for _, e := range []edge{{t.a, t.b}, {t.b, t.c}, {t.c, t.a}} {
+				l := length(vs, e)
+				lo, hi = math.Min(lo, l), math.Max(hi, l)
+			}
+		}
+		return lo + concavity*(hi-lo)
+	})
+	if !ok {
+		return Convex(points)
+	}
+	return closeRing(ps, ring), nil
+}
+
+// prepare removes the duplicate points and projects them.
+func prepare(points []geometry.Point) ([]geometry.Point, []vec, error) {
+	if len(points) == 0 {
+		return nil, nil, errors.New("points can't be empty")
+	}
+
+	seen := make(map[geometry.Point]bool, len(points))
+	ps := make([]geometry.Point, 0, len(points))
+	var sum float64
+	for _, p := range points {
+		if math.IsNaN(p.Lat) || math.IsNaN(p.Lng) || math.IsInf(p.Lat, 0) || math.IsInf(p.Lng, 0) {
+			return nil, nil, errors.New("points must have finite coordinates")
+		}
+		if seen[p] {
+			continue
+		}
+		seen[p] = true
+		ps = append(ps, p)
+		sum += p.Lat
+	}
+
+	k := math.Cos(sum / float64(len(ps)) * math.Pi / 180)
+	vs := make([]vec, 0, len(ps))
+	for _, p := range ps {
+		vs = append(vs, vec{x: p.Lng * math.Pi / 180 * k, y: p.Lat * math.Pi / 180})
+	}
+	return ps, vs, nil
+}
+
+// convex returns the indices of the convex hull vertices in counterclockwise order with Andrew's
+// monotone chain algorithm.
+func convex(vs []vec) []int {
+	idx := make([]int, len(vs))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool {
+		a, b := vs[idx[i]], vs[idx[j]]
+		return a.x < b.x || (a.x == b.x && a.y < b.y)
+	})
+	if len(idx) < 3 {
+		return idx
+	}
+
+	h := make([]int, 0, 2*len(idx))
+	for _, i := range idx {
+		for len(h) >= 2 && orientation(vs[h[len(h)-2]], vs[h[len(h)-1]], vs[i]) <= 0 {
+			h = h[:len(h)-1]
+		}
+		h = append(h, i)
+	}
+	lower := len(h) + 1
+	for j := len(idx) - 2; j >= 0; j-- {
+		i := idx[j]
+		for len(h) >= lower && orientation(vs[h[len(h)-2]], vs[h[len(h)-1]], vs[i]) <= 0 {
+			h = h[:len(h)-1]
+		}
+		h = append(h, i)
+	}
+	return h[:len(h)-1]
+}
+
+type boundaryEdge struct {
+	edge
+	length float64
+	t      int // the triangle inside the boundary
+}
+
+type edgeQueue []boundaryEdge
+
+func (q edgeQueue) Len() int            { return len(q) }
+func (q edgeQueue) Less(i, j int) bool  { return q[i].length > q[j].length }
+func (q edgeQueue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *edgeQueue) Push(x interface{}) { *q = append(*q, x.(boundaryEdge)) }
+func (q *edgeQueue) Pop() interface{} {
+	old := *q
+	e := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return e
+}
+
+// chiShape erodes the triangulation of the points and returns the vertices of its boundary in
+// counterclockwise order, or false when the points can't be triangulated.
+func chiShape(vs []vec, threshold func([]triangle) float64) ([]int, bool) {
+	if len(vs) < 3 {
+		return nil, false
+	}
+	ts := delaunay(vs)
+	if len(ts) == 0 {
+		return nil, false
+	}
+	maxEdge := threshold(ts)
+
+	// every directed edge belongs to the triangle on its left
+	owner := make(map[edge]int, 3*len(ts))
+	for i, t := range ts {
+		owner[edge{t.a, t.b}], owner[edge{t.b, t.c}], owner[edge{t.c, t.a}] = i, i, i
+	}
+
+	// the boundary edges have no triangle on their right
+	next := make(map[int]int)
+	onBoundary := make(map[int]bool)
+	q := &edgeQueue{}
+	for e, t := range owner {
+		if _, ok := owner[edge{e.b, e.a}]; !ok {
+			next[e.a] = e.b
+			onBoundary[e.a] = true
+			heap.Push(q, boundaryEdge{edge: e, length: length(vs, e), t: t})
+		}
+	}
+
+	removed := make([]bool, len(ts))
+	remaining := len(ts)
+	for q.Len() > 0 {
+		e := heap.Pop(q).(boundaryEdge)
+		if e.length <= maxEdge {
+			break
+		}
+		t := ts[e.t]
+		c := third(t, e.edge)
+		// removing the triangle would split the polygon when its third vertex is on the boundary
+		if removed[e.t] || onBoundary[c] || remaining == 1 {
+			continue
+		}
+		removed[e.t] = true
+		remaining--
+		onBoundary[c] = true
+		next[e.a], next[c] = c, e.b
+		for _, ne := range []edge{{e.a, c}, {c, e.b}} {
+			heap.Push(q, boundaryEdge{edge: ne, length: length(vs, ne), t: owner[ne]})
+		}
+	}
+
+	start := -1
+	for v := range next {
+		if start < 0 || v < start {
+			start = v
+		}
+	}
+	ring := []int{start}
+	for v := next[start]; v != start; v = next[v] {
+		ring = append(ring, v)
+		if len(ring) > len(vs) {
+			return nil, false
+		}
+	}
+	return ring, true
+}
+
+// third returns the vertex of the triangle that isn't on the edge.
+func third(t triangle, e edge) int {
+	for _, v := range []int{t.a, t.b, t.c} {
+		if v != e.a && v != e.b {
+			return v
+		}
+	}
+	return -1
+}
+
+func length(vs []vec, e edge) float64 {
+	return math.Hypot(vs[e.b].x-vs[e.a].x, vs[e.b].y-vs[e.a].y)
+}
+
+func closeRing(ps []geometry.Point, ring []int) []geometry.Point {
+	res := make([]geometry.Point, 0, len(ring)+1)
+	for _, i := range ring {
+		res = append(res, ps[i])
+	}
+	return append(res, res[0])
+}
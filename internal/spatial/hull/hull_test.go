@@ -0,0 +1,106 @@
+package hull
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// cShape returns a grid of points shaped like the letter C, open to the east.
+func cShape() []geometry.Point {
+	var ps []geometry.Point
+	for x := 0; x <= 10; x++ {
+		for y := 0; y <= 10; y++ {
+			if x > 3 && y > 3 && y < 7 {
+				continue
+			}
+			ps = append(ps, geometry.Point{Lat: float64(y) / 10, Lng: float64(x) / 10})
+		}
+	}
+	return ps
+}
+
+func area(ring []geometry.Point) float64 {
+	var a float64
+	for i := 0; i+1 < len(ring); i++ {
+		a += ring[i].Lng*ring[i+1].Lat - ring[i+1].Lng*ring[i].Lat
+	}
+	return a / 2
+}
+
+func TestConvex(t *testing.T) {
+	points := []geometry.Point{
+		{Lat: 0, Lng: 0}, {Lat: 0, Lng: 2}, {Lat: 1, Lng: 1}, {Lat: 2, Lng: 2},
+		{Lat: 2, Lng: 0}, {Lat: 0.5, Lng: 1.5}, {Lat: 0, Lng: 0},
+	}
+	h, err := Convex(points)
+	assert.NoError(t, err)
+	assert.Equal(t, []geometry.Point{
+		{Lat: 0, Lng: 0}, {Lat: 0, Lng: 2}, {Lat: 2, Lng: 2}, {Lat: 2, Lng: 0}, {Lat: 0, Lng: 0},
+	}, h)
+
+	h, err = Convex([]geometry.Point{{Lat: 0, Lng: 0}, {Lat: 1, Lng: 1}, {Lat: 2, Lng: 2}})
+	assert.NoError(t, err)
+	assert.Equal(t, []geometry.Point{{Lat: 0, Lng: 0}, {Lat: 2, Lng: 2}}, h)
+
+	_, err = Convex(nil)
+	assert.EqualError(t, err, "points can't be empty")
+}
+
+func TestConcave(t *testing.T) {
+	ps := cShape()
+	convexHull, err := Convex(ps)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, area(convexHull), 1e-9)
+
+	// edges longer than the grid diagonal are eroded, carving the opening of the C except for
+	// the two triangles at its inner corners
+	h, err := Concave(ps, 0.15*math.Pi/180)
+	assert.NoError(t, err)
+	assert.Equal(t, h[0], h[len(h)-1])
+	assert.InDelta(t, 1-0.7*0.4+2*0.005, area(h), 1e-9)
+
+	h, err = ConcaveByConcavity(ps, 1)
+	assert.NoError(t, err)
+	assert.InDelta(t, 1, area(h), 1e-9)
+
+	h, err = ConcaveByConcavity(ps, 0)
+	assert.NoError(t, err)
+	assert.Greater(t, area(h), 0.0)
+	assert.Less(t, area(h), 1.0)
+
+	// collinear points fall back to the convex hull
+	h, err = Concave([]geometry.Point{{Lat: 0, Lng: 0}, {Lat: 1, Lng: 1}, {Lat: 2, Lng: 2}}, 0.01)
+	assert.NoError(t, err)
+	assert.Len(t, h, 2)
+
+	_, err = Concave(ps, 0)
+	assert.EqualError(t, err, "max edge must be a positive number")
+
+	_, err = ConcaveByConcavity(ps, 2)
+	assert.EqualError(t, err, "concavity must be between 0 and 1")
+}
+
+func TestDelaunay(t *testing.T) {
+	r := rand.New(rand.NewSource(1))
+	vs := make([]vec, 200)
+	for i := range vs {
+		vs[i] = vec{x: r.Float64(), y: r.Float64()}
+	}
+
+	ts := delaunay(vs)
+	// a triangulation of n points with h of them on the hull has 2n - h - 2 triangles
+	assert.Len(t, ts, 2*len(vs)-len(convex(vs))-2)
+	for _, tr := range ts {
+		for i, v := range vs {
+			if i == tr.a || i == tr.b || i == tr.c {
+				continue
+			}
+			dx, dy := v.x-tr.cx, v.y-tr.cy
+			assert.Greater(t, dx*dx+dy*dy, tr.r2*(1-1e-9))
+		}
+	}
+}
@@ -0,0 +1,208 @@
+package isochrone
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// grid holds the least cost of reaching each cell, on the plane tangent at the origin in meters.
+// The cells are stored row by row from the south west one.
+type grid struct {
+	x0, y0       float64
+	cell, buffer float64
+	w, h         int
+	costs        []float64
+}
+
+// newGrid returns the grid covering an area and a margin, its cells not reached yet.
+func newGrid(lo, hi [2]float64, cell, buffer float64) *grid {
+	margin := buffer + cell
+	g := &grid{
+		x0:     lo[0] - margin,
+		y0:     lo[1] - margin,
+		cell:   cell,
+		buffer: buffer,
+		w:      int(math.Ceil((hi[0]-lo[0]+2*margin)/cell)) + 1,
+		h:      int(math.Ceil((hi[1]-lo[1]+2*margin)/cell)) + 1,
+	}
+	g.costs = make([]float64, g.w*g.h)
+	for i := range g.costs {
+		g.costs[i] = math.Inf(1)
+	}
+	return g
+}
+
+// mark lowers the cost of the cells whose centre is within the buffer of a position.
+func (g *grid) mark(x, y, c float64) {
+	i0, i1 := g.index(x-g.buffer, g.x0, g.w), g.index(x+g.buffer, g.x0, g.w)
+	j0, j1 := g.index(y-g.buffer, g.y0, g.h), g.index(y+g.buffer, g.y0, g.h)
+	for j := j0; j <= j1; j++ {
+		for i := i0; i <= i1; i++ {
+			cx, cy := g.x0+(float64(i)+0.5)*g.cell, g.y0+(float64(j)+0.5)*g.cell
+			if math.Hypot(cx-x, cy-y) > g.buffer {
+				continue
+			}
+			if k := j*g.w + i; c < g.costs[k] {
+				g.costs[k] = c
+			}
+		}
+	}
+}
+
+func (g *grid) index(v, v0 float64, n int) int {
+	i := int(math.Floor((v - v0) / g.cell))
+	if i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
+
+// draw marks the positions of a piece every half cell, their cost growing along it from the cost
+// of its start to the one of its end.
+func (g *grid) draw(p piece, project func(geometry.Point) (float64, float64)) {
+	xs, ys := make([]float64, len(p.points)), make([]float64, len(p.points))
+	total := 0.0
+	for i, q := range p.points {
+		xs[i], ys[i] = project(q)
+		if i > 0 {
+			total += math.Hypot(xs[i]-xs[i-1], ys[i]-ys[i-1])
+		}
+	}
+	cost := func(s float64) float64 {
+		if total == 0 {
+			return p.cost
+		}
+		return p.cost + (p.end-p.cost)*s/total
+	}
+
+	g.mark(xs[0], ys[0], p.cost)
+	s := 0.0
+	for i := 1; i < len(xs); i++ {
+		l := math.Hypot(xs[i]-xs[i-1], ys[i]-ys[i-1])
+		steps := int(math.Max(1, math.Ceil(l/(g.cell/2))))
+		for k := 1; k <= steps; k++ {
+			t := float64(k) / float64(steps)
+			g.mark(xs[i-1]+t*(xs[i]-xs[i-1]), ys[i-1]+t*(ys[i]-ys[i-1]), cost(s+t*l))
+		}
+		s += l
+	}
+}
+
+// outline returns the outer rings of the cells reached for at most a cost, counterclockwise and
+// closed, the largest first. The cells touching at a corner only are outlined apart.
+func (g *grid) outline(v float64) [][][2]float64 {
+	in := func(i, j int) bool {
+		return i >= 0 && j >= 0 && i < g.w && j < g.h && g.costs[j*g.w+i] <= v
+	}
+	vertex := func(i, j int) int { return j*(g.w+1) + i }
+
+	// the sides of the cells reached facing cells that are not, the reached cell on their left
+	type side struct{ from, to int }
+	var sides []side
+	out := map[int][]int{}
+	add := func(a, b int) {
+		out[a] = append(out[a], len(sides))
+		sides = append(sides, side{from: a, to: b})
+	}
+	for j := 0; j < g.h; j++ {
+		for i := 0; i < g.w; i++ {
+			if !in(i, j) {
+				continue
+			}
+			if !in(i, j-1) {
+				add(vertex(i, j), vertex(i+1, j))
+			}
+			if !in(i+1, j) {
+				add(vertex(i+1, j), vertex(i+1, j+1))
+			}
+			if !in(i, j+1) {
+				add(vertex(i+1, j+1), vertex(i, j+1))
+			}
+			if !in(i-1, j) {
+				add(vertex(i, j+1), vertex(i, j))
+			}
+		}
+	}
+
+	point := func(v int) [2]int { return [2]int{v % (g.w + 1), v / (g.w + 1)} }
+	direction := func(s side) [2]int {
+		a, b := point(s.from), point(s.to)
+		return [2]int{b[0] - a[0], b[1] - a[1]}
+	}
+	// turn ranks the turns from a side to the next one: left first, then straight on, then right
+	turn := func(a, b side) int {
+		d, e := direction(a), direction(b)
+		switch cross := d[0]*e[1] - d[1]*e[0]; {
+		case cross > 0:
+			return 0
+		case cross == 0:
+			return 1
+		}
+		return 2
+	}
+
+	var rings [][][2]int
+	used := make([]bool, len(sides))
+	for start := range sides {
+		if used[start] {
+			continue
+		}
+		var ring [][2]int
+		cur := start
+		for {
+			used[cur] = true
+			ring = append(ring, point(sides[cur].from))
+			next := -1
+			for _, k := range out[sides[cur].to] {
+				if (!used[k] || k == start) && (next < 0 || turn(sides[cur], sides[k]) < turn(sides[cur], sides[next])) {
+					next = k
+				}
+			}
+			if next < 0 || next == start {
+				break
+			}
+			cur = next
+		}
+		if ring = straighten(ring); area(ring) > 0 {
+			rings = append(rings, ring)
+		}
+	}
+	sort.SliceStable(rings, func(i, j int) bool { return area(rings[i]) > area(rings[j]) })
+
+	res := make([][][2]float64, 0, len(rings))
+	for _, ring := range rings {
+		ps := make([][2]float64, 0, len(ring)+1)
+		for _, q := range ring {
+			ps = append(ps, [2]float64{g.x0 + float64(q[0])*g.cell, g.y0 + float64(q[1])*g.cell})
+		}
+		res = append(res, append(ps, ps[0]))
+	}
+	return res
+}
+
+// straighten removes the vertices of a ring lying on a straight line between their neighbours.
+func straighten(ring [][2]int) [][2]int {
+	res := make([][2]int, 0, len(ring))
+	for i, q := range ring {
+		a, b := ring[(i+len(ring)-1)%len(ring)], ring[(i+1)%len(ring)]
+		if (q[0]-a[0])*(b[1]-q[1])-(q[1]-a[1])*(b[0]-q[0]) != 0 {
+			res = append(res, q)
+		}
+	}
+	return res
+}
+
+// area returns twice the signed area of a ring, positive when it's counterclockwise.
+func area(ring [][2]int) int {
+	s := 0
+	for i, a := range ring {
+		b := ring[(i+1)%len(ring)]
+		s += a[0]*b[1] - b[0]*a[1]
+	}
+	return s
+}
@@ -0,0 +1,150 @@
+// Package isochrone computes the areas reachable over a road network within travel times or
+// distances.
+//
+// The roads reached from the origin are drawn on a grid around it, each cell holding the least
+// cost of the roads passing within a buffer of its centre. The contour of a cost is the outline of
+// the cells reached for that cost, so the contours of increasing costs nest in each other. The holes
+// of the contours are filled.
+package isochrone
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geo-api/internal/spatial/routing"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+const (
+	// maxContours bounds the number of contours of a request.
+	maxContours = 10
+	// maxSeconds bounds the travel time of the isochrones.
+	maxSeconds = 2 * 60 * 60
+	// maxMeters bounds the travel distance of the isodistances.
+	maxMeters = 100000
+	// gridCells is the number of cells across the widest side of the area reached.
+	gridCells = 256
+	// minCell is the smallest side of the cells in meters.
+	minCell = 5
+	// bufferCells is the distance in cells within which the cells are reached from a road.
+	bufferCells = 2
+)
+
+// Contour is the area reached within a travel time in seconds or distance in meters, as polygons
+// without holes.
+type Contour struct {
+	Value    float64
+	Polygons [][][]geometry.Point
+}
+
+// Isochrones returns the areas reached from an origin within travel times in seconds, the
+// smallest first.
+func Isochrones(n *roads.Network, origin geometry.Point, profile *roads.Profile, seconds []float64) ([]Contour, error) {
+	if err := check(seconds, maxSeconds, "the isochrones can't be longer than 2 hours"); err != nil {
+		return nil, err
+	}
+	return contours(n, origin, profile, profile.Weight, seconds)
+}
+
+// Isodistances returns the areas reached from an origin within travel distances in meters over
+// the roads open to a profile, the smallest first.
+func Isodistances(n *roads.Network, origin geometry.Point, profile *roads.Profile, meters []float64) ([]Contour, error) {
+	if err := check(meters, maxMeters, "the isodistances can't be longer than 100 km"); err != nil {
+		return nil, err
+	}
+	w := func(e *roads.Edge, forward bool) float64 {
+		if !profile.Open(e, forward) {
+			return math.Inf(1)
+		}
+		return e.Length
+	}
+	return contours(n, origin, profile, w, meters)
+}
+
+func check(values []float64, limit float64, tooLong string) error {
+	if len(values) == 0 {
+		return errors.New("at least one contour is needed")
+	}
+	if len(values) > maxContours {
+		return fmt.Errorf("too many contours, the limit is %d", maxContours)
+	}
+	for _, v := range values {
+		if !(v > 0) || math.IsInf(v, 1) {
+			return errors.New("the contours must be positive numbers")
+		}
+		if v > limit {
+			return errors.New(tooLong)
+		}
+	}
+	return nil
+}
+
+// piece is the part of an edge travelled, with the costs at its ends.
+type piece struct {
+	points    []geometry.Point
+	cost, end float64
+}
+
+func contours(n *roads.Network, origin geometry.Point, profile *roads.Profile, w roads.Weight, values []float64) ([]Contour, error) {
+	from, err := routing.Snap(n, origin, profile, "the origin")
+	if err != nil {
+		return nil, err
+	}
+	vs := append([]float64(nil), values...)
+	sort.Float64s(vs)
+	limit := vs[len(vs)-1]
+
+	// the parts of the edges travelled, projected on the plane tangent at the origin
+	lng0, lat0 := from.Point.Lng, from.Point.Lat
+	cos := math.Cos(lat0 * math.Pi / 180)
+	project := func(p geometry.Point) (float64, float64) {
+		return (p.Lng - lng0) * cos * geom.MetersPerDegree, (p.Lat - lat0) * geom.MetersPerDegree
+	}
+	var pieces []piece
+	lo, hi := [2]float64{}, [2]float64{}
+	for _, r := range n.Reachable(from, w, limit) {
+		d, ok := r.Within(limit)
+		if !ok {
+			continue
+		}
+		ps := n.Edges[r.Edge].Slice(r.From, d)
+		end := r.End
+		if full := math.Abs(r.To - r.From); full > 0 {
+			end = r.Cost + (r.End-r.Cost)*math.Abs(d-r.From)/full
+		}
+		pieces = append(pieces, piece{points: ps, cost: r.Cost, end: end})
+		for _, p := range ps {
+			x, y := project(p)
+			lo[0], lo[1] = math.Min(lo[0], x), math.Min(lo[1], y)
+			hi[0], hi[1] = math.Max(hi[0], x), math.Max(hi[1], y)
+		}
+	}
+
+	cell := math.Max(minCell, math.Max(hi[0]-lo[0], hi[1]-lo[1])/gridCells)
+	g := newGrid(lo, hi, cell, bufferCells*cell)
+	g.mark(0, 0, 0)
+	for _, p := range pieces {
+		g.draw(p, project)
+	}
+
+	res := make([]Contour, 0, len(vs))
+	for i, v := range vs {
+		if i > 0 && v == vs[i-1] {
+			continue
+		}
+		c := Contour{Value: v}
+		for _, ring := range g.outline(v) {
+			ps := make([]geometry.Point, len(ring))
+			for k, q := range ring {
+				ps[k] = geometry.Point{Lng: lng0 + q[0]/(cos*geom.MetersPerDegree), Lat: lat0 + q[1]/geom.MetersPerDegree}
+			}
+			c.Polygons = append(c.Polygons, [][]geometry.Point{ps})
+		}
+		res = append(res, c)
+	}
+	return res, nil
+}
@@ -0,0 +1,137 @@
+package isochrone
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func pt(lng, lat float64) geometry.Point {
+	return geometry.Point{Lng: lng, Lat: lat}
+}
+
+func cells(w, h int, reached ...[2]int) *grid {
+	g := &grid{cell: 1, w: w, h: h, costs: make([]float64, w*h)}
+	for i := range g.costs {
+		g.costs[i] = math.Inf(1)
+	}
+	for _, c := range reached {
+		g.costs[c[1]*w+c[0]] = 1
+	}
+	return g
+}
+
+func TestOutline(t *testing.T) {
+	g := cells(3, 3, [2]int{1, 1})
+	assert.Equal(t, [][][2]float64{{{1, 1}, {2, 1}, {2, 2}, {1, 2}, {1, 1}}}, g.outline(1))
+	assert.Empty(t, g.outline(0.5))
+
+	// cells touching at a corner are outlined apart
+	g = cells(4, 4, [2]int{1, 1}, [2]int{2, 2})
+	assert.Len(t, g.outline(1), 2)
+
+	// holes are filled
+	var ring [][2]int
+	for i := 1; i <= 3; i++ {
+		for j := 1; j <= 3; j++ {
+			if i != 2 || j != 2 {
+				ring = append(ring, [2]int{i, j})
+			}
+		}
+	}
+	g = cells(5, 5, ring...)
+	assert.Equal(t, [][][2]float64{{{1, 1}, {4, 1}, {4, 4}, {1, 4}, {1, 1}}}, g.outline(1))
+
+	g = cells(5, 3, [2]int{1, 1}, [2]int{2, 1}, [2]int{3, 1})
+	g.costs[1*5+3] = 2
+	assert.Equal(t, [][][2]float64{{{1, 1}, {3, 1}, {3, 2}, {1, 2}, {1, 1}}}, g.outline(1))
+}
+
+// street is a street going east for 11 km with a side street going north from its middle.
+func street(t *testing.T) *roads.Network {
+	n, err := roads.Build([]roads.Way{
+		{ID: "main", Points: []geometry.Point{pt(0, 0), pt(0.05, 0), pt(0.1, 0)}, Tags: map[string]string{"highway": "residential"}},
+		{ID: "side", Points: []geometry.Point{pt(0.05, 0), pt(0.05, 0.05)}, Tags: map[string]string{"highway": "residential"}},
+	})
+	assert.NoError(t, err)
+	return n
+}
+
+// extent returns the bounding box of the polygons of a contour in meters from a position.
+func extent(c Contour, p geometry.Point) (west, east, south, north float64) {
+	west, east, south, north = math.Inf(1), math.Inf(-1), math.Inf(1), math.Inf(-1)
+	for _, poly := range c.Polygons {
+		for _, q := range poly[0] {
+			x, y := (q.Lng-p.Lng)*geom.MetersPerDegree, (q.Lat-p.Lat)*geom.MetersPerDegree
+			west, east = math.Min(west, x), math.Max(east, x)
+			south, north = math.Min(south, y), math.Max(north, y)
+		}
+	}
+	return west, east, south, north
+}
+
+func TestIsochrones(t *testing.T) {
+	n := street(t)
+	origin := pt(0.049, -0.0001)
+	cs, err := Isochrones(n, origin, roads.Foot, []float64{600, 300, 300})
+	assert.NoError(t, err)
+	assert.Len(t, cs, 2)
+	assert.Equal(t, 300.0, cs[0].Value)
+	assert.Equal(t, 600.0, cs[1].Value)
+
+	// walking 833 meters in 10 minutes, 111 meters of it to the side street
+	west, east, south, north := extent(cs[1], pt(0.049, 0))
+	cell := math.Max(minCell, 2*833.3/gridCells)
+	assert.Len(t, cs[1].Polygons, 1)
+	assert.InDelta(t, -833.3, west, 3*cell)
+	assert.InDelta(t, 833.3, east, 3*cell)
+	assert.InDelta(t, 0, south, 3*cell)
+	assert.InDelta(t, 722.2, north, 3*cell)
+	assert.Less(t, west, -833.3+cell)
+	assert.Less(t, 722.2-cell, north)
+
+	w5, e5, s5, n5 := extent(cs[0], pt(0.049, 0))
+	assert.True(t, w5 > west && e5 < east && s5 >= south && n5 < north)
+	assert.InDelta(t, -416.7, w5, 3*cell)
+}
+
+func TestIsodistances(t *testing.T) {
+	n := street(t)
+	cs, err := Isodistances(n, pt(0.05, 0), roads.Car, []float64{1000})
+	assert.NoError(t, err)
+	assert.Len(t, cs, 1)
+	west, east, _, north := extent(cs[0], pt(0.05, 0))
+	cell := 2000.0 / gridCells
+	assert.InDelta(t, -1000, west, 3*cell)
+	assert.InDelta(t, 1000, east, 3*cell)
+	assert.InDelta(t, 1000, north, 3*cell)
+}
+
+func TestContourErrors(t *testing.T) {
+	n := street(t)
+	tests := []struct {
+		name   string
+		values []float64
+		err    string
+	}{
+		{"none", nil, "at least one contour is needed"},
+		{"too many", make([]float64, 11), "too many contours, the limit is 10"},
+		{"negative", []float64{60, -60}, "the contours must be positive numbers"},
+		{"too long", []float64{3 * 60 * 60}, "the isochrones can't be longer than 2 hours"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Isochrones(n, pt(0.05, 0), roads.Car, tt.values)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+
+	_, err := Isodistances(n, pt(0.05, 0), roads.Car, []float64{200000})
+	assert.EqualError(t, err, "the isodistances can't be longer than 100 km")
+	_, err = Isochrones(n, pt(1, 1), roads.Car, []float64{60})
+	assert.EqualError(t, err, "the origin is farther than 1000 meters from the roads open to car")
+}
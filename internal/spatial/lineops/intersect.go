@@ -0,0 +1,132 @@
+package lineops
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// crossing is a position where a segment of a line meets another line, at the fraction t of the
+// segment starting at the vertex index.
+type crossing struct {
+	index int
+	t     float64
+	p     geometry.Point
+}
+
+// Intersect returns the positions where the lines cross or touch, in the order of the first line.
+// Overlapping segments meet at the ends of their overlap.
+func Intersect(a, b []geometry.Point) ([]geometry.Point, error) {
+	a, err := prepare(a)
+	if err != nil {
+		return nil, err
+	}
+	b, err = prepare(b)
+	if err != nil {
+		return nil, err
+	}
+
+	cs := crossings(a, b)
+	seen := make(map[geometry.Point]bool, len(cs))
+	res := []geometry.Point{}
+	for _, c := range cs {
+		if !seen[c.p] {
+			seen[c.p] = true
+			res = append(res, c.p)
+		}
+	}
+	return res, nil
+}
+
+type span struct {
+	line, index int
+	minLng      float64
+	maxLng      float64
+}
+
+// crossings returns where the segments of line a meet the segments of line b, sorted along a. The
+// segments are compared on the plane of longitude and latitude, sweeping them along the longitudes.
+func crossings(a, b []geometry.Point) []crossing {
+	var spans []span
+	for li, l := range [][]geometry.Point{a, b} {
+		for i := 0; i+1 < len(l); i++ {
+			spans = append(spans, span{
+				line: li, index: i,
+				minLng: math.Min(l[i].Lng, l[i+1].Lng), maxLng: math.Max(l[i].Lng, l[i+1].Lng),
+			})
+		}
+	}
+	sort.Slice(spans, func(i, j int) bool { return spans[i].minLng < spans[j].minLng })
+
+	var res []crossing
+	for n, s := range spans {
+		for _, o := range spans[n+1:] {
+			if o.minLng > s.maxLng {
+				break
+			}
+			if o.line == s.line {
+				continue
+			}
+			sa, oa := s, o
+			if sa.line == 1 {
+				sa, oa = oa, sa
+			}
+			res = append(res, meet(a[sa.index], a[sa.index+1], b[oa.index], b[oa.index+1], sa.index)...)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].index < res[j].index || (res[i].index == res[j].index && res[i].t < res[j].t)
+	})
+	return res
+}
+
+// meet returns where the segment p1 p2, starting at the vertex index of its line, meets the segment
+// q1 q2.
+func meet(p1, p2, q1, q2 geometry.Point, index int) []crossing {
+	r := geometry.Point{Lat: p2.Lat - p1.Lat, Lng: p2.Lng - p1.Lng}
+	s := geometry.Point{Lat: q2.Lat - q1.Lat, Lng: q2.Lng - q1.Lng}
+	qp := geometry.Point{Lat: q1.Lat - p1.Lat, Lng: q1.Lng - p1.Lng}
+	denom := cross(r, s)
+
+	if denom == 0 {
+		if cross(qp, r) != 0 {
+			return nil
+		}
+		// collinear segments meet at the ends of their overlap
+		rr := r.Lng*r.Lng + r.Lat*r.Lat
+		t0 := (qp.Lng*r.Lng + qp.Lat*r.Lat) / rr
+		t1 := t0 + (s.Lng*r.Lng+s.Lat*r.Lat)/rr
+		lo, hi := math.Max(0, math.Min(t0, t1)), math.Min(1, math.Max(t0, t1))
+		if lo > hi {
+			return nil
+		}
+		res := []crossing{{index: index, t: lo, p: interpolate(p1, p2, lo)}}
+		if hi > lo {
+			res = append(res, crossing{index: index, t: hi, p: interpolate(p1, p2, hi)})
+		}
+		return res
+	}
+
+	t := cross(qp, s) / denom
+	u := cross(qp, r) / denom
+	if t < 0 || t > 1 || u < 0 || u > 1 {
+		return nil
+	}
+	return []crossing{{index: index, t: t, p: interpolate(p1, p2, t)}}
+}
+
+func interpolate(a, b geometry.Point, t float64) geometry.Point {
+	switch t {
+	case 0:
+		return a
+	case 1:
+		return b
+	}
+	return geometry.Point{Lat: a.Lat + t*(b.Lat-a.Lat), Lng: a.Lng + t*(b.Lng-a.Lng)}
+}
+
+func cross(a, b geometry.Point) float64 {
+	return a.Lng*b.Lat - a.Lat*b.Lng
+}
@@ -0,0 +1,259 @@
+package lineops
+
+import (
+	"errors"
+	"math"
+	"sort"
+
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/constants"
+	"github.com/tomchavakis/turf-go/measurement"
+)
+
+// maxChunks bounds the number of pieces a line is chunked into.
+const maxChunks = 100000
+
+// Distances along the lines are angular distances in radians, measured along great circles. Split
+// positions off the line are first snapped to the closest position on it.
+
+// location is a position on a line: on the segment starting at the vertex index, at the fraction t
+// of it. Locations on a vertex have a zero fraction.
+type location struct {
+	index int
+	t     float64
+	p     geometry.Point
+}
+
+func (l location) before(o location) bool {
+	return l.index < o.index || (l.index == o.index && l.t < o.t)
+}
+
+// Split splits the line at the positions closest to the points.
+func Split(line, points []geometry.Point) ([][]geometry.Point, error) {
+	line, err := prepare(line)
+	if err != nil {
+		return nil, err
+	}
+	if len(points) == 0 {
+		return nil, errors.New("points can't be empty")
+	}
+
+	locs := make([]location, 0, len(points))
+	for _, p := range points {
+		locs = append(locs, locate(line, p))
+	}
+	return cut(line, locs), nil
+}
+
+// SplitByLine splits the line where the splitter crosses or touches it.
+func SplitByLine(line, splitter []geometry.Point) ([][]geometry.Point, error) {
+	line, err := prepare(line)
+	if err != nil {
+		return nil, err
+	}
+	splitter, err = prepare(splitter)
+	if err != nil {
+		return nil, err
+	}
+
+	var locs []location
+	for _, c := range crossings(line, splitter) {
+		locs = append(locs, at(line, c.index, c.t, c.p))
+	}
+	return cut(line, locs), nil
+}
+
+// Slice returns the part of the line between the positions closest to the start and stop points,
+// in the direction of the line.
+func Slice(line []geometry.Point, start, stop geometry.Point) ([]geometry.Point, error) {
+	line, err := prepare(line)
+	if err != nil {
+		return nil, err
+	}
+
+	return between(line, locate(line, start), locate(line, stop))
+}
+
+// SliceAlong returns the part of the line between the distances from its start. The stop distance
+// is clamped to the length of the line.
+func SliceAlong(line []geometry.Point, start, stop float64) ([]geometry.Point, error) {
+	line, err := prepare(line)
+	if err != nil {
+		return nil, err
+	}
+	if start < 0 || stop < 0 || math.IsNaN(start) || math.IsNaN(stop) || math.IsInf(start, 0) {
+		return nil, errors.New("distances must be positive numbers")
+	}
+	if start >= stop {
+		return nil, errors.New("start must be before stop")
+	}
+	if start >= length(line) {
+		return nil, errors.New("start is beyond the end of the line")
+	}
+
+	locs := along(line, []float64{start, stop})
+	return between(line, locs[0], locs[1])
+}
+
+// Chunk cuts the line into pieces of the given length, the last one being shorter.
+func Chunk(line []geometry.Point, size float64) ([][]geometry.Point, error) {
+	line, err := prepare(line)
+	if err != nil {
+		return nil, err
+	}
+	if size <= 0 || math.IsNaN(size) || math.IsInf(size, 0) {
+		return nil, errors.New("length must be a positive number")
+	}
+
+	total := length(line)
+	if total/size > maxChunks {
+		return nil, errors.New("too many chunks, the length must be larger")
+	}
+	var ds []float64
+	for d := size; d < total; d += size {
+		ds = append(ds, d)
+	}
+	return cut(line, along(line, ds)), nil
+}
+
+// prepare drops the repeated positions of the line and checks that it has at least two.
+func prepare(line []geometry.Point) ([]geometry.Point, error) {
+	res := make([]geometry.Point, 0, len(line))
+	for _, p := range line {
+		if math.IsNaN(p.Lat) || math.IsNaN(p.Lng) || math.IsInf(p.Lat, 0) || math.IsInf(p.Lng, 0) {
+			return nil, errors.New("positions must have finite coordinates")
+		}
+		if len(res) > 0 && res[len(res)-1] == p {
+			continue
+		}
+		res = append(res, p)
+	}
+	if len(res) < 2 {
+		return nil, errors.New("a line must have at least two distinct positions")
+	}
+	return res, nil
+}
+
+func distance(a, b geometry.Point) float64 {
+	d, _ := measurement.PointDistance(a, b, constants.UnitRadians)
+	return d
+}
+
+func destination(p geometry.Point, d, bearing float64) geometry.Point {
+	q, _ := measurement.Destination(p, d, bearing, constants.UnitRadians)
+	return *q
+}
+
+func length(line []geometry.Point) float64 {
+	var l float64
+	for i := 0; i+1 < len(line); i++ {
+		l += distance(line[i], line[i+1])
+	}
+	return l
+}
+
+// at returns the location at the fraction t of the segment starting at the vertex index, moving
+// the locations at the end of a segment to the start of the next one.
+func at(line []geometry.Point, index int, t float64, p geometry.Point) location {
+	switch {
+	case t <= 0:
+		return location{index: index, p: line[index]}
+	case t >= 1:
+		return location{index: index + 1, p: line[index+1]}
+	default:
+		return location{index: index, t: t, p: p}
+	}
+}
+
+// locate returns the location on the line closest to p. Each segment is projected on a plane
+// tangent to p, where longitudes are scaled by the cosine of the latitude.
+func locate(line []geometry.Point, p geometry.Point) location {
+	k := math.Cos(p.Lat * math.Pi / 180)
+	best, bestDist := location{}, math.Inf(1)
+	for i := 0; i+1 < len(line); i++ {
+		a, b := line[i], line[i+1]
+		dx, dy := (b.Lng-a.Lng)*k, b.Lat-a.Lat
+		t := ((p.Lng-a.Lng)*k*dx + (p.Lat-a.Lat)*dy) / (dx*dx + dy*dy)
+		t = math.Max(0, math.Min(1, t))
+		q := geometry.Point{Lat: a.Lat + t*(b.Lat-a.Lat), Lng: a.Lng + t*(b.Lng-a.Lng)}
+		if d := distance(p, q); d < bestDist {
+			best, bestDist = at(line, i, t, q), d
+		}
+	}
+	return best
+}
+
+// along returns the locations at the sorted distances from the start of the line, following the
+// great circle of each segment. Distances beyond the line are located at its end.
+func along(line []geometry.Point, ds []float64) []location {
+	res := make([]location, 0, len(ds))
+	var walked float64
+	k := 0
+	for i := 0; i+1 < len(line) && k < len(ds); i++ {
+		a, b := line[i], line[i+1]
+		seg := distance(a, b)
+		for ; k < len(ds) && ds[k] < walked+seg; k++ {
+			d := ds[k] - walked
+			if d <= 0 {
+				res = append(res, location{index: i, p: a})
+				continue
+			}
+			res = append(res, location{index: i, t: d / seg, p: destination(a, d, measurement.PointBearing(a, b))})
+		}
+		walked += seg
+	}
+	for ; k < len(ds); k++ {
+		res = append(res, location{index: len(line) - 1, p: line[len(line)-1]})
+	}
+	return res
+}
+
+// cut splits the line at the locations, dropping the pieces of zero length.
+func cut(line []geometry.Point, locs []location) [][]geometry.Point {
+	sort.Slice(locs, func(i, j int) bool { return locs[i].before(locs[j]) })
+
+	var res [][]geometry.Point
+	cur := []geometry.Point{line[0]}
+	k := 0
+	for i := 0; i+1 < len(line); i++ {
+		for ; k < len(locs) && locs[k].index == i; k++ {
+			l := locs[k]
+			if l.t == 0 {
+				if len(cur) > 1 {
+					res = append(res, cur)
+					cur = []geometry.Point{line[i]}
+				}
+				continue
+			}
+			if l.p == cur[len(cur)-1] {
+				continue
+			}
+			cur = append(cur, l.p)
+			res = append(res, cur)
+			cur = []geometry.Point{l.p}
+		}
+		cur = append(cur, line[i+1])
+	}
+	return append(res, cur)
+}
+
+// between returns the part of the line between two locations, in the direction of the line.
+func between(line []geometry.Point, from, to location) ([]geometry.Point, error) {
+	if to.before(from) {
+		from, to = to, from
+	}
+	if !from.before(to) {
+		return nil, errors.New("start and stop are at the same position of the line")
+	}
+	res := []geometry.Point{from.p}
+	for i := from.index + 1; i <= to.index; i++ {
+		res = append(res, line[i])
+	}
+	if to.t > 0 {
+		res = append(res, to.p)
+	}
+	if len(res) < 2 {
+		return nil, errors.New("start and stop are at the same position of the line")
+	}
+	return res, nil
+}
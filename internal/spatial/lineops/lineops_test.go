@@ -0,0 +1,156 @@
+package lineops
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+const degree = math.Pi / 180
+
+func line(coords ...float64) []geometry.Point {
+	l := make([]geometry.Point, 0, len(coords)/2)
+	for i := 0; i+1 < len(coords); i += 2 {
+		l = append(l, geometry.Point{Lng: coords[i], Lat: coords[i+1]})
+	}
+	return l
+}
+
+func assertLine(t *testing.T, want, got []geometry.Point, delta float64) {
+	t.Helper()
+	if !assert.Len(t, got, len(want)) {
+		return
+	}
+	for i := range want {
+		assert.InDelta(t, want[i].Lat, got[i].Lat, delta, "lat of position %d", i)
+		assert.InDelta(t, want[i].Lng, got[i].Lng, delta, "lng of position %d", i)
+	}
+}
+
+func assertLines(t *testing.T, want, got [][]geometry.Point) {
+	t.Helper()
+	if !assert.Len(t, got, len(want)) {
+		return
+	}
+	for i := range want {
+		assertLine(t, want[i], got[i], 1e-9)
+	}
+}
+
+func TestSplit(t *testing.T) {
+	l := line(0, 0, 2, 0, 3, 0)
+
+	tests := map[string]struct {
+		points []geometry.Point
+		want   [][]geometry.Point
+	}{
+		"off the line":   {points: line(1.5, 0.1), want: [][]geometry.Point{line(0, 0, 1.5, 0), line(1.5, 0, 2, 0, 3, 0)}},
+		"on a vertex":    {points: line(2, 0), want: [][]geometry.Point{line(0, 0, 2, 0), line(2, 0, 3, 0)}},
+		"on the ends":    {points: line(0, 0, 3, 0), want: [][]geometry.Point{l}},
+		"several":        {points: line(2.5, 0, 1, 0, 1, 0), want: [][]geometry.Point{line(0, 0, 1, 0), line(1, 0, 2, 0, 2.5, 0), line(2.5, 0, 3, 0)}},
+		"beyond the end": {points: line(5, 1), want: [][]geometry.Point{l}},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Split(l, tt.points)
+			assert.NoError(t, err)
+			assertLines(t, tt.want, got)
+		})
+	}
+
+	_, err := Split(line(1, 1, 1, 1), line(1, 1))
+	assert.EqualError(t, err, "a line must have at least two distinct positions")
+}
+
+func TestSplitByLine(t *testing.T) {
+	got, err := SplitByLine(line(0, 0, 2, 0, 3, 0), line(1.5, -1, 1.5, 1, 2.5, -1))
+	assert.NoError(t, err)
+	assertLines(t, [][]geometry.Point{line(0, 0, 1.5, 0), line(1.5, 0, 2, 0), line(2, 0, 3, 0)}, got)
+}
+
+func TestSlice(t *testing.T) {
+	l := line(0, 0, 2, 0, 3, 0)
+
+	got, err := Slice(l, geometry.Point{Lng: 2.5, Lat: -1}, geometry.Point{Lng: 1, Lat: 1})
+	assert.NoError(t, err)
+	assertLine(t, line(1, 0, 2, 0, 2.5, 0), got, 1e-9)
+
+	_, err = Slice(l, geometry.Point{Lng: 1, Lat: 1}, geometry.Point{Lng: 1, Lat: -1})
+	assert.EqualError(t, err, "start and stop are at the same position of the line")
+}
+
+func TestSliceAlong(t *testing.T) {
+	l := line(0, 0, 2, 0, 3, 0)
+
+	got, err := SliceAlong(l, 1*degree, 2.5*degree)
+	assert.NoError(t, err)
+	assertLine(t, line(1, 0, 2, 0, 2.5, 0), got, 1e-9)
+
+	got, err = SliceAlong(l, 2*degree, 10*degree)
+	assert.NoError(t, err)
+	assertLine(t, line(2, 0, 3, 0), got, 1e-9)
+
+	_, err = SliceAlong(l, 4*degree, 5*degree)
+	assert.EqualError(t, err, "start is beyond the end of the line")
+	_, err = SliceAlong(l, 2*degree, 1*degree)
+	assert.EqualError(t, err, "start must be before stop")
+}
+
+func TestChunk(t *testing.T) {
+	got, err := Chunk(line(0, 0, 2.5, 0), 1*degree)
+	assert.NoError(t, err)
+	assertLines(t, [][]geometry.Point{line(0, 0, 1, 0), line(1, 0, 2, 0), line(2, 0, 2.5, 0)}, got)
+
+	got, err = Chunk(line(0, 0, 0.5, 0), 1*degree)
+	assert.NoError(t, err)
+	assertLines(t, [][]geometry.Point{line(0, 0, 0.5, 0)}, got)
+
+	_, err = Chunk(line(0, 0, 1, 0), 0)
+	assert.EqualError(t, err, "length must be a positive number")
+	_, err = Chunk(line(0, 0, 180, 0), 1e-10)
+	assert.EqualError(t, err, "too many chunks, the length must be larger")
+}
+
+func TestOffset(t *testing.T) {
+	got, err := Offset(line(0, 0, 2, 0), 1*degree)
+	assert.NoError(t, err)
+	assertLine(t, line(0, -1, 2, -1), got, 1e-9)
+
+	got, err = Offset(line(0, 0, 2, 0), -1*degree)
+	assert.NoError(t, err)
+	assertLine(t, line(0, 1, 2, 1), got, 1e-9)
+
+	// a left turn pulls the corner out along the bisector
+	got, err = Offset(line(0, 0, 1, 0, 1, 1), 0.1*degree)
+	assert.NoError(t, err)
+	assertLine(t, line(0, -0.1, 1.1, -0.1, 1.1, 1), got, 1e-3)
+
+	// a U turn is bevelled
+	got, err = Offset(line(0, 0, 1, 0, 0, 0.001), 0.1*degree)
+	assert.NoError(t, err)
+	assert.Len(t, got, 4)
+}
+
+func TestIntersect(t *testing.T) {
+	tests := map[string]struct {
+		a, b []geometry.Point
+		want []geometry.Point
+	}{
+		"cross":    {a: line(0, 0, 2, 2), b: line(0, 2, 2, 0), want: line(1, 1)},
+		"disjoint": {a: line(0, 0, 1, 0), b: line(0, 1, 1, 1), want: []geometry.Point{}},
+		"touch":    {a: line(0, 0, 1, 0, 2, 0), b: line(1, 0, 1, 1), want: line(1, 0)},
+		"overlap":  {a: line(0, 0, 3, 0), b: line(1, 0, 2, 0), want: line(1, 0, 2, 0)},
+		"zig zag":  {a: line(0, 0, 4, 0), b: line(0, -1, 1, 1, 2, -1, 3, 1), want: line(0.5, 0, 1.5, 0, 2.5, 0)},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Intersect(tt.a, tt.b)
+			assert.NoError(t, err)
+			assertLine(t, tt.want, got, 1e-12)
+		})
+	}
+}
@@ -0,0 +1,58 @@
+package lineops
+
+import (
+	"errors"
+	"math"
+
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/measurement"
+)
+
+// miterLimit bounds how far, in multiples of the offset distance, the join of two offset segments
+// may be from their vertex. Sharper turns are bevelled.
+const miterLimit = 4
+
+// Offset returns the line parallel to the given one at the distance, on its right for a positive
+// distance and on its left for a negative one. Every vertex is moved along the bisector of its
+// segments, computed from their bearings at the vertex.
+func Offset(line []geometry.Point, d float64) ([]geometry.Point, error) {
+	line, err := prepare(line)
+	if err != nil {
+		return nil, err
+	}
+	if math.IsNaN(d) || math.IsInf(d, 0) {
+		return nil, errors.New("distance must be a finite number")
+	}
+	if d == 0 {
+		return line, nil
+	}
+
+	n := len(line)
+	res := make([]geometry.Point, 0, n)
+	res = append(res, destination(line[0], d, measurement.PointBearing(line[0], line[1])+90))
+	for i := 1; i < n-1; i++ {
+		v := line[i]
+		in := measurement.PointBearing(v, line[i-1]) + 180
+		out := measurement.PointBearing(v, line[i+1])
+		n1, n2 := normal(in), normal(out)
+
+		// the miter is the sum of the normals scaled so that its projection on each normal is 1
+		dot := n1[0]*n2[0] + n1[1]*n2[1]
+		if 1+dot < 2.0/(miterLimit*miterLimit) {
+			res = append(res, destination(v, d, in+90), destination(v, d, out+90))
+			continue
+		}
+		m := [2]float64{(n1[0] + n2[0]) / (1 + dot), (n1[1] + n2[1]) / (1 + dot)}
+		bearing := math.Atan2(m[0], m[1]) * 180 / math.Pi
+		res = append(res, destination(v, d*math.Hypot(m[0], m[1]), bearing))
+	}
+	res = append(res, destination(line[n-1], d, measurement.PointBearing(line[n-1], line[n-2])+270))
+
+	return res, nil
+}
+
+// normal returns the unit vector, east and north, on the right of the bearing.
+func normal(bearing float64) [2]float64 {
+	r := (bearing + 90) * math.Pi / 180
+	return [2]float64{math.Sin(r), math.Cos(r)}
+}
@@ -0,0 +1,246 @@
+// Package mapmatch snaps GPS traces to a road network with the hidden Markov model of Newson and
+// Krumm, "Hidden Markov Map Matching Through Noise and Sparseness" (2009).
+//
+// The states are the positions of the roads near each point of the trace. A state is as likely as
+// a Gaussian of its distance to the point, and a transition between the states of two points as
+// an exponential of the difference between the length of the route joining them and the distance
+// between the points. The Viterbi algorithm finds the most likely sequence of states.
+package mapmatch
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// maxPoints bounds the number of points of a trace.
+const maxPoints = 10000
+
+// maxRadius bounds the radius in meters of the search for roads around each point, and
+// maxCandidates the number of roads kept for each point.
+const (
+	maxRadius     = 500
+	maxCandidates = 20
+)
+
+// routeFactor bounds the length of the routes searched between two points, in proportion to the
+// distance between them, and maxRoute bounds it in meters. The sequence is broken between points
+// more than maxGap meters apart.
+const (
+	routeFactor = 4
+	maxRoute    = 10000
+	maxGap      = 2000
+)
+
+// Options holds the parameters of the model, in meters: the radius of the search for roads around
+// each point, the standard deviation of the GPS noise, the scale of the difference between the
+// route and the distance between two points, and the number of roads kept for each point.
+type Options struct {
+	Radius     float64
+	Sigma      float64
+	Beta       float64
+	Candidates int
+}
+
+// Matched is a point of a trace snapped to an edge, by its index in the trace.
+type Matched struct {
+	Index    int
+	Edge     string
+	Point    geometry.Point
+	Distance float64
+}
+
+// Result holds the matched geometry, a line for each part of the trace that could be routed, the
+// IDs of the edges travelled in order, the points snapped and the indexes of the points that are
+// far from any road.
+type Result struct {
+	Lines     [][]geometry.Point
+	Edges     []string
+	Points    []Matched
+	Unmatched []int
+}
+
+// state is a candidate of a point with the log probability of the best sequence ending there,
+// the state before it and the route from that state.
+type state struct {
+	snap  roads.Snap
+	score float64
+	prev  int
+	route *roads.Route
+}
+
+// Match returns the most likely path of a trace over a network. The sequence is broken where
+// consecutive points are too far apart or no route joins their candidates, each part being
+// matched on its own.
+func Match(n *roads.Network, trace []geometry.Point, o Options) (*Result, error) {
+	if err := check(trace, o); err != nil {
+		return nil, err
+	}
+
+	res := &Result{}
+	var steps [][]state
+	var points []int
+	flush := func() {
+		res.add(n, points, steps)
+		steps, points = nil, nil
+	}
+
+	for i, p := range trace {
+		cs := n.Candidates(p, o.Radius, o.Candidates)
+		if len(cs) == 0 {
+			res.Unmatched = append(res.Unmatched, i)
+			continue
+		}
+		cur := states(cs, o.Sigma)
+		if len(steps) > 0 {
+			if d := geom.Distance(trace[points[len(points)-1]], p); d > maxGap || !transition(n, d, steps[len(steps)-1], cur, o) {
+				flush()
+				cur = states(cs, o.Sigma)
+			}
+		}
+		steps, points = append(steps, cur), append(points, i)
+	}
+	flush()
+
+	if len(res.Points) == 0 {
+		return nil, errors.New("no point of the trace is near a road")
+	}
+	return res, nil
+}
+
+func check(trace []geometry.Point, o Options) error {
+	if len(trace) < 2 {
+		return errors.New("a trace must have at least 2 points")
+	}
+	if len(trace) > maxPoints {
+		return fmt.Errorf("too many points, the limit is %d", maxPoints)
+	}
+	for _, p := range trace {
+		if math.IsNaN(p.Lat) || math.IsInf(p.Lat, 0) || math.IsNaN(p.Lng) || math.IsInf(p.Lng, 0) {
+			return errors.New("points must have finite coordinates")
+		}
+	}
+	if !(o.Radius > 0) || o.Radius > maxRadius {
+		return fmt.Errorf("search radius must be a positive number up to %d meters", maxRadius)
+	}
+	if !(o.Sigma > 0) || math.IsInf(o.Sigma, 1) {
+		return errors.New("sigma must be a positive number")
+	}
+	if !(o.Beta > 0) || math.IsInf(o.Beta, 1) {
+		return errors.New("beta must be a positive number")
+	}
+	if o.Candidates < 1 || o.Candidates > maxCandidates {
+		return fmt.Errorf("the number of candidates must be between 1 and %d", maxCandidates)
+	}
+	return nil
+}
+
+// states returns the states of the candidates of a point, as the first ones of a sequence.
+func states(cs []roads.Snap, sigma float64) []state {
+	res := make([]state, len(cs))
+	for j, c := range cs {
+		res[j] = state{snap: c, score: emission(c.Distance, sigma), prev: -1}
+	}
+	return res
+}
+
+// emission returns the log probability of a point being measured at a distance from its road.
+func emission(d, sigma float64) float64 {
+	return -0.5 * (d / sigma) * (d / sigma)
+}
+
+// transition scores the states of a point from the ones of the point before, d meters away,
+// reporting whether any of them can be reached. The states that can't be reached score -Inf.
+func transition(n *roads.Network, d float64, prev, cur []state, o Options) bool {
+	limit := math.Min(routeFactor*d+2*o.Radius, maxRoute)
+	best := make([]float64, len(cur))
+	for j := range best {
+		best[j] = math.Inf(-1)
+	}
+
+	snaps := make([]roads.Snap, len(cur))
+	for j, c := range cur {
+		snaps[j] = c.snap
+	}
+	for i, p := range prev {
+		if math.IsInf(p.score, -1) {
+			continue
+		}
+		for j, r := range n.Routes(p.snap, snaps, roads.ByLength, limit) {
+			if r == nil {
+				continue
+			}
+			s := p.score - math.Abs(r.Length()-d)/o.Beta
+			if s > best[j] {
+				best[j] = s
+				cur[j].prev, cur[j].route = i, r
+			}
+		}
+	}
+
+	reached := false
+	for j := range cur {
+		if cur[j].prev >= 0 {
+			reached = true
+		}
+		cur[j].score += best[j]
+	}
+	return reached
+}
+
+// add appends the most likely path of a part of the trace to the result.
+func (res *Result) add(n *roads.Network, points []int, steps [][]state) {
+	if len(steps) == 0 {
+		return
+	}
+
+	last := steps[len(steps)-1]
+	k := -1
+	for j, s := range last {
+		if k < 0 || s.score > last[k].score {
+			k = j
+		}
+	}
+	path := make([]state, len(steps))
+	for i := len(steps) - 1; i >= 0; i-- {
+		path[i] = steps[i][k]
+		k = path[i].prev
+	}
+
+	var line []geometry.Point
+	edge := func(id int) {
+		e := n.Edges[id].ID
+		if len(res.Edges) == 0 || res.Edges[len(res.Edges)-1] != e {
+			res.Edges = append(res.Edges, e)
+		}
+	}
+	for i, s := range path {
+		res.Points = append(res.Points, Matched{
+			Index:    points[i],
+			Edge:     n.Edges[s.snap.Edge].ID,
+			Point:    s.snap.Point,
+			Distance: s.snap.Distance,
+		})
+		if i == 0 {
+			edge(s.snap.Edge)
+			continue
+		}
+		for _, st := range s.route.Steps {
+			if st.From != st.To {
+				edge(st.Edge)
+			}
+		}
+		ps := s.route.Points(n)
+		if len(line) > 0 {
+			ps = ps[1:]
+		}
+		line = append(line, ps...)
+	}
+	if len(line) > 1 {
+		res.Lines = append(res.Lines, line)
+	}
+}
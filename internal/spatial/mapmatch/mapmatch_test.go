@@ -0,0 +1,115 @@
+package mapmatch
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func pt(lng, lat float64) geometry.Point {
+	return geometry.Point{Lng: lng, Lat: lat}
+}
+
+var options = Options{Radius: 50, Sigma: 5, Beta: 5, Candidates: 5}
+
+// streets are two parallel streets 67 meters apart, joined at their middle.
+func streets(t *testing.T) *roads.Network {
+	n, err := roads.Build([]roads.Way{
+		{ID: "main", Points: []geometry.Point{pt(0, 0.001), pt(0.005, 0.001), pt(0.01, 0.001)}},
+		{ID: "side", Points: []geometry.Point{pt(0, 0.0016), pt(0.005, 0.0016), pt(0.01, 0.0016)}},
+		{ID: "link", Points: []geometry.Point{pt(0.005, 0.001), pt(0.005, 0.0016)}},
+	})
+	assert.NoError(t, err)
+	return n
+}
+
+func TestMatch(t *testing.T) {
+	// the fourth point is as near to both streets, but the side street can't be reached in time
+	trace := []geometry.Point{pt(0.0005, 0.00105), pt(0.0015, 0.00095), pt(0.003, 0.01), pt(0.0025, 0.0013), pt(0.0035, 0.001), pt(0.0045, 0.00098)}
+	res, err := Match(streets(t), trace, options)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []string{"main:0"}, res.Edges)
+	assert.Equal(t, []int{2}, res.Unmatched)
+	assert.Len(t, res.Points, 5)
+	assert.Equal(t, 3, res.Points[2].Index)
+	assert.Equal(t, "main:0", res.Points[2].Edge)
+	assert.InDelta(t, 0.0025, res.Points[2].Point.Lng, 1e-9)
+	assert.InDelta(t, 33.4, res.Points[2].Distance, 0.1)
+
+	assert.Len(t, res.Lines, 1)
+	line := res.Lines[0]
+	assert.InDelta(t, 0.0005, line[0].Lng, 1e-9)
+	assert.InDelta(t, 0.0045, line[len(line)-1].Lng, 1e-9)
+	for _, p := range line {
+		assert.InDelta(t, 0.001, p.Lat, 1e-12)
+	}
+}
+
+func TestMatchTurn(t *testing.T) {
+	trace := []geometry.Point{pt(0.0035, 0.00102), pt(0.0049, 0.0012), pt(0.0051, 0.0015), pt(0.0065, 0.0016)}
+	res, err := Match(streets(t), trace, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"main:0", "link:0", "side:1"}, res.Edges)
+	assert.Len(t, res.Lines, 1)
+	assert.Contains(t, res.Lines[0], pt(0.005, 0.001))
+	assert.Contains(t, res.Lines[0], pt(0.005, 0.0016))
+}
+
+func TestMatchBreak(t *testing.T) {
+	n, err := roads.Build([]roads.Way{
+		{ID: "a", Points: []geometry.Point{pt(0, 0), pt(0.002, 0)}},
+		{ID: "b", Points: []geometry.Point{pt(0.003, 0), pt(0.005, 0)}},
+	})
+	assert.NoError(t, err)
+
+	res, err := Match(n, []geometry.Point{pt(0.0005, 0), pt(0.0015, 0), pt(0.0035, 0), pt(0.0045, 0)}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a:0", "b:0"}, res.Edges)
+	assert.Len(t, res.Lines, 2)
+	assert.Len(t, res.Points, 4)
+	assert.Empty(t, res.Unmatched)
+}
+
+func TestMatchGap(t *testing.T) {
+	// the points at both ends of a 5.5 kilometre road are too far apart to be routed
+	n, err := roads.Build([]roads.Way{{ID: "a", Points: []geometry.Point{pt(0, 0), pt(0.05, 0)}}})
+	assert.NoError(t, err)
+
+	res, err := Match(n, []geometry.Point{pt(0.0005, 0), pt(0.001, 0), pt(0.049, 0), pt(0.0495, 0)}, options)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"a:0"}, res.Edges)
+	assert.Len(t, res.Lines, 2)
+	assert.Len(t, res.Points, 4)
+}
+
+func TestMatchErrors(t *testing.T) {
+	n := streets(t)
+	trace := []geometry.Point{pt(0.001, 0.001), pt(0.002, 0.001)}
+	tests := []struct {
+		name    string
+		trace   []geometry.Point
+		options Options
+		err     string
+	}{
+		{"one point", trace[:1], options, "a trace must have at least 2 points"},
+		{"too many points", make([]geometry.Point, maxPoints+1), options, "too many points, the limit is 10000"},
+		{"not finite", []geometry.Point{pt(0, 0), pt(math.NaN(), 0)}, options, "points must have finite coordinates"},
+		{"radius", trace, Options{Sigma: 5, Beta: 5}, "search radius must be a positive number up to 500 meters"},
+		{"large radius", trace, Options{Radius: 1e7, Sigma: 5, Beta: 5, Candidates: 5}, "search radius must be a positive number up to 500 meters"},
+		{"sigma", trace, Options{Radius: 50, Sigma: -1, Beta: 5}, "sigma must be a positive number"},
+		{"beta", trace, Options{Radius: 50, Sigma: 5, Beta: math.Inf(1)}, "beta must be a positive number"},
+		{"candidates", trace, Options{Radius: 50, Sigma: 5, Beta: 5, Candidates: -1}, "the number of candidates must be between 1 and 20"},
+		{"all candidates", trace, Options{Radius: 50, Sigma: 5, Beta: 5}, "the number of candidates must be between 1 and 20"},
+		{"far from roads", []geometry.Point{pt(1, 1), pt(1.001, 1)}, options, "no point of the trace is near a road"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Match(n, tt.trace, tt.options)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package mvt
+
+import (
+	"math"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/simplify"
+)
+
+// point is a position in tile units, y growing southwards.
+type point struct {
+	x, y float64
+}
+
+func (p point) coord(axis int) float64 {
+	if axis == 0 {
+		return p.x
+	}
+	return p.y
+}
+
+// clipLine returns the parts of a line inside the square [lo, hi]².
+func clipLine(ps []point, lo, hi float64) [][]point {
+	var parts [][]point
+	var cur []point
+	for i := 0; i+1 < len(ps); i++ {
+		a, b, ok := clipSegment(ps[i], ps[i+1], lo, hi)
+		if !ok {
+			continue
+		}
+		if len(cur) > 0 && cur[len(cur)-1] == a {
+			cur = append(cur, b)
+			continue
+		}
+		if len(cur) > 1 {
+			parts = append(parts, cur)
+		}
+		cur = []point{a, b}
+	}
+	if len(cur) > 1 {
+		parts = append(parts, cur)
+	}
+	return parts
+}
+
+// clipSegment clips a segment to the square [lo, hi]² with the Liang-Barsky algorithm.
+func clipSegment(a, b point, lo, hi float64) (point, point, bool) {
+	t0, t1 := 0.0, 1.0
+	dx, dy := b.x-a.x, b.y-a.y
+	for _, e := range [4][2]float64{{-dx, a.x - lo}, {dx, hi - a.x}, {-dy, a.y - lo}, {dy, hi - a.y}} {
+		p, q := e[0], e[1]
+		if p == 0 {
+			if q < 0 {
+				return a, b, false
+			}
+			continue
+		}
+		r := q / p
+		if p < 0 {
+			if r > t1 {
+				return a, b, false
+			}
+			t0 = math.Max(t0, r)
+		} else {
+			if r < t0 {
+				return a, b, false
+			}
+			t1 = math.Min(t1, r)
+		}
+	}
+	return point{x: a.x + t0*dx, y: a.y + t0*dy}, point{x: a.x + t1*dx, y: a.y + t1*dy}, true
+}
+
+// clipRing clips an open ring to the square [lo, hi]² with the Sutherland-Hodgman algorithm. The
+// parts of the ring outside the square follow its edges.
+func clipRing(ps []point, lo, hi float64) []point {
+	for axis := 0; axis < 2; axis++ {
+		ps = clipRingEdge(ps, axis, lo, true)
+		ps = clipRingEdge(ps, axis, hi, false)
+	}
+	return ps
+}
+
+func clipRingEdge(ps []point, axis int, v float64, above bool) []point {
+	in := func(p point) bool {
+		if above {
+			return p.coord(axis) >= v
+		}
+		return p.coord(axis) <= v
+	}
+	cross := func(a, b point) point {
+		t := (v - a.coord(axis)) / (b.coord(axis) - a.coord(axis))
+		return point{x: a.x + t*(b.x-a.x), y: a.y + t*(b.y-a.y)}
+	}
+
+	var out []point
+	for i, cur := range ps {
+		prev := ps[(i+len(ps)-1)%len(ps)]
+		switch {
+		case in(cur):
+			if !in(prev) {
+				out = append(out, cross(prev, cur))
+			}
+			out = append(out, cur)
+		case in(prev):
+			out = append(out, cross(prev, cur))
+		}
+	}
+	return out
+}
+
+// simplifyPoints removes the vertices closer than the tolerance to the line joining their neighbours
+// with the Douglas-Peucker algorithm, keeping the ends.
+func simplifyPoints(ps []point, tolerance float64) []point {
+	if len(ps) < 3 {
+		return ps
+	}
+	xy := make([][2]float64, len(ps))
+	for i, p := range ps {
+		xy[i] = [2]float64{p.x, p.y}
+	}
+	kept := simplify.Planar(xy, tolerance)
+	res := make([]point, len(kept))
+	for i, p := range kept {
+		res[i] = point{x: p[0], y: p[1]}
+	}
+	return res
+}
+
+// area returns the signed area of an open ring, positive when it turns clockwise on the tile.
+func area(ps []point) float64 {
+	var s float64
+	for i, p := range ps {
+		q := ps[(i+1)%len(ps)]
+		s += p.x*q.y - q.x*p.y
+	}
+	return s / 2
+}
+
+// round snaps the positions to the integer grid of the tile, dropping the repeated ones.
+func round(ps []point) []point {
+	res := make([]point, 0, len(ps))
+	for _, p := range ps {
+		r := point{x: math.Round(p.x), y: math.Round(p.y)}
+		if len(res) > 0 && res[len(res)-1] == r {
+			continue
+		}
+		res = append(res, r)
+	}
+	return res
+}
@@ -0,0 +1,335 @@
+package mvt
+
+import (
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"strconv"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+const (
+	// Extent is the number of units across a tile.
+	Extent = 4096
+	// Buffer is the margin in units kept around the tiles, so that the lines and the polygon
+	// outlines don't show seams between tiles.
+	Buffer = 64
+	// tolerance is the distance in units below which the vertices are simplified away, half a
+	// pixel of a 256 pixels tile. Being in tile units, it generalises the features more at the
+	// lower zoom levels. The rings smaller than its square are dropped.
+	tolerance = 8
+)
+
+const (
+	geomPoint   = 1
+	geomLine    = 2
+	geomPolygon = 3
+
+	cmdMoveTo    = 1
+	cmdLineTo    = 2
+	cmdClosePath = 7
+)
+
+// Feature is a feature decoded once to be encoded on any number of tiles.
+type Feature struct {
+	id         *uint64
+	kind       int
+	points     []geometry.Point
+	lines      [][]geometry.Point
+	polygons   [][][]geometry.Point
+	properties map[string]interface{}
+
+	west, south, east, north float64
+}
+
+// NewFeature prepares a GeoJSON feature. Ids holding an unsigned integer are kept as the feature
+// id, the others are left out.
+func NewFeature(f feature.Feature) (*Feature, error) {
+	mf := &Feature{properties: f.Properties}
+	if id, err := strconv.ParseUint(f.ID, 10, 64); err == nil {
+		mf.id = &id
+	}
+
+	var err error
+	var ps []geometry.Point
+	switch f.Geometry.GeoJSONType {
+	case geojson.Point, geojson.MultiPoint:
+		mf.kind = geomPoint
+		mf.points, err = geom.Coords(f.Geometry)
+		ps = mf.points
+	case geojson.LineString, geojson.MultiLineString:
+		mf.kind = geomLine
+		mf.lines, err = geom.Lines(f.Geometry)
+		for _, l := range mf.lines {
+			ps = append(ps, l...)
+		}
+	case geojson.Polygon, geojson.MultiPolygon:
+		mf.kind = geomPolygon
+		mf.polygons, err = geom.Polygons(f.Geometry)
+		for _, p := range mf.polygons {
+			if len(p) > 0 {
+				ps = append(ps, p[0]...)
+			}
+		}
+	default:
+		return nil, errors.New("only Point, LineString and Polygon geometries and their Multi variants are supported")
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	mf.west, mf.south, mf.east, mf.north = math.Inf(1), math.Inf(1), math.Inf(-1), math.Inf(-1)
+	for _, p := range ps {
+		mf.west, mf.east = math.Min(mf.west, p.Lng), math.Max(mf.east, p.Lng)
+		mf.south, mf.north = math.Min(mf.south, p.Lat), math.Max(mf.north, p.Lat)
+	}
+	return mf, nil
+}
+
+// Intersects reports whether the bounding box of the feature intersects a box.
+func (f *Feature) Intersects(b geojson.BBOX) bool {
+	return f.west <= b.East && f.east >= b.West && f.south <= b.North && f.north >= b.South
+}
+
+// Layer is a named group of features.
+type Layer struct {
+	Name     string
+	Features []*Feature
+}
+
+// Bounds returns the bounding box of a tile with its buffer, to select the features to encode.
+func Bounds(t tile.Tile) geojson.BBOX {
+	nw := t.Position(-Buffer, -Buffer, Extent)
+	se := t.Position(Extent+Buffer, Extent+Buffer, Extent)
+	return *geojson.NewBBox(nw.Lng, se.Lat, se.Lng, nw.Lat)
+}
+
+// Encode clips the features of the layers to the tile with its buffer, simplifies them and
+// encodes them as a vector tile. The features left empty and the empty layers are dropped.
+func Encode(t tile.Tile, layers []Layer) []byte {
+	var b []byte
+	for _, l := range layers {
+		if lb := encodeLayer(t, l); lb != nil {
+			b = appendBytes(b, 3, lb)
+		}
+	}
+	return b
+}
+
+// layerBuilder collects the keys and values of the properties shared by the features of a layer.
+type layerBuilder struct {
+	keys       []string
+	keyIndex   map[string]uint32
+	values     [][]byte
+	valueIndex map[string]uint32
+}
+
+func encodeLayer(t tile.Tile, l Layer) []byte {
+	lb := &layerBuilder{keyIndex: map[string]uint32{}, valueIndex: map[string]uint32{}}
+	var features [][]byte
+	for _, f := range l.Features {
+		g := f.geometry(t)
+		if len(g) == 0 {
+			continue
+		}
+		var fb []byte
+		if f.id != nil {
+			fb = appendUint(fb, 1, *f.id)
+		}
+		if tags := lb.tags(f.properties); len(tags) > 0 {
+			fb = appendPacked(fb, 2, tags)
+		}
+		fb = appendUint(fb, 3, uint64(f.kind))
+		fb = appendPacked(fb, 4, g)
+		features = append(features, fb)
+	}
+	if len(features) == 0 {
+		return nil
+	}
+
+	b := appendUint(nil, 15, 2)
+	b = appendString(b, 1, l.Name)
+	for _, fb := range features {
+		b = appendBytes(b, 2, fb)
+	}
+	for _, k := range lb.keys {
+		b = appendString(b, 3, k)
+	}
+	for _, v := range lb.values {
+		b = appendBytes(b, 4, v)
+	}
+	return appendUint(b, 5, Extent)
+}
+
+// tags returns the pairs of key and value indices of the properties, in the order of the keys.
+func (lb *layerBuilder) tags(props map[string]interface{}) []uint32 {
+	keys := make([]string, 0, len(props))
+	for k, v := range props {
+		if v != nil {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	tags := make([]uint32, 0, 2*len(keys))
+	for _, k := range keys {
+		ki, ok := lb.keyIndex[k]
+		if !ok {
+			ki = uint32(len(lb.keys))
+			lb.keyIndex[k] = ki
+			lb.keys = append(lb.keys, k)
+		}
+		v := encodeValue(props[k])
+		vi, ok := lb.valueIndex[string(v)]
+		if !ok {
+			vi = uint32(len(lb.values))
+			lb.valueIndex[string(v)] = vi
+			lb.values = append(lb.values, v)
+		}
+		tags = append(tags, ki, vi)
+	}
+	return tags
+}
+
+// encodeValue encodes a property as a Value message. Integral numbers are written as integers,
+// and arrays and objects as their JSON text.
+func encodeValue(v interface{}) []byte {
+	switch x := v.(type) {
+	case string:
+		return appendString(nil, 1, x)
+	case bool:
+		b := uint64(0)
+		if x {
+			b = 1
+		}
+		return appendUint(nil, 7, b)
+	case float64:
+		if x == math.Trunc(x) && math.Abs(x) < 1<<53 {
+			if x >= 0 {
+				return appendUint(nil, 5, uint64(x))
+			}
+			return appendUint(nil, 6, zigzag(int64(x)))
+		}
+		return appendDouble(nil, 3, x)
+	default:
+		s, err := json.Marshal(x)
+		if err != nil {
+			return appendString(nil, 1, "")
+		}
+		return appendString(nil, 1, string(s))
+	}
+}
+
+// geometry returns the geometry commands of the feature on the tile, or nothing when the feature
+// is outside it or too small to show.
+func (f *Feature) geometry(t tile.Tile) []uint32 {
+	project := func(ps []geometry.Point) []point {
+		res := make([]point, 0, len(ps))
+		for _, p := range ps {
+			x, y := t.Pixel(p, Extent)
+			res = append(res, point{x: x, y: y})
+		}
+		return res
+	}
+	const lo, hi = -Buffer, Extent + Buffer
+
+	e := &encoder{}
+	switch f.kind {
+	case geomPoint:
+		var ps []point
+		for _, p := range round(project(f.points)) {
+			if p.x >= lo && p.x <= hi && p.y >= lo && p.y <= hi {
+				ps = append(ps, p)
+			}
+		}
+		if len(ps) > 0 {
+			e.command(cmdMoveTo, len(ps))
+			for _, p := range ps {
+				e.point(p)
+			}
+		}
+	case geomLine:
+		for _, l := range f.lines {
+			for _, part := range clipLine(project(l), lo, hi) {
+				if part = round(simplifyPoints(part, tolerance)); len(part) > 1 {
+					e.line(part)
+				}
+			}
+		}
+	case geomPolygon:
+		for _, rings := range f.polygons {
+			for i, r := range rings {
+				ring := openRing(project(r))
+				if len(ring) >= 3 {
+					ring = openRing(round(simplifyPoints(closeRing(clipRing(ring, lo, hi)), tolerance)))
+				}
+				a := area(ring)
+				if len(ring) < 3 || math.Abs(a) < tolerance*tolerance {
+					if i == 0 {
+						// without its shell the holes of the polygon have nothing to cut
+						break
+					}
+					continue
+				}
+				// the shells turn clockwise on the tile and the holes anticlockwise
+				if (i == 0) != (a > 0) {
+					reverse(ring)
+				}
+				e.line(ring)
+				e.command(cmdClosePath, 1)
+			}
+		}
+	}
+	return e.cmds
+}
+
+// encoder writes the geometry commands with the positions relative to the previous one.
+type encoder struct {
+	cmds   []uint32
+	cx, cy int64
+}
+
+func (e *encoder) command(id, count int) {
+	e.cmds = append(e.cmds, uint32(id&0x7|count<<3))
+}
+
+func (e *encoder) point(p point) {
+	x, y := int64(p.x), int64(p.y)
+	e.cmds = append(e.cmds, uint32(zigzag(x-e.cx)), uint32(zigzag(y-e.cy)))
+	e.cx, e.cy = x, y
+}
+
+func (e *encoder) line(ps []point) {
+	e.command(cmdMoveTo, 1)
+	e.point(ps[0])
+	e.command(cmdLineTo, len(ps)-1)
+	for _, p := range ps[1:] {
+		e.point(p)
+	}
+}
+
+func openRing(ps []point) []point {
+	if len(ps) > 1 && ps[0] == ps[len(ps)-1] {
+		return ps[:len(ps)-1]
+	}
+	return ps
+}
+
+func closeRing(ps []point) []point {
+	if len(ps) == 0 {
+		return ps
+	}
+	return append(ps, ps[0])
+}
+
+func reverse(ps []point) {
+	for i, j := 0, len(ps)-1; i < j; i, j = i+1, j-1 {
+		ps[i], ps[j] = ps[j], ps[i]
+	}
+}
@@ -0,0 +1,227 @@
+package mvt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// message decodes the fields of a protocol buffer message, varints as uint64, 64 bits values as
+// float64 and the others as bytes.
+func message(t *testing.T, b []byte) map[int][]interface{} {
+	t.Helper()
+	fields := map[int][]interface{}{}
+	varint := func() uint64 {
+		var v uint64
+		for s := uint(0); ; s += 7 {
+			c := b[0]
+			b = b[1:]
+			v |= uint64(c&0x7f) << s
+			if c < 0x80 {
+				return v
+			}
+		}
+	}
+	for len(b) > 0 {
+		key := varint()
+		field, wire := int(key>>3), key&7
+		switch wire {
+		case wireVarint:
+			fields[field] = append(fields[field], varint())
+		case wire64:
+			var u uint64
+			for i := 0; i < 8; i++ {
+				u |= uint64(b[i]) << (8 * i)
+			}
+			b = b[8:]
+			fields[field] = append(fields[field], math.Float64frombits(u))
+		case wireBytes:
+			n := varint()
+			fields[field] = append(fields[field], b[:n])
+			b = b[n:]
+		default:
+			t.Fatalf("unexpected wire type %d", wire)
+		}
+	}
+	return fields
+}
+
+func packed(t *testing.T, b []byte) []uint32 {
+	t.Helper()
+	var res []uint32
+	for len(b) > 0 {
+		var v uint64
+		for s := uint(0); ; s += 7 {
+			c := b[0]
+			b = b[1:]
+			v |= uint64(c&0x7f) << s
+			if c < 0x80 {
+				break
+			}
+		}
+		res = append(res, uint32(v))
+	}
+	return res
+}
+
+func unzigzag(v uint32) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
+
+// positions decodes the geometry commands into rings or lines of tile positions.
+func positions(cmds []uint32) [][][2]int64 {
+	var res [][][2]int64
+	var x, y int64
+	for i := 0; i < len(cmds); {
+		id, count := cmds[i]&7, int(cmds[i]>>3)
+		i++
+		if id == cmdClosePath {
+			continue
+		}
+		if id == cmdMoveTo {
+			res = append(res, nil)
+		}
+		for k := 0; k < count; k++ {
+			x += unzigzag(cmds[i])
+			y += unzigzag(cmds[i+1])
+			i += 2
+			res[len(res)-1] = append(res[len(res)-1], [2]int64{x, y})
+		}
+	}
+	return res
+}
+
+// rotate starts the closed rings at their smallest position, as clipping may move their start.
+func rotate(rings [][][2]int64) [][][2]int64 {
+	for _, r := range rings {
+		k := 0
+		for i, p := range r {
+			if p[1] < r[k][1] || p[1] == r[k][1] && p[0] < r[k][0] {
+				k = i
+			}
+		}
+		copy(r, append(append([][2]int64{}, r[k:]...), r[:k]...))
+	}
+	return rings
+}
+
+func newFeature(t *testing.T, g geometry.Geometry, props map[string]interface{}, id string) *Feature {
+	t.Helper()
+	f, err := NewFeature(feature.Feature{ID: id, Geometry: g, Properties: props})
+	assert.NoError(t, err)
+	return f
+}
+
+func TestEncode(t *testing.T) {
+	tl := tile.Tile{Z: 10, X: 528, Y: 340}
+	at := func(x, y float64) geometry.Point { return tl.Position(x, y, Extent) }
+
+	tests := map[string]struct {
+		g    geometry.Geometry
+		kind uint64
+		want [][][2]int64
+	}{
+		"point": {
+			g:    geom.MultiPointGeometry([]geometry.Point{at(2048, 1024), at(5000, 5000)}),
+			kind: geomPoint,
+			want: [][][2]int64{{{2048, 1024}}},
+		},
+		"line crossing the tile": {
+			g:    geom.LineStringGeometry([]geometry.Point{at(-1000, 2048), at(2048, 2048), at(2048, 3000), at(2048, 9000)}),
+			kind: geomLine,
+			want: [][][2]int64{{{-64, 2048}, {2048, 2048}, {2048, 4160}}},
+		},
+		"polygon covering the tile": {
+			g:    geom.PolygonGeometry([][]geometry.Point{{at(-1000, -1000), at(-1000, 9000), at(9000, 9000), at(9000, -1000), at(-1000, -1000)}}),
+			kind: geomPolygon,
+			want: [][][2]int64{{{-64, -64}, {4160, -64}, {4160, 4160}, {-64, 4160}}},
+		},
+		"polygon with a hole": {
+			g: geom.PolygonGeometry([][]geometry.Point{
+				{at(0, 0), at(1000, 0), at(1000, 1000), at(0, 1000), at(0, 0)},
+				{at(100, 100), at(200, 100), at(200, 200), at(100, 200), at(100, 100)},
+			}),
+			kind: geomPolygon,
+			want: [][][2]int64{{{0, 0}, {1000, 0}, {1000, 1000}, {0, 1000}}, {{100, 100}, {100, 200}, {200, 200}, {200, 100}}},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			b := Encode(tl, []Layer{{Name: "roads", Features: []*Feature{newFeature(t, tt.g, nil, "7")}}})
+
+			layers := message(t, b)[3]
+			assert.Len(t, layers, 1)
+			layer := message(t, layers[0].([]byte))
+			assert.Equal(t, []interface{}{uint64(2)}, layer[15])
+			assert.Equal(t, "roads", string(layer[1][0].([]byte)))
+			assert.Equal(t, []interface{}{uint64(Extent)}, layer[5])
+
+			f := message(t, layer[2][0].([]byte))
+			assert.Equal(t, []interface{}{uint64(7)}, f[1])
+			assert.Equal(t, []interface{}{tt.kind}, f[3])
+			got := positions(packed(t, f[4][0].([]byte)))
+			if tt.kind == geomPolygon {
+				got = rotate(got)
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestEncodeSkipped(t *testing.T) {
+	tl := tile.Tile{Z: 10, X: 528, Y: 340}
+	at := func(x, y float64) geometry.Point { return tl.Position(x, y, Extent) }
+
+	outside := newFeature(t, geom.PointGeometry(at(-500, 100)), nil, "")
+	tiny := newFeature(t, geom.PolygonGeometry([][]geometry.Point{{at(10, 10), at(12, 10), at(12, 12), at(10, 10)}}), nil, "")
+	assert.Empty(t, Encode(tl, []Layer{{Name: "empty", Features: []*Feature{outside, tiny}}}))
+}
+
+func TestEncodeProperties(t *testing.T) {
+	tl := tile.Tile{}
+	props := map[string]interface{}{"name": "a", "lanes": 2.0, "offset": -3.0, "width": 2.5, "oneway": true, "tags": []interface{}{"x"}, "none": nil}
+	f1 := newFeature(t, geom.PointGeometry(geometry.Point{}), props, "")
+	f2 := newFeature(t, geom.PointGeometry(geometry.Point{Lng: 1}), map[string]interface{}{"name": "a"}, "")
+
+	layer := message(t, message(t, Encode(tl, []Layer{{Name: "l", Features: []*Feature{f1, f2}}}))[3][0].([]byte))
+
+	var keys []string
+	for _, k := range layer[3] {
+		keys = append(keys, string(k.([]byte)))
+	}
+	assert.Equal(t, []string{"lanes", "name", "offset", "oneway", "tags", "width"}, keys)
+
+	var values []interface{}
+	for _, v := range layer[4] {
+		for field, vs := range message(t, v.([]byte)) {
+			switch field {
+			case 1:
+				values = append(values, string(vs[0].([]byte)))
+			case 6:
+				values = append(values, unzigzag(uint32(vs[0].(uint64))))
+			default:
+				values = append(values, vs[0])
+			}
+		}
+	}
+	assert.Equal(t, []interface{}{uint64(2), "a", int64(-3), uint64(1), `["x"]`, 2.5}, values)
+
+	f := message(t, layer[2][1].([]byte))
+	assert.Equal(t, []uint32{1, 1}, packed(t, f[2][0].([]byte)))
+}
+
+func TestNewFeature(t *testing.T) {
+	_, err := NewFeature(feature.Feature{Geometry: geometry.Geometry{GeoJSONType: "GeometryCollection"}})
+	assert.EqualError(t, err, "only Point, LineString and Polygon geometries and their Multi variants are supported")
+
+	f := newFeature(t, geom.LineStringGeometry([]geometry.Point{{Lng: 1, Lat: 2}, {Lng: 3, Lat: 4}}), nil, "road-1")
+	assert.Nil(t, f.id)
+	assert.True(t, f.Intersects(Bounds(tile.Tile{})))
+	assert.False(t, f.Intersects(Bounds(tile.Tile{Z: 2, X: 0, Y: 0})))
+}
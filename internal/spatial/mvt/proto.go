@@ -0,0 +1,67 @@
+package mvt
+
+import "math"
+
+// The tiles are written following version 2 of the vector tile specification, with the protocol
+// buffers encoded by hand since the messages are few and simple:
+//
+//	Tile    { repeated Layer layers = 3; }
+//	Layer   { uint32 version = 15; string name = 1; repeated Feature features = 2;
+//	          repeated string keys = 3; repeated Value values = 4; uint32 extent = 5; }
+//	Feature { uint64 id = 1; repeated uint32 tags = 2 [packed]; GeomType type = 3;
+//	          repeated uint32 geometry = 4 [packed]; }
+//	Value   { string string_value = 1; double double_value = 3; uint64 uint_value = 5;
+//	          sint64 sint_value = 6; bool bool_value = 7; }
+
+const (
+	wireVarint = 0
+	wire64     = 1
+	wireBytes  = 2
+)
+
+func appendVarint(b []byte, v uint64) []byte {
+	for v >= 0x80 {
+		b = append(b, byte(v)|0x80)
+		v >>= 7
+	}
+	return append(b, byte(v))
+}
+
+func appendKey(b []byte, field, wire int) []byte {
+	return appendVarint(b, uint64(field<<3|wire))
+}
+
+func appendUint(b []byte, field int, v uint64) []byte {
+	return appendVarint(appendKey(b, field, wireVarint), v)
+}
+
+func appendBytes(b []byte, field int, v []byte) []byte {
+	b = appendVarint(appendKey(b, field, wireBytes), uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendString(b []byte, field int, v string) []byte {
+	b = appendVarint(appendKey(b, field, wireBytes), uint64(len(v)))
+	return append(b, v...)
+}
+
+func appendDouble(b []byte, field int, v float64) []byte {
+	b = appendKey(b, field, wire64)
+	u := math.Float64bits(v)
+	for i := 0; i < 8; i++ {
+		b = append(b, byte(u>>(8*i)))
+	}
+	return b
+}
+
+func appendPacked(b []byte, field int, vs []uint32) []byte {
+	var p []byte
+	for _, v := range vs {
+		p = appendVarint(p, uint64(v))
+	}
+	return appendBytes(b, field, p)
+}
+
+func zigzag(v int64) uint64 {
+	return uint64((v << 1) ^ (v >> 63))
+}
@@ -0,0 +1,320 @@
+package olc
+
+import (
+	"errors"
+	"math"
+	"strings"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Open Location Code (Plus Codes) encoding as described in the specification.
+// https://github.com/google/open-location-code/blob/main/docs/specification.md
+const (
+	// Alphabet is the set of valid characters of a code.
+	Alphabet = "23456789CFGHJMPQRVWX"
+	// Separator is the character separating the first eight digits from the rest of the code.
+	Separator = '+'
+	// Padding is the character used to pad codes shorter than eight digits.
+	Padding = '0'
+
+	// PairCodeLength is the default length of a code, giving a precision of about 14 metres.
+	PairCodeLength = 10
+	// MaxCodeLength is the maximum number of significant digits of a code.
+	MaxCodeLength = 15
+
+	sepPos   = 8
+	encBase  = len(Alphabet)
+	gridCols = 4
+	gridRows = 5
+	latMax   = 90
+	lngMax   = 180
+
+	gridCodeLength     = MaxCodeLength - PairCodeLength
+	pairFirstPlaceVal  = 160000 // encBase^(PairCodeLength/2-1)
+	pairPrecision      = 8000   // encBase^3
+	gridLatFirstPlace  = 625    // gridRows^(gridCodeLength-1)
+	gridLngFirstPlace  = 256    // gridCols^(gridCodeLength-1)
+	gridLatFullValue   = 3125   // gridRows^gridCodeLength
+	gridLngFullValue   = 1024   // gridCols^gridCodeLength
+	finalLatPrecision  = pairPrecision * gridLatFullValue
+	finalLngPrecision  = pairPrecision * gridLngFullValue
+	minTrimmableLength = 6
+)
+
+var pairResolutions = []float64{20.0, 1.0, .05, .0025, .000125}
+
+// CodeArea is the area represented by a code.
+type CodeArea struct {
+	LatLo  float64 `json:"latLo"`
+	LngLo  float64 `json:"lngLo"`
+	LatHi  float64 `json:"latHi"`
+	LngHi  float64 `json:"lngHi"`
+	Length int     `json:"length"`
+}
+
+// Center returns the center of the area.
+func (a CodeArea) Center() geometry.Point {
+	return geometry.Point{
+		Lat: math.Min(a.LatLo+(a.LatHi-a.LatLo)/2, latMax),
+		Lng: math.Min(a.LngLo+(a.LngHi-a.LngLo)/2, lngMax),
+	}
+}
+
+// Encode returns the code of the point with the given number of digits.
+// Lengths are adjusted to the closest valid length: at least two, even when less than ten and at most fifteen.
+func Encode(p geometry.Point, length int) string {
+	switch {
+	case length <= 0:
+		length = PairCodeLength
+	case length < 2:
+		length = 2
+	case length < PairCodeLength && length%2 == 1:
+		length++
+	case length > MaxCodeLength:
+		length = MaxCodeLength
+	}
+
+	lat := clipLatitude(p.Lat)
+	lng := normalizeLongitude(p.Lng)
+	if lat == latMax {
+		lat -= latitudePrecision(length)
+	}
+
+	latVal := int64(math.Round((lat+latMax)*finalLatPrecision*1e6) / 1e6)
+	lngVal := int64(math.Round((lng+lngMax)*finalLngPrecision*1e6) / 1e6)
+
+	var code [MaxCodeLength]byte
+	if length > PairCodeLength {
+		for i := MaxCodeLength - 1; i >= PairCodeLength; i-- {
+			code[i] = Alphabet[(latVal%gridRows)*gridCols+lngVal%gridCols]
+			latVal /= gridRows
+			lngVal /= gridCols
+		}
+	} else {
+		latVal /= gridLatFullValue
+		lngVal /= gridLngFullValue
+	}
+	for i := PairCodeLength - 1; i > 0; i -= 2 {
+		code[i] = Alphabet[lngVal%int64(encBase)]
+		code[i-1] = Alphabet[latVal%int64(encBase)]
+		latVal /= int64(encBase)
+		lngVal /= int64(encBase)
+	}
+
+	if length < sepPos {
+		return string(code[:length]) + strings.Repeat(string(Padding), sepPos-length) + string(Separator)
+	}
+	return string(code[:sepPos]) + string(Separator) + string(code[sepPos:length])
+}
+
+// Decode returns the area represented by a full code.
+func Decode(code string) (*CodeArea, error) {
+	if !IsFull(code) {
+		return nil, errors.New("code is not a valid full code")
+	}
+	digits := stripCode(code)
+	if len(digits) > MaxCodeLength {
+		digits = digits[:MaxCodeLength]
+	}
+
+	normalLat := int64(-latMax * pairPrecision)
+	normalLng := int64(-lngMax * pairPrecision)
+	n := len(digits)
+	if n > PairCodeLength {
+		n = PairCodeLength
+	}
+	pv := int64(pairFirstPlaceVal)
+	for i := 0; i < n; i += 2 {
+		normalLat += int64(strings.IndexByte(Alphabet, digits[i])) * pv
+		normalLng += int64(strings.IndexByte(Alphabet, digits[i+1])) * pv
+		if i < n-2 {
+			pv /= int64(encBase)
+		}
+	}
+	latPrecision := float64(pv) / pairPrecision
+	lngPrecision := float64(pv) / pairPrecision
+
+	var extraLat, extraLng int64
+	if len(digits) > PairCodeLength {
+		rowPV, colPV := int64(gridLatFirstPlace), int64(gridLngFirstPlace)
+		for i := PairCodeLength; i < len(digits); i++ {
+			d := int64(strings.IndexByte(Alphabet, digits[i]))
+			extraLat += d / gridCols * rowPV
+			extraLng += d % gridCols * colPV
+			if i < len(digits)-1 {
+				rowPV /= gridRows
+				colPV /= gridCols
+			}
+		}
+		latPrecision = float64(rowPV) / finalLatPrecision
+		lngPrecision = float64(colPV) / finalLngPrecision
+	}
+
+	lat := float64(normalLat)/pairPrecision + float64(extraLat)/finalLatPrecision
+	lng := float64(normalLng)/pairPrecision + float64(extraLng)/finalLngPrecision
+
+	return &CodeArea{
+		LatLo:  round(lat),
+		LngLo:  round(lng),
+		LatHi:  round(lat + latPrecision),
+		LngHi:  round(lng + lngPrecision),
+		Length: len(digits),
+	}, nil
+}
+
+// Shorten removes as many leading digits as possible from a full code, so that it can be
+// recovered using a reference location close enough to the code's area.
+func Shorten(code string, ref geometry.Point) (string, error) {
+	if !IsFull(code) {
+		return "", errors.New("code is not a valid full code")
+	}
+	if strings.IndexByte(code, Padding) >= 0 {
+		return "", errors.New("padded codes can't be shortened")
+	}
+	code = strings.ToUpper(code)
+	area, err := Decode(code)
+	if err != nil {
+		return "", err
+	}
+	if area.Length < minTrimmableLength {
+		return "", errors.New("code is too short to be shortened")
+	}
+
+	c := area.Center()
+	r := math.Max(math.Abs(c.Lat-clipLatitude(ref.Lat)), math.Abs(c.Lng-normalizeLongitude(ref.Lng)))
+	for i := len(pairResolutions) - 2; i >= 1; i-- {
+		// the 0.3 factor leaves a safety margin in case the reference location is slightly off
+		if r < pairResolutions[i]*0.3 {
+			return code[(i+1)*2:], nil
+		}
+	}
+
+	return code, nil
+}
+
+// Recover returns the full code nearest to the reference location that matches the short code.
+// Full codes are returned as they are.
+func Recover(code string, ref geometry.Point) (string, error) {
+	if IsFull(code) {
+		return strings.ToUpper(code), nil
+	}
+	if !IsShort(code) {
+		return "", errors.New("code is not a valid short code")
+	}
+	code = strings.ToUpper(code)
+
+	refLat := clipLatitude(ref.Lat)
+	refLng := normalizeLongitude(ref.Lng)
+	paddingLength := sepPos - strings.IndexByte(code, Separator)
+	resolution := math.Pow(float64(encBase), 2-float64(paddingLength/2))
+	halfResolution := resolution / 2
+
+	prefix := Encode(geometry.Point{Lat: refLat, Lng: refLng}, PairCodeLength)[:paddingLength]
+	area, err := Decode(prefix + code)
+	if err != nil {
+		return "", err
+	}
+
+	c := area.Center()
+	lat, lng := c.Lat, c.Lng
+	if refLat+halfResolution < lat && lat-resolution >= -latMax {
+		lat -= resolution
+	} else if refLat-halfResolution > lat && lat+resolution <= latMax {
+		lat += resolution
+	}
+	if refLng+halfResolution < lng {
+		lng -= resolution
+	} else if refLng-halfResolution > lng {
+		lng += resolution
+	}
+
+	return Encode(geometry.Point{Lat: lat, Lng: lng}, area.Length), nil
+}
+
+// IsValid reports whether the code is a valid full or short code.
+func IsValid(code string) bool {
+	if len(code) < 2 {
+		return false
+	}
+	code = strings.ToUpper(code)
+	sep := strings.IndexByte(code, Separator)
+	if sep < 0 || sep != strings.LastIndexByte(code, Separator) || sep > sepPos || sep%2 == 1 {
+		return false
+	}
+
+	if pad := strings.IndexByte(code, Padding); pad >= 0 {
+		if sep < sepPos || pad == 0 || pad%2 == 1 {
+			return false
+		}
+		rest := code[pad:]
+		if strings.TrimLeft(rest, string(Padding)) != string(Separator) {
+			return false
+		}
+	}
+
+	if len(code)-sep-1 == 1 {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		ch := code[i]
+		if ch != Separator && ch != Padding && strings.IndexByte(Alphabet, ch) < 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsShort reports whether the code is a valid short code.
+func IsShort(code string) bool {
+	return IsValid(code) && strings.IndexByte(code, Separator) < sepPos
+}
+
+// IsFull reports whether the code is a valid full code.
+func IsFull(code string) bool {
+	if !IsValid(code) || IsShort(code) {
+		return false
+	}
+	code = strings.ToUpper(code)
+	if strings.IndexByte(Alphabet, code[0])*encBase >= latMax*2 {
+		return false
+	}
+	if strings.IndexByte(Alphabet, code[1])*encBase >= lngMax*2 {
+		return false
+	}
+
+	return true
+}
+
+func stripCode(code string) string {
+	code = strings.ToUpper(code)
+	code = strings.ReplaceAll(code, string(Separator), "")
+	return strings.ReplaceAll(code, string(Padding), "")
+}
+
+func latitudePrecision(length int) float64 {
+	if length <= PairCodeLength {
+		return math.Pow(float64(encBase), float64(length/-2+2))
+	}
+	return math.Pow(float64(encBase), -3) / math.Pow(gridRows, float64(length-PairCodeLength))
+}
+
+func clipLatitude(lat float64) float64 {
+	return math.Min(math.Max(lat, -latMax), latMax)
+}
+
+func normalizeLongitude(lng float64) float64 {
+	for lng < -lngMax {
+		lng += 2 * lngMax
+	}
+	for lng >= lngMax {
+		lng -= 2 * lngMax
+	}
+	return lng
+}
+
+// round removes the floating point noise introduced by the divisions.
+func round(v float64) float64 {
+	return math.Round(v*1e14) / 1e14
+}
@@ -0,0 +1,131 @@
+package olc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestEncode(t *testing.T) {
+	tests := map[string]struct {
+		point  geometry.Point
+		length int
+		want   string
+	}{
+		"default length": {
+			point:  geometry.Point{Lat: 47.365590, Lng: 8.524997},
+			length: 0,
+			want:   "8FVC9G8F+6X",
+		},
+		"padded": {
+			point:  geometry.Point{Lat: 20.3700625, Lng: 2.7821875},
+			length: 4,
+			want:   "7FG40000+",
+		},
+		"grid digits": {
+			point:  geometry.Point{Lat: 20.3701125, Lng: 2.782234375},
+			length: 11,
+			want:   "7FG49QCJ+2VX",
+		},
+		"north pole": {
+			point:  geometry.Point{Lat: 90, Lng: 1},
+			length: 4,
+			want:   "CFX30000+",
+		},
+		"longitude wraps": {
+			point:  geometry.Point{Lat: 47.365590, Lng: 368.524997},
+			length: 10,
+			want:   "8FVC9G8F+6X",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Encode(tt.point, tt.length))
+		})
+	}
+}
+
+func TestDecode(t *testing.T) {
+	area, err := Decode("7FG49QCJ+2V")
+	assert.NoError(t, err)
+	assert.InDelta(t, 20.37, area.LatLo, 1e-9)
+	assert.InDelta(t, 2.782125, area.LngLo, 1e-9)
+	assert.InDelta(t, 20.370125, area.LatHi, 1e-9)
+	assert.InDelta(t, 2.78225, area.LngHi, 1e-9)
+	assert.Equal(t, 10, area.Length)
+
+	area, err = Decode("7fg49qcj+2vx")
+	assert.NoError(t, err)
+	assert.Equal(t, 11, area.Length)
+	assert.InDelta(t, 20.3701, area.LatLo, 1e-9)
+
+	_, err = Decode("9QCJ+2VX")
+	assert.Error(t, err)
+	_, err = Decode("not a code")
+	assert.Error(t, err)
+}
+
+func TestShortenRecover(t *testing.T) {
+	tests := map[string]struct {
+		code  string
+		ref   geometry.Point
+		short string
+	}{
+		"four digits": {
+			code:  "9C3W9QCJ+2VX",
+			ref:   geometry.Point{Lat: 51.3701125, Lng: -1.217765625},
+			short: "+2VX",
+		},
+		"two digits": {
+			code:  "9C3W9QCJ+2VX",
+			ref:   geometry.Point{Lat: 51.3708675, Lng: -1.217765625},
+			short: "CJ+2VX",
+		},
+		"four digits removed": {
+			code:  "9C3W9QCJ+2VX",
+			ref:   geometry.Point{Lat: 51.3922, Lng: -1.2},
+			short: "9QCJ+2VX",
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			short, err := Shorten(tt.code, tt.ref)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.short, short)
+
+			full, err := Recover(short, tt.ref)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.code, full)
+		})
+	}
+}
+
+func TestRecoverNearest(t *testing.T) {
+	full, err := Recover("9G8F+6X", geometry.Point{Lat: 47.4, Lng: 8.6})
+	assert.NoError(t, err)
+	assert.Equal(t, "8FVC9G8F+6X", full)
+
+	// the nearest match is across the antimeridian
+	full, err = Recover("2222+22", geometry.Point{Lat: 1, Lng: 179.9})
+	assert.NoError(t, err)
+	assert.Equal(t, "62H22222+22", full)
+}
+
+func TestIsValid(t *testing.T) {
+	valid := []string{"8FWC2345+G6", "8FWC2345+G6G", "8fwc2345+", "8FWCX400+", "WC2345+G6g", "2345+G6", "45+G6", "+G6"}
+	invalid := []string{"G+", "+", "8FWC2345+G", "8FWC2_45+G6", "8FWC2η45+G6", "8FWC2345+G6+", "8FWC2345G6+", "8FWC2300+G6", "WC2300+G6g", "WC2345+G"}
+
+	for _, c := range valid {
+		assert.True(t, IsValid(c), c)
+	}
+	for _, c := range invalid {
+		assert.False(t, IsValid(c), c)
+	}
+
+	assert.True(t, IsFull("8FWC2345+G6"))
+	assert.False(t, IsFull("WC2345+G6g"))
+	assert.True(t, IsShort("WC2345+G6g"))
+}
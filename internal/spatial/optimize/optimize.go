@@ -0,0 +1,165 @@
+// Package optimize orders the stops of a vehicle route, a travelling salesman problem with
+// optional time windows solved with heuristics: a nearest neighbour tour improved with 2-opt and
+// Or-opt moves until none of them helps.
+//
+// The tours are compared by their total lateness first, then by their distance.
+package optimize
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geo-api/internal/spatial/routing"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// maxStops bounds the number of stops of a route.
+const maxStops = 100
+
+// maxSegment is the length of the longest chain of stops moved by the Or-opt moves.
+const maxSegment = 3
+
+// epsilon is the smallest improvement of a tour that is kept.
+const epsilon = 1e-9
+
+// Metric is how the distances between the stops are measured.
+type Metric string
+
+const (
+	// Geodesic measures the great circle distances, travelled at a constant speed.
+	Geodesic Metric = "geodesic"
+	// Road measures the fastest routes over the road network.
+	Road Metric = "road"
+)
+
+// ParseMetric returns the metric of a name.
+func ParseMetric(s string) (Metric, error) {
+	switch m := Metric(s); m {
+	case Geodesic, Road:
+		return m, nil
+	}
+	return "", fmt.Errorf("unsupported metric %q, expected geodesic or road", s)
+}
+
+// Window is the time a stop can be visited, in seconds after the departure.
+type Window struct {
+	Earliest float64
+	Latest   float64
+}
+
+// Request is a route to order: the stops visited from a start, ending at the last stop or at an
+// end when set. The stops have time windows when Windows holds one for each of them, nil for the
+// stops that can be visited any time, and take Service seconds each. The speed in km/h is the one
+// of the geodesic metric, the road metric using the speeds of the profile.
+type Request struct {
+	Start   geometry.Point
+	Stops   []geometry.Point
+	End     *geometry.Point
+	Windows []*Window
+	Service float64
+	Metric  Metric
+	Profile *roads.Profile
+	Speed   float64
+}
+
+// Tour is the order of the stops, by their index in the request, with the arrival at each one in
+// seconds after the departure. Its distance is in meters, its duration in seconds including the
+// waiting and the service, and Late sums the seconds the stops are reached after their window.
+type Tour struct {
+	Order    []int
+	Arrivals []float64
+	Distance float64
+	Duration float64
+	Late     float64
+}
+
+// Optimize orders the stops of a request, the road network being used by the road metric only.
+func Optimize(n *roads.Network, req Request) (*Tour, error) {
+	if err := check(req); err != nil {
+		return nil, err
+	}
+
+	points := append([]geometry.Point{req.Start}, req.Stops...)
+	if req.End != nil {
+		points = append(points, *req.End)
+	}
+	var dist, dur [][]float64
+	if req.Metric == Road {
+		names := make([]string, len(points))
+		for i := range names {
+			names[i] = fmt.Sprintf("stop %d", i-1)
+		}
+		names[0] = "the start"
+		if req.End != nil {
+			names[len(names)-1] = "the end"
+		}
+		var err error
+		if dist, dur, err = routing.Matrix(n, points, names, req.Profile); err != nil {
+			return nil, err
+		}
+	} else {
+		dist, dur = geodesic(points, req.Speed)
+	}
+	return solve(dist, dur, req.End != nil, req.Windows, req.Service), nil
+}
+
+func check(req Request) error {
+	if len(req.Stops) == 0 {
+		return errors.New("stops can't be empty")
+	}
+	if len(req.Stops) > maxStops {
+		return fmt.Errorf("too many stops, the limit is %d", maxStops)
+	}
+	points := append([]geometry.Point{req.Start}, req.Stops...)
+	if req.End != nil {
+		points = append(points, *req.End)
+	}
+	for _, p := range points {
+		if math.IsNaN(p.Lat) || math.IsNaN(p.Lng) || math.Abs(p.Lat) > 90 || math.Abs(p.Lng) > 180 {
+			return errors.New("points must have a latitude between -90 and 90 and a longitude between -180 and 180")
+		}
+	}
+	if req.Windows != nil && len(req.Windows) != len(req.Stops) {
+		return errors.New("the time windows must be as many as the stops")
+	}
+	for _, w := range req.Windows {
+		if w != nil && !(w.Earliest <= w.Latest) {
+			return errors.New("the time windows must end after they start")
+		}
+	}
+	if !(req.Service >= 0) || math.IsInf(req.Service, 1) {
+		return errors.New("service time can't be negative")
+	}
+	switch req.Metric {
+	case Geodesic:
+		if !(req.Speed > 0) || math.IsInf(req.Speed, 1) {
+			return errors.New("speed must be a positive number")
+		}
+	case Road:
+		if req.Profile == nil {
+			return errors.New("the road metric needs a profile")
+		}
+	default:
+		return fmt.Errorf("unsupported metric %q, expected geodesic or road", req.Metric)
+	}
+	return nil
+}
+
+// geodesic returns the great circle distances between the points and the time taken to travel
+// them at a speed in km/h.
+func geodesic(points []geometry.Point, speed float64) ([][]float64, [][]float64) {
+	dist, dur := make([][]float64, len(points)), make([][]float64, len(points))
+	for i, a := range points {
+		dist[i], dur[i] = make([]float64, len(points)), make([]float64, len(points))
+		for j, b := range points {
+			if i != j {
+				dist[i][j] = geom.Distance(a, b)
+				dur[i][j] = dist[i][j] / (speed / 3.6)
+			}
+		}
+	}
+	return dist, dur
+}
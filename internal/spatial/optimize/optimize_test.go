@@ -0,0 +1,150 @@
+package optimize
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func pt(lng, lat float64) geometry.Point {
+	return geometry.Point{Lng: lng, Lat: lat}
+}
+
+func TestOptimize(t *testing.T) {
+	req := Request{
+		Start:  pt(0, 0),
+		Stops:  []geometry.Point{pt(0.03, 0), pt(0.01, 0), pt(0.02, 0)},
+		Metric: Geodesic,
+		Speed:  36,
+	}
+	tour, err := Optimize(nil, req)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 0}, tour.Order)
+	assert.InDelta(t, 3335.8, tour.Distance, 0.5)
+	assert.InDelta(t, 333.6, tour.Duration, 0.5)
+	assert.InDelta(t, 111.2, tour.Arrivals[0], 0.5)
+	assert.InDelta(t, 333.6, tour.Arrivals[2], 0.5)
+	assert.Equal(t, 0.0, tour.Late)
+
+	// coming back to the start
+	req.End = &req.Start
+	req.Service = 60
+	tour, err = Optimize(nil, req)
+	assert.NoError(t, err)
+	assert.InDelta(t, 6671.7, tour.Distance, 0.5)
+	assert.InDelta(t, 667.2+3*60, tour.Duration, 0.5)
+
+	// waiting 20 minutes at the nearest stop would make the farthest one late
+	req.End, req.Service = nil, 0
+	req.Windows = []*Window{{Earliest: 0, Latest: 360}, {Earliest: 1200, Latest: 1800}, nil}
+	tour, err = Optimize(nil, req)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, tour.Order[2])
+	assert.Equal(t, 0.0, tour.Late)
+	assert.InDelta(t, 5559.7, tour.Distance, 0.5)
+	assert.InDelta(t, 556, tour.Arrivals[2], 0.5)
+	assert.InDelta(t, 1200, tour.Duration, 0.5)
+
+	// windows that can't all be kept are missed by as little as possible
+	req.Windows = []*Window{{Earliest: 0, Latest: 100}, {Earliest: 0, Latest: 100}, nil}
+	tour, err = Optimize(nil, req)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2, 0}, tour.Order)
+	assert.InDelta(t, 111.2-100+333.6-100, tour.Late, 0.5)
+}
+
+func TestOptimizeRoad(t *testing.T) {
+	n, err := roads.Build([]roads.Way{
+		{ID: "street", Points: []geometry.Point{pt(0, 0), pt(0.03, 0)}, Tags: map[string]string{"highway": "residential"}},
+	})
+	assert.NoError(t, err)
+
+	req := Request{
+		Start:   pt(0, 0.0001),
+		Stops:   []geometry.Point{pt(0.02, 0.0001), pt(0.01, -0.0001)},
+		Metric:  Road,
+		Profile: roads.Car,
+	}
+	tour, err := Optimize(n, req)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 0}, tour.Order)
+	assert.InDelta(t, 2223.9, tour.Distance, 0.5)
+
+	req.Stops = append(req.Stops, pt(0.5, 0))
+	_, err = Optimize(n, req)
+	assert.EqualError(t, err, "stop 2 is farther than 1000 meters from the roads open to car")
+}
+
+// TestOptimizeRandom compares the tours with the shortest ones, found by trying every order. The
+// heuristics don't always find them but must come close.
+func TestOptimizeRandom(t *testing.T) {
+	rnd := rand.New(rand.NewSource(1))
+	for i := 0; i < 20; i++ {
+		req := Request{Start: pt(rnd.Float64(), rnd.Float64()), Metric: Geodesic, Speed: 50}
+		for j := 0; j < 7; j++ {
+			req.Stops = append(req.Stops, pt(rnd.Float64(), rnd.Float64()))
+		}
+		tour, err := Optimize(nil, req)
+		assert.NoError(t, err)
+
+		best := math.Inf(1)
+		permute(make([]int, 0, len(req.Stops)), make([]bool, len(req.Stops)), func(order []int) {
+			d, prev := 0.0, req.Start
+			for _, s := range order {
+				d += geom.Distance(prev, req.Stops[s])
+				prev = req.Stops[s]
+			}
+			best = math.Min(best, d)
+		})
+		assert.GreaterOrEqual(t, tour.Distance, best-1e-6)
+		assert.LessOrEqual(t, tour.Distance, best*1.02)
+	}
+}
+
+func permute(order []int, used []bool, visit func([]int)) {
+	if len(order) == len(used) {
+		visit(order)
+		return
+	}
+	for i := range used {
+		if !used[i] {
+			used[i] = true
+			permute(append(order, i), used, visit)
+			used[i] = false
+		}
+	}
+}
+
+func TestOptimizeErrors(t *testing.T) {
+	stops := []geometry.Point{pt(0.01, 0), pt(0.02, 0)}
+	tests := map[string]struct {
+		req Request
+		err string
+	}{
+		"no stops":         {Request{Metric: Geodesic, Speed: 30}, "stops can't be empty"},
+		"too many stops":   {Request{Stops: make([]geometry.Point, 101), Metric: Geodesic, Speed: 30}, "too many stops, the limit is 100"},
+		"invalid end":      {Request{Stops: stops, End: &geometry.Point{Lat: 91}, Metric: Geodesic, Speed: 30}, "points must have a latitude between -90 and 90 and a longitude between -180 and 180"},
+		"missing windows":  {Request{Stops: stops, Windows: []*Window{nil}, Metric: Geodesic, Speed: 30}, "the time windows must be as many as the stops"},
+		"inverted window":  {Request{Stops: stops, Windows: []*Window{nil, {Earliest: 60, Latest: 0}}, Metric: Geodesic, Speed: 30}, "the time windows must end after they start"},
+		"negative service": {Request{Stops: stops, Service: -1, Metric: Geodesic, Speed: 30}, "service time can't be negative"},
+		"no speed":         {Request{Stops: stops, Metric: Geodesic}, "speed must be a positive number"},
+		"no profile":       {Request{Stops: stops, Metric: Road}, "the road metric needs a profile"},
+		"unknown metric":   {Request{Stops: stops, Metric: "flight"}, `unsupported metric "flight", expected geodesic or road`},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Optimize(nil, tt.req)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+
+	_, err := ParseMetric("road")
+	assert.NoError(t, err)
+	_, err = ParseMetric("")
+	assert.EqualError(t, err, `unsupported metric "", expected geodesic or road`)
+}
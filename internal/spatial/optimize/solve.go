@@ -0,0 +1,167 @@
+package optimize
+
+import "math"
+
+// maxSweeps bounds the number of times the moves are all tried.
+const maxSweeps = 100
+
+// problem holds the distances and durations between the nodes: the start 0, the stops 1 to n and
+// the end n+1 when there's one.
+type problem struct {
+	dist, dur [][]float64
+	end       bool
+	windows   []*Window
+	service   float64
+}
+
+// cost ranks the tours by their lateness, then by their distance.
+type cost struct {
+	late, distance float64
+}
+
+func (c cost) less(d cost) bool {
+	if math.Abs(c.late-d.late) > epsilon {
+		return c.late < d.late
+	}
+	return c.distance < d.distance-epsilon
+}
+
+func solve(dist, dur [][]float64, end bool, windows []*Window, service float64) *Tour {
+	p := &problem{dist: dist, dur: dur, end: end, windows: windows, service: service}
+	order := p.nearest()
+	best := p.eval(order)
+	for i := 0; i < maxSweeps; i++ {
+		improved := false
+		if c, ok := p.twoOpt(order, best); ok {
+			best, improved = c, true
+		}
+		if c, ok := p.orOpt(order, best); ok {
+			best, improved = c, true
+		}
+		if !improved {
+			break
+		}
+	}
+	return p.tour(order)
+}
+
+// nearest returns the tour going from each node to the nearest stop not visited yet.
+func (p *problem) nearest() []int {
+	n := len(p.dist) - 1
+	if p.end {
+		n--
+	}
+	visited := make([]bool, n+1)
+	order := make([]int, 0, n)
+	prev := 0
+	for len(order) < n {
+		next := -1
+		for s := 1; s <= n; s++ {
+			if !visited[s] && (next < 0 || p.dist[prev][s] < p.dist[prev][next]) {
+				next = s
+			}
+		}
+		visited[next] = true
+		order = append(order, next)
+		prev = next
+	}
+	return order
+}
+
+// walk travels a tour, calling visit with each stop and its arrival, and returns its cost and
+// duration.
+func (p *problem) walk(order []int, visit func(node int, arrival float64)) (cost, float64) {
+	var c cost
+	t, prev := 0.0, 0
+	for _, node := range order {
+		c.distance += p.dist[prev][node]
+		t += p.dur[prev][node]
+		if visit != nil {
+			visit(node, t)
+		}
+		if p.windows != nil {
+			if w := p.windows[node-1]; w != nil {
+				if t > w.Latest {
+					c.late += t - w.Latest
+				}
+				t = math.Max(t, w.Earliest)
+			}
+		}
+		t += p.service
+		prev = node
+	}
+	if p.end {
+		c.distance += p.dist[prev][len(p.dist)-1]
+		t += p.dur[prev][len(p.dist)-1]
+	}
+	return c, t
+}
+
+func (p *problem) eval(order []int) cost {
+	c, _ := p.walk(order, nil)
+	return c
+}
+
+func (p *problem) tour(order []int) *Tour {
+	res := &Tour{Order: make([]int, 0, len(order)), Arrivals: make([]float64, 0, len(order))}
+	c, t := p.walk(order, func(node int, arrival float64) {
+		res.Order = append(res.Order, node-1)
+		res.Arrivals = append(res.Arrivals, arrival)
+	})
+	res.Distance, res.Duration, res.Late = c.distance, t, c.late
+	return res
+}
+
+// twoOpt reverses the parts of a tour that make it cheaper, in place.
+func (p *problem) twoOpt(order []int, best cost) (cost, bool) {
+	improved := false
+	for i := 0; i < len(order)-1; i++ {
+		for j := i + 1; j < len(order); j++ {
+			reverse(order[i : j+1])
+			if c := p.eval(order); c.less(best) {
+				best, improved = c, true
+				continue
+			}
+			reverse(order[i : j+1])
+		}
+	}
+	return best, improved
+}
+
+// orOpt moves the chains of up to maxSegment stops of a tour, as they are or reversed, where they
+// make it cheaper, in place.
+func (p *problem) orOpt(order []int, best cost) (cost, bool) {
+	improved := false
+	cand := make([]int, 0, len(order))
+	for l := 1; l <= maxSegment && l < len(order); l++ {
+		for i := 0; i+l <= len(order); i++ {
+			seg := append([]int(nil), order[i:i+l]...)
+			rest := append(append([]int(nil), order[:i]...), order[i+l:]...)
+		moves:
+			for k := 0; k <= len(rest); k++ {
+				for _, reversed := range []bool{false, true} {
+					if (k == i && !reversed) || (reversed && l == 1) {
+						continue
+					}
+					cand = append(append(cand[:0], rest[:k]...), seg...)
+					if reversed {
+						reverse(cand[k:])
+					}
+					cand = append(cand, rest[k:]...)
+					if c := p.eval(cand); c.less(best) {
+						best, improved = c, true
+						copy(order, cand)
+						break moves
+					}
+				}
+			}
+		}
+	}
+	return best, improved
+}
+
+func reverse(s []int) {
+	for i, j := 0, len(s)-1; i < j; i, j = i+1, j-1 {
+		s[i], s[j] = s[j], s[i]
+	}
+}
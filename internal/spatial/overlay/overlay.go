@@ -0,0 +1,400 @@
+package overlay
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// The overlay works on the plane of longitude and latitude: every segment of the two inputs is
+// split where it meets another one, the sides of the resulting segments are labelled with the
+// winding numbers of both inputs and the segments separating the inside of the result from its
+// outside are chained into rings. Positions are snapped to a 1e-10 degrees grid, so touching
+// edges and vertices closer than that are merged.
+
+// Op is a boolean operation between two sets of polygons.
+type Op string
+
+const (
+	// Union keeps the area covered by any of the inputs.
+	Union Op = "union"
+	// Intersection keeps the area covered by both inputs.
+	Intersection Op = "intersection"
+	// Difference keeps the area of the first input not covered by the second.
+	Difference Op = "difference"
+	// XOR keeps the area covered by exactly one of the inputs.
+	XOR Op = "xor"
+)
+
+// ParseOp returns the operation with the given name.
+func ParseOp(s string) (Op, error) {
+	switch op := Op(strings.ToLower(s)); op {
+	case Union, Intersection, Difference, XOR:
+		return op, nil
+	default:
+		return "", fmt.Errorf("unsupported operation %q, expected one of union, intersection, difference or xor", s)
+	}
+}
+
+func (op Op) apply(a, b bool) bool {
+	switch op {
+	case Union:
+		return a || b
+	case Intersection:
+		return a && b
+	case Difference:
+		return a && !b
+	default:
+		return a != b
+	}
+}
+
+// Overlay computes the boolean operation between the subject and the clip polygons, given as
+// lists of rings where the first ring is the exterior. Overlapping polygons of the same input
+// are merged. The result polygons have counterclockwise exteriors and clockwise holes.
+func Overlay(subject, clip [][][]geometry.Point, op Op) ([][][]geometry.Point, error) {
+	if _, err := ParseOp(string(op)); err != nil {
+		return nil, err
+	}
+
+	var segs []segment
+	for owner, polys := range [][][][]geometry.Point{subject, clip} {
+		for _, rings := range polys {
+			for i, r := range rings {
+				vs, err := ring(r)
+				if err != nil {
+					return nil, err
+				}
+				// exteriors run counterclockwise and holes clockwise, so that the inside of the
+				// polygon is on the left of every segment
+				if area := signedArea(vs); (i == 0) != (area > 0) {
+					reverse(vs)
+				}
+				for k := range vs {
+					if a, b := vs[k], vs[(k+1)%len(vs)]; a != b {
+						segs = append(segs, segment{a: a, b: b, owner: owner})
+					}
+				}
+			}
+		}
+	}
+
+	edges := label(split(segs))
+
+	var result []edge
+	for _, e := range edges {
+		left := op.apply(e.left[0], e.left[1])
+		right := op.apply(e.right[0], e.right[1])
+		switch {
+		case left && !right:
+			result = append(result, e)
+		case right && !left:
+			result = append(result, edge{a: e.b, b: e.a})
+		}
+	}
+
+	return assemble(trace(result)), nil
+}
+
+// ring converts a ring to snapped positions without the closing one and without repeated positions.
+func ring(r []geometry.Point) ([]vec, error) {
+	vs := make([]vec, 0, len(r))
+	for _, p := range r {
+		if math.IsNaN(p.Lat) || math.IsNaN(p.Lng) || math.IsInf(p.Lat, 0) || math.IsInf(p.Lng, 0) {
+			return nil, errors.New("positions must have finite coordinates")
+		}
+		v := snap(vec{x: p.Lng, y: p.Lat})
+		if len(vs) > 0 && vs[len(vs)-1] == v {
+			continue
+		}
+		vs = append(vs, v)
+	}
+	if len(vs) > 1 && vs[0] == vs[len(vs)-1] {
+		vs = vs[:len(vs)-1]
+	}
+	return vs, nil
+}
+
+type edge struct {
+	a, b  vec
+	left  [2]bool // whether the area on the left is inside the subject and the clip
+	right [2]bool
+}
+
+// label merges the coincident segments and finds for each one whether the areas on its sides are
+// inside each input, using the nonzero winding rule.
+func label(segs []segment) []edge {
+	type merged struct {
+		a, b  vec
+		count [2]int // the signed number of segments of each input running from a to b
+	}
+	index := make(map[[2]vec]int)
+	var ms []merged
+	for _, s := range segs {
+		a, b, sign := s.a, s.b, 1
+		if b.less(a) {
+			a, b, sign = b, a, -1
+		}
+		k := [2]vec{a, b}
+		i, ok := index[k]
+		if !ok {
+			i = len(ms)
+			index[k] = i
+			ms = append(ms, merged{a: a, b: b})
+		}
+		ms[i].count[s.owner] += sign
+	}
+
+	byOwner := [2][]segment{}
+	for _, s := range segs {
+		byOwner[s.owner] = append(byOwner[s.owner], s)
+	}
+	strips := [2]*stripIndex{newStripIndex(byOwner[0]), newStripIndex(byOwner[1])}
+
+	edges := make([]edge, 0, len(ms))
+	for _, m := range ms {
+		e := edge{a: m.a, b: m.b}
+		mid := vec{x: (m.a.x + m.b.x) / 2, y: (m.a.y + m.b.y) / 2}
+		for owner := 0; owner < 2; owner++ {
+			c := m.count[owner]
+			w := strips[owner].winding(mid, m.a, m.b)
+			var l, r int
+			switch {
+			case m.a.y == m.b.y:
+				// the ray runs along the edge, the winding is the one above it, on its left since a
+				// is on the left of b
+				l, r = w, w-c
+			case m.a.y < m.b.y:
+				// the ray leaves on the right of an upward edge
+				l, r = w+c, w
+			default:
+				l, r = w, w-c
+			}
+			e.left[owner], e.right[owner] = l != 0, r != 0
+		}
+		edges = append(edges, e)
+	}
+	return edges
+}
+
+// stripIndex groups the segments by horizontal strips, so that a ray only meets the segments of
+// the strip it runs in.
+type stripIndex struct {
+	segs   []segment
+	minY   float64
+	height float64
+	strips [][]int
+}
+
+func newStripIndex(segs []segment) *stripIndex {
+	si := &stripIndex{segs: segs}
+	if len(segs) == 0 {
+		return si
+	}
+
+	minY, maxY := math.Inf(1), math.Inf(-1)
+	for _, s := range segs {
+		minY = math.Min(minY, math.Min(s.a.y, s.b.y))
+		maxY = math.Max(maxY, math.Max(s.a.y, s.b.y))
+	}
+	n := int(math.Sqrt(float64(len(segs)))) + 1
+	si.minY = minY
+	si.height = (maxY - minY) / float64(n)
+	if si.height == 0 {
+		n, si.height = 1, 1
+	}
+	si.strips = make([][]int, n)
+	for i, s := range segs {
+		lo, hi := si.strip(math.Min(s.a.y, s.b.y)), si.strip(math.Max(s.a.y, s.b.y))
+		for k := lo; k <= hi; k++ {
+			si.strips[k] = append(si.strips[k], i)
+		}
+	}
+	return si
+}
+
+func (si *stripIndex) strip(y float64) int {
+	k := int((y - si.minY) / si.height)
+	if k < 0 {
+		return 0
+	}
+	if k >= len(si.strips) {
+		return len(si.strips) - 1
+	}
+	return k
+}
+
+// winding returns the winding number of the segments at p, found by casting a ray towards the
+// positive x axis and skipping the segments between a and b that p lies on. The half open rule
+// counts the vertices on the ray as if it ran just above them.
+func (si *stripIndex) winding(p, a, b vec) int {
+	if len(si.strips) == 0 || p.y < si.minY {
+		return 0
+	}
+	w := 0
+	for _, i := range si.strips[si.strip(p.y)] {
+		s := si.segs[i]
+		if (s.a == a && s.b == b) || (s.a == b && s.b == a) {
+			continue
+		}
+		if s.a.y <= p.y {
+			if s.b.y > p.y && orientation(s.a, s.b, p) > 0 {
+				w++
+			}
+		} else if s.b.y <= p.y && orientation(s.a, s.b, p) < 0 {
+			w--
+		}
+	}
+	return w
+}
+
+// trace chains the edges into closed rings, keeping the inside on their left. At a vertex with
+// several outgoing edges the sharpest left turn is taken, so rings touching at a vertex are kept
+// apart.
+func trace(edges []edge) [][]vec {
+	out := make(map[vec][]int)
+	for i, e := range edges {
+		out[e.a] = append(out[e.a], i)
+	}
+	used := make([]bool, len(edges))
+
+	var rings [][]vec
+	for start := range edges {
+		if used[start] {
+			continue
+		}
+		used[start] = true
+		r := []vec{edges[start].a}
+		prev, cur := edges[start].a, edges[start].b
+		for cur != edges[start].a {
+			r = append(r, cur)
+			next, best := -1, math.Inf(1)
+			back := vec{prev.x - cur.x, prev.y - cur.y}
+			for _, i := range out[cur] {
+				if used[i] {
+					continue
+				}
+				d := vec{edges[i].b.x - cur.x, edges[i].b.y - cur.y}
+				// the clockwise angle from the incoming edge, reversed, to the outgoing one
+				angle := -math.Atan2(back.x*d.y-back.y*d.x, back.x*d.x+back.y*d.y)
+				if angle <= 0 {
+					angle += 2 * math.Pi
+				}
+				if angle < best {
+					next, best = i, angle
+				}
+			}
+			if next < 0 {
+				break
+			}
+			used[next] = true
+			prev, cur = cur, edges[next].b
+		}
+		if cur == edges[start].a && len(r) > 2 {
+			rings = append(rings, r)
+		}
+	}
+	return rings
+}
+
+// assemble groups the rings into polygons. Counterclockwise rings are exteriors and clockwise rings
+// are holes of the smallest exterior containing them.
+func assemble(rings [][]vec) [][][]geometry.Point {
+	type shell struct {
+		ring  []vec
+		area  float64
+		holes [][]vec
+	}
+	var shells []*shell
+	var holes [][]vec
+	for _, r := range rings {
+		switch a := signedArea(r); {
+		case a > 0:
+			shells = append(shells, &shell{ring: r, area: a})
+		case a < 0:
+			holes = append(holes, r)
+		}
+	}
+	sort.Slice(shells, func(i, j int) bool { return shells[i].area < shells[j].area })
+
+	for _, h := range holes {
+		for _, s := range shells {
+			if contains(s.ring, h) {
+				s.holes = append(s.holes, h)
+				break
+			}
+		}
+	}
+
+	// larger shells first, the way they are usually listed
+	res := make([][][]geometry.Point, 0, len(shells))
+	for i := len(shells) - 1; i >= 0; i-- {
+		s := shells[i]
+		rings := [][]geometry.Point{points(s.ring)}
+		for _, h := range s.holes {
+			rings = append(rings, points(h))
+		}
+		res = append(res, rings)
+	}
+	return res
+}
+
+// contains reports whether the hole lies inside the ring, testing the first vertex of the hole
+// that isn't on the ring.
+func contains(r, hole []vec) bool {
+	for _, p := range hole {
+		switch locate(p, r) {
+		case 1:
+			return true
+		case -1:
+			return false
+		}
+	}
+	// the hole has all its vertices on the ring, test the middle of its first edge instead
+	return locate(vec{(hole[0].x + hole[1].x) / 2, (hole[0].y + hole[1].y) / 2}, r) >= 0
+}
+
+// locate returns 1 when p is inside the ring, -1 when it is outside and 0 when it is on it.
+func locate(p vec, r []vec) int {
+	inside := false
+	for i, j := 0, len(r)-1; i < len(r); j, i = i, i+1 {
+		a, b := r[i], r[j]
+		if onSegment(p, segment{a: a, b: b}) || p == a {
+			return 0
+		}
+		if (a.y > p.y) != (b.y > p.y) && p.x < (b.x-a.x)*(p.y-a.y)/(b.y-a.y)+a.x {
+			inside = !inside
+		}
+	}
+	if inside {
+		return 1
+	}
+	return -1
+}
+
+func signedArea(r []vec) float64 {
+	var a float64
+	for i := range r {
+		p, q := r[i], r[(i+1)%len(r)]
+		a += p.x*q.y - q.x*p.y
+	}
+	return a / 2
+}
+
+func reverse(r []vec) {
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+}
+
+func points(r []vec) []geometry.Point {
+	res := make([]geometry.Point, 0, len(r)+1)
+	for _, v := range r {
+		res = append(res, geometry.Point{Lat: v.y, Lng: v.x})
+	}
+	return append(res, res[0])
+}
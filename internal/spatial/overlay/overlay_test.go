@@ -0,0 +1,108 @@
+package overlay
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func rect(x0, y0, x1, y1 float64) [][]geometry.Point {
+	return [][]geometry.Point{{
+		{Lat: y0, Lng: x0}, {Lat: y0, Lng: x1}, {Lat: y1, Lng: x1}, {Lat: y1, Lng: x0}, {Lat: y0, Lng: x0},
+	}}
+}
+
+// area returns the area of the polygons, subtracting their holes.
+func area(polys [][][]geometry.Point) float64 {
+	var res float64
+	for _, rings := range polys {
+		for _, r := range rings {
+			for i := 0; i+1 < len(r); i++ {
+				res += (r[i].Lng*r[i+1].Lat - r[i+1].Lng*r[i].Lat) / 2
+			}
+		}
+	}
+	return res
+}
+
+func TestOverlay(t *testing.T) {
+	a := [][][]geometry.Point{rect(0, 0, 2, 2)}
+	b := [][][]geometry.Point{rect(1, 1, 3, 3)}
+
+	tests := map[string]struct {
+		subject, clip [][][]geometry.Point
+		op            Op
+		area          float64
+		polygons      int
+		holes         int
+	}{
+		"union":                {subject: a, clip: b, op: Union, area: 7, polygons: 1},
+		"intersection":         {subject: a, clip: b, op: Intersection, area: 1, polygons: 1},
+		"difference":           {subject: a, clip: b, op: Difference, area: 3, polygons: 1},
+		"xor":                  {subject: a, clip: b, op: XOR, area: 6, polygons: 2},
+		"disjoint union":       {subject: a, clip: [][][]geometry.Point{rect(5, 5, 6, 6)}, op: Union, area: 5, polygons: 2},
+		"disjoint intersect":   {subject: a, clip: [][][]geometry.Point{rect(5, 5, 6, 6)}, op: Intersection, area: 0, polygons: 0},
+		"difference with hole": {subject: [][][]geometry.Point{rect(0, 0, 4, 4)}, clip: [][][]geometry.Point{rect(1, 1, 2, 2)}, op: Difference, area: 15, polygons: 1, holes: 1},
+		"shared edge union":    {subject: a, clip: [][][]geometry.Point{rect(2, 0, 4, 2)}, op: Union, area: 8, polygons: 1},
+		"shared edge intersect": {
+			subject: a, clip: [][][]geometry.Point{rect(2, 0, 4, 2)}, op: Intersection, area: 0, polygons: 0,
+		},
+		"touching corners": {subject: a, clip: [][][]geometry.Point{rect(2, 2, 3, 3)}, op: Union, area: 5, polygons: 2},
+		"identical":        {subject: a, clip: a, op: Union, area: 4, polygons: 1},
+		"near-degenerate edge": {
+			subject: a, clip: [][][]geometry.Point{rect(2+1e-12, 0, 3, 2)}, op: Union, area: 6, polygons: 1,
+		},
+		"hole filled by the clip": {
+			subject: [][][]geometry.Point{{rect(0, 0, 4, 4)[0], rect(1, 1, 2, 2)[0]}},
+			clip:    [][][]geometry.Point{rect(1, 1, 2, 2)},
+			op:      Union, area: 16, polygons: 1,
+		},
+		"clip across the hole": {
+			subject: [][][]geometry.Point{{rect(0, 0, 4, 4)[0], rect(1, 1, 3, 3)[0]}},
+			clip:    [][][]geometry.Point{rect(2, -1, 5, 5)},
+			op:      Intersection, area: 8 - 2, polygons: 1,
+		},
+		"overlapping parts merged": {
+			subject: [][][]geometry.Point{rect(0, 0, 2, 2), rect(1, 0, 3, 2)},
+			clip:    [][][]geometry.Point{rect(10, 10, 11, 11)},
+			op:      Union, area: 7, polygons: 2,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Overlay(tt.subject, tt.clip, tt.op)
+			assert.NoError(t, err)
+			assert.InDelta(t, tt.area, area(got), 1e-9)
+			assert.Len(t, got, tt.polygons)
+			holes := 0
+			for _, rings := range got {
+				holes += len(rings) - 1
+				for _, r := range rings {
+					assert.Equal(t, r[0], r[len(r)-1])
+				}
+			}
+			assert.Equal(t, tt.holes, holes)
+		})
+	}
+}
+
+func TestOverlayClockwiseInput(t *testing.T) {
+	cw := rect(0, 0, 2, 2)[0]
+	for i, j := 0, len(cw)-1; i < j; i, j = i+1, j-1 {
+		cw[i], cw[j] = cw[j], cw[i]
+	}
+	got, err := Overlay([][][]geometry.Point{{cw}}, [][][]geometry.Point{rect(1, 1, 3, 3)}, Union)
+	assert.NoError(t, err)
+	assert.InDelta(t, 7, area(got), 1e-9)
+}
+
+func TestParseOp(t *testing.T) {
+	op, err := ParseOp("XOR")
+	assert.NoError(t, err)
+	assert.Equal(t, XOR, op)
+
+	_, err = ParseOp("merge")
+	assert.EqualError(t, err, `unsupported operation "merge", expected one of union, intersection, difference or xor`)
+}
@@ -0,0 +1,169 @@
+package overlay
+
+import (
+	"math"
+	"sort"
+)
+
+const (
+	// grid is the size of the grid the positions are snapped to, about 0.01 mm in degrees.
+	grid = 1e-10
+	// eps is the distance below which a vertex is considered to be on a segment.
+	eps = 1e-9
+	// maxSplitPasses bounds the passes made to split segments crossing after the snapping.
+	maxSplitPasses = 8
+)
+
+type vec struct {
+	x, y float64
+}
+
+func snap(v vec) vec {
+	return vec{x: math.Round(v.x/grid) * grid, y: math.Round(v.y/grid) * grid}
+}
+
+func (v vec) less(o vec) bool {
+	return v.x < o.x || (v.x == o.x && v.y < o.y)
+}
+
+type segment struct {
+	a, b  vec
+	owner int // 0 for the subject, 1 for the clip polygons
+}
+
+func (s segment) bounds() (float64, float64, float64, float64) {
+	return math.Min(s.a.x, s.b.x), math.Min(s.a.y, s.b.y), math.Max(s.a.x, s.b.x), math.Max(s.a.y, s.b.y)
+}
+
+// split splits the segments at their intersections and at the vertices lying on them, until no
+// segment crosses another one or passes through a vertex.
+func split(segs []segment) []segment {
+	for pass := 0; pass < maxSplitPasses; pass++ {
+		points := intersections(segs)
+		if len(points) == 0 {
+			return segs
+		}
+
+		res := make([]segment, 0, len(segs)+2*len(points))
+		for i, s := range segs {
+			ps, ok := points[i]
+			if !ok {
+				res = append(res, s)
+				continue
+			}
+			d := vec{s.b.x - s.a.x, s.b.y - s.a.y}
+			sort.Slice(ps, func(i, j int) bool {
+				return (ps[i].x-s.a.x)*d.x+(ps[i].y-s.a.y)*d.y < (ps[j].x-s.a.x)*d.x+(ps[j].y-s.a.y)*d.y
+			})
+			prev := s.a
+			for _, p := range append(ps, s.b) {
+				if p != prev {
+					res = append(res, segment{a: prev, b: p, owner: s.owner})
+					prev = p
+				}
+			}
+		}
+		segs = res
+	}
+	return segs
+}
+
+// intersections returns the points splitting every segment, found by sweeping the segments along
+// the x axis.
+func intersections(segs []segment) map[int][]vec {
+	idx := make([]int, len(segs))
+	for i := range idx {
+		idx[i] = i
+	}
+	minX := func(i int) float64 { return math.Min(segs[i].a.x, segs[i].b.x) }
+	sort.Slice(idx, func(i, j int) bool { return minX(idx[i]) < minX(idx[j]) })
+
+	points := make(map[int][]vec)
+	add := func(i int, p vec) {
+		s := segs[i]
+		if p == s.a || p == s.b {
+			return
+		}
+		for _, q := range points[i] {
+			if q == p {
+				return
+			}
+		}
+		points[i] = append(points[i], p)
+	}
+
+	for n, i := range idx {
+		s := segs[i]
+		_, sMinY, sMaxX, sMaxY := s.bounds()
+		for _, j := range idx[n+1:] {
+			t := segs[j]
+			tMinX, tMinY, _, tMaxY := t.bounds()
+			if tMinX > sMaxX+eps {
+				break
+			}
+			if tMinY > sMaxY+eps || tMaxY < sMinY-eps {
+				continue
+			}
+
+			// vertices lying on the other segment, which also covers collinear overlaps
+			touched := false
+			for _, p := range []vec{t.a, t.b} {
+				if onSegment(p, s) {
+					add(i, p)
+					touched = true
+				}
+			}
+			for _, p := range []vec{s.a, s.b} {
+				if onSegment(p, t) {
+					add(j, p)
+					touched = true
+				}
+			}
+			if touched {
+				continue
+			}
+
+			if p, ok := crossing(s, t); ok {
+				add(i, p)
+				add(j, p)
+			}
+		}
+	}
+	return points
+}
+
+// onSegment reports whether p lies within eps of the interior of the segment.
+func onSegment(p vec, s segment) bool {
+	if p == s.a || p == s.b {
+		return false
+	}
+	dx, dy := s.b.x-s.a.x, s.b.y-s.a.y
+	l2 := dx*dx + dy*dy
+	if l2 == 0 {
+		return false
+	}
+	t := ((p.x-s.a.x)*dx + (p.y-s.a.y)*dy) / l2
+	if t <= 0 || t >= 1 {
+		return false
+	}
+	return math.Hypot(s.a.x+t*dx-p.x, s.a.y+t*dy-p.y) < eps
+}
+
+// crossing returns the snapped point where the segments properly cross.
+func crossing(s, t segment) (vec, bool) {
+	d1 := orientation(t.a, t.b, s.a)
+	d2 := orientation(t.a, t.b, s.b)
+	d3 := orientation(s.a, s.b, t.a)
+	d4 := orientation(s.a, s.b, t.b)
+	if !((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) || !((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return vec{}, false
+	}
+
+	r := d1 / (d1 - d2)
+	p := snap(vec{x: s.a.x + r*(s.b.x-s.a.x), y: s.a.y + r*(s.b.y-s.a.y)})
+	return p, true
+}
+
+func orientation(a, b, c vec) float64 {
+	return (b.x-a.x)*(c.y-a.y) - (b.y-a.y)*(c.x-a.x)
+}
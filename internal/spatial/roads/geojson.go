@@ -0,0 +1,50 @@
+package roads
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/feature"
+)
+
+// FromFeatures returns the ways of LineString and MultiLineString features, tagged by their
+// properties. A way is identified by the id of its feature, else its id property, else its
+// position in the list, the lines of a MultiLineString being ways of the same id.
+func FromFeatures(fs []feature.Feature) ([]Way, error) {
+	var res []Way
+	for i, f := range fs {
+		if f.Geometry.GeoJSONType != geojson.LineString && f.Geometry.GeoJSONType != geojson.MultiLineString {
+			return nil, errors.New("a road network must be made of LineString or MultiLineString features")
+		}
+		lines, err := geom.Lines(f.Geometry)
+		if err != nil {
+			return nil, err
+		}
+
+		tags := map[string]string{}
+		for k, v := range f.Properties {
+			switch x := v.(type) {
+			case string:
+				tags[k] = x
+			case float64:
+				tags[k] = strconv.FormatFloat(x, 'f', -1, 64)
+			case bool:
+				tags[k] = strconv.FormatBool(x)
+			}
+		}
+		id := f.ID
+		if id == "" {
+			id = tags["id"]
+		}
+		if id == "" {
+			id = fmt.Sprint(i)
+		}
+		for _, l := range lines {
+			res = append(res, Way{ID: id, Points: l, Tags: tags})
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,123 @@
+package roads
+
+import (
+	"math"
+	"sort"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// cellSize is the side in degrees of the cells of the index.
+const cellSize = 0.01
+
+// segment is a segment of an edge, by the index of its first position.
+type segment struct {
+	edge, i int
+}
+
+// index finds the segments of the edges near a position, each segment being in the cells its
+// bounding box overlaps.
+type index struct {
+	cells map[[2]int][]segment
+}
+
+func cell(lng, lat float64) [2]int {
+	return [2]int{int(math.Floor(lng / cellSize)), int(math.Floor(lat / cellSize))}
+}
+
+func newIndex(n *Network) *index {
+	ix := &index{cells: map[[2]int][]segment{}}
+	for ei, e := range n.Edges {
+		for i := 0; i+1 < len(e.Points); i++ {
+			a, b := e.Points[i], e.Points[i+1]
+			lo := cell(math.Min(a.Lng, b.Lng), math.Min(a.Lat, b.Lat))
+			hi := cell(math.Max(a.Lng, b.Lng), math.Max(a.Lat, b.Lat))
+			for x := lo[0]; x <= hi[0]; x++ {
+				for y := lo[1]; y <= hi[1]; y++ {
+					k := [2]int{x, y}
+					ix.cells[k] = append(ix.cells[k], segment{edge: ei, i: i})
+				}
+			}
+		}
+	}
+	return ix
+}
+
+// Snap is a position on an edge, at Offset meters from its start, with its distance in meters
+// from the position snapped.
+type Snap struct {
+	Edge     int
+	Offset   float64
+	Point    geometry.Point
+	Distance float64
+}
+
+// Candidates returns the nearest position of each edge within a radius in meters of a position,
+// the k nearest first, all of them when k is 0.
+func (n *Network) Candidates(p geometry.Point, radius float64, k int) []Snap {
+	cos := math.Cos(p.Lat * math.Pi / 180)
+	dLat := radius / geom.MetersPerDegree
+	dLng := dLat / math.Max(cos, 1e-6)
+	lo, hi := cell(p.Lng-dLng, p.Lat-dLat), cell(p.Lng+dLng, p.Lat+dLat)
+
+	best := map[int]Snap{}
+	for x := lo[0]; x <= hi[0]; x++ {
+		for y := lo[1]; y <= hi[1]; y++ {
+			for _, s := range n.index.cells[[2]int{x, y}] {
+				snap := n.snapSegment(p, cos, s)
+				if snap.Distance > radius {
+					continue
+				}
+				if b, ok := best[s.edge]; !ok || snap.Distance < b.Distance {
+					best[s.edge] = snap
+				}
+			}
+		}
+	}
+
+	res := make([]Snap, 0, len(best))
+	for _, s := range best {
+		res = append(res, s)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Distance != res[j].Distance {
+			return res[i].Distance < res[j].Distance
+		}
+		return res[i].Edge < res[j].Edge
+	})
+	if k > 0 && len(res) > k {
+		res = res[:k]
+	}
+	return res
+}
+
+// Nearest returns the nearest position of the network within a radius in meters of a position.
+func (n *Network) Nearest(p geometry.Point, radius float64) (Snap, bool) {
+	cs := n.Candidates(p, radius, 1)
+	if len(cs) == 0 {
+		return Snap{}, false
+	}
+	return cs[0], true
+}
+
+// snapSegment returns the nearest position of a segment to a position, measured on the plane
+// tangent at the position.
+func (n *Network) snapSegment(p geometry.Point, cos float64, s segment) Snap {
+	e := &n.Edges[s.edge]
+	a, b := e.Points[s.i], e.Points[s.i+1]
+	ax, ay := (a.Lng-p.Lng)*cos*geom.MetersPerDegree, (a.Lat-p.Lat)*geom.MetersPerDegree
+	bx, by := (b.Lng-p.Lng)*cos*geom.MetersPerDegree, (b.Lat-p.Lat)*geom.MetersPerDegree
+	dx, dy := bx-ax, by-ay
+	t := 0.0
+	if l2 := dx*dx + dy*dy; l2 > 0 {
+		t = math.Max(0, math.Min(1, -(ax*dx+ay*dy)/l2))
+	}
+	x, y := ax+t*dx, ay+t*dy
+	return Snap{
+		Edge:     s.edge,
+		Offset:   e.lengths[s.i] + t*(e.lengths[s.i+1]-e.lengths[s.i]),
+		Point:    geometry.Point{Lng: a.Lng + t*(b.Lng-a.Lng), Lat: a.Lat + t*(b.Lat-a.Lat)},
+		Distance: math.Hypot(x, y),
+	}
+}
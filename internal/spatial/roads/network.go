@@ -0,0 +1,226 @@
+package roads
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// ErrNoNetwork is returned when no road network is loaded.
+var ErrNoNetwork = errors.New("no road network is loaded")
+
+// Way is a road as a line with its OpenStreetMap tags: highway, oneway, junction, maxspeed and
+// name are read.
+type Way struct {
+	ID     string
+	Points []geometry.Point
+	Tags   map[string]string
+}
+
+// Edge is a part of a way between two nodes of the network, the ends of the way or the positions
+// it shares with other ways. Its length is in meters and its max speed in km/h, 0 when unknown.
+type Edge struct {
+	ID       string
+	Way      string
+	Name     string
+	Highway  string
+	From, To int
+	Points   []geometry.Point
+	Length   float64
+	MaxSpeed float64
+	// Oneway is 0 for the roads open both ways, 1 for the ones open from From to To only and -1
+	// for the ones open from To to From only.
+	Oneway int
+
+	// lengths holds the distance of each position from the start of the edge.
+	lengths []float64
+}
+
+// arc is an edge leaving a node, forward when it goes from its From node to its To node.
+type arc struct {
+	edge    int
+	forward bool
+}
+
+// Network is a graph of roads.
+type Network struct {
+	Nodes []geometry.Point
+	Edges []Edge
+
+	out   [][]arc
+	index *index
+}
+
+// Build returns the network of ways, split into edges where they share positions. Positions are
+// shared when they are equal to 1e-7 degrees.
+func Build(ways []Way) (*Network, error) {
+	key := func(p geometry.Point) [2]int64 {
+		return [2]int64{int64(math.Round(p.Lng * 1e7)), int64(math.Round(p.Lat * 1e7))}
+	}
+
+	// the positions used more than once, the ends of the ways counting twice, become nodes
+	uses := map[[2]int64]int{}
+	for _, w := range ways {
+		for i, p := range w.Points {
+			uses[key(p)]++
+			if i == 0 || i == len(w.Points)-1 {
+				uses[key(p)]++
+			}
+		}
+	}
+
+	n := &Network{}
+	nodes := map[[2]int64]int{}
+	node := func(p geometry.Point) int {
+		k := key(p)
+		if i, ok := nodes[k]; ok {
+			return i
+		}
+		nodes[k] = len(n.Nodes)
+		n.Nodes = append(n.Nodes, p)
+		n.out = append(n.out, nil)
+		return nodes[k]
+	}
+
+	for _, w := range ways {
+		ps := dedupe(w.Points, key)
+		if len(ps) < 2 {
+			continue
+		}
+		oneway := parseOneway(w.Tags)
+		maxSpeed := parseSpeed(w.Tags["maxspeed"])
+		start, piece := 0, 0
+		for i := 1; i < len(ps); i++ {
+			if i < len(ps)-1 && uses[key(ps[i])] < 2 {
+				continue
+			}
+			e := Edge{
+				ID:       fmt.Sprintf("%s:%d", w.ID, piece),
+				Way:      w.ID,
+				Name:     w.Tags["name"],
+				Highway:  w.Tags["highway"],
+				From:     node(ps[start]),
+				To:       node(ps[i]),
+				Points:   ps[start : i+1],
+				MaxSpeed: maxSpeed,
+				Oneway:   oneway,
+			}
+			e.lengths = make([]float64, len(e.Points))
+			for k := 1; k < len(e.Points); k++ {
+				e.lengths[k] = e.lengths[k-1] + geom.Distance(e.Points[k-1], e.Points[k])
+			}
+			e.Length = e.lengths[len(e.lengths)-1]
+
+			id := len(n.Edges)
+			n.Edges = append(n.Edges, e)
+			n.out[e.From] = append(n.out[e.From], arc{edge: id, forward: true})
+			if e.From != e.To {
+				n.out[e.To] = append(n.out[e.To], arc{edge: id, forward: false})
+			}
+			start, piece = i, piece+1
+		}
+	}
+	if len(n.Edges) == 0 {
+		return nil, errors.New("the road network has no roads")
+	}
+
+	n.index = newIndex(n)
+	return n, nil
+}
+
+func dedupe(ps []geometry.Point, key func(geometry.Point) [2]int64) []geometry.Point {
+	res := make([]geometry.Point, 0, len(ps))
+	for _, p := range ps {
+		if len(res) > 0 && key(res[len(res)-1]) == key(p) {
+			continue
+		}
+		res = append(res, p)
+	}
+	return res
+}
+
+// parseOneway reads the direction of a way from its oneway and junction tags, the motorways and
+// the roundabouts being one way unless tagged otherwise.
+func parseOneway(tags map[string]string) int {
+	switch tags["oneway"] {
+	case "yes", "true", "1":
+		return 1
+	case "-1", "reverse":
+		return -1
+	case "no", "false", "0":
+		return 0
+	}
+	if tags["junction"] == "roundabout" || tags["highway"] == "motorway" {
+		return 1
+	}
+	return 0
+}
+
+// parseSpeed reads a maxspeed tag in km/h, or in mph when it says so, returning 0 when it holds
+// no number.
+func parseSpeed(s string) float64 {
+	s = strings.TrimSpace(s)
+	end := 0
+	for end < len(s) && (s[end] >= '0' && s[end] <= '9' || s[end] == '.') {
+		end++
+	}
+	v, err := strconv.ParseFloat(s[:end], 64)
+	if err != nil || v <= 0 {
+		return 0
+	}
+	if strings.Contains(s[end:], "mph") {
+		v *= 1.609344
+	}
+	return v
+}
+
+// Open reports whether the edge can be travelled in a direction.
+func (e *Edge) Open(forward bool) bool {
+	if forward {
+		return e.Oneway >= 0
+	}
+	return e.Oneway <= 0
+}
+
+// Slice returns the part of the edge between two distances from its start, reversed when the
+// first one is the greater.
+func (e *Edge) Slice(from, to float64) []geometry.Point {
+	if from > to {
+		ps := e.Slice(to, from)
+		for i, j := 0, len(ps)-1; i < j; i, j = i+1, j-1 {
+			ps[i], ps[j] = ps[j], ps[i]
+		}
+		return ps
+	}
+	res := []geometry.Point{e.At(from)}
+	for i, l := range e.lengths {
+		if l > from && l < to {
+			res = append(res, e.Points[i])
+		}
+	}
+	return append(res, e.At(to))
+}
+
+// At returns the position at a distance from the start of the edge.
+func (e *Edge) At(d float64) geometry.Point {
+	if d <= 0 {
+		return e.Points[0]
+	}
+	for i := 1; i < len(e.Points); i++ {
+		if d <= e.lengths[i] {
+			seg := e.lengths[i] - e.lengths[i-1]
+			if seg == 0 {
+				return e.Points[i]
+			}
+			t := (d - e.lengths[i-1]) / seg
+			a, b := e.Points[i-1], e.Points[i]
+			return geometry.Point{Lng: a.Lng + t*(b.Lng-a.Lng), Lat: a.Lat + t*(b.Lat-a.Lat)}
+		}
+	}
+	return e.Points[len(e.Points)-1]
+}
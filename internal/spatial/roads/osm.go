@@ -0,0 +1,330 @@
+package roads
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// The OpenStreetMap PBF files are read with a small protocol buffer decoder of the messages used,
+// the raw and zlib compressed blobs being supported:
+//
+//	BlobHeader     { string type = 1; int32 datasize = 3; }
+//	Blob           { bytes raw = 1; bytes zlib_data = 3; }
+//	PrimitiveBlock { StringTable stringtable = 1; repeated PrimitiveGroup primitivegroup = 2;
+//	                 int32 granularity = 17 [default 100]; int64 lat_offset = 19; int64 lon_offset = 20; }
+//	StringTable    { repeated bytes s = 1; }
+//	PrimitiveGroup { repeated Node nodes = 1; DenseNodes dense = 2; repeated Way ways = 3; }
+//	Node           { sint64 id = 1; sint64 lat = 8; sint64 lon = 9; }
+//	DenseNodes     { repeated sint64 id = 1 [packed, delta]; repeated sint64 lat = 8 [packed, delta];
+//	                 repeated sint64 lon = 9 [packed, delta]; }
+//	Way            { int64 id = 1; repeated uint32 keys = 2; repeated uint32 vals = 3;
+//	                 repeated sint64 refs = 8 [packed, delta]; }
+
+// maxBlobSize bounds the size of the blobs, 32 MiB by the PBF specification.
+const maxBlobSize = 32 << 20
+
+// routable are the highway values of the ways kept from the OpenStreetMap files.
+var routable = map[string]bool{
+	"motorway": true, "trunk": true, "primary": true, "secondary": true, "tertiary": true,
+	"motorway_link": true, "trunk_link": true, "primary_link": true, "secondary_link": true, "tertiary_link": true,
+	"unclassified": true, "residential": true, "living_street": true, "service": true, "road": true,
+	"track": true, "pedestrian": true, "footway": true, "path": true, "cycleway": true, "steps": true, "bridleway": true,
+}
+
+var errPBF = errors.New("cannot decode the OSM PBF file")
+
+// ReadPBF returns the roads of an OpenStreetMap PBF file, the ways with a routable highway tag.
+// The positions of all the nodes are kept in memory while reading, so the file should be an
+// extract of the area served.
+func ReadPBF(r io.Reader) ([]Way, error) {
+	nodes := map[int64]geometry.Point{}
+	var ways []osmWay
+	var size [4]byte
+	for {
+		if _, err := io.ReadFull(r, size[:]); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, errPBF
+		}
+		n := binary.BigEndian.Uint32(size[:])
+		if n > 64<<10 {
+			return nil, errPBF
+		}
+		header := make([]byte, n)
+		if _, err := io.ReadFull(r, header); err != nil {
+			return nil, errPBF
+		}
+		var kind string
+		var dataSize uint64
+		err := fields(header, func(f int, v uint64, b []byte) error {
+			switch f {
+			case 1:
+				kind = string(b)
+			case 3:
+				dataSize = v
+			}
+			return nil
+		})
+		if err != nil || dataSize > maxBlobSize {
+			return nil, errPBF
+		}
+		blob := make([]byte, dataSize)
+		if _, err := io.ReadFull(r, blob); err != nil {
+			return nil, errPBF
+		}
+		if kind != "OSMData" {
+			continue
+		}
+		data, err := unpack(blob)
+		if err != nil {
+			return nil, err
+		}
+		if ways, err = readBlock(data, nodes, ways); err != nil {
+			return nil, err
+		}
+	}
+
+	res := make([]Way, 0, len(ways))
+	for _, w := range ways {
+		ps := make([]geometry.Point, 0, len(w.refs))
+		for _, ref := range w.refs {
+			if p, ok := nodes[ref]; ok {
+				ps = append(ps, p)
+			}
+		}
+		res = append(res, Way{ID: strconv.FormatInt(w.id, 10), Points: ps, Tags: w.tags})
+	}
+	return res, nil
+}
+
+type osmWay struct {
+	id   int64
+	refs []int64
+	tags map[string]string
+}
+
+// unpack returns the data of a blob.
+func unpack(blob []byte) ([]byte, error) {
+	var raw, compressed []byte
+	err := fields(blob, func(f int, v uint64, b []byte) error {
+		switch f {
+		case 1:
+			raw = b
+		case 3:
+			compressed = b
+		case 4, 5, 6, 7:
+			return errors.New("only the raw and zlib compressed OSM PBF blobs are supported")
+		}
+		return nil
+	})
+	if err != nil || raw != nil {
+		return raw, err
+	}
+	zr, err := zlib.NewReader(bytes.NewReader(compressed))
+	if err != nil {
+		return nil, errPBF
+	}
+	data, err := io.ReadAll(io.LimitReader(zr, maxBlobSize+1))
+	if err != nil || len(data) > maxBlobSize {
+		return nil, errPBF
+	}
+	return data, nil
+}
+
+func readBlock(data []byte, nodes map[int64]geometry.Point, ways []osmWay) ([]osmWay, error) {
+	var strings []string
+	var groups [][]byte
+	granularity, latOffset, lonOffset := int64(100), int64(0), int64(0)
+	err := fields(data, func(f int, v uint64, b []byte) error {
+		switch f {
+		case 1:
+			return fields(b, func(f int, _ uint64, s []byte) error {
+				if f == 1 {
+					strings = append(strings, string(s))
+				}
+				return nil
+			})
+		case 2:
+			groups = append(groups, b)
+		case 17:
+			granularity = int64(v)
+		case 19:
+			latOffset = int64(v)
+		case 20:
+			lonOffset = int64(v)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	position := func(lat, lon int64) geometry.Point {
+		return geometry.Point{Lat: float64(latOffset+granularity*lat) * 1e-9, Lng: float64(lonOffset+granularity*lon) * 1e-9}
+	}
+	str := func(i uint64) string {
+		if i < uint64(len(strings)) {
+			return strings[i]
+		}
+		return ""
+	}
+
+	for _, g := range groups {
+		err := fields(g, func(f int, _ uint64, b []byte) error {
+			switch f {
+			case 1:
+				var id, lat, lon int64
+				err := fields(b, func(f int, v uint64, _ []byte) error {
+					switch f {
+					case 1:
+						id = unzigzag(v)
+					case 8:
+						lat = unzigzag(v)
+					case 9:
+						lon = unzigzag(v)
+					}
+					return nil
+				})
+				nodes[id] = position(lat, lon)
+				return err
+			case 2:
+				var ids, lats, lons []int64
+				err := fields(b, func(f int, _ uint64, p []byte) error {
+					var err error
+					switch f {
+					case 1:
+						ids, err = deltas(p)
+					case 8:
+						lats, err = deltas(p)
+					case 9:
+						lons, err = deltas(p)
+					}
+					return err
+				})
+				if err != nil || len(lats) != len(ids) || len(lons) != len(ids) {
+					return errPBF
+				}
+				for i, id := range ids {
+					nodes[id] = position(lats[i], lons[i])
+				}
+			case 3:
+				var w osmWay
+				var keys, vals []uint64
+				err := fields(b, func(f int, v uint64, p []byte) error {
+					var err error
+					switch f {
+					case 1:
+						w.id = int64(v)
+					case 2:
+						keys, err = varints(p)
+					case 3:
+						vals, err = varints(p)
+					case 8:
+						w.refs, err = deltas(p)
+					}
+					return err
+				})
+				if err != nil || len(keys) != len(vals) {
+					return errPBF
+				}
+				w.tags = make(map[string]string, len(keys))
+				for i, k := range keys {
+					w.tags[str(k)] = str(vals[i])
+				}
+				if routable[w.tags["highway"]] && w.tags["area"] != "yes" {
+					ways = append(ways, w)
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return ways, nil
+}
+
+// fields calls fn with each field of a message, its value for the varints and its bytes for the
+// length delimited ones.
+func fields(b []byte, fn func(field int, v uint64, data []byte) error) error {
+	for len(b) > 0 {
+		key, n := binary.Uvarint(b)
+		if n <= 0 {
+			return errPBF
+		}
+		b = b[n:]
+		field := int(key >> 3)
+		switch key & 7 {
+		case 0:
+			v, n := binary.Uvarint(b)
+			if n <= 0 {
+				return errPBF
+			}
+			b = b[n:]
+			if err := fn(field, v, nil); err != nil {
+				return err
+			}
+		case 1:
+			if len(b) < 8 {
+				return errPBF
+			}
+			b = b[8:]
+		case 2:
+			l, n := binary.Uvarint(b)
+			if n <= 0 || uint64(len(b)-n) < l {
+				return errPBF
+			}
+			data := b[n : n+int(l)]
+			b = b[n+int(l):]
+			if err := fn(field, 0, data); err != nil {
+				return err
+			}
+		case 5:
+			if len(b) < 4 {
+				return errPBF
+			}
+			b = b[4:]
+		default:
+			return fmt.Errorf("%w: unexpected wire type %d", errPBF, key&7)
+		}
+	}
+	return nil
+}
+
+func varints(b []byte) ([]uint64, error) {
+	var res []uint64
+	for len(b) > 0 {
+		v, n := binary.Uvarint(b)
+		if n <= 0 {
+			return nil, errPBF
+		}
+		res = append(res, v)
+		b = b[n:]
+	}
+	return res, nil
+}
+
+// deltas decodes packed signed integers, each one stored as the difference with the previous one.
+func deltas(b []byte) ([]int64, error) {
+	vs, err := varints(b)
+	if err != nil {
+		return nil, err
+	}
+	res := make([]int64, len(vs))
+	var sum int64
+	for i, v := range vs {
+		sum += unzigzag(v)
+		res[i] = sum
+	}
+	return res, nil
+}
+
+func unzigzag(v uint64) int64 {
+	return int64(v>>1) ^ -int64(v&1)
+}
@@ -0,0 +1,314 @@
+package roads
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Weight returns the cost of travelling a whole edge in a direction, +Inf when it's closed that
+// way. The cost of a part of an edge is in proportion to its length.
+type Weight func(e *Edge, forward bool) float64
+
+// ByLength weighs the edges by their length in meters, following their direction.
+func ByLength(e *Edge, forward bool) float64 {
+	if !e.Open(forward) {
+		return math.Inf(1)
+	}
+	return e.Length
+}
+
+// Step is the travel along an edge between two distances from its start, From being the greater
+// when the edge is travelled backwards.
+type Step struct {
+	Edge     int
+	From, To float64
+}
+
+// Route is a path over the network with its cost.
+type Route struct {
+	Cost  float64
+	Steps []Step
+}
+
+// Points returns the positions of the route.
+func (r *Route) Points(n *Network) []geometry.Point {
+	var res []geometry.Point
+	for _, s := range r.Steps {
+		ps := n.Edges[s.Edge].Slice(s.From, s.To)
+		if len(res) > 0 {
+			ps = ps[1:]
+		}
+		res = append(res, ps...)
+	}
+	return res
+}
+
+// Length returns the length of the route in meters.
+func (r *Route) Length() float64 {
+	var l float64
+	for _, s := range r.Steps {
+		l += math.Abs(s.To - s.From)
+	}
+	return l
+}
+
+// part returns the cost of travelling an edge between two distances from its start.
+func part(e *Edge, w Weight, from, to float64) float64 {
+	if from == to {
+		return 0
+	}
+	c := w(e, to > from)
+	if math.IsInf(c, 1) || e.Length == 0 {
+		return c
+	}
+	return c * math.Abs(to-from) / e.Length
+}
+
+// entry is how a node is reached: from the previous node over an edge, or from the start of the
+// search over a part of its edge.
+type entry struct {
+	cost    float64
+	edge    int
+	forward bool
+	start   *Step
+}
+
+// tree holds the cheapest entries of the nodes reached by a search.
+type tree map[int]entry
+
+// steps returns the steps from the start of the search to a node.
+func (t tree) steps(n *Network, node int) []Step {
+	var res []Step
+	for {
+		en := t[node]
+		if en.start != nil {
+			res = append(res, *en.start)
+			break
+		}
+		e := &n.Edges[en.edge]
+		if en.forward {
+			res = append(res, Step{Edge: en.edge, From: 0, To: e.Length})
+			node = e.From
+		} else {
+			res = append(res, Step{Edge: en.edge, From: e.Length, To: 0})
+			node = e.To
+		}
+	}
+	for i, j := 0, len(res)-1; i < j; i, j = i+1, j-1 {
+		res[i], res[j] = res[j], res[i]
+	}
+	return res
+}
+
+// Heuristic returns a lower bound of the cost of reaching the target of a search from a position.
+type Heuristic func(p geometry.Point) float64
+
+// search runs Dijkstra's algorithm from a snapped position until the cheapest nodes cost more
+// than limit or all the stop nodes are reached. With a heuristic it runs A*, the nodes being
+// visited by their cost plus the heuristic of their position, which must never overestimate the
+// cost of any edge.
+func (n *Network) search(from Snap, w Weight, limit float64, stop map[int]bool, h Heuristic) tree {
+	rank := func(node int, c float64) float64 {
+		if h == nil {
+			return c
+		}
+		return c + h(n.Nodes[node])
+	}
+
+	t := tree{}
+	q := &queue{}
+	e := &n.Edges[from.Edge]
+	for _, node := range []int{e.To, e.From} {
+		s := Step{Edge: from.Edge, From: from.Offset, To: e.Length}
+		if node == e.From {
+			s.To = 0
+		}
+		c := part(e, w, s.From, s.To)
+		if math.IsInf(c, 1) || c > limit {
+			continue
+		}
+		if en, ok := t[node]; !ok || c < en.cost {
+			t[node] = entry{cost: c, start: &s}
+			heap.Push(q, item{node: node, cost: c, rank: rank(node, c)})
+		}
+	}
+
+	settled := map[int]bool{}
+	remaining := len(stop)
+	for q.Len() > 0 {
+		it := heap.Pop(q).(item)
+		if settled[it.node] || it.cost > t[it.node].cost {
+			continue
+		}
+		settled[it.node] = true
+		if stop[it.node] {
+			if remaining--; remaining == 0 {
+				break
+			}
+		}
+		for _, a := range n.out[it.node] {
+			e := &n.Edges[a.edge]
+			c := w(e, a.forward)
+			if math.IsInf(c, 1) {
+				continue
+			}
+			next := e.To
+			if !a.forward {
+				next = e.From
+			}
+			c += it.cost
+			if c > limit || settled[next] {
+				continue
+			}
+			if en, ok := t[next]; !ok || c < en.cost {
+				t[next] = entry{cost: c, edge: a.edge, forward: a.forward}
+				heap.Push(q, item{node: next, cost: c, rank: rank(next, c)})
+			}
+		}
+	}
+	return t
+}
+
+// Routes returns the cheapest routes from a snapped position to others, nil for the ones that
+// can't be reached for at most limit.
+func (n *Network) Routes(from Snap, to []Snap, w Weight, limit float64) []*Route {
+	t := n.search(from, w, limit, stops(n, to), nil)
+	res := make([]*Route, len(to))
+	for i, s := range to {
+		res[i] = n.route(t, from, s, w, limit)
+	}
+	return res
+}
+
+// Route returns the cheapest route between two snapped positions with A*, nil when there's none.
+func (n *Network) Route(from, to Snap, w Weight, h Heuristic) *Route {
+	limit := math.Inf(1)
+	t := n.search(from, w, limit, stops(n, []Snap{to}), h)
+	return n.route(t, from, to, w, limit)
+}
+
+// Reach is the travel along an edge from the distance From of its start toward To, leaving at
+// the cost Cost and arriving at the cost End.
+type Reach struct {
+	Edge      int
+	From, To  float64
+	Cost, End float64
+}
+
+// Within returns the distance from the start of the edge reached for a cost, at most To, and
+// whether the travel along the edge started at that cost.
+func (r Reach) Within(cost float64) (float64, bool) {
+	if cost < r.Cost {
+		return 0, false
+	}
+	if cost >= r.End || r.End == r.Cost {
+		return r.To, true
+	}
+	return r.From + (r.To-r.From)*(cost-r.Cost)/(r.End-r.Cost), true
+}
+
+// Reachable returns the travels along the edges starting from a snapped position for at most
+// limit, the ones out of the position itself and out of each node reached.
+func (n *Network) Reachable(from Snap, w Weight, limit float64) []Reach {
+	var res []Reach
+	e := &n.Edges[from.Edge]
+	for _, to := range []float64{e.Length, 0} {
+		if c := part(e, w, from.Offset, to); !math.IsInf(c, 1) && to != from.Offset {
+			res = append(res, Reach{Edge: from.Edge, From: from.Offset, To: to, End: c})
+		}
+	}
+
+	t := n.search(from, w, limit, nil, nil)
+	for node, en := range t {
+		for _, a := range n.out[node] {
+			e := &n.Edges[a.edge]
+			c := w(e, a.forward)
+			if math.IsInf(c, 1) {
+				continue
+			}
+			r := Reach{Edge: a.edge, From: 0, To: e.Length, Cost: en.cost, End: en.cost + c}
+			if !a.forward {
+				r.From, r.To = e.Length, 0
+			}
+			res = append(res, r)
+		}
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if res[i].Cost != res[j].Cost {
+			return res[i].Cost < res[j].Cost
+		}
+		if res[i].Edge != res[j].Edge {
+			return res[i].Edge < res[j].Edge
+		}
+		if res[i].From != res[j].From {
+			return res[i].From < res[j].From
+		}
+		return res[i].To < res[j].To
+	})
+	return res
+}
+
+// stops returns the nodes ending the edges of snapped positions.
+func stops(n *Network, to []Snap) map[int]bool {
+	res := map[int]bool{}
+	for _, s := range to {
+		e := &n.Edges[s.Edge]
+		res[e.From], res[e.To] = true, true
+	}
+	return res
+}
+
+// route returns the cheapest route of a search tree to a snapped position, nil when it costs more
+// than limit.
+func (n *Network) route(t tree, from, s Snap, w Weight, limit float64) *Route {
+	e := &n.Edges[s.Edge]
+	var best *Route
+	try := func(r *Route) {
+		if r.Cost <= limit && (best == nil || r.Cost < best.Cost) {
+			best = r
+		}
+	}
+	if s.Edge == from.Edge {
+		if c := part(e, w, from.Offset, s.Offset); !math.IsInf(c, 1) {
+			try(&Route{Cost: c, Steps: []Step{{Edge: s.Edge, From: from.Offset, To: s.Offset}}})
+		}
+	}
+	for _, node := range []int{e.From, e.To} {
+		end := Step{Edge: s.Edge, From: 0, To: s.Offset}
+		if node == e.To {
+			end.From = e.Length
+		}
+		en, ok := t[node]
+		if !ok {
+			continue
+		}
+		c := part(e, w, end.From, end.To)
+		if math.IsInf(c, 1) {
+			continue
+		}
+		try(&Route{Cost: en.cost + c, Steps: append(t.steps(n, node), end)})
+	}
+	return best
+}
+
+type item struct {
+	node       int
+	cost, rank float64
+}
+
+// queue is a priority queue of the nodes to visit, the lowest ranked first.
+type queue []item
+
+func (q queue) Len() int            { return len(q) }
+func (q queue) Less(i, j int) bool  { return q[i].rank < q[j].rank }
+func (q queue) Swap(i, j int)       { q[i], q[j] = q[j], q[i] }
+func (q *queue) Push(x interface{}) { *q = append(*q, x.(item)) }
+func (q *queue) Pop() interface{} {
+	old := *q
+	it := old[len(old)-1]
+	*q = old[:len(old)-1]
+	return it
+}
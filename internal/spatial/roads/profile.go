@@ -0,0 +1,103 @@
+package roads
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Profile is a way of travelling the roads: the speed in km/h on each kind of highway, the roads
+// missing being closed, whether the one way roads are followed and the top speed. The speed of
+// the roads with no highway tag is the one of the empty kind.
+type Profile struct {
+	Name     string
+	Speeds   map[string]float64
+	Oneway   bool
+	MaxSpeed bool
+	TopSpeed float64
+}
+
+// Car drives the roads open to motor vehicles, at their max speed when it's known.
+var Car = &Profile{
+	Name: "car",
+	Speeds: map[string]float64{
+		"motorway": 110, "motorway_link": 60, "trunk": 90, "trunk_link": 50,
+		"primary": 65, "primary_link": 40, "secondary": 55, "secondary_link": 35,
+		"tertiary": 45, "tertiary_link": 30, "unclassified": 35, "road": 35,
+		"residential": 30, "living_street": 10, "service": 15, "track": 15, "": 40,
+	},
+	Oneway:   true,
+	MaxSpeed: true,
+	TopSpeed: 130,
+}
+
+// Bike rides the roads but the motorways and trunks, and the cycleways and paths.
+var Bike = &Profile{
+	Name: "bike",
+	Speeds: map[string]float64{
+		"primary": 18, "primary_link": 18, "secondary": 18, "secondary_link": 18,
+		"tertiary": 18, "tertiary_link": 18, "unclassified": 16, "road": 16, "residential": 16,
+		"living_street": 12, "service": 14, "track": 12, "cycleway": 18, "path": 12,
+		"pedestrian": 6, "footway": 6, "bridleway": 8, "": 15,
+	},
+	Oneway:   true,
+	TopSpeed: 18,
+}
+
+// Foot walks the roads but the motorways and trunks, both ways.
+var Foot = &Profile{
+	Name: "foot",
+	Speeds: map[string]float64{
+		"primary": 5, "primary_link": 5, "secondary": 5, "secondary_link": 5,
+		"tertiary": 5, "tertiary_link": 5, "unclassified": 5, "road": 5, "residential": 5,
+		"living_street": 5, "service": 5, "track": 5, "cycleway": 5, "path": 5,
+		"pedestrian": 5, "footway": 5, "bridleway": 5, "steps": 2, "": 5,
+	},
+	TopSpeed: 5,
+}
+
+// ParseProfile returns the profile of a name, car, bike or foot.
+func ParseProfile(s string) (*Profile, error) {
+	for _, p := range []*Profile{Car, Bike, Foot} {
+		if p.Name == s {
+			return p, nil
+		}
+	}
+	return nil, fmt.Errorf("unsupported profile %q, expected one of car, bike or foot", s)
+}
+
+// Speed returns the speed in km/h on an edge, 0 when it's closed.
+func (p *Profile) Speed(e *Edge) float64 {
+	v, ok := p.Speeds[e.Highway]
+	if !ok {
+		return 0
+	}
+	if p.MaxSpeed && e.MaxSpeed > 0 {
+		v = e.MaxSpeed
+	}
+	return math.Min(v, p.TopSpeed)
+}
+
+// Open reports whether an edge can be travelled in a direction.
+func (p *Profile) Open(e *Edge, forward bool) bool {
+	return p.Speed(e) > 0 && (!p.Oneway || e.Open(forward))
+}
+
+// Weight weighs the edges by the seconds taken to travel them.
+func (p *Profile) Weight(e *Edge, forward bool) float64 {
+	if !p.Open(e, forward) {
+		return math.Inf(1)
+	}
+	return e.Length / (p.Speed(e) / 3.6)
+}
+
+// Heuristic returns a lower bound of the seconds taken to reach a position, travelling at the top
+// speed in a straight line.
+func (p *Profile) Heuristic(to geometry.Point) func(geometry.Point) float64 {
+	v := p.TopSpeed / 3.6
+	return func(q geometry.Point) float64 {
+		return geom.Distance(q, to) / v
+	}
+}
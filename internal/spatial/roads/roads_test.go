@@ -0,0 +1,280 @@
+package roads
+
+import (
+	"bytes"
+	"compress/zlib"
+	"encoding/binary"
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func pt(lng, lat float64) geometry.Point {
+	return geometry.Point{Lng: lng, Lat: lat}
+}
+
+// grid is a cross of two streets meeting at (0.001, 0.001) and a one way street from its east end
+// going north.
+func grid(t *testing.T) *Network {
+	n, err := Build([]Way{
+		{ID: "1", Points: []geometry.Point{pt(0, 0.001), pt(0.001, 0.001), pt(0.002, 0.001)}, Tags: map[string]string{"name": "Main", "highway": "primary", "maxspeed": "30 mph"}},
+		{ID: "2", Points: []geometry.Point{pt(0.001, 0), pt(0.001, 0.001), pt(0.001, 0.002)}, Tags: map[string]string{"highway": "residential"}},
+		{ID: "3", Points: []geometry.Point{pt(0.002, 0.001), pt(0.002, 0.002)}, Tags: map[string]string{"oneway": "yes"}},
+	})
+	assert.NoError(t, err)
+	return n
+}
+
+func TestBuild(t *testing.T) {
+	n := grid(t)
+	assert.Len(t, n.Nodes, 6)
+	assert.Len(t, n.Edges, 5)
+
+	e := n.Edges[0]
+	assert.Equal(t, "1:0", e.ID)
+	assert.Equal(t, "1", e.Way)
+	assert.Equal(t, "Main", e.Name)
+	assert.Equal(t, "primary", e.Highway)
+	assert.InDelta(t, 111.2, e.Length, 0.1)
+	assert.InDelta(t, 48.28, e.MaxSpeed, 0.01)
+	assert.Equal(t, n.Edges[2].To, n.Edges[1].From)
+	assert.Equal(t, n.Edges[1].From, e.To)
+
+	assert.True(t, n.Edges[4].Open(true))
+	assert.False(t, n.Edges[4].Open(false))
+
+	_, err := Build([]Way{{ID: "1", Points: []geometry.Point{pt(0, 0)}}})
+	assert.EqualError(t, err, "the road network has no roads")
+}
+
+func TestParseOneway(t *testing.T) {
+	assert.Equal(t, 1, parseOneway(map[string]string{"highway": "motorway"}))
+	assert.Equal(t, 0, parseOneway(map[string]string{"highway": "motorway", "oneway": "no"}))
+	assert.Equal(t, 1, parseOneway(map[string]string{"junction": "roundabout"}))
+	assert.Equal(t, -1, parseOneway(map[string]string{"oneway": "-1"}))
+	assert.Equal(t, 0, parseOneway(nil))
+}
+
+func TestEdgeSlice(t *testing.T) {
+	n := grid(t)
+	e := n.Edges[1]
+	ps := e.Slice(e.Length/2, 0)
+	assert.Len(t, ps, 2)
+	assert.InDelta(t, 0.0015, ps[0].Lng, 1e-9)
+	assert.Equal(t, e.Points[0], ps[1])
+
+	ps = n.Edges[0].Slice(0, n.Edges[0].Length)
+	assert.Equal(t, n.Edges[0].Points, ps)
+}
+
+func TestCandidates(t *testing.T) {
+	n := grid(t)
+	cs := n.Candidates(pt(0.0011, 0.0012), 50, 0)
+	assert.Len(t, cs, 4)
+	assert.Equal(t, "2:1", n.Edges[cs[0].Edge].ID)
+	assert.InDelta(t, 11.1, cs[0].Distance, 0.1)
+	assert.InDelta(t, 22.2, cs[0].Offset, 0.1)
+
+	assert.Len(t, n.Candidates(pt(0.0011, 0.0012), 50, 2), 2)
+
+	_, ok := n.Nearest(pt(0.01, 0.01), 50)
+	assert.False(t, ok)
+}
+
+func TestRoutes(t *testing.T) {
+	n := grid(t)
+	from, _ := n.Nearest(pt(0.0005, 0.001), 10)
+	north, _ := n.Nearest(pt(0.001, 0.0015), 10)
+	east, _ := n.Nearest(pt(0.002, 0.0015), 10)
+	back, _ := n.Nearest(pt(0.0002, 0.001), 10)
+
+	rs := n.Routes(from, []Snap{north, east, back}, ByLength, 1000)
+	assert.Len(t, rs, 3)
+	assert.InDelta(t, 111.2, rs[0].Cost, 0.1)
+	assert.Len(t, rs[0].Steps, 2)
+	assert.InDelta(t, 111.2, rs[0].Length(), 0.1)
+	assert.Equal(t, []geometry.Point{pt(0.0005, 0.001), pt(0.001, 0.001), pt(0.001, 0.0015)}, rs[0].Points(n))
+	assert.InDelta(t, 222.4, rs[1].Cost, 0.1)
+	assert.Len(t, rs[2].Steps, 1)
+	assert.InDelta(t, 33.4, rs[2].Cost, 0.1)
+
+	// the one way street can't be travelled backwards
+	start, _ := n.Nearest(pt(0.002, 0.0015), 10)
+	rs = n.Routes(start, []Snap{from}, ByLength, 1000)
+	assert.Nil(t, rs[0])
+
+	rs = n.Routes(from, []Snap{east}, ByLength, 100)
+	assert.Nil(t, rs[0])
+}
+
+func TestFromFeatures(t *testing.T) {
+	ml := geom.MultiLineStringGeometry([][]geometry.Point{{pt(0, 0), pt(1, 0)}, {pt(1, 0), pt(1, 1)}})
+	f := geom.NewFeature(ml, map[string]interface{}{"highway": "primary", "lanes": 2.0, "bridge": true})
+
+	ways, err := FromFeatures([]feature.Feature{f})
+	assert.NoError(t, err)
+	assert.Len(t, ways, 2)
+	assert.Equal(t, "0", ways[1].ID)
+	assert.Equal(t, map[string]string{"highway": "primary", "lanes": "2", "bridge": "true"}, ways[0].Tags)
+
+	_, err = FromFeatures([]feature.Feature{geom.NewFeature(geom.PointGeometry(pt(0, 0)), nil)})
+	assert.EqualError(t, err, "a road network must be made of LineString or MultiLineString features")
+}
+
+// pb writes protocol buffer messages.
+type pb struct{ bytes.Buffer }
+
+func (b *pb) varint(field int, v uint64) *pb {
+	b.uvarint(uint64(field<<3 | 0))
+	b.uvarint(v)
+	return b
+}
+
+func (b *pb) data(field int, d []byte) *pb {
+	b.uvarint(uint64(field<<3 | 2))
+	b.uvarint(uint64(len(d)))
+	b.Write(d)
+	return b
+}
+
+func (b *pb) uvarint(v uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	b.Write(buf[:binary.PutUvarint(buf[:], v)])
+}
+
+func packed(vs ...uint64) []byte {
+	var b pb
+	for _, v := range vs {
+		b.uvarint(v)
+	}
+	return b.Bytes()
+}
+
+func zigzag(vs ...int64) []byte {
+	us := make([]uint64, len(vs))
+	for i, v := range vs {
+		us[i] = uint64(v<<1 ^ v>>63)
+	}
+	return packed(us...)
+}
+
+func fileBlock(kind string, data []byte, compress bool) []byte {
+	var blob pb
+	if compress {
+		var z bytes.Buffer
+		w := zlib.NewWriter(&z)
+		_, _ = w.Write(data)
+		_ = w.Close()
+		blob.varint(2, uint64(len(data))).data(3, z.Bytes())
+	} else {
+		blob.data(1, data)
+	}
+	var header pb
+	header.data(1, []byte(kind)).varint(3, uint64(blob.Len()))
+
+	var res bytes.Buffer
+	_ = binary.Write(&res, binary.BigEndian, uint32(header.Len()))
+	res.Write(header.Bytes())
+	res.Write(blob.Bytes())
+	return res.Bytes()
+}
+
+func TestReadPBF(t *testing.T) {
+	var strings pb
+	for _, s := range []string{"", "highway", "residential", "name", "Main", "building", "yes"} {
+		strings.data(1, []byte(s))
+	}
+	// nodes 10, 11 and 12 at 1e-7 degree steps, the granularity being 100 nanodegrees
+	var dense pb
+	dense.data(1, zigzag(10, 1, 1)).data(8, zigzag(0, 10000, 0)).data(9, zigzag(10000, 0, 10000))
+	var node pb
+	node.varint(1, uint64(13<<1)).varint(8, uint64(30000<<1)).varint(9, uint64(40000<<1))
+	var nodes pb
+	nodes.data(1, node.Bytes()).data(2, dense.Bytes())
+
+	var road, building pb
+	road.varint(1, 7).data(2, packed(1, 3)).data(3, packed(2, 4)).data(8, zigzag(10, 1, 1, 1))
+	building.varint(1, 8).data(2, packed(5)).data(3, packed(6)).data(8, zigzag(10, 1, 1))
+	var ways pb
+	ways.data(3, road.Bytes()).data(3, building.Bytes())
+
+	var block pb
+	block.data(1, strings.Bytes()).data(2, nodes.Bytes()).data(2, ways.Bytes())
+
+	var file bytes.Buffer
+	file.Write(fileBlock("OSMHeader", []byte{}, false))
+	file.Write(fileBlock("OSMData", block.Bytes(), true))
+
+	res, err := ReadPBF(&file)
+	assert.NoError(t, err)
+	assert.Len(t, res, 1)
+	assert.Equal(t, "7", res[0].ID)
+	assert.Equal(t, map[string]string{"highway": "residential", "name": "Main"}, res[0].Tags)
+	assert.Len(t, res[0].Points, 4)
+	assert.InDelta(t, 0.001, res[0].Points[0].Lng, 1e-12)
+	assert.InDelta(t, 0.001, res[0].Points[1].Lat, 1e-12)
+	assert.InDelta(t, 0.004, res[0].Points[3].Lng, 1e-12)
+	assert.InDelta(t, 0.003, res[0].Points[3].Lat, 1e-12)
+
+	_, err = ReadPBF(bytes.NewReader([]byte{0, 0, 0, 5, 1}))
+	assert.EqualError(t, err, "cannot decode the OSM PBF file")
+}
+
+func TestProfiles(t *testing.T) {
+	p, err := ParseProfile("bike")
+	assert.NoError(t, err)
+	assert.Equal(t, Bike, p)
+	_, err = ParseProfile("boat")
+	assert.EqualError(t, err, `unsupported profile "boat", expected one of car, bike or foot`)
+
+	n := grid(t)
+	main, oneway := &n.Edges[0], &n.Edges[4]
+	assert.InDelta(t, 48.28, Car.Speed(main), 0.01)
+	assert.Equal(t, 18.0, Bike.Speed(main))
+	assert.InDelta(t, 111.2/(48.28/3.6), Car.Weight(main, false), 0.01)
+	assert.True(t, math.IsInf(Car.Weight(oneway, false), 1))
+	assert.InDelta(t, 111.2/(5/3.6), Foot.Weight(oneway, false), 0.1)
+	assert.Equal(t, 0.0, Foot.Speed(&Edge{Highway: "motorway"}))
+	assert.Equal(t, 130.0, Car.Speed(&Edge{Highway: "motorway", MaxSpeed: 150}))
+}
+
+func TestRouteAStar(t *testing.T) {
+	n := grid(t)
+	from, _ := n.Nearest(pt(0.0005, 0.001), 10)
+	for _, p := range []geometry.Point{pt(0.001, 0.0015), pt(0.002, 0.0015), pt(0.0002, 0.001), pt(0.001, 0.0002)} {
+		to, _ := n.Nearest(p, 10)
+		want := n.Routes(from, []Snap{to}, Car.Weight, math.Inf(1))[0]
+		got := n.Route(from, to, Car.Weight, Car.Heuristic(to.Point))
+		assert.InDelta(t, want.Cost, got.Cost, 1e-9)
+		assert.Equal(t, want.Steps, got.Steps)
+	}
+
+	start, _ := n.Nearest(pt(0.002, 0.0015), 10)
+	assert.Nil(t, n.Route(start, from, Car.Weight, Car.Heuristic(from.Point)))
+}
+
+func TestReachable(t *testing.T) {
+	n := grid(t)
+	from, _ := n.Nearest(pt(0.0005, 0.001), 10)
+	rs := n.Reachable(from, ByLength, 100)
+	// both ways from the position, then out of the two nodes ending its edge
+	assert.Len(t, rs, 7)
+	assert.Equal(t, Reach{Edge: 0, From: from.Offset, To: 0, End: from.Offset}, rs[0])
+	assert.Equal(t, n.Edges[0].Length, rs[1].To)
+	for _, r := range rs[2:] {
+		assert.InDelta(t, 55.6, r.Cost, 0.1)
+		assert.InDelta(t, 166.8, r.End, 0.1)
+	}
+
+	d, ok := rs[2].Within(100)
+	assert.True(t, ok)
+	assert.InDelta(t, 44.4, math.Abs(d-rs[2].From), 0.1)
+	_, ok = rs[2].Within(50)
+	assert.False(t, ok)
+	d, _ = rs[2].Within(1000)
+	assert.Equal(t, rs[2].To, d)
+}
@@ -0,0 +1,91 @@
+// Package routing finds the fastest routes over a road network for a travel profile.
+package routing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// SnapRadius is the distance in meters within which the points are snapped to the roads.
+const SnapRadius = 1000
+
+// ErrNoRoute is returned when the roads don't join two points.
+var ErrNoRoute = errors.New("no route joins the points")
+
+// Result is a route with its length in meters and its duration in seconds, and the IDs of the
+// edges travelled in order.
+type Result struct {
+	Points   []geometry.Point
+	Distance float64
+	Duration float64
+	Edges    []string
+}
+
+// Snap returns the nearest position of the roads open to a profile within SnapRadius meters of a
+// point, name naming the point in the error.
+func Snap(n *roads.Network, p geometry.Point, profile *roads.Profile, name string) (roads.Snap, error) {
+	if math.IsNaN(p.Lat) || math.IsNaN(p.Lng) || math.Abs(p.Lat) > 90 || math.Abs(p.Lng) > 180 {
+		return roads.Snap{}, fmt.Errorf("%s must have a latitude between -90 and 90 and a longitude between -180 and 180", name)
+	}
+	for _, c := range n.Candidates(p, SnapRadius, 0) {
+		e := &n.Edges[c.Edge]
+		if profile.Open(e, true) || profile.Open(e, false) {
+			return c, nil
+		}
+	}
+	return roads.Snap{}, fmt.Errorf("%s is farther than %d meters from the roads open to %s", name, SnapRadius, profile.Name)
+}
+
+// Route returns the fastest route from a to b for a profile, found with A*.
+func Route(n *roads.Network, a, b geometry.Point, profile *roads.Profile) (*Result, error) {
+	from, err := Snap(n, a, profile, "point A")
+	if err != nil {
+		return nil, err
+	}
+	to, err := Snap(n, b, profile, "point B")
+	if err != nil {
+		return nil, err
+	}
+
+	r := n.Route(from, to, profile.Weight, profile.Heuristic(to.Point))
+	if r == nil {
+		return nil, ErrNoRoute
+	}
+	res := &Result{Points: r.Points(n), Distance: r.Length(), Duration: r.Cost}
+	for _, s := range r.Steps {
+		id := n.Edges[s.Edge].ID
+		if s.From != s.To && (len(res.Edges) == 0 || res.Edges[len(res.Edges)-1] != id) {
+			res.Edges = append(res.Edges, id)
+		}
+	}
+	return res, nil
+}
+
+// Matrix returns the distances in meters and the durations in seconds of the fastest routes
+// between each pair of points for a profile, names naming the points in the errors.
+func Matrix(n *roads.Network, points []geometry.Point, names []string, profile *roads.Profile) ([][]float64, [][]float64, error) {
+	snaps := make([]roads.Snap, len(points))
+	for i, p := range points {
+		s, err := Snap(n, p, profile, names[i])
+		if err != nil {
+			return nil, nil, err
+		}
+		snaps[i] = s
+	}
+
+	dist, dur := make([][]float64, len(points)), make([][]float64, len(points))
+	for i, from := range snaps {
+		dist[i], dur[i] = make([]float64, len(points)), make([]float64, len(points))
+		for j, r := range n.Routes(from, snaps, profile.Weight, math.Inf(1)) {
+			if r == nil {
+				return nil, nil, fmt.Errorf("%w: from %s to %s", ErrNoRoute, names[i], names[j])
+			}
+			dist[i][j], dur[i][j] = r.Length(), r.Cost
+		}
+	}
+	return dist, dur, nil
+}
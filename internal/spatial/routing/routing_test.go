@@ -0,0 +1,99 @@
+package routing
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func pt(lng, lat float64) geometry.Point {
+	return geometry.Point{Lng: lng, Lat: lat}
+}
+
+// detour is a residential street from (0, 0) to (0.01, 0) and a longer but faster way between its
+// ends, over a one way motorway going east.
+func detour(t *testing.T) *roads.Network {
+	n, err := roads.Build([]roads.Way{
+		{ID: "street", Points: []geometry.Point{pt(0, 0), pt(0.01, 0)}, Tags: map[string]string{"highway": "residential"}},
+		{ID: "west", Points: []geometry.Point{pt(0, 0), pt(0, 0.002)}, Tags: map[string]string{"highway": "residential"}},
+		{ID: "motorway", Points: []geometry.Point{pt(0, 0.002), pt(0.01, 0.002)}, Tags: map[string]string{"highway": "motorway"}},
+		{ID: "east", Points: []geometry.Point{pt(0.01, 0.002), pt(0.01, 0)}, Tags: map[string]string{"highway": "residential"}},
+		{ID: "island", Points: []geometry.Point{pt(0.02, 0), pt(0.03, 0)}, Tags: map[string]string{"highway": "residential"}},
+	})
+	assert.NoError(t, err)
+	return n
+}
+
+func TestRoute(t *testing.T) {
+	n := detour(t)
+	a, b := pt(0.0001, -0.0001), pt(0.0099, -0.0001)
+
+	r, err := Route(n, a, b, roads.Car)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"street:0", "west:0", "motorway:0", "east:0", "street:0"}, r.Edges)
+	assert.InDelta(t, 1579.0, r.Distance, 0.5)
+	assert.InDelta(t, 92.4, r.Duration, 0.5)
+	assert.InDelta(t, 0.0001, r.Points[0].Lng, 1e-12)
+	assert.InDelta(t, 0.0099, r.Points[len(r.Points)-1].Lng, 1e-12)
+	assert.Len(t, r.Points, 6)
+
+	// the motorway is one way
+	r, err = Route(n, b, a, roads.Car)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"street:0"}, r.Edges)
+	assert.InDelta(t, 1089.7, r.Distance, 0.5)
+	assert.InDelta(t, 130.8, r.Duration, 0.5)
+
+	// walkers can't take the motorway
+	r, err = Route(n, a, b, roads.Foot)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"street:0"}, r.Edges)
+	assert.InDelta(t, 784.6, r.Duration, 0.5)
+
+	r, err = Route(n, a, a, roads.Bike)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, r.Distance)
+	assert.Empty(t, r.Edges)
+}
+
+func TestRouteErrors(t *testing.T) {
+	n := detour(t)
+	_, err := Route(n, pt(0.0001, 0), pt(0.025, 0), roads.Car)
+	assert.Equal(t, ErrNoRoute, err)
+
+	_, err = Route(n, pt(0.0001, 0), pt(1, 1), roads.Car)
+	assert.EqualError(t, err, "point B is farther than 1000 meters from the roads open to car")
+
+	_, err = Route(n, pt(math.NaN(), 0), pt(0.0001, 0), roads.Car)
+	assert.EqualError(t, err, "point A must have a latitude between -90 and 90 and a longitude between -180 and 180")
+
+	// the only road near is the motorway
+	_, err = Route(n, pt(0.005, 0.0021), pt(0.0001, 0), roads.Foot)
+	assert.NoError(t, err)
+	motorway, err := roads.Build([]roads.Way{{ID: "m", Points: []geometry.Point{pt(0, 0), pt(0.01, 0)}, Tags: map[string]string{"highway": "motorway"}}})
+	assert.NoError(t, err)
+	_, err = Route(motorway, pt(0.001, 0), pt(0.002, 0), roads.Foot)
+	assert.EqualError(t, err, "point A is farther than 1000 meters from the roads open to foot")
+}
+
+func TestMatrix(t *testing.T) {
+	n := detour(t)
+	names := []string{"a", "b", "c"}
+	dist, dur, err := Matrix(n, []geometry.Point{pt(0.0001, 0), pt(0.0099, 0), pt(0.005, 0.002)}, names, roads.Car)
+	assert.NoError(t, err)
+	assert.Equal(t, 0.0, dist[0][0])
+	assert.InDelta(t, 1579.0, dist[0][1], 0.5)
+	assert.InDelta(t, 92.4, dur[0][1], 0.5)
+	assert.InDelta(t, 1089.7, dist[1][0], 0.5)
+	// the motorway is one way, its middle is reached from its west end only
+	assert.InDelta(t, 1100.8+222.4+556, dist[1][2], 1)
+
+	_, _, err = Matrix(n, []geometry.Point{pt(0.0001, 0), pt(0.025, 0)}, names, roads.Car)
+	assert.EqualError(t, err, "no route joins the points: from a to b")
+	assert.ErrorIs(t, err, ErrNoRoute)
+	_, _, err = Matrix(n, []geometry.Point{pt(0.0001, 0), pt(1, 0)}, names, roads.Car)
+	assert.EqualError(t, err, "b is farther than 1000 meters from the roads open to car")
+}
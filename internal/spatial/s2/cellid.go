@@ -0,0 +1,244 @@
+package s2
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"math/bits"
+	"strconv"
+	"strings"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// The cell ID layout follows the S2 geometry library: 3 bits for the face followed by
+// 2 bits per level along the Hilbert curve and a trailing 1 bit marking the level.
+// https://s2geometry.io/devguide/s2cell_hierarchy
+const (
+	// MaxLevel is the level of the leaf cells.
+	MaxLevel = 30
+
+	faceBits   = 3
+	numFaces   = 6
+	posBits    = 2*MaxLevel + 1
+	maxSize    = 1 << MaxLevel
+	lookupBits = 4
+	swapMask   = 0x01
+	invertMask = 0x02
+)
+
+var (
+	posToIJ          = [4][4]int{{0, 1, 3, 2}, {0, 2, 3, 1}, {3, 2, 0, 1}, {3, 1, 0, 2}}
+	posToOrientation = [4]int{swapMask, 0, 0, invertMask | swapMask}
+
+	lookupPos, lookupIJ = buildLookupTables()
+)
+
+// CellID uniquely identifies a cell in the S2 cell decomposition.
+type CellID uint64
+
+// CellIDFromPoint returns the cell containing the point at the given level.
+func CellIDFromPoint(p geometry.Point, level int) (CellID, error) {
+	if level < 0 || level > MaxLevel {
+		return 0, fmt.Errorf("level must be between 0 and %d", MaxLevel)
+	}
+	if math.IsNaN(p.Lat) || math.IsNaN(p.Lng) || p.Lat < -90 || p.Lat > 90 {
+		return 0, errors.New("invalid point")
+	}
+	f, u, v := xyzToFaceUV(latLngToXYZ(p))
+	i := stToIJ(uvToST(u))
+	j := stToIJ(uvToST(v))
+
+	return cellIDFromFaceIJ(f, i, j).Parent(level), nil
+}
+
+// CellIDFromToken parses a cell token, the hexadecimal representation of the ID without trailing zeros.
+func CellIDFromToken(token string) (CellID, error) {
+	if token == "" || len(token) > 16 {
+		return 0, errors.New("invalid cell token")
+	}
+	id, err := strconv.ParseUint(token+strings.Repeat("0", 16-len(token)), 16, 64)
+	if err != nil {
+		return 0, errors.New("invalid cell token")
+	}
+	c := CellID(id)
+	if !c.IsValid() {
+		return 0, errors.New("invalid cell token")
+	}
+	return c, nil
+}
+
+// CellIDFromString parses a cell ID given either as a token or as a signed or unsigned decimal integer.
+// BigQuery stores cell IDs as signed INT64 values.
+func CellIDFromString(s string) (CellID, error) {
+	if u, err := strconv.ParseUint(s, 10, 64); err == nil && CellID(u).IsValid() {
+		return CellID(u), nil
+	}
+	if i, err := strconv.ParseInt(s, 10, 64); err == nil && CellID(uint64(i)).IsValid() {
+		return CellID(uint64(i)), nil
+	}
+	return CellIDFromToken(s)
+}
+
+// IsValid reports whether the ID represents a valid cell.
+func (c CellID) IsValid() bool {
+	return c.Face() < numFaces && c.lsb()&0x1555555555555555 != 0
+}
+
+// Face returns the cube face of the cell.
+func (c CellID) Face() int {
+	return int(uint64(c) >> posBits)
+}
+
+// Level returns the subdivision level of the cell.
+func (c CellID) Level() int {
+	return MaxLevel - bits.TrailingZeros64(uint64(c))>>1
+}
+
+// Token returns the hexadecimal representation of the ID without trailing zeros.
+func (c CellID) Token() string {
+	if c == 0 {
+		return "X"
+	}
+	s := strings.TrimRight(fmt.Sprintf("%016x", uint64(c)), "0")
+	return s
+}
+
+// Int64 returns the signed representation of the ID, as used by BigQuery.
+func (c CellID) Int64() int64 {
+	return int64(c)
+}
+
+// Parent returns the ancestor of the cell at the given level.
+// Levels deeper than the cell's level return the cell itself.
+func (c CellID) Parent(level int) CellID {
+	if level >= c.Level() {
+		return c
+	}
+	lsb := lsbForLevel(level)
+	return CellID((uint64(c) & -lsb) | lsb)
+}
+
+// Children returns the four children of the cell.
+func (c CellID) Children() [4]CellID {
+	var ch [4]CellID
+	lsb := c.lsb()
+	child := uint64(c) - lsb + lsb>>2
+	for k := 0; k < 4; k++ {
+		ch[k] = CellID(child)
+		child += lsb >> 1
+	}
+	return ch
+}
+
+// Contains reports whether the cell contains the other cell.
+func (c CellID) Contains(o CellID) bool {
+	return c.rangeMin() <= o && o <= c.rangeMax()
+}
+
+// Center returns the center of the cell.
+func (c CellID) Center() geometry.Point {
+	u0, v0, u1, v1 := c.boundUV()
+	return xyzToLatLng(faceUVToXYZ(c.Face(), (u0+u1)/2, (v0+v1)/2))
+}
+
+// Vertices returns the four corners of the cell in counterclockwise order.
+func (c CellID) Vertices() [4]geometry.Point {
+	u0, v0, u1, v1 := c.boundUV()
+	f := c.Face()
+	return [4]geometry.Point{
+		xyzToLatLng(faceUVToXYZ(f, u0, v0)),
+		xyzToLatLng(faceUVToXYZ(f, u1, v0)),
+		xyzToLatLng(faceUVToXYZ(f, u1, v1)),
+		xyzToLatLng(faceUVToXYZ(f, u0, v1)),
+	}
+}
+
+func (c CellID) lsb() uint64 {
+	return uint64(c) & -uint64(c)
+}
+
+func (c CellID) rangeMin() CellID {
+	return CellID(uint64(c) - (c.lsb() - 1))
+}
+
+func (c CellID) rangeMax() CellID {
+	return CellID(uint64(c) + (c.lsb() - 1))
+}
+
+// boundUV returns the bounds of the cell in the (u,v) coordinates of its face.
+func (c CellID) boundUV() (u0, v0, u1, v1 float64) {
+	_, i, j, _ := c.faceIJOrientation()
+	size := 1 << uint(MaxLevel-c.Level())
+	i &= -size
+	j &= -size
+	u0 = stToUV(float64(i) / maxSize)
+	u1 = stToUV(float64(i+size) / maxSize)
+	v0 = stToUV(float64(j) / maxSize)
+	v1 = stToUV(float64(j+size) / maxSize)
+	return u0, v0, u1, v1
+}
+
+func (c CellID) faceIJOrientation() (f, i, j, orientation int) {
+	f = c.Face()
+	orientation = f & swapMask
+	nbits := MaxLevel - 7*lookupBits
+	for k := 7; k >= 0; k-- {
+		orientation += (int(uint64(c)>>uint(k*2*lookupBits+1)) & ((1 << uint(2*nbits)) - 1)) << 2
+		orientation = lookupIJ[orientation]
+		i += (orientation >> (lookupBits + 2)) << uint(k*lookupBits)
+		j += ((orientation >> 2) & ((1 << lookupBits) - 1)) << uint(k*lookupBits)
+		orientation &= swapMask | invertMask
+		nbits = lookupBits
+	}
+	if c.lsb()&0x1111111111111110 != 0 {
+		orientation ^= swapMask
+	}
+	return f, i, j, orientation
+}
+
+func lsbForLevel(level int) uint64 {
+	return 1 << uint(2*(MaxLevel-level))
+}
+
+func cellIDFromFace(f int) CellID {
+	return CellID(uint64(f)<<posBits + lsbForLevel(0))
+}
+
+func cellIDFromFaceIJ(f, i, j int) CellID {
+	n := uint64(f) << (posBits - 1)
+	b := f & swapMask
+	for k := 7; k >= 0; k-- {
+		mask := (1 << lookupBits) - 1
+		b += ((i >> uint(k*lookupBits)) & mask) << (lookupBits + 2)
+		b += ((j >> uint(k*lookupBits)) & mask) << 2
+		b = lookupPos[b]
+		n |= uint64(b>>2) << (uint(k) * 2 * lookupBits)
+		b &= swapMask | invertMask
+	}
+	return CellID(n*2 + 1)
+}
+
+func buildLookupTables() (pos, ij [1 << (2*lookupBits + 2)]int) {
+	var initCell func(level, i, j, origOrientation, p, orientation int)
+	initCell = func(level, i, j, origOrientation, p, orientation int) {
+		if level == lookupBits {
+			v := (i << lookupBits) + j
+			pos[(v<<2)+origOrientation] = (p << 2) + orientation
+			ij[(p<<2)+origOrientation] = (v << 2) + orientation
+			return
+		}
+		level++
+		i <<= 1
+		j <<= 1
+		p <<= 2
+		r := posToIJ[orientation]
+		for k := 0; k < 4; k++ {
+			initCell(level, i+(r[k]>>1), j+(r[k]&1), origOrientation, p+k, orientation^posToOrientation[k])
+		}
+	}
+	for _, o := range []int{0, swapMask, invertMask, swapMask | invertMask} {
+		initCell(0, 0, 0, o, 0, o)
+	}
+	return pos, ij
+}
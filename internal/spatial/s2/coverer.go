@@ -0,0 +1,177 @@
+package s2
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// DefaultMaxCells is the number of cells a covering is limited to when no limit is given.
+const DefaultMaxCells = 8
+
+// MaxCoveringCells bounds the number of cells of a covering, including the cells a coarse covering
+// is expanded to in order to reach the minimum level.
+const MaxCoveringCells = 10000
+
+// Coverer approximates regions with collections of cells, similar to S2RegionCoverer.
+// MinLevel takes priority over MaxCells: coverings are never made of cells coarser than MinLevel,
+// even if this results in more than MaxCells cells.
+type Coverer struct {
+	MinLevel int
+	MaxLevel int
+	MaxCells int
+}
+
+// NewCoverer returns a coverer with the given options.
+func NewCoverer(minLevel, maxLevel, maxCells int) (*Coverer, error) {
+	if minLevel < 0 || minLevel > MaxLevel || maxLevel < 0 || maxLevel > MaxLevel {
+		return nil, fmt.Errorf("levels must be between 0 and %d", MaxLevel)
+	}
+	if minLevel > maxLevel {
+		return nil, errors.New("min level can't be greater than max level")
+	}
+	if maxCells < 1 || maxCells > MaxCoveringCells {
+		return nil, fmt.Errorf("max cells must be between 1 and %d", MaxCoveringCells)
+	}
+	return &Coverer{
+		MinLevel: minLevel,
+		MaxLevel: maxLevel,
+		MaxCells: maxCells,
+	}, nil
+}
+
+// Covering returns a normalized list of cells that covers the given polygons.
+// Every polygon is given as a list of rings; polygon edges are geodesics as in S2.
+func (rc *Coverer) Covering(polygons [][][]geometry.Point) ([]CellID, error) {
+	if len(polygons) == 0 {
+		return nil, errors.New("polygons can't be empty")
+	}
+	reg := newRegion(polygons)
+
+	var result []CellID
+	q := &candidates{}
+	add := func(id CellID, rel relation) {
+		if rel == contained || id.Level() >= rc.MaxLevel {
+			result = append(result, id)
+			return
+		}
+		heap.Push(q, id)
+	}
+
+	for f := 0; f < numFaces; f++ {
+		id := cellIDFromFace(f)
+		if rel := reg.relate(id); rel != disjoint {
+			add(id, rel)
+		}
+	}
+
+	for q.Len() > 0 {
+		id := heap.Pop(q).(CellID)
+		var children []CellID
+		var rels []relation
+		for _, ch := range id.Children() {
+			if rel := reg.relate(ch); rel != disjoint {
+				children = append(children, ch)
+				rels = append(rels, rel)
+			}
+		}
+		if id.Level() < rc.MinLevel || len(result)+q.Len()+len(children) <= rc.MaxCells {
+			for k := range children {
+				add(children[k], rels[k])
+			}
+			// the cells are never fewer than before splitting one, nor than the covering
+			if len(result)+q.Len() > MaxCoveringCells {
+				return nil, errTooManyCells
+			}
+			continue
+		}
+		result = append(result, id)
+	}
+
+	// the cells coarser than the minimum level are expanded to their descendants at that level
+	n := 0
+	for _, id := range result {
+		d := rc.MinLevel - id.Level()
+		if d <= 0 {
+			n++
+			continue
+		}
+		if d > 7 {
+			return nil, errTooManyCells
+		}
+		n += 1 << (2 * d)
+	}
+	if n > MaxCoveringCells {
+		return nil, errTooManyCells
+	}
+
+	return normalize(result, rc.MinLevel), nil
+}
+
+var errTooManyCells = fmt.Errorf("the covering has more than %d cells, lower the min level", MaxCoveringCells)
+
+// normalize sorts the cells, removes the ones contained in others, replaces groups of four
+// siblings with their parent and expands the cells coarser than the minimum level.
+func normalize(ids []CellID, minLevel int) []CellID {
+	var expanded []CellID
+	for _, id := range ids {
+		expanded = append(expanded, expand(id, minLevel)...)
+	}
+	sort.Slice(expanded, func(i, j int) bool { return expanded[i] < expanded[j] })
+
+	var out []CellID
+	for _, id := range expanded {
+		if len(out) > 0 && out[len(out)-1].Contains(id) {
+			continue
+		}
+		for len(out) > 0 && id.Contains(out[len(out)-1]) {
+			out = out[:len(out)-1]
+		}
+		out = append(out, id)
+		for len(out) >= 4 {
+			last := out[len(out)-1]
+			if last.Level() <= minLevel || last.Level() == 0 {
+				break
+			}
+			parent := last.Parent(last.Level() - 1)
+			if out[len(out)-4] != parent.Children()[0] ||
+				out[len(out)-3] != parent.Children()[1] ||
+				out[len(out)-2] != parent.Children()[2] ||
+				last != parent.Children()[3] {
+				break
+			}
+			out = append(out[:len(out)-4], parent)
+		}
+	}
+
+	return out
+}
+
+func expand(id CellID, minLevel int) []CellID {
+	if id.Level() >= minLevel {
+		return []CellID{id}
+	}
+	var res []CellID
+	for _, ch := range id.Children() {
+		res = append(res, expand(ch, minLevel)...)
+	}
+	return res
+}
+
+// candidates is a priority queue that returns the coarsest cells first.
+type candidates []CellID
+
+func (c candidates) Len() int            { return len(c) }
+func (c candidates) Less(i, j int) bool  { return c[i].Level() < c[j].Level() }
+func (c candidates) Swap(i, j int)       { c[i], c[j] = c[j], c[i] }
+func (c *candidates) Push(x interface{}) { *c = append(*c, x.(CellID)) }
+func (c *candidates) Pop() interface{} {
+	old := *c
+	n := len(old)
+	x := old[n-1]
+	*c = old[:n-1]
+	return x
+}
@@ -0,0 +1,116 @@
+package s2
+
+import (
+	"math"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// vector is a point in 3D space; points on the sphere are unit vectors.
+type vector struct {
+	X, Y, Z float64
+}
+
+func latLngToXYZ(p geometry.Point) vector {
+	lat := p.Lat * math.Pi / 180
+	lng := p.Lng * math.Pi / 180
+	return vector{
+		X: math.Cos(lat) * math.Cos(lng),
+		Y: math.Cos(lat) * math.Sin(lng),
+		Z: math.Sin(lat),
+	}
+}
+
+func xyzToLatLng(v vector) geometry.Point {
+	lat := math.Atan2(v.Z, math.Hypot(v.X, v.Y))
+	lng := math.Atan2(v.Y, v.X)
+	return geometry.Point{
+		Lat: lat * 180 / math.Pi,
+		Lng: lng * 180 / math.Pi,
+	}
+}
+
+func xyzToFaceUV(v vector) (f int, u, w float64) {
+	f = largestAbsComponent(v)
+	if (f == 0 && v.X < 0) || (f == 1 && v.Y < 0) || (f == 2 && v.Z < 0) {
+		f += 3
+	}
+	a, b, c := faceUVW(f, v)
+	return f, a / c, b / c
+}
+
+// faceUVW returns the coordinates of the vector in the frame of the face, where w is
+// the component along the face normal. Dividing a and b by w gives the (u,v) gnomonic projection.
+func faceUVW(f int, v vector) (a, b, w float64) {
+	switch f {
+	case 0:
+		return v.Y, v.Z, v.X
+	case 1:
+		return -v.X, v.Z, v.Y
+	case 2:
+		return -v.X, -v.Y, v.Z
+	case 3:
+		return -v.Z, -v.Y, -v.X
+	case 4:
+		return -v.Z, v.X, -v.Y
+	default:
+		return v.Y, v.X, -v.Z
+	}
+}
+
+func faceUVToXYZ(f int, u, v float64) vector {
+	switch f {
+	case 0:
+		return vector{1, u, v}
+	case 1:
+		return vector{-u, 1, v}
+	case 2:
+		return vector{-u, -v, 1}
+	case 3:
+		return vector{-1, -v, -u}
+	case 4:
+		return vector{v, -1, -u}
+	default:
+		return vector{v, u, -1}
+	}
+}
+
+func largestAbsComponent(v vector) int {
+	x, y, z := math.Abs(v.X), math.Abs(v.Y), math.Abs(v.Z)
+	if x > y {
+		if x > z {
+			return 0
+		}
+		return 2
+	}
+	if y > z {
+		return 1
+	}
+	return 2
+}
+
+// stToUV and uvToST implement the quadratic projection used by S2 to keep the cell areas uniform.
+func stToUV(s float64) float64 {
+	if s >= 0.5 {
+		return (1 / 3.) * (4*s*s - 1)
+	}
+	return (1 / 3.) * (1 - 4*(1-s)*(1-s))
+}
+
+func uvToST(u float64) float64 {
+	if u >= 0 {
+		return 0.5 * math.Sqrt(1+3*u)
+	}
+	return 1 - 0.5*math.Sqrt(1-3*u)
+}
+
+func stToIJ(s float64) int {
+	i := int(math.Floor(maxSize * s))
+	if i < 0 {
+		return 0
+	}
+	if i > maxSize-1 {
+		return maxSize - 1
+	}
+	return i
+}
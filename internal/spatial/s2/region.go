@@ -0,0 +1,169 @@
+package s2
+
+import (
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+type relation int
+
+const (
+	disjoint relation = iota
+	intersects
+	contained
+)
+
+// faceClip bounds the area of a face the polygons are clipped to, in (u,v) coordinates.
+// It is larger than the face itself so that the clipping edges never touch a cell.
+const faceClip = 2.0
+
+type uv struct {
+	U, V float64
+}
+
+// region is a set of polygons projected on each of the cube faces. The gnomonic projection maps
+// geodesic edges to straight lines, so the relation with a cell can be computed in the plane.
+type region struct {
+	faces [numFaces][][][]uv
+}
+
+func newRegion(polygons [][][]geometry.Point) *region {
+	r := &region{}
+	for f := 0; f < numFaces; f++ {
+		for _, rings := range polygons {
+			var projected [][]uv
+			for _, ring := range rings {
+				if pr := projectRing(f, ring); len(pr) >= 3 {
+					projected = append(projected, pr)
+				}
+			}
+			if len(projected) > 0 {
+				r.faces[f] = append(r.faces[f], projected)
+			}
+		}
+	}
+	return r
+}
+
+// relate returns the relation of the cell with the region.
+func (r *region) relate(id CellID) relation {
+	polys := r.faces[id.Face()]
+	if len(polys) == 0 {
+		return disjoint
+	}
+	u0, v0, u1, v1 := id.boundUV()
+	for _, rings := range polys {
+		for _, ring := range rings {
+			for k := range ring {
+				a, b := ring[k], ring[(k+1)%len(ring)]
+				if segmentIntersectsRect(a, b, u0, v0, u1, v1) {
+					return intersects
+				}
+			}
+		}
+	}
+	c := uv{(u0 + u1) / 2, (v0 + v1) / 2}
+	for _, rings := range polys {
+		if containsUV(rings, c) {
+			return contained
+		}
+	}
+	return disjoint
+}
+
+// projectRing clips the ring to the area around the face and projects it on the face plane.
+// Clipping is done against planes through the origin, so interpolating linearly in 3D
+// keeps the clipped points on the original geodesic edges.
+func projectRing(f int, ring []geometry.Point) []uv {
+	pts := make([][3]float64, 0, len(ring))
+	for _, p := range ring {
+		a, b, w := faceUVW(f, latLngToXYZ(p))
+		pts = append(pts, [3]float64{a, b, w})
+	}
+	if len(pts) > 1 && pts[0] == pts[len(pts)-1] {
+		pts = pts[:len(pts)-1]
+	}
+
+	planes := [][3]float64{
+		{1, 0, -faceClip},
+		{-1, 0, -faceClip},
+		{0, 1, -faceClip},
+		{0, -1, -faceClip},
+	}
+	for _, pl := range planes {
+		pts = clipPlane(pts, pl)
+		if len(pts) == 0 {
+			return nil
+		}
+	}
+
+	res := make([]uv, 0, len(pts))
+	for _, p := range pts {
+		res = append(res, uv{p[0] / p[2], p[1] / p[2]})
+	}
+	return res
+}
+
+// clipPlane keeps the part of the closed ring where dot(p, plane) <= 0 (Sutherland-Hodgman).
+func clipPlane(pts [][3]float64, pl [3]float64) [][3]float64 {
+	dot := func(p [3]float64) float64 { return p[0]*pl[0] + p[1]*pl[1] + p[2]*pl[2] }
+	var out [][3]float64
+	for k := range pts {
+		cur, next := pts[k], pts[(k+1)%len(pts)]
+		dc, dn := dot(cur), dot(next)
+		if dc <= 0 {
+			out = append(out, cur)
+		}
+		if (dc < 0 && dn > 0) || (dc > 0 && dn < 0) {
+			t := dc / (dc - dn)
+			out = append(out, [3]float64{
+				cur[0] + t*(next[0]-cur[0]),
+				cur[1] + t*(next[1]-cur[1]),
+				cur[2] + t*(next[2]-cur[2]),
+			})
+		}
+	}
+	return out
+}
+
+// containsUV applies the even-odd rule over all the rings of a polygon.
+func containsUV(rings [][]uv, p uv) bool {
+	in := false
+	for _, ring := range rings {
+		for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+			a, b := ring[i], ring[j]
+			if (a.V > p.V) != (b.V > p.V) && p.U < (b.U-a.U)*(p.V-a.V)/(b.V-a.V)+a.U {
+				in = !in
+			}
+		}
+	}
+	return in
+}
+
+// segmentIntersectsRect uses the Liang-Barsky algorithm to test a segment against a rectangle.
+func segmentIntersectsRect(a, b uv, u0, v0, u1, v1 float64) bool {
+	t0, t1 := 0.0, 1.0
+	du, dv := b.U-a.U, b.V-a.V
+	clip := func(p, q float64) bool {
+		if p == 0 {
+			return q >= 0
+		}
+		t := q / p
+		if p < 0 {
+			if t > t1 {
+				return false
+			}
+			if t > t0 {
+				t0 = t
+			}
+		} else {
+			if t < t0 {
+				return false
+			}
+			if t < t1 {
+				t1 = t
+			}
+		}
+		return true
+	}
+	return clip(-du, a.U-u0) && clip(du, u1-a.U) && clip(-dv, a.V-v0) && clip(dv, v1-a.V)
+}
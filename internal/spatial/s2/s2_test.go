@@ -0,0 +1,209 @@
+package s2
+
+import (
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestCellIDFromPoint(t *testing.T) {
+	tests := map[string]struct {
+		point geometry.Point
+		level int
+		token string
+		face  int
+	}{
+		"face 0": {
+			point: geometry.Point{Lat: 0, Lng: 0},
+			level: 0,
+			token: "1",
+			face:  0,
+		},
+		"face 1": {
+			point: geometry.Point{Lat: 0, Lng: 90},
+			level: 0,
+			token: "3",
+			face:  1,
+		},
+		"north pole": {
+			point: geometry.Point{Lat: 90, Lng: 0},
+			level: 0,
+			token: "5",
+			face:  2,
+		},
+		"south pole": {
+			point: geometry.Point{Lat: -90, Lng: 0},
+			level: 0,
+			token: "b",
+			face:  5,
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			id, err := CellIDFromPoint(tt.point, tt.level)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.token, id.Token())
+			assert.Equal(t, tt.face, id.Face())
+			assert.Equal(t, tt.level, id.Level())
+		})
+	}
+}
+
+func TestCellIDInvalidLevel(t *testing.T) {
+	_, err := CellIDFromPoint(geometry.Point{Lat: 0, Lng: 0}, 31)
+	assert.Error(t, err)
+}
+
+func TestCellIDHierarchy(t *testing.T) {
+	p := geometry.Point{Lat: 37.7749, Lng: -122.4194}
+	leaf, err := CellIDFromPoint(p, MaxLevel)
+	assert.NoError(t, err)
+	assert.True(t, leaf.IsValid())
+
+	for level := 0; level < MaxLevel; level++ {
+		id, err := CellIDFromPoint(p, level)
+		assert.NoError(t, err)
+		assert.Equal(t, level, id.Level())
+		assert.True(t, id.Contains(leaf))
+		assert.Equal(t, id, leaf.Parent(level))
+
+		found := false
+		for _, ch := range id.Children() {
+			assert.Equal(t, level+1, ch.Level())
+			assert.Equal(t, id, ch.Parent(level))
+			if ch.Contains(leaf) {
+				found = true
+			}
+		}
+		assert.True(t, found)
+	}
+
+	center := leaf.Center()
+	assert.InDelta(t, p.Lat, center.Lat, 1e-6)
+	assert.InDelta(t, p.Lng, center.Lng, 1e-6)
+}
+
+func TestCellIDFromToken(t *testing.T) {
+	p := geometry.Point{Lat: 40.7128, Lng: -74.0060}
+	id, err := CellIDFromPoint(p, 12)
+	assert.NoError(t, err)
+
+	parsed, err := CellIDFromToken(id.Token())
+	assert.NoError(t, err)
+	assert.Equal(t, id, parsed)
+
+	parsed, err = CellIDFromString(id.Token())
+	assert.NoError(t, err)
+	assert.Equal(t, id, parsed)
+
+	_, err = CellIDFromToken("zz")
+	assert.Error(t, err)
+	_, err = CellIDFromToken("")
+	assert.Error(t, err)
+}
+
+func TestCellIDFromStringSigned(t *testing.T) {
+	// cells on faces 4 and 5 have the sign bit set when stored as INT64.
+	id, err := CellIDFromPoint(geometry.Point{Lat: -89, Lng: 10}, 10)
+	assert.NoError(t, err)
+	assert.Less(t, id.Int64(), int64(0))
+
+	parsed, err := CellIDFromString(strconv.FormatInt(id.Int64(), 10))
+	assert.NoError(t, err)
+	assert.Equal(t, id, parsed)
+}
+
+func TestCovering(t *testing.T) {
+	square := [][][]geometry.Point{{{
+		{Lat: 37.70, Lng: -122.50},
+		{Lat: 37.70, Lng: -122.35},
+		{Lat: 37.82, Lng: -122.35},
+		{Lat: 37.82, Lng: -122.50},
+		{Lat: 37.70, Lng: -122.50},
+	}}}
+
+	rc, err := NewCoverer(4, 16, 8)
+	assert.NoError(t, err)
+	cells, err := rc.Covering(square)
+	assert.NoError(t, err)
+	assert.NotEmpty(t, cells)
+	assert.LessOrEqual(t, len(cells), 8)
+
+	for _, p := range []geometry.Point{
+		{Lat: 37.70, Lng: -122.50},
+		{Lat: 37.76, Lng: -122.42},
+		{Lat: 37.82, Lng: -122.35},
+	} {
+		leaf, err := CellIDFromPoint(p, MaxLevel)
+		assert.NoError(t, err)
+		covered := false
+		for _, c := range cells {
+			assert.GreaterOrEqual(t, c.Level(), 4)
+			assert.LessOrEqual(t, c.Level(), 16)
+			if c.Contains(leaf) {
+				covered = true
+			}
+		}
+		assert.True(t, covered, "point %v is not covered", p)
+	}
+
+	outside, err := CellIDFromPoint(geometry.Point{Lat: 36.0, Lng: -120.0}, MaxLevel)
+	assert.NoError(t, err)
+	for _, c := range cells {
+		assert.False(t, c.Contains(outside))
+	}
+}
+
+func TestCoveringMinLevel(t *testing.T) {
+	square := [][][]geometry.Point{{{
+		{Lat: -1, Lng: -1},
+		{Lat: -1, Lng: 1},
+		{Lat: 1, Lng: 1},
+		{Lat: 1, Lng: -1},
+		{Lat: -1, Lng: -1},
+	}}}
+
+	rc, err := NewCoverer(10, 10, 1)
+	assert.NoError(t, err)
+	cells, err := rc.Covering(square)
+	assert.NoError(t, err)
+	assert.Greater(t, len(cells), 1)
+	for _, c := range cells {
+		assert.Equal(t, 10, c.Level())
+	}
+}
+
+func TestCoveringTooManyCells(t *testing.T) {
+	square := func(d float64) [][][]geometry.Point {
+		return [][][]geometry.Point{{{{Lat: -d, Lng: -d}, {Lat: -d, Lng: d}, {Lat: d, Lng: d}, {Lat: d, Lng: -d}, {Lat: -d, Lng: -d}}}}
+	}
+
+	rc, err := NewCoverer(12, 12, 8)
+	assert.NoError(t, err)
+	_, err = rc.Covering(square(40))
+	assert.EqualError(t, err, "the covering has more than 10000 cells, lower the min level")
+
+	// a face contained in the region would expand to 4^12 cells
+	_, err = rc.Covering(square(89))
+	assert.EqualError(t, err, "the covering has more than 10000 cells, lower the min level")
+
+	rc, err = NewCoverer(20, 30, 8)
+	assert.NoError(t, err)
+	cells, err := rc.Covering(square(0.001))
+	assert.NoError(t, err)
+	assert.LessOrEqual(t, len(cells), MaxCoveringCells)
+}
+
+func TestNewCovererInvalid(t *testing.T) {
+	_, err := NewCoverer(10, 5, 8)
+	assert.Error(t, err)
+	_, err = NewCoverer(0, 31, 8)
+	assert.Error(t, err)
+	_, err = NewCoverer(0, 10, 0)
+	assert.Error(t, err)
+	_, err = NewCoverer(0, 10, MaxCoveringCells+1)
+	assert.Error(t, err)
+}
@@ -0,0 +1,327 @@
+package simplify
+
+import (
+	"container/heap"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Algorithm is a line simplification algorithm.
+type Algorithm string
+
+const (
+	// DouglasPeucker keeps the vertices farther than the tolerance from the simplified line.
+	DouglasPeucker Algorithm = "douglas-peucker"
+	// Visvalingam removes the vertices forming the smallest triangles with their neighbours
+	// until every triangle is larger than the square of the tolerance.
+	Visvalingam Algorithm = "visvalingam"
+)
+
+// ParseAlgorithm returns the algorithm with the given name, Douglas-Peucker when it is empty.
+func ParseAlgorithm(s string) (Algorithm, error) {
+	switch Algorithm(strings.ToLower(s)) {
+	case "", DouglasPeucker:
+		return DouglasPeucker, nil
+	case Visvalingam:
+		return Visvalingam, nil
+	default:
+		return "", fmt.Errorf("unsupported algorithm %q, expected one of %s or %s", s, DouglasPeucker, Visvalingam)
+	}
+}
+
+// Options configures a simplification.
+type Options struct {
+	Algorithm Algorithm
+	// Tolerance is the angular distance in radians below which vertices are removed.
+	Tolerance float64
+	// PreserveTopology restores removed vertices until the simplified parts don't intersect
+	// each other or themselves.
+	PreserveTopology bool
+}
+
+// Validate checks the options.
+func (o Options) Validate() error {
+	if o.Tolerance < 0 || math.IsNaN(o.Tolerance) || math.IsInf(o.Tolerance, 0) {
+		return errors.New("tolerance must be a positive number")
+	}
+	if _, err := ParseAlgorithm(string(o.Algorithm)); err != nil {
+		return err
+	}
+	return nil
+}
+
+// Lines simplifies a group of lines. With PreserveTopology none of the simplified lines intersect
+// each other, unless the input lines already did.
+func Lines(ls [][]geometry.Point, o Options) ([][]geometry.Point, error) {
+	return simplify(ls, false, o)
+}
+
+// Rings simplifies a group of closed rings, such as the rings of a Polygon or a MultiPolygon.
+// Every ring keeps at least four positions.
+func Rings(rings [][]geometry.Point, o Options) ([][]geometry.Point, error) {
+	return simplify(rings, true, o)
+}
+
+type vec struct {
+	x, y float64
+}
+
+func simplify(parts [][]geometry.Point, closed bool, o Options) ([][]geometry.Point, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+
+	projected := project(parts)
+	keeps := make([][]bool, len(parts))
+	for i, ps := range projected {
+		minKeep := 2
+		if closed {
+			minKeep = 4
+		}
+		if o.Algorithm == Visvalingam {
+			keeps[i] = visvalingam(ps, o.Tolerance*o.Tolerance, minKeep)
+		} else {
+			keeps[i] = douglasPeucker(ps, o.Tolerance, minKeep)
+		}
+	}
+	if o.PreserveTopology {
+		repair(projected, keeps, closed)
+	}
+
+	res := make([][]geometry.Point, 0, len(parts))
+	for i, ps := range parts {
+		out := make([]geometry.Point, 0, len(ps))
+		for j, p := range ps {
+			if keeps[i][j] {
+				out = append(out, p)
+			}
+		}
+		res = append(res, out)
+	}
+
+	return res, nil
+}
+
+// project converts the positions to an equirectangular plane in radians centered on their mean
+// latitude, where the distances are close to the angular distances on the sphere. Longitudes are
+// unwrapped so that lines crossing the antimeridian stay continuous.
+func project(parts [][]geometry.Point) [][]vec {
+	var sum float64
+	n := 0
+	for _, ps := range parts {
+		for _, p := range ps {
+			sum += p.Lat
+			n++
+		}
+	}
+	k := 1.0
+	if n > 0 {
+		k = math.Cos(sum / float64(n) * math.Pi / 180)
+	}
+
+	res := make([][]vec, 0, len(parts))
+	for _, ps := range parts {
+		vs := make([]vec, 0, len(ps))
+		var lng float64
+		for i, p := range ps {
+			if i == 0 {
+				lng = p.Lng
+			} else {
+				d := p.Lng - ps[i-1].Lng
+				if d > 180 {
+					d -= 360
+				} else if d < -180 {
+					d += 360
+				}
+				lng += d
+			}
+			vs = append(vs, vec{x: lng * math.Pi / 180 * k, y: p.Lat * math.Pi / 180})
+		}
+		res = append(res, vs)
+	}
+
+	return res
+}
+
+// Planar simplifies a line of positions on a plane, as x and y, with the Douglas-Peucker algorithm,
+// keeping its ends. A closed line keeps its first and last positions too.
+func Planar(ps [][2]float64, tolerance float64) [][2]float64 {
+	vs := make([]vec, len(ps))
+	for i, p := range ps {
+		vs[i] = vec{x: p[0], y: p[1]}
+	}
+	keep := douglasPeucker(vs, tolerance, 2)
+	res := make([][2]float64, 0, len(ps))
+	for i, p := range ps {
+		if keep[i] {
+			res = append(res, p)
+		}
+	}
+	return res
+}
+
+// douglasPeucker marks the vertices kept by the Douglas-Peucker algorithm. The distances are
+// measured to the segments and not to the lines through them, so that closed rings, where the
+// first and last vertices are the same, are split at their farthest vertex first.
+func douglasPeucker(ps []vec, tolerance float64, minKeep int) []bool {
+	keep := make([]bool, len(ps))
+	if len(ps) <= minKeep {
+		for i := range keep {
+			keep[i] = true
+		}
+		return keep
+	}
+	keep[0], keep[len(ps)-1] = true, true
+	kept := 2
+
+	type span struct{ i, j int }
+	stack := []span{{0, len(ps) - 1}}
+	var skipped []span
+	for len(stack) > 0 {
+		s := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+
+		idx, d := farthest(ps, s.i, s.j)
+		if idx < 0 {
+			continue
+		}
+		if d <= tolerance {
+			skipped = append(skipped, s)
+			continue
+		}
+		keep[idx] = true
+		kept++
+		stack = append(stack, span{s.i, idx}, span{idx, s.j})
+	}
+
+	// short rings get back the farthest vertices regardless of the tolerance
+	for kept < minKeep && len(skipped) > 0 {
+		best, bestIdx, bestD := -1, -1, -1.0
+		for k, s := range skipped {
+			if idx, d := farthest(ps, s.i, s.j); idx >= 0 && d > bestD {
+				best, bestIdx, bestD = k, idx, d
+			}
+		}
+		if best < 0 {
+			break
+		}
+		s := skipped[best]
+		skipped = append(skipped[:best], skipped[best+1:]...)
+		skipped = append(skipped, span{s.i, bestIdx}, span{bestIdx, s.j})
+		keep[bestIdx] = true
+		kept++
+	}
+
+	return keep
+}
+
+// farthest returns the vertex between i and j farthest from the segment joining them, or -1 when
+// there are no vertices between them.
+func farthest(ps []vec, i, j int) (int, float64) {
+	idx, max := -1, -1.0
+	for k := i + 1; k < j; k++ {
+		if d := segmentDistance(ps[k], ps[i], ps[j]); d > max {
+			idx, max = k, d
+		}
+	}
+	return idx, max
+}
+
+func segmentDistance(p, a, b vec) float64 {
+	dx, dy := b.x-a.x, b.y-a.y
+	px, py := a.x, a.y
+	if dx != 0 || dy != 0 {
+		t := ((p.x-a.x)*dx + (p.y-a.y)*dy) / (dx*dx + dy*dy)
+		if t > 1 {
+			px, py = b.x, b.y
+		} else if t > 0 {
+			px += dx * t
+			py += dy * t
+		}
+	}
+	return math.Hypot(p.x-px, p.y-py)
+}
+
+type vertex struct {
+	i          int
+	area       float64
+	prev, next int
+	index      int // position in the heap
+}
+
+type vertexQueue []*vertex
+
+func (q vertexQueue) Len() int           { return len(q) }
+func (q vertexQueue) Less(i, j int) bool { return q[i].area < q[j].area }
+func (q vertexQueue) Swap(i, j int) {
+	q[i], q[j] = q[j], q[i]
+	q[i].index = i
+	q[j].index = j
+}
+func (q *vertexQueue) Push(x interface{}) {
+	v := x.(*vertex)
+	v.index = len(*q)
+	*q = append(*q, v)
+}
+func (q *vertexQueue) Pop() interface{} {
+	old := *q
+	v := old[len(old)-1]
+	*q = old[:len(old)-1]
+	v.index = -1
+	return v
+}
+
+// visvalingam marks the vertices kept by the Visvalingam-Whyatt algorithm. The effective area of
+// a vertex never decreases below the area of the last removed vertex, so that the removal order
+// follows the significance of the vertices.
+func visvalingam(ps []vec, minArea float64, minKeep int) []bool {
+	keep := make([]bool, len(ps))
+	for i := range keep {
+		keep[i] = true
+	}
+	if len(ps) <= minKeep {
+		return keep
+	}
+
+	vs := make([]*vertex, len(ps))
+	for i := range ps {
+		vs[i] = &vertex{i: i, prev: i - 1, next: i + 1, area: math.Inf(1), index: -1}
+	}
+	q := &vertexQueue{}
+	for i := 1; i < len(ps)-1; i++ {
+		vs[i].area = triangleArea(ps[i-1], ps[i], ps[i+1])
+		heap.Push(q, vs[i])
+	}
+
+	remaining := len(ps)
+	last := 0.0
+	for q.Len() > 0 && remaining > minKeep {
+		v := heap.Pop(q).(*vertex)
+		if v.area >= minArea {
+			break
+		}
+		last = math.Max(last, v.area)
+		keep[v.i] = false
+		remaining--
+
+		prev, next := vs[v.prev], vs[v.next]
+		prev.next, next.prev = next.i, prev.i
+		for _, n := range []*vertex{prev, next} {
+			if n.index < 0 {
+				continue
+			}
+			n.area = math.Max(triangleArea(ps[n.prev], ps[n.i], ps[n.next]), last)
+			heap.Fix(q, n.index)
+		}
+	}
+
+	return keep
+}
+
+func triangleArea(a, b, c vec) float64 {
+	return math.Abs((b.x-a.x)*(c.y-a.y)-(c.x-a.x)*(b.y-a.y)) / 2
+}
@@ -0,0 +1,113 @@
+package simplify
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func degrees(d float64) float64 {
+	return d * math.Pi / 180
+}
+
+func TestLines(t *testing.T) {
+	line := []geometry.Point{
+		{Lat: 0, Lng: 0}, {Lat: 0.1, Lng: 1}, {Lat: -0.1, Lng: 2}, {Lat: 5, Lng: 3},
+		{Lat: 6, Lng: 4}, {Lat: 7, Lng: 5}, {Lat: 8.05, Lng: 6}, {Lat: 9, Lng: 7},
+	}
+
+	tests := map[string]struct {
+		options Options
+		want    []geometry.Point
+	}{
+		"douglas-peucker": {
+			options: Options{Algorithm: DouglasPeucker, Tolerance: degrees(0.5)},
+			want:    []geometry.Point{{Lat: 0, Lng: 0}, {Lat: -0.1, Lng: 2}, {Lat: 5, Lng: 3}, {Lat: 9, Lng: 7}},
+		},
+		"visvalingam": {
+			options: Options{Algorithm: Visvalingam, Tolerance: degrees(0.5)},
+			want:    []geometry.Point{{Lat: 0, Lng: 0}, {Lat: -0.1, Lng: 2}, {Lat: 5, Lng: 3}, {Lat: 9, Lng: 7}},
+		},
+		"zero tolerance removes collinear vertices": {
+			options: Options{Algorithm: DouglasPeucker},
+			want:    append(append([]geometry.Point{}, line[:4]...), line[5:]...),
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Lines([][]geometry.Point{line}, tt.options)
+			assert.NoError(t, err)
+			assert.Equal(t, [][]geometry.Point{tt.want}, got)
+		})
+	}
+}
+
+func TestRings(t *testing.T) {
+	var ring []geometry.Point
+	for i := 0; i < 10; i++ {
+		ring = append(ring, geometry.Point{Lat: 0, Lng: float64(i)})
+	}
+	for i := 0; i < 10; i++ {
+		ring = append(ring, geometry.Point{Lat: float64(i), Lng: 10})
+	}
+	ring = append(ring, geometry.Point{Lat: 10, Lng: 10}, geometry.Point{Lat: 10, Lng: 0}, geometry.Point{Lat: 0, Lng: 0})
+
+	for _, a := range []Algorithm{DouglasPeucker, Visvalingam} {
+		got, err := Rings([][]geometry.Point{ring}, Options{Algorithm: a, Tolerance: degrees(1)})
+		assert.NoError(t, err)
+		assert.Equal(t, []geometry.Point{
+			{Lat: 0, Lng: 0}, {Lat: 0, Lng: 10}, {Lat: 10, Lng: 10}, {Lat: 10, Lng: 0}, {Lat: 0, Lng: 0},
+		}, got[0], string(a))
+	}
+
+	// a triangle smaller than the tolerance keeps four positions
+	triangle := []geometry.Point{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 0.1}, {Lat: 0.05, Lng: 0.1}, {Lat: 0.1, Lng: 0.1}, {Lat: 0, Lng: 0}}
+	for _, a := range []Algorithm{DouglasPeucker, Visvalingam} {
+		got, err := Rings([][]geometry.Point{triangle}, Options{Algorithm: a, Tolerance: degrees(1)})
+		assert.NoError(t, err)
+		assert.Len(t, got[0], 4, string(a))
+	}
+}
+
+func TestPlanar(t *testing.T) {
+	line := [][2]float64{{0, 0}, {1, 0.5}, {2, -0.5}, {3, 5}, {4, 6}, {5, 7}, {6, 8.5}, {7, 9}}
+	assert.Equal(t, [][2]float64{{0, 0}, {2, -0.5}, {3, 5}, {7, 9}}, Planar(line, 1))
+	assert.Equal(t, line[:2], Planar(line[:2], 1))
+
+	ring := [][2]float64{{0, 0}, {4, 0}, {4, 0.1}, {4, 4}, {0, 4}, {0, 0}}
+	assert.Equal(t, [][2]float64{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}, Planar(ring, 0.5))
+}
+
+func TestPreserveTopology(t *testing.T) {
+	lines := [][]geometry.Point{
+		{{Lat: 0, Lng: 0}, {Lat: 1, Lng: 5}, {Lat: 0, Lng: 10}},
+		{{Lat: 0.5, Lng: 5}, {Lat: -0.5, Lng: 5}},
+	}
+
+	got, err := Lines(lines, Options{Algorithm: DouglasPeucker, Tolerance: degrees(2)})
+	assert.NoError(t, err)
+	assert.Len(t, got[0], 2)
+
+	got, err = Lines(lines, Options{Algorithm: DouglasPeucker, Tolerance: degrees(2), PreserveTopology: true})
+	assert.NoError(t, err)
+	assert.Equal(t, lines, got)
+
+	got, err = Lines(lines, Options{Algorithm: Visvalingam, Tolerance: degrees(2), PreserveTopology: true})
+	assert.NoError(t, err)
+	assert.Equal(t, lines, got)
+}
+
+func TestOptions(t *testing.T) {
+	_, err := Lines(nil, Options{Tolerance: -1})
+	assert.EqualError(t, err, "tolerance must be a positive number")
+
+	_, err = ParseAlgorithm("radial")
+	assert.EqualError(t, err, `unsupported algorithm "radial", expected one of douglas-peucker or visvalingam`)
+
+	a, err := ParseAlgorithm("")
+	assert.NoError(t, err)
+	assert.Equal(t, DouglasPeucker, a)
+}
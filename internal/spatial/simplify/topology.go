@@ -0,0 +1,123 @@
+package simplify
+
+import (
+	"math"
+	"sort"
+)
+
+type segment struct {
+	part, seq  int // the part and the order of the segment in it
+	i, j       int // the indices of the original vertices joined by the segment
+	minX, maxX float64
+	minY, maxY float64
+}
+
+// repair restores removed vertices until none of the simplified segments intersect. Every pass
+// splits the intersecting segments at their farthest removed vertex, so the parts converge to
+// the original ones, which are assumed to be valid.
+func repair(parts [][]vec, keeps [][]bool, closed bool) {
+	for {
+		segs, counts := segments(parts, keeps)
+		changed := false
+		for _, s := range crossing(parts, segs, counts, closed) {
+			if idx, _ := farthest(parts[s.part], s.i, s.j); idx >= 0 {
+				keeps[s.part][idx] = true
+				changed = true
+			}
+		}
+		if !changed {
+			return
+		}
+	}
+}
+
+// segments returns the simplified segments sorted by their minimum x and the number of segments
+// of every part.
+func segments(parts [][]vec, keeps [][]bool) ([]segment, []int) {
+	var segs []segment
+	counts := make([]int, len(parts))
+	for p, ps := range parts {
+		prev := -1
+		for i := range ps {
+			if !keeps[p][i] {
+				continue
+			}
+			if prev >= 0 {
+				a, b := ps[prev], ps[i]
+				segs = append(segs, segment{
+					part: p, seq: counts[p], i: prev, j: i,
+					minX: math.Min(a.x, b.x), maxX: math.Max(a.x, b.x),
+					minY: math.Min(a.y, b.y), maxY: math.Max(a.y, b.y),
+				})
+				counts[p]++
+			}
+			prev = i
+		}
+	}
+	sort.Slice(segs, func(a, b int) bool { return segs[a].minX < segs[b].minX })
+
+	return segs, counts
+}
+
+// crossing returns the segments intersecting another segment that isn't next to them, found by
+// sweeping the segments along the x axis.
+func crossing(parts [][]vec, segs []segment, counts []int, closed bool) []segment {
+	bad := make(map[int]bool)
+	for a := range segs {
+		sa := segs[a]
+		for b := a + 1; b < len(segs) && segs[b].minX <= sa.maxX; b++ {
+			sb := segs[b]
+			if sb.minY > sa.maxY || sb.maxY < sa.minY || adjacent(sa, sb, counts, closed) {
+				continue
+			}
+			pa, pb := parts[sa.part], parts[sb.part]
+			if intersects(pa[sa.i], pa[sa.j], pb[sb.i], pb[sb.j]) {
+				bad[a], bad[b] = true, true
+			}
+		}
+	}
+
+	res := make([]segment, 0, len(bad))
+	for a := range segs {
+		if bad[a] {
+			res = append(res, segs[a])
+		}
+	}
+	return res
+}
+
+// adjacent reports whether the segments follow each other in the same part, sharing a vertex.
+func adjacent(a, b segment, counts []int, closed bool) bool {
+	if a.part != b.part {
+		return false
+	}
+	d := a.seq - b.seq
+	if d == 1 || d == -1 {
+		return true
+	}
+	n := counts[a.part]
+	return closed && n > 2 && (d == n-1 || d == 1-n)
+}
+
+// intersects reports whether the segments ab and cd intersect or touch.
+func intersects(a, b, c, d vec) bool {
+	d1 := orientation(c, d, a)
+	d2 := orientation(c, d, b)
+	d3 := orientation(a, b, c)
+	d4 := orientation(a, b, d)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		return true
+	}
+	return (d1 == 0 && onSegment(c, d, a)) || (d2 == 0 && onSegment(c, d, b)) ||
+		(d3 == 0 && onSegment(a, b, c)) || (d4 == 0 && onSegment(a, b, d))
+}
+
+func orientation(a, b, c vec) float64 {
+	return (b.x-a.x)*(c.y-a.y) - (b.y-a.y)*(c.x-a.x)
+}
+
+// onSegment reports whether p, collinear with ab, lies within the segment.
+func onSegment(a, b, p vec) bool {
+	return math.Min(a.x, b.x) <= p.x && p.x <= math.Max(a.x, b.x) &&
+		math.Min(a.y, b.y) <= p.y && p.y <= math.Max(a.y, b.y)
+}
@@ -0,0 +1,130 @@
+package tile
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// maxCover bounds the number of tiles of a cover.
+const maxCover = 100000
+
+// interval is a range of columns of a row of tiles.
+type interval struct {
+	lo, hi int
+}
+
+// Cover returns the tiles at a zoom level that intersect polygons, ordered by row and column. The
+// polygons are the rings of their shell and holes, with their edges straight on the map. Each row
+// of tiles holds the tiles crossed by the edges, and the ones between them that are inside the
+// polygons.
+func Cover(polygons [][][]geometry.Point, z int) ([]Tile, error) {
+	if z < 0 || z > MaxZoom {
+		return nil, fmt.Errorf("zoom must be between 0 and %d", MaxZoom)
+	}
+	n := 1 << uint(z)
+	w := Tile{Z: z}
+	tooMany := fmt.Errorf("too many tiles, the limit is %d", maxCover)
+
+	rows := map[int][]interval{}
+	for _, rings := range polygons {
+		// the crossings of the rings of the polygon with the middle line of the rows
+		crossings := map[int][]float64{}
+		for _, ring := range rings {
+			ps := make([][2]float64, len(ring))
+			for i, p := range ring {
+				ps[i][0], ps[i][1] = w.Pixel(p, 1)
+			}
+			for i := 0; i+1 < len(ps); i++ {
+				a, b := ps[i], ps[i+1]
+				if a[1] == b[1] && a[1] == math.Floor(a[1]) {
+					// an edge along the border of two rows only touches them, the other edges
+					// and the inside of the polygon show which one it reaches
+					continue
+				}
+				ylo, yhi := math.Min(a[1], b[1]), math.Max(a[1], b[1])
+				r0, r1 := clamp(int(math.Floor(ylo)), n), clamp(last(ylo, yhi), n)
+				if r1-r0 >= maxCover {
+					return nil, tooMany
+				}
+				for r := r0; r <= r1; r++ {
+					lo, hi := a[0], b[0]
+					if a[1] != b[1] {
+						t0 := (float64(r) - a[1]) / (b[1] - a[1])
+						t1 := (float64(r+1) - a[1]) / (b[1] - a[1])
+						t0, t1 = math.Max(0, math.Min(t0, t1)), math.Min(1, math.Max(t0, t1))
+						lo, hi = a[0]+t0*(b[0]-a[0]), a[0]+t1*(b[0]-a[0])
+					}
+					if lo > hi {
+						lo, hi = hi, lo
+					}
+					if lo == hi && lo == math.Floor(lo) {
+						continue
+					}
+					rows[r] = append(rows[r], columns(lo, hi, n))
+				}
+				for r := int(math.Ceil(ylo - 0.5)); float64(r)+0.5 < yhi; r++ {
+					if r >= 0 && r < n {
+						y := float64(r) + 0.5
+						crossings[r] = append(crossings[r], a[0]+(y-a[1])/(b[1]-a[1])*(b[0]-a[0]))
+					}
+				}
+			}
+		}
+		for r, xs := range crossings {
+			sort.Float64s(xs)
+			for i := 0; i+1 < len(xs); i += 2 {
+				rows[r] = append(rows[r], columns(xs[i], xs[i+1], n))
+			}
+		}
+	}
+
+	ys := make([]int, 0, len(rows))
+	for y := range rows {
+		ys = append(ys, y)
+	}
+	sort.Ints(ys)
+	var res []Tile
+	for _, y := range ys {
+		for _, iv := range merge(rows[y]) {
+			if len(res)+iv.hi-iv.lo+1 > maxCover {
+				return nil, tooMany
+			}
+			for x := iv.lo; x <= iv.hi; x++ {
+				res = append(res, Tile{Z: z, X: x, Y: y})
+			}
+		}
+	}
+	return res, nil
+}
+
+// last returns the last row or column reached by a range, leaving out the one it only touches.
+func last(lo, hi float64) int {
+	l := int(math.Ceil(hi)) - 1
+	if f := int(math.Floor(lo)); l < f {
+		return f
+	}
+	return l
+}
+
+func columns(lo, hi float64, n int) interval {
+	return interval{lo: clamp(int(math.Floor(lo)), n), hi: clamp(last(lo, hi), n)}
+}
+
+// merge joins the overlapping and adjacent intervals.
+func merge(ivs []interval) []interval {
+	sort.Slice(ivs, func(i, j int) bool { return ivs[i].lo < ivs[j].lo })
+	var res []interval
+	for _, iv := range ivs {
+		if k := len(res) - 1; k >= 0 && iv.lo <= res[k].hi+1 {
+			if iv.hi > res[k].hi {
+				res[k].hi = iv.hi
+			}
+			continue
+		}
+		res = append(res, iv)
+	}
+	return res
+}
@@ -0,0 +1,142 @@
+package tile
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// The tiles follow the XYZ scheme of web maps: the Web Mercator square between latitudes
+// ±85.0511 is split in 2^z by 2^z tiles at zoom z, numbered from the north-west corner.
+
+// MaxZoom is the deepest zoom level supported.
+const MaxZoom = 24
+
+// Size is the side of a raster tile in pixels.
+const Size = 256
+
+// Tile is a tile of the XYZ scheme.
+type Tile struct {
+	Z, X, Y int
+}
+
+// New returns the tile after checking that it exists at its zoom level.
+func New(z, x, y int) (*Tile, error) {
+	if z < 0 || z > MaxZoom {
+		return nil, fmt.Errorf("zoom must be between 0 and %d", MaxZoom)
+	}
+	n := 1 << uint(z)
+	if x < 0 || x >= n || y < 0 || y >= n {
+		return nil, fmt.Errorf("x and y must be between 0 and %d at zoom %d", n-1, z)
+	}
+	return &Tile{Z: z, X: x, Y: y}, nil
+}
+
+// BBox returns the bounding box of the tile.
+func (t Tile) BBox() geojson.BBOX {
+	nw := t.Position(0, 0, 1)
+	se := t.Position(1, 1, 1)
+	return *geojson.NewBBox(nw.Lng, se.Lat, se.Lng, nw.Lat)
+}
+
+// Position returns the position of a point of the tile given by its coordinates from the
+// north-west corner, on a tile of extent by extent units.
+func (t Tile) Position(x, y, extent float64) geometry.Point {
+	n := float64(int(1) << uint(t.Z))
+	wx := (float64(t.X) + x/extent) / n
+	wy := (float64(t.Y) + y/extent) / n
+	return geometry.Point{
+		Lng: wx*360 - 180,
+		Lat: math.Atan(math.Sinh(math.Pi*(1-2*wy))) * 180 / math.Pi,
+	}
+}
+
+// maxLat is the latitude of the north edge of the Web Mercator square.
+var maxLat = math.Atan(math.Sinh(math.Pi)) * 180 / math.Pi
+
+// Pixel returns the coordinates of a position from the north-west corner of the tile, on a tile of
+// extent by extent units. Positions beyond the Web Mercator square are clamped to its edges.
+func (t Tile) Pixel(p geometry.Point, extent float64) (float64, float64) {
+	n := float64(int(1) << uint(t.Z))
+	lat := math.Max(math.Min(p.Lat, maxLat), -maxLat) * math.Pi / 180
+	wx := p.Lng/360 + 0.5
+	wy := 0.5 - math.Log(math.Tan(math.Pi/4+lat/2))/(2*math.Pi)
+	return (wx*n - float64(t.X)) * extent, (wy*n - float64(t.Y)) * extent
+}
+
+// At returns the tile holding a position at a zoom level.
+func At(p geometry.Point, z int) (*Tile, error) {
+	if z < 0 || z > MaxZoom {
+		return nil, fmt.Errorf("zoom must be between 0 and %d", MaxZoom)
+	}
+	if p.Lat < -90 || p.Lat > 90 || p.Lng < -180 || p.Lng > 180 {
+		return nil, errors.New("latitude must be between -90 and 90 and longitude between -180 and 180")
+	}
+	x, y := Tile{Z: z}.Pixel(p, 1)
+	n := 1 << uint(z)
+	// the east and south edges of the square belong to the last tiles
+	return &Tile{Z: z, X: clamp(int(math.Floor(x)), n), Y: clamp(int(math.Floor(y)), n)}, nil
+}
+
+func clamp(i, n int) int {
+	if i < 0 {
+		return 0
+	}
+	if i >= n {
+		return n - 1
+	}
+	return i
+}
+
+// FromTMS returns the tile of the TMS scheme coordinates, which number the rows from the south.
+func FromTMS(z, x, y int) (*Tile, error) {
+	t, err := New(z, x, y)
+	if err != nil {
+		return nil, err
+	}
+	t.Y = t.TMS()
+	return t, nil
+}
+
+// TMS returns the row of the tile in the TMS scheme, numbered from the south.
+func (t Tile) TMS() int {
+	return 1<<uint(t.Z) - 1 - t.Y
+}
+
+// Quadkey returns the Bing Maps quadkey of the tile, one digit per zoom level. The tile at zoom
+// 0 has an empty quadkey.
+func (t Tile) Quadkey() string {
+	b := make([]byte, t.Z)
+	for i := t.Z; i > 0; i-- {
+		mask := 1 << uint(i-1)
+		d := byte('0')
+		if t.X&mask != 0 {
+			d++
+		}
+		if t.Y&mask != 0 {
+			d += 2
+		}
+		b[t.Z-i] = d
+	}
+	return string(b)
+}
+
+// ParseQuadkey returns the tile of a Bing Maps quadkey.
+func ParseQuadkey(q string) (*Tile, error) {
+	if len(q) > MaxZoom {
+		return nil, fmt.Errorf("quadkey can't be longer than %d digits", MaxZoom)
+	}
+	t := &Tile{Z: len(q)}
+	for i := 0; i < len(q); i++ {
+		if q[i] < '0' || q[i] > '3' {
+			return nil, errors.New("quadkey can only hold the digits 0 to 3")
+		}
+		d := int(q[i] - '0')
+		t.X = t.X<<1 | d&1
+		t.Y = t.Y<<1 | d>>1
+	}
+	return t, nil
+}
@@ -0,0 +1,171 @@
+package tile
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func TestNew(t *testing.T) {
+	tl, err := New(3, 7, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, Tile{Z: 3, X: 7, Y: 0}, *tl)
+
+	_, err = New(25, 0, 0)
+	assert.EqualError(t, err, "zoom must be between 0 and 24")
+	_, err = New(2, 4, 0)
+	assert.EqualError(t, err, "x and y must be between 0 and 3 at zoom 2")
+}
+
+func TestBBox(t *testing.T) {
+	b := Tile{}.BBox()
+	assert.InDelta(t, -180, b.West, 1e-9)
+	assert.InDelta(t, 180, b.East, 1e-9)
+	assert.InDelta(t, 85.0511, b.North, 1e-4)
+	assert.InDelta(t, -85.0511, b.South, 1e-4)
+
+	b = Tile{Z: 1, X: 1, Y: 0}.BBox()
+	assert.InDelta(t, 0, b.West, 1e-9)
+	assert.InDelta(t, 0, b.South, 1e-9)
+
+	p := Tile{Z: 1, X: 0, Y: 0}.Position(Size, Size, Size)
+	assert.InDelta(t, 0, p.Lng, 1e-9)
+	assert.InDelta(t, 0, p.Lat, 1e-9)
+}
+
+func TestPixel(t *testing.T) {
+	tl := Tile{Z: 12, X: 2161, Y: 1399}
+	for _, xy := range [][2]float64{{0, 0}, {100.5, 3000}, {4096, 4096}, {-64, 4160}} {
+		x, y := tl.Pixel(tl.Position(xy[0], xy[1], 4096), 4096)
+		assert.InDelta(t, xy[0], x, 1e-6)
+		assert.InDelta(t, xy[1], y, 1e-6)
+	}
+
+	_, y := Tile{}.Pixel(Tile{}.Position(0, 0, 1), 1)
+	assert.InDelta(t, 0, y, 1e-9)
+	_, y = Tile{}.Pixel(geometry.Point{Lat: 90}, 1)
+	assert.InDelta(t, 0, y, 1e-9)
+}
+
+func TestAt(t *testing.T) {
+	tests := map[string]struct {
+		p    geometry.Point
+		z    int
+		want Tile
+	}{
+		"athens":          {p: geometry.Point{Lat: 37.9838, Lng: 23.7275}, z: 12, want: Tile{Z: 12, X: 2317, Y: 1580}},
+		"world":           {p: geometry.Point{Lat: 10, Lng: 10}, z: 0, want: Tile{}},
+		"south east edge": {p: geometry.Point{Lat: -90, Lng: 180}, z: 2, want: Tile{Z: 2, X: 3, Y: 3}},
+		"north west edge": {p: geometry.Point{Lat: 90, Lng: -180}, z: 2, want: Tile{Z: 2}},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			tl, err := At(tt.p, tt.z)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, *tl)
+		})
+	}
+
+	_, err := At(geometry.Point{}, -1)
+	assert.EqualError(t, err, "zoom must be between 0 and 24")
+	_, err = At(geometry.Point{Lat: 91}, 1)
+	assert.EqualError(t, err, "latitude must be between -90 and 90 and longitude between -180 and 180")
+}
+
+func TestTMS(t *testing.T) {
+	tl, err := FromTMS(3, 2, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, Tile{Z: 3, X: 2, Y: 6}, *tl)
+	assert.Equal(t, 1, tl.TMS())
+
+	_, err = FromTMS(3, 2, 8)
+	assert.EqualError(t, err, "x and y must be between 0 and 7 at zoom 3")
+}
+
+func TestQuadkey(t *testing.T) {
+	tests := map[string]Tile{
+		"":    {},
+		"213": {Z: 3, X: 3, Y: 5},
+		"0":   {Z: 1},
+		"333": {Z: 3, X: 7, Y: 7},
+	}
+	for q, tl := range tests {
+		t.Run(q, func(t *testing.T) {
+			assert.Equal(t, q, tl.Quadkey())
+			got, err := ParseQuadkey(q)
+			assert.NoError(t, err)
+			assert.Equal(t, tl, *got)
+		})
+	}
+
+	_, err := ParseQuadkey("124")
+	assert.EqualError(t, err, "quadkey can only hold the digits 0 to 3")
+	_, err = ParseQuadkey("0123012301230123012301230")
+	assert.EqualError(t, err, "quadkey can't be longer than 24 digits")
+}
+
+func TestCover(t *testing.T) {
+	box := func(w, s, e, n float64) [][]geometry.Point {
+		return [][]geometry.Point{{{Lng: w, Lat: s}, {Lng: e, Lat: s}, {Lng: e, Lat: n}, {Lng: w, Lat: n}, {Lng: w, Lat: s}}}
+	}
+	at := func(z, x, y int) geometry.Point { return Tile{Z: z, X: x, Y: y}.Position(0.5, 0.5, 1) }
+
+	tests := map[string]struct {
+		polygons [][][]geometry.Point
+		z        int
+		want     []Tile
+	}{
+		"inside a tile": {
+			polygons: [][][]geometry.Point{box(10, 10, 11, 11)},
+			z:        2,
+			want:     []Tile{{Z: 2, X: 2, Y: 1}},
+		},
+		"across four tiles": {
+			polygons: [][][]geometry.Point{box(-10, -10, 10, 10)},
+			z:        1,
+			want:     []Tile{{Z: 1, X: 0, Y: 0}, {Z: 1, X: 1, Y: 0}, {Z: 1, X: 0, Y: 1}, {Z: 1, X: 1, Y: 1}},
+		},
+		"on the edges of a tile": {
+			polygons: [][][]geometry.Point{box(0, 0, 90, Tile{Z: 2, X: 2, Y: 1}.BBox().North)},
+			z:        2,
+			want:     []Tile{{Z: 2, X: 2, Y: 1}},
+		},
+		"hole": {
+			polygons: [][][]geometry.Point{{
+				{at(3, 0, 0), at(3, 4, 0), at(3, 4, 4), at(3, 0, 4), at(3, 0, 0)},
+				{at(3, 1, 1), at(3, 3, 1), at(3, 3, 3), at(3, 1, 3), at(3, 1, 1)},
+			}},
+			z: 3,
+			want: []Tile{
+				{Z: 3, X: 0, Y: 0}, {Z: 3, X: 1, Y: 0}, {Z: 3, X: 2, Y: 0}, {Z: 3, X: 3, Y: 0}, {Z: 3, X: 4, Y: 0},
+				{Z: 3, X: 0, Y: 1}, {Z: 3, X: 1, Y: 1}, {Z: 3, X: 2, Y: 1}, {Z: 3, X: 3, Y: 1}, {Z: 3, X: 4, Y: 1},
+				{Z: 3, X: 0, Y: 2}, {Z: 3, X: 1, Y: 2}, {Z: 3, X: 3, Y: 2}, {Z: 3, X: 4, Y: 2},
+				{Z: 3, X: 0, Y: 3}, {Z: 3, X: 1, Y: 3}, {Z: 3, X: 2, Y: 3}, {Z: 3, X: 3, Y: 3}, {Z: 3, X: 4, Y: 3},
+				{Z: 3, X: 0, Y: 4}, {Z: 3, X: 1, Y: 4}, {Z: 3, X: 2, Y: 4}, {Z: 3, X: 3, Y: 4}, {Z: 3, X: 4, Y: 4},
+			},
+		},
+		"diagonal": {
+			polygons: [][][]geometry.Point{{{at(4, 0, 0), at(4, 3, 3), at(4, 0, 3), at(4, 0, 0)}}},
+			z:        4,
+			want: []Tile{
+				{Z: 4, X: 0, Y: 0},
+				{Z: 4, X: 0, Y: 1}, {Z: 4, X: 1, Y: 1},
+				{Z: 4, X: 0, Y: 2}, {Z: 4, X: 1, Y: 2}, {Z: 4, X: 2, Y: 2},
+				{Z: 4, X: 0, Y: 3}, {Z: 4, X: 1, Y: 3}, {Z: 4, X: 2, Y: 3}, {Z: 4, X: 3, Y: 3},
+			},
+		},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Cover(tt.polygons, tt.z)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := Cover([][][]geometry.Point{box(-10, -10, 10, 10)}, 25)
+	assert.EqualError(t, err, "zoom must be between 0 and 24")
+	_, err = Cover([][][]geometry.Point{box(-10, -10, 10, 10)}, 13)
+	assert.EqualError(t, err, "too many tiles, the limit is 100000")
+}
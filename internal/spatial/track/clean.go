@@ -0,0 +1,243 @@
+package track
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+)
+
+// Smoother is the filter applied to the positions of a cleaned track.
+type Smoother string
+
+const (
+	// NoSmoother keeps the positions as they are.
+	NoSmoother Smoother = "none"
+	// Kalman filters the positions forwards with a constant velocity model, each one depending
+	// on the previous ones only.
+	Kalman Smoother = "kalman"
+	// RTS runs the Rauch-Tung-Striebel smoother backwards over the Kalman filter, so that each
+	// position depends on the next ones too.
+	RTS Smoother = "rts"
+)
+
+// ParseSmoother returns the smoother of a name.
+func ParseSmoother(s string) (Smoother, error) {
+	switch sm := Smoother(s); sm {
+	case NoSmoother, Kalman, RTS:
+		return sm, nil
+	default:
+		return "", fmt.Errorf("unsupported smoother %q, expected one of none, kalman or rts", s)
+	}
+}
+
+// The reasons points are dropped from a track.
+const (
+	// ReasonSpeed drops the points reached from the previous kept one faster than the max speed.
+	ReasonSpeed = "speed"
+	// ReasonStationary drops the points within the jitter radius of the previous kept one.
+	ReasonStationary = "stationary"
+)
+
+// CleanOptions defines the cleaning of a track: the max speed in meters per second and the
+// jitter radius in meters of the dropped points, and the smoother with the standard deviations
+// of the acceleration in meters per second squared and of the measured positions in meters.
+type CleanOptions struct {
+	MaxSpeed         float64
+	JitterRadius     float64
+	Smoother         Smoother
+	ProcessNoise     float64
+	MeasurementNoise float64
+}
+
+// Dropped is a point left out of a cleaned track, by its index in the original track.
+type Dropped struct {
+	Index  int
+	Reason string
+}
+
+// Cleaned is a cleaned track with the points dropped from it.
+type Cleaned struct {
+	Points  []Point
+	Dropped []Dropped
+}
+
+// reanchorPoints is the number of points dropped for their speed since the last kept one, each
+// within reach of the previous of them, after which the track resumes from them.
+const reanchorPoints = 3
+
+// Clean drops the points of a track reached at an impossible speed and the ones jittering around
+// a stationary position, then smooths the positions left. The speeds are measured from the
+// previous kept point, and the last one isn't dropped as stationary so that the track keeps its
+// end time. When a few of the points out of reach of the previous kept point are within reach of
+// each other, the track resumes from them, dropping the first point when it is the only one kept
+// so far.
+func Clean(points []Point, opts CleanOptions) (*Cleaned, error) {
+	if err := Check(points); err != nil {
+		return nil, err
+	}
+	if !(opts.MaxSpeed > 0) || math.IsInf(opts.MaxSpeed, 0) {
+		return nil, errors.New("max speed must be a positive number")
+	}
+	if !(opts.JitterRadius >= 0) || math.IsInf(opts.JitterRadius, 0) {
+		return nil, errors.New("jitter radius must be a positive number or 0")
+	}
+	if _, err := ParseSmoother(string(opts.Smoother)); err != nil {
+		return nil, err
+	}
+	if opts.Smoother != NoSmoother {
+		if !(opts.ProcessNoise > 0) || math.IsInf(opts.ProcessNoise, 0) {
+			return nil, errors.New("process noise must be a positive number")
+		}
+		if !(opts.MeasurementNoise > 0) || math.IsInf(opts.MeasurementNoise, 0) {
+			return nil, errors.New("measurement noise must be a positive number")
+		}
+	}
+
+	c := &Cleaned{Points: []Point{points[0]}}
+	// the chains of the points dropped for their speed since the last kept one, each point within
+	// reach of the one before, the latest ones only
+	var runs [][]int
+	for i := 1; i < len(points); i++ {
+		prev, p := c.Points[len(c.Points)-1], points[i]
+		switch {
+		case tooFast(prev, p, opts.MaxSpeed):
+			run := -1
+			for r := range runs {
+				if !tooFast(points[runs[r][len(runs[r])-1]], p, opts.MaxSpeed) {
+					run = r
+					break
+				}
+			}
+			if run < 0 {
+				if len(runs) == reanchorPoints {
+					runs = runs[1:]
+				}
+				runs = append(runs, nil)
+				run = len(runs) - 1
+			}
+			runs[run] = append(runs[run], i)
+			if len(runs[run]) < reanchorPoints {
+				c.Dropped = append(c.Dropped, Dropped{Index: i, Reason: ReasonSpeed})
+				continue
+			}
+			// the points agree with each other and not with the track kept so far, which
+			// resumes from the first of them
+			first := runs[run][0]
+			k := len(c.Dropped)
+			for k > 0 && c.Dropped[k-1].Index >= first {
+				k--
+			}
+			c.Dropped = c.Dropped[:k]
+			if len(c.Points) == 1 {
+				c.Points = c.Points[:0]
+				c.Dropped = append([]Dropped{{Index: 0, Reason: ReasonSpeed}}, c.Dropped...)
+			}
+			c.Points = append(c.Points, points[first])
+			i, runs = first, nil
+		case geom.Distance(prev.Point, p.Point) <= opts.JitterRadius && i < len(points)-1:
+			runs = nil
+			c.Dropped = append(c.Dropped, Dropped{Index: i, Reason: ReasonStationary})
+		default:
+			runs = nil
+			c.Points = append(c.Points, p)
+		}
+	}
+
+	if opts.Smoother != NoSmoother && len(c.Points) > 1 {
+		smooth(c.Points, opts)
+	}
+	return c, nil
+}
+
+// tooFast reports whether a point is reached from another faster than the max speed.
+func tooFast(from, to Point, maxSpeed float64) bool {
+	return geom.Distance(from.Point, to.Point)/to.Time.Sub(from.Time).Seconds() > maxSpeed
+}
+
+// smooth filters the positions in meters east and north of the first one, each axis following a
+// constant velocity model of its own.
+func smooth(points []Point, opts CleanOptions) {
+	lat0, lng0 := points[0].Lat, points[0].Lng
+	k := geom.MetersPerDegree
+	cos := math.Cos(lat0 * math.Pi / 180)
+
+	xs, ys := make([]float64, len(points)), make([]float64, len(points))
+	dts := make([]float64, len(points))
+	for i, p := range points {
+		xs[i], ys[i] = (p.Lng-lng0)*k*cos, (p.Lat-lat0)*k
+		if i > 0 {
+			dts[i] = p.Time.Sub(points[i-1].Time).Seconds()
+		}
+	}
+	xs = filter(xs, dts, opts)
+	ys = filter(ys, dts, opts)
+	for i := range points {
+		points[i].Lng, points[i].Lat = lng0+xs[i]/(k*cos), lat0+ys[i]/k
+	}
+}
+
+// state is the position and the velocity of an axis with their covariance.
+type state struct {
+	p, v       float64
+	pp, pv, vv float64
+}
+
+// filter returns the filtered positions of an axis measured after the time steps.
+func filter(zs, dts []float64, opts CleanOptions) []float64 {
+	q := opts.ProcessNoise * opts.ProcessNoise
+	r := opts.MeasurementNoise * opts.MeasurementNoise
+	filtered := make([]state, len(zs))
+	predicted := make([]state, len(zs))
+	// the initial velocity is unknown, up to the max speed
+	filtered[0] = state{p: zs[0], pp: r, vv: opts.MaxSpeed * opts.MaxSpeed}
+
+	for i := 1; i < len(zs); i++ {
+		s, dt := filtered[i-1], dts[i]
+		// predict with the white noise acceleration model
+		pred := state{
+			p:  s.p + dt*s.v,
+			v:  s.v,
+			pp: s.pp + 2*dt*s.pv + dt*dt*s.vv + q*dt*dt*dt/3,
+			pv: s.pv + dt*s.vv + q*dt*dt/2,
+			vv: s.vv + q*dt,
+		}
+		// update with the measured position
+		gp, gv := pred.pp/(pred.pp+r), pred.pv/(pred.pp+r)
+		innovation := zs[i] - pred.p
+		predicted[i] = pred
+		filtered[i] = state{
+			p:  pred.p + gp*innovation,
+			v:  pred.v + gv*innovation,
+			pp: (1 - gp) * pred.pp,
+			pv: (1 - gp) * pred.pv,
+			vv: pred.vv - gv*pred.pv,
+		}
+	}
+
+	res := make([]float64, len(zs))
+	for i, s := range filtered {
+		res[i] = s.p
+	}
+	if opts.Smoother != RTS {
+		return res
+	}
+
+	// the smoothed state of each step corrects its filtered one by the gain C = P Fᵀ P'⁻¹ times
+	// the difference between the smoothed and the predicted states of the next step
+	last := filtered[len(zs)-1]
+	sp, sv := last.p, last.v
+	for i := len(zs) - 2; i >= 0; i-- {
+		s, pred, dt := filtered[i], predicted[i+1], dts[i+1]
+		// P Fᵀ with F = [[1, dt], [0, 1]]
+		a, b := s.pp+dt*s.pv, s.pv
+		c, d := s.pv+dt*s.vv, s.vv
+		det := pred.pp*pred.vv - pred.pv*pred.pv
+		ipp, ipv, ivv := pred.vv/det, -pred.pv/det, pred.pp/det
+		dp, dv := sp-pred.p, sv-pred.v
+		sp, sv = s.p+(a*ipp+b*ipv)*dp+(a*ipv+b*ivv)*dv, s.v+(c*ipp+d*ipv)*dp+(c*ipv+d*ivv)*dv
+		res[i] = sp
+	}
+	return res
+}
@@ -0,0 +1,95 @@
+package track
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// deg is the angle of a meter on the earth in degrees.
+const deg = 180 / math.Pi / geom.EarthRadius
+
+func TestCleanDrops(t *testing.T) {
+	// a walk east at 1 m/s with a jump of a kilometre and a pause
+	points := []Point{
+		at(0, 0, 0), at(10*deg, 0, 10), at(20*deg, 1000*deg, 20), at(30*deg, 0, 30),
+		at(31*deg, 0, 40), at(30*deg, 1*deg, 50), at(40*deg, 0, 60), at(40.5*deg, 0, 70),
+	}
+
+	c, err := Clean(points, CleanOptions{MaxSpeed: 5, JitterRadius: 2, Smoother: NoSmoother})
+	assert.NoError(t, err)
+	assert.Equal(t, []Dropped{{Index: 2, Reason: ReasonSpeed}, {Index: 4, Reason: ReasonStationary}, {Index: 5, Reason: ReasonStationary}}, c.Dropped)
+	assert.Equal(t, []Point{points[0], points[1], points[3], points[6], points[7]}, c.Points)
+}
+
+func TestCleanFirstOutlier(t *testing.T) {
+	// the first fix is a kilometre off a walk east at 1 m/s, which has a jump of its own
+	points := []Point{
+		at(0, 1000*deg, 0), at(10*deg, 0, 10), at(20*deg, 0, 20), at(30*deg, -1000*deg, 30),
+		at(40*deg, 0, 40), at(50*deg, 0, 50), at(60*deg, 0, 60),
+	}
+
+	c, err := Clean(points, CleanOptions{MaxSpeed: 5, JitterRadius: 2, Smoother: NoSmoother})
+	assert.NoError(t, err)
+	assert.Equal(t, []Dropped{{Index: 0, Reason: ReasonSpeed}, {Index: 3, Reason: ReasonSpeed}}, c.Dropped)
+	assert.Equal(t, []Point{points[1], points[2], points[4], points[5], points[6]}, c.Points)
+}
+
+func TestCleanSmooth(t *testing.T) {
+	// a drive north east at 10 m/s measured with up to 8 meters of error
+	var truth, points []Point
+	for i := 0; i < 60; i++ {
+		p := at(float64(i)*7*deg, float64(i)*7*deg, i)
+		truth = append(truth, p)
+		noise := 8 * math.Sin(float64(i)*2.3)
+		points = append(points, Point{Point: geometry.Point{Lng: p.Lng + noise*deg, Lat: p.Lat - noise*deg}, Time: p.Time})
+	}
+	rms := func(ps []Point) float64 {
+		var s float64
+		for i, p := range ps {
+			d := geom.Distance(p.Point, truth[i].Point)
+			s += d * d
+		}
+		return math.Sqrt(s / float64(len(ps)))
+	}
+
+	opts := CleanOptions{MaxSpeed: 50, Smoother: Kalman, ProcessNoise: 0.5, MeasurementNoise: 8}
+	kalman, err := Clean(points, opts)
+	assert.NoError(t, err)
+	opts.Smoother = RTS
+	rts, err := Clean(points, opts)
+	assert.NoError(t, err)
+
+	raw := rms(points)
+	assert.InDelta(t, 8, raw, 1.5)
+	assert.Less(t, rms(kalman.Points), raw*0.75)
+	assert.Less(t, rms(rts.Points), rms(kalman.Points))
+	assert.Less(t, rms(rts.Points), raw*0.4)
+	assert.Equal(t, points[10].Time, rts.Points[10].Time)
+	assert.Empty(t, rts.Dropped)
+}
+
+func TestCleanErrors(t *testing.T) {
+	tests := map[string]struct {
+		opts CleanOptions
+		err  string
+	}{
+		"max speed":         {opts: CleanOptions{Smoother: NoSmoother}, err: "max speed must be a positive number"},
+		"jitter radius":     {opts: CleanOptions{MaxSpeed: 10, JitterRadius: -1, Smoother: NoSmoother}, err: "jitter radius must be a positive number or 0"},
+		"smoother":          {opts: CleanOptions{MaxSpeed: 10, Smoother: "average"}, err: `unsupported smoother "average", expected one of none, kalman or rts`},
+		"process noise":     {opts: CleanOptions{MaxSpeed: 10, Smoother: RTS, MeasurementNoise: 5}, err: "process noise must be a positive number"},
+		"measurement noise": {opts: CleanOptions{MaxSpeed: 10, Smoother: Kalman, ProcessNoise: 1}, err: "measurement noise must be a positive number"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Clean(drive, tt.opts)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+
+	_, err := Clean(drive[:1], CleanOptions{MaxSpeed: 10, Smoother: NoSmoother})
+	assert.EqualError(t, err, "a track must have at least 2 points")
+}
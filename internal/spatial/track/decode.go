@@ -0,0 +1,108 @@
+package track
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/feature"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// FromFeatures returns the points of LineString and MultiLineString features timed by their
+// coordTimes property, which holds an RFC 3339 time for each of their positions, in arrays per
+// line for the MultiLineStrings.
+func FromFeatures(fs []feature.Feature) ([]Point, error) {
+	var res []Point
+	for _, f := range fs {
+		if f.Geometry.GeoJSONType != geojson.LineString && f.Geometry.GeoJSONType != geojson.MultiLineString {
+			return nil, errors.New("a track must be made of LineString or MultiLineString features")
+		}
+		lines, err := geom.Lines(f.Geometry)
+		if err != nil {
+			return nil, err
+		}
+		times, err := parseTimes(f.Properties["coordTimes"])
+		if err != nil {
+			return nil, err
+		}
+
+		var ps []geometry.Point
+		for _, l := range lines {
+			ps = append(ps, l...)
+		}
+		if len(times) != len(ps) {
+			return nil, errors.New("coordTimes must hold a time for each position")
+		}
+		for i, p := range ps {
+			res = append(res, Point{Point: p, Time: times[i]})
+		}
+	}
+	return res, nil
+}
+
+// parseTimes returns the times of a coordTimes property, flattening the arrays of the lines.
+func parseTimes(v interface{}) ([]time.Time, error) {
+	switch x := v.(type) {
+	case nil:
+		return nil, errors.New("coordTimes can't be empty")
+	case string:
+		t, err := time.Parse(time.RFC3339, x)
+		if err != nil {
+			return nil, fmt.Errorf("invalid time %q", x)
+		}
+		return []time.Time{t}, nil
+	case []interface{}:
+		var res []time.Time
+		for _, e := range x {
+			ts, err := parseTimes(e)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, ts...)
+		}
+		return res, nil
+	default:
+		return nil, errors.New("coordTimes must hold RFC 3339 times")
+	}
+}
+
+type gpx struct {
+	Tracks []struct {
+		Segments []struct {
+			Points []struct {
+				Lat  float64 `xml:"lat,attr"`
+				Lon  float64 `xml:"lon,attr"`
+				Time string  `xml:"time"`
+			} `xml:"trkpt"`
+		} `xml:"trkseg"`
+	} `xml:"trk"`
+}
+
+// ParseGPX returns the points of the tracks of a GPX document, one segment after the other.
+func ParseGPX(data []byte) ([]Point, error) {
+	var g gpx
+	if err := xml.Unmarshal(data, &g); err != nil {
+		return nil, errors.New("cannot decode the GPX document")
+	}
+
+	var res []Point
+	for _, trk := range g.Tracks {
+		for _, seg := range trk.Segments {
+			for _, p := range seg.Points {
+				if p.Time == "" {
+					return nil, errors.New("the track points must have a time")
+				}
+				t, err := time.Parse(time.RFC3339, p.Time)
+				if err != nil {
+					return nil, fmt.Errorf("invalid time %q", p.Time)
+				}
+				res = append(res, Point{Point: geometry.Point{Lat: p.Lat, Lng: p.Lon}, Time: t})
+			}
+		}
+	}
+	return res, nil
+}
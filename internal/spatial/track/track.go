@@ -0,0 +1,193 @@
+package track
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/measurement"
+)
+
+// maxPoints bounds the number of points of a track.
+const maxPoints = 100000
+
+// Point is a position of a track with the time it was recorded.
+type Point struct {
+	geometry.Point
+	Time time.Time
+}
+
+// Segment is the move between two consecutive points of a track, with its distance in meters,
+// its duration in seconds, its speed in meters per second and its heading in degrees clockwise
+// from the north.
+type Segment struct {
+	From, To int
+	Distance float64
+	Duration float64
+	Speed    float64
+	Heading  float64
+}
+
+// Stop is a run of points staying within a radius for a minimum duration, with its duration in
+// seconds and the mean of its positions.
+type Stop struct {
+	From, To   int
+	Start, End time.Time
+	Duration   float64
+	Centre     geometry.Point
+}
+
+// Move is the part of a track between two stops, or before the first and after the last one,
+// with its distance in meters, its duration in seconds and its speeds in meters per second.
+type Move struct {
+	From, To     int
+	Start, End   time.Time
+	Distance     float64
+	Duration     float64
+	AverageSpeed float64
+	MaxSpeed     float64
+}
+
+// Analysis holds the segments, the stops and the moves of a track with its totals.
+type Analysis struct {
+	Distance       float64
+	Duration       float64
+	MovingDuration float64
+	AverageSpeed   float64
+	MaxSpeed       float64
+	Segments       []Segment
+	Stops          []Stop
+	Moves          []Move
+}
+
+// StopOptions defines the stops: the points staying within Radius meters of the first one for at
+// least Duration.
+type StopOptions struct {
+	Radius   float64
+	Duration time.Duration
+}
+
+// Check returns an error when the track can't be analysed: it needs at least two points with
+// finite coordinates recorded one after the other.
+func Check(points []Point) error {
+	if len(points) < 2 {
+		return errors.New("a track must have at least 2 points")
+	}
+	if len(points) > maxPoints {
+		return fmt.Errorf("too many points, the limit is %d", maxPoints)
+	}
+	for i, p := range points {
+		if math.IsNaN(p.Lat) || math.IsNaN(p.Lng) || math.IsInf(p.Lat, 0) || math.IsInf(p.Lng, 0) {
+			return errors.New("points must have finite coordinates")
+		}
+		if i > 0 && !p.Time.After(points[i-1].Time) {
+			return errors.New("the times of the points must be increasing")
+		}
+	}
+	return nil
+}
+
+// Analyze returns the segments, the stops and the moves of a track.
+func Analyze(points []Point, opts StopOptions) (*Analysis, error) {
+	if err := Check(points); err != nil {
+		return nil, err
+	}
+	if !(opts.Radius > 0) || math.IsInf(opts.Radius, 0) {
+		return nil, errors.New("stop radius must be a positive number")
+	}
+	if opts.Duration <= 0 {
+		return nil, errors.New("stop duration must be positive")
+	}
+
+	a := &Analysis{Segments: make([]Segment, 0, len(points)-1)}
+	for i := 0; i+1 < len(points); i++ {
+		s := segment(points, i)
+		a.Distance += s.Distance
+		a.MaxSpeed = math.Max(a.MaxSpeed, s.Speed)
+		a.Segments = append(a.Segments, s)
+	}
+	a.Duration = points[len(points)-1].Time.Sub(points[0].Time).Seconds()
+	a.AverageSpeed = a.Distance / a.Duration
+
+	a.Stops = stops(points, opts)
+	from := 0
+	for _, s := range a.Stops {
+		if s.From > from {
+			a.Moves = append(a.Moves, a.move(points, from, s.From))
+		}
+		from = s.To
+	}
+	if from < len(points)-1 {
+		a.Moves = append(a.Moves, a.move(points, from, len(points)-1))
+	}
+	for _, m := range a.Moves {
+		a.MovingDuration += m.Duration
+	}
+	return a, nil
+}
+
+func segment(points []Point, i int) Segment {
+	p, q := points[i], points[i+1]
+	d := geom.Distance(p.Point, q.Point)
+	dt := q.Time.Sub(p.Time).Seconds()
+	return Segment{From: i, To: i + 1, Distance: d, Duration: dt, Speed: d / dt, Heading: Heading(p.Point, q.Point)}
+}
+
+func (a *Analysis) move(points []Point, from, to int) Move {
+	m := Move{From: from, To: to, Start: points[from].Time, End: points[to].Time}
+	for _, s := range a.Segments[from:to] {
+		m.Distance += s.Distance
+		m.MaxSpeed = math.Max(m.MaxSpeed, s.Speed)
+	}
+	m.Duration = m.End.Sub(m.Start).Seconds()
+	m.AverageSpeed = m.Distance / m.Duration
+	return m
+}
+
+// stops returns the runs of points staying within the radius of their first point for the
+// duration. The track is read once: a point leaving the radius of the first point of a run ends it
+// and starts the next one.
+func stops(points []Point, opts StopOptions) []Stop {
+	var res []Stop
+	i := 0
+	for j := 1; j <= len(points); j++ {
+		if j < len(points) && geom.Distance(points[i].Point, points[j].Point) <= opts.Radius {
+			continue
+		}
+		if last := j - 1; last > i && points[last].Time.Sub(points[i].Time) >= opts.Duration {
+			res = append(res, stop(points, i, last))
+		}
+		i = j
+	}
+	return res
+}
+
+func stop(points []Point, from, to int) Stop {
+	s := Stop{From: from, To: to, Start: points[from].Time, End: points[to].Time}
+	s.Duration = s.End.Sub(s.Start).Seconds()
+	for _, p := range points[from : to+1] {
+		s.Centre.Lat += p.Lat
+		s.Centre.Lng += p.Lng
+	}
+	s.Centre.Lat /= float64(to - from + 1)
+	s.Centre.Lng /= float64(to - from + 1)
+	return s
+}
+
+// Length returns the length of a track in meters.
+func Length(points []Point) float64 {
+	var l float64
+	for i := 0; i+1 < len(points); i++ {
+		l += geom.Distance(points[i].Point, points[i+1].Point)
+	}
+	return l
+}
+
+// Heading returns the initial bearing from a position to another in degrees clockwise from the
+// north, between 0 and 360.
+func Heading(a, b geometry.Point) float64 {
+	return math.Mod(measurement.PointBearing(a, b)+360, 360)
+}
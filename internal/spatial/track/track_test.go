@@ -0,0 +1,158 @@
+package track
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+var start = time.Date(2024, 5, 1, 8, 0, 0, 0, time.UTC)
+
+func at(lng, lat float64, seconds int) Point {
+	return Point{Point: geometry.Point{Lng: lng, Lat: lat}, Time: start.Add(time.Duration(seconds) * time.Second)}
+}
+
+// drive moves east by about 111 meters every 10 seconds, stays 10 minutes and moves north.
+var drive = []Point{
+	at(0, 0, 0), at(0.001, 0, 10), at(0.002, 0, 20),
+	at(0.002, 0.00005, 80), at(0.00205, 0, 200), at(0.002, 0, 620),
+	at(0.002, 0.001, 630), at(0.002, 0.003, 640),
+}
+
+func TestAnalyze(t *testing.T) {
+	a, err := Analyze(drive, StopOptions{Radius: 20, Duration: 5 * time.Minute})
+	assert.NoError(t, err)
+
+	assert.Len(t, a.Segments, 7)
+	s := a.Segments[0]
+	assert.Equal(t, 0, s.From)
+	assert.Equal(t, 1, s.To)
+	assert.InDelta(t, 111.2, s.Distance, 0.1)
+	assert.Equal(t, 10.0, s.Duration)
+	assert.InDelta(t, 11.12, s.Speed, 0.01)
+	assert.InDelta(t, 90, s.Heading, 1e-6)
+	assert.InDelta(t, 0, a.Segments[5].Heading, 1e-6)
+
+	assert.Equal(t, 640.0, a.Duration)
+	assert.InDelta(t, 22.24, a.MaxSpeed, 0.01)
+	assert.InDelta(t, a.Distance/640, a.AverageSpeed, 1e-9)
+
+	assert.Len(t, a.Stops, 1)
+	assert.Equal(t, 2, a.Stops[0].From)
+	assert.Equal(t, 5, a.Stops[0].To)
+	assert.Equal(t, 600.0, a.Stops[0].Duration)
+	assert.InDelta(t, 0.0020125, a.Stops[0].Centre.Lng, 1e-9)
+
+	assert.Len(t, a.Moves, 2)
+	assert.Equal(t, [2]int{0, 2}, [2]int{a.Moves[0].From, a.Moves[0].To})
+	assert.InDelta(t, 222.4, a.Moves[0].Distance, 0.1)
+	assert.Equal(t, 20.0, a.Moves[0].Duration)
+	assert.Equal(t, [2]int{5, 7}, [2]int{a.Moves[1].From, a.Moves[1].To})
+	assert.Equal(t, 40.0, a.MovingDuration)
+}
+
+func TestAnalyzeNoStops(t *testing.T) {
+	a, err := Analyze(drive, StopOptions{Radius: 20, Duration: time.Hour})
+	assert.NoError(t, err)
+	assert.Empty(t, a.Stops)
+	assert.Len(t, a.Moves, 1)
+	assert.Equal(t, a.Distance, a.Moves[0].Distance)
+}
+
+// TestAnalyzeLongStop reads a track of the largest size jittering within a few meters, sampled
+// every 10 milliseconds and leaving after 15 minutes.
+func TestAnalyzeLongStop(t *testing.T) {
+	points := make([]Point, maxPoints)
+	for i := range points {
+		points[i] = Point{
+			Point: geometry.Point{Lng: float64(i%7) * 0.00001, Lat: float64(i%5) * 0.00001},
+			Time:  start.Add(time.Duration(i) * 10 * time.Millisecond),
+		}
+	}
+	last := len(points) - 1
+	points[last].Lat = 0.01
+
+	a, err := Analyze(points, StopOptions{Radius: 50, Duration: 5 * time.Minute})
+	assert.NoError(t, err)
+	assert.Len(t, a.Stops, 1)
+	assert.Equal(t, [2]int{0, last - 1}, [2]int{a.Stops[0].From, a.Stops[0].To})
+	assert.Len(t, a.Moves, 1)
+	assert.Equal(t, [2]int{last - 1, last}, [2]int{a.Moves[0].From, a.Moves[0].To})
+}
+
+func TestAnalyzeErrors(t *testing.T) {
+	opts := StopOptions{Radius: 20, Duration: time.Minute}
+	tests := map[string]struct {
+		points []Point
+		opts   StopOptions
+		err    string
+	}{
+		"one point":       {points: drive[:1], opts: opts, err: "a track must have at least 2 points"},
+		"unordered times": {points: []Point{drive[1], drive[0]}, opts: opts, err: "the times of the points must be increasing"},
+		"radius":          {points: drive, opts: StopOptions{Duration: time.Minute}, err: "stop radius must be a positive number"},
+		"duration":        {points: drive, opts: StopOptions{Radius: 20}, err: "stop duration must be positive"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Analyze(tt.points, tt.opts)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestFromFeatures(t *testing.T) {
+	fs, err := geom.Decode([]byte(`{"type": "FeatureCollection", "features": [
+		{"type": "Feature", "properties": {"coordTimes": ["2024-05-01T08:00:00Z", "2024-05-01T08:00:10.5Z"]},
+			"geometry": {"type": "LineString", "coordinates": [[0, 0], [0.001, 0]]}},
+		{"type": "Feature", "properties": {"coordTimes": [["2024-05-01T08:00:20Z"], ["2024-05-01T10:00:20+02:00"]]},
+			"geometry": {"type": "MultiLineString", "coordinates": [[[0.002, 0]], [[0.003, 0]]]}}
+	]}`))
+	assert.NoError(t, err)
+	ps, err := FromFeatures(fs)
+	assert.NoError(t, err)
+	assert.Len(t, ps, 4)
+	assert.Equal(t, 10500*time.Millisecond, ps[1].Time.Sub(start))
+	assert.Equal(t, 0.003, ps[3].Lng)
+	assert.True(t, ps[3].Time.Equal(start.Add(20*time.Second)))
+
+	tests := map[string]struct {
+		data string
+		err  string
+	}{
+		"point":         {data: `{"type": "Point", "coordinates": [0, 0]}`, err: "a track must be made of LineString or MultiLineString features"},
+		"no times":      {data: `{"type": "LineString", "coordinates": [[0, 0], [1, 1]]}`, err: "coordTimes can't be empty"},
+		"missing times": {data: `{"type": "Feature", "properties": {"coordTimes": ["2024-05-01T08:00:00Z"]}, "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}}`, err: "coordTimes must hold a time for each position"},
+		"invalid time":  {data: `{"type": "Feature", "properties": {"coordTimes": ["yesterday", "today"]}, "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}}`, err: `invalid time "yesterday"`},
+		"number times":  {data: `{"type": "Feature", "properties": {"coordTimes": [1, 2]}, "geometry": {"type": "LineString", "coordinates": [[0, 0], [1, 1]]}}`, err: "coordTimes must hold RFC 3339 times"},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			fs, err := geom.Decode([]byte(tt.data))
+			assert.NoError(t, err)
+			_, err = FromFeatures(fs)
+			assert.EqualError(t, err, tt.err)
+		})
+	}
+}
+
+func TestParseGPX(t *testing.T) {
+	ps, err := ParseGPX([]byte(`<?xml version="1.0"?>
+<gpx version="1.1" xmlns="http://www.topografix.com/GPX/1/1">
+  <trk><trkseg>
+    <trkpt lat="0" lon="0"><ele>10</ele><time>2024-05-01T08:00:00Z</time></trkpt>
+    <trkpt lat="0" lon="0.001"><time>2024-05-01T08:00:10Z</time></trkpt>
+  </trkseg><trkseg>
+    <trkpt lat="0.001" lon="0.002"><time>2024-05-01T08:00:20Z</time></trkpt>
+  </trkseg></trk>
+</gpx>`))
+	assert.NoError(t, err)
+	assert.Equal(t, []Point{at(0, 0, 0), at(0.001, 0, 10), at(0.002, 0.001, 20)}, ps)
+
+	_, err = ParseGPX([]byte(`<gpx><trk><trkseg><trkpt lat="0" lon="0"/></trkseg></trk></gpx>`))
+	assert.EqualError(t, err, "the track points must have a time")
+	_, err = ParseGPX([]byte(`<gpx><trk>`))
+	assert.EqualError(t, err, "cannot decode the GPX document")
+}
@@ -0,0 +1,169 @@
+package transform
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+	"github.com/tomchavakis/turf-go/constants"
+	"github.com/tomchavakis/turf-go/measurement"
+)
+
+// The transforms move every position along a great circle, with the same destination formula as
+// the destination endpoint, so they keep the distances on the sphere rather than on a map
+// projection. Longitudes aren't wrapped, so a geometry moved across the antimeridian stays in one
+// piece with longitudes beyond 180.
+
+// Translate moves every position of the geometry by the distance, in radians, towards the bearing in
+// degrees from true north.
+func Translate(g geometry.Geometry, distance, bearing float64) (*geometry.Geometry, error) {
+	if !finite(distance) {
+		return nil, errors.New("distance must be a finite number")
+	}
+	if !finite(bearing) {
+		return nil, errors.New("bearing must be a finite number")
+	}
+
+	return apply(g, func(p geometry.Point) (geometry.Point, error) {
+		return destination(p, distance, bearing)
+	})
+}
+
+// Rotate turns every position of the geometry clockwise around the pivot by the angle in degrees,
+// keeping its distance to the pivot.
+func Rotate(g geometry.Geometry, angle float64, pivot geometry.Point) (*geometry.Geometry, error) {
+	if !finite(angle) {
+		return nil, errors.New("angle must be a finite number")
+	}
+
+	return apply(g, func(p geometry.Point) (geometry.Point, error) {
+		d, err := measurement.PointDistance(pivot, p, constants.UnitRadians)
+		if err != nil {
+			return geometry.Point{}, err
+		}
+		if d == 0 {
+			return p, nil
+		}
+		return destination(pivot, d, measurement.PointBearing(pivot, p)+angle)
+	})
+}
+
+// Scale multiplies the distance of every position of the geometry to the origin by the factor,
+// keeping its bearing from the origin.
+func Scale(g geometry.Geometry, factor float64, origin geometry.Point) (*geometry.Geometry, error) {
+	if factor <= 0 || !finite(factor) {
+		return nil, errors.New("factor must be a positive number")
+	}
+
+	return apply(g, func(p geometry.Point) (geometry.Point, error) {
+		d, err := measurement.PointDistance(origin, p, constants.UnitRadians)
+		if err != nil {
+			return geometry.Point{}, err
+		}
+		if d == 0 {
+			return p, nil
+		}
+		return destination(origin, d*factor, measurement.PointBearing(origin, p))
+	})
+}
+
+func destination(p geometry.Point, distance, bearing float64) (geometry.Point, error) {
+	d, err := measurement.Destination(p, distance, bearing, constants.UnitRadians)
+	if err != nil {
+		return geometry.Point{}, err
+	}
+	return *d, nil
+}
+
+// apply maps every position of the geometry, keeping its type and structure.
+func apply(g geometry.Geometry, fn func(geometry.Point) (geometry.Point, error)) (*geometry.Geometry, error) {
+	line := func(ps []geometry.Point) ([]geometry.Point, error) {
+		res := make([]geometry.Point, 0, len(ps))
+		for _, p := range ps {
+			q, err := fn(p)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, q)
+		}
+		return res, nil
+	}
+	lines := func(ls [][]geometry.Point) ([][]geometry.Point, error) {
+		res := make([][]geometry.Point, 0, len(ls))
+		for _, l := range ls {
+			m, err := line(l)
+			if err != nil {
+				return nil, err
+			}
+			res = append(res, m)
+		}
+		return res, nil
+	}
+
+	var res geometry.Geometry
+	switch g.GeoJSONType {
+	case geojson.Point:
+		p, err := geom.Point(g)
+		if err != nil {
+			return nil, err
+		}
+		q, err := fn(*p)
+		if err != nil {
+			return nil, err
+		}
+		res = geom.PointGeometry(q)
+	case geojson.MultiPoint, geojson.LineString:
+		l, err := geom.Line(g)
+		if err != nil {
+			return nil, err
+		}
+		m, err := line(l)
+		if err != nil {
+			return nil, err
+		}
+		if g.GeoJSONType == geojson.MultiPoint {
+			res = geom.MultiPointGeometry(m)
+		} else {
+			res = geom.LineStringGeometry(m)
+		}
+	case geojson.MultiLineString:
+		ls, err := geom.Lines(g)
+		if err != nil {
+			return nil, err
+		}
+		m, err := lines(ls)
+		if err != nil {
+			return nil, err
+		}
+		res = geom.MultiLineStringGeometry(m)
+	case geojson.Polygon, geojson.MultiPolygon:
+		polys, err := geom.Polygons(g)
+		if err != nil {
+			return nil, err
+		}
+		m := make([][][]geometry.Point, 0, len(polys))
+		for _, rings := range polys {
+			r, err := lines(rings)
+			if err != nil {
+				return nil, err
+			}
+			m = append(m, r)
+		}
+		if g.GeoJSONType == geojson.Polygon {
+			res = geom.PolygonGeometry(m[0])
+		} else {
+			res = geom.MultiPolygonGeometry(m)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %q", g.GeoJSONType)
+	}
+
+	return &res, nil
+}
+
+func finite(f float64) bool {
+	return !math.IsNaN(f) && !math.IsInf(f, 0)
+}
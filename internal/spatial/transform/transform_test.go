@@ -0,0 +1,76 @@
+package transform
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+const degree = math.Pi / 180
+
+func assertPoints(t *testing.T, want, got []geometry.Point) {
+	t.Helper()
+	assert.Len(t, got, len(want))
+	for i := range want {
+		assert.InDelta(t, want[i].Lat, got[i].Lat, 1e-9, "lat of position %d", i)
+		assert.InDelta(t, want[i].Lng, got[i].Lng, 1e-9, "lng of position %d", i)
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	g := geom.LineStringGeometry([]geometry.Point{{Lat: 0, Lng: 10}, {Lat: 10, Lng: 10}})
+
+	got, err := Translate(g, 2*degree, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, geojson.LineString, got.GeoJSONType)
+	l, err := geom.Line(*got)
+	assert.NoError(t, err)
+	assertPoints(t, []geometry.Point{{Lat: 2, Lng: 10}, {Lat: 12, Lng: 10}}, l)
+
+	got, err = Translate(geom.PointGeometry(geometry.Point{Lat: 0, Lng: 179.5}), 1*degree, 90)
+	assert.NoError(t, err)
+	p, err := geom.Point(*got)
+	assert.NoError(t, err)
+	assertPoints(t, []geometry.Point{{Lat: 0, Lng: 180.5}}, []geometry.Point{*p})
+
+	_, err = Translate(g, math.Inf(1), 0)
+	assert.EqualError(t, err, "distance must be a finite number")
+}
+
+func TestRotate(t *testing.T) {
+	pivot := geometry.Point{Lat: 0, Lng: 0}
+	g := geom.PolygonGeometry([][]geometry.Point{{
+		{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 1, Lng: 0}, {Lat: 0, Lng: 0},
+	}})
+
+	got, err := Rotate(g, 90, pivot)
+	assert.NoError(t, err)
+	polys, err := geom.Polygons(*got)
+	assert.NoError(t, err)
+	assertPoints(t, []geometry.Point{{Lat: 0, Lng: 0}, {Lat: -1, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 0, Lng: 0}}, polys[0][0])
+
+	got, err = Rotate(g, 360, pivot)
+	assert.NoError(t, err)
+	polys, err = geom.Polygons(*got)
+	assert.NoError(t, err)
+	assertPoints(t, []geometry.Point{{Lat: 0, Lng: 0}, {Lat: 0, Lng: 1}, {Lat: 1, Lng: 0}, {Lat: 0, Lng: 0}}, polys[0][0])
+}
+
+func TestScale(t *testing.T) {
+	origin := geometry.Point{Lat: 0, Lng: 0}
+	g := geom.MultiPointGeometry([]geometry.Point{{Lat: 1, Lng: 0}, {Lat: 0, Lng: -1}, origin})
+
+	got, err := Scale(g, 2, origin)
+	assert.NoError(t, err)
+	assert.Equal(t, geojson.MultiPoint, got.GeoJSONType)
+	ps, err := geom.Line(*got)
+	assert.NoError(t, err)
+	assertPoints(t, []geometry.Point{{Lat: 2, Lng: 0}, {Lat: 0, Lng: -2}, origin}, ps)
+
+	_, err = Scale(g, 0, origin)
+	assert.EqualError(t, err, "factor must be a positive number")
+}
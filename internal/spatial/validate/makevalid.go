@@ -0,0 +1,155 @@
+package validate
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geo-api/internal/spatial/overlay"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// MakeValid repairs the geometry. Latitudes are clamped and longitudes wrapped into range,
+// positions that aren't finite and repeated positions are dropped, and lines collapsing to a
+// single position become points. Polygons are rebuilt from their rings, which closes them, fixes
+// their winding, resolves their self-intersections and drops the rings without area; polygons
+// collapsing to nothing give an empty MultiPolygon.
+func MakeValid(g geometry.Geometry) (*geometry.Geometry, error) {
+	var res geometry.Geometry
+	switch g.GeoJSONType {
+	case geojson.Point:
+		p, err := geom.Point(g)
+		if err != nil {
+			return nil, err
+		}
+		ps := clean([]geometry.Point{*p})
+		if len(ps) == 0 {
+			return nil, errors.New("the point has no valid position")
+		}
+		res = geom.PointGeometry(ps[0])
+	case geojson.MultiPoint:
+		ps, err := geom.Line(g)
+		if err != nil {
+			return nil, err
+		}
+		res = geom.MultiPointGeometry(clean(ps))
+	case geojson.LineString:
+		l, err := geom.Line(g)
+		if err != nil {
+			return nil, err
+		}
+		l = dedupe(clean(l))
+		switch len(l) {
+		case 0:
+			return nil, errors.New("the line has no valid position")
+		case 1:
+			res = geom.PointGeometry(l[0])
+		default:
+			res = geom.LineStringGeometry(l)
+		}
+	case geojson.MultiLineString:
+		ls, err := geom.Lines(g)
+		if err != nil {
+			return nil, err
+		}
+		res = makeValidLines(ls)
+	case geojson.Polygon, geojson.MultiPolygon:
+		polys, err := geom.Polygons(g)
+		if err != nil {
+			return nil, err
+		}
+		valid, err := makeValidPolygons(polys)
+		if err != nil {
+			return nil, err
+		}
+		if len(valid) == 1 {
+			res = geom.PolygonGeometry(valid[0])
+		} else {
+			res = geom.MultiPolygonGeometry(valid)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %q", g.GeoJSONType)
+	}
+	return &res, nil
+}
+
+// makeValidLines drops the lines collapsing to a single position, keeping them as a MultiPoint
+// when every line collapses.
+func makeValidLines(ls [][]geometry.Point) geometry.Geometry {
+	var lines [][]geometry.Point
+	var points []geometry.Point
+	for _, l := range ls {
+		l = dedupe(clean(l))
+		switch len(l) {
+		case 0:
+		case 1:
+			points = append(points, l[0])
+		default:
+			lines = append(lines, l)
+		}
+	}
+	if len(lines) == 0 {
+		return geom.MultiPointGeometry(points)
+	}
+	return geom.MultiLineStringGeometry(lines)
+}
+
+// makeValidPolygons rebuilds every polygon as its exterior minus its holes, so that holes outside
+// the exterior are ignored, and merges the polygons overlapping each other.
+func makeValidPolygons(polys [][][]geometry.Point) ([][][]geometry.Point, error) {
+	var parts [][][]geometry.Point
+	for _, rings := range polys {
+		if len(rings) == 0 {
+			continue
+		}
+		shell := [][][]geometry.Point{{clean(rings[0])}}
+		holes := make([][][]geometry.Point, 0, len(rings)-1)
+		for _, h := range rings[1:] {
+			holes = append(holes, [][]geometry.Point{clean(h)})
+		}
+		p, err := overlay.Overlay(shell, holes, overlay.Difference)
+		if err != nil {
+			return nil, err
+		}
+		parts = append(parts, p...)
+	}
+	if len(parts) < 2 {
+		return parts, nil
+	}
+	return overlay.Overlay(parts, nil, overlay.Union)
+}
+
+// clean drops the positions that aren't finite and brings the others in range.
+func clean(ps []geometry.Point) []geometry.Point {
+	res := make([]geometry.Point, 0, len(ps))
+	for _, p := range ps {
+		if math.IsNaN(p.Lat) || math.IsNaN(p.Lng) || math.IsInf(p.Lat, 0) || math.IsInf(p.Lng, 0) {
+			continue
+		}
+		lat := math.Max(-90, math.Min(90, p.Lat))
+		lng := p.Lng
+		if lng < -180 || lng > 180 {
+			lng = math.Mod(lng+180, 360)
+			if lng < 0 {
+				lng += 360
+			}
+			lng -= 180
+		}
+		res = append(res, geometry.Point{Lat: lat, Lng: lng})
+	}
+	return res
+}
+
+// dedupe drops the positions repeating the previous one.
+func dedupe(ps []geometry.Point) []geometry.Point {
+	res := make([]geometry.Point, 0, len(ps))
+	for _, p := range ps {
+		if len(res) > 0 && res[len(res)-1] == p {
+			continue
+		}
+		res = append(res, p)
+	}
+	return res
+}
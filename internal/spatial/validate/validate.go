@@ -0,0 +1,348 @@
+package validate
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// Code identifies a validity problem.
+type Code string
+
+const (
+	// OutOfRange is a coordinate that isn't a finite longitude or latitude.
+	OutOfRange Code = "out_of_range"
+	// TooFewPoints is a LineString with fewer than two distinct positions or a ring with fewer
+	// than four positions.
+	TooFewPoints Code = "too_few_points"
+	// UnclosedRing is a ring whose first and last positions differ.
+	UnclosedRing Code = "unclosed_ring"
+	// DuplicatePoint is a position repeating the previous one.
+	DuplicatePoint Code = "duplicate_point"
+	// WrongWinding is an exterior ring that isn't counterclockwise or a hole that isn't clockwise,
+	// as required by RFC 7946.
+	WrongWinding Code = "wrong_winding"
+	// SelfIntersection is a ring crossing or touching itself, or crossing another ring.
+	SelfIntersection Code = "self_intersection"
+	// HoleOutsideShell is a hole lying outside the exterior ring of its polygon.
+	HoleOutsideShell Code = "hole_outside_shell"
+)
+
+// Problem describes a validity problem of a geometry. The path holds the indices of the
+// coordinates arrays leading to the problem, e.g. [polygon, ring, position] for a MultiPolygon.
+type Problem struct {
+	Code     Code            `json:"code"`
+	Message  string          `json:"message"`
+	Location *geometry.Point `json:"location,omitempty"`
+	Path     []int           `json:"path"`
+}
+
+// Validate returns the problems of the geometry, or none when it is valid.
+func Validate(g geometry.Geometry) ([]Problem, error) {
+	var v validator
+	switch g.GeoJSONType {
+	case geojson.Point:
+		p, err := geom.Point(g)
+		if err != nil {
+			return nil, err
+		}
+		v.position(*p, nil)
+	case geojson.MultiPoint:
+		ps, err := geom.Line(g)
+		if err != nil {
+			return nil, err
+		}
+		for i, p := range ps {
+			v.position(p, []int{i})
+		}
+	case geojson.LineString:
+		l, err := geom.Line(g)
+		if err != nil {
+			return nil, err
+		}
+		v.line(l, nil)
+	case geojson.MultiLineString:
+		ls, err := geom.Lines(g)
+		if err != nil {
+			return nil, err
+		}
+		for i, l := range ls {
+			v.line(l, []int{i})
+		}
+	case geojson.Polygon:
+		polys, err := geom.Polygons(g)
+		if err != nil {
+			return nil, err
+		}
+		v.polygons(polys, false)
+	case geojson.MultiPolygon:
+		polys, err := geom.Polygons(g)
+		if err != nil {
+			return nil, err
+		}
+		v.polygons(polys, true)
+	default:
+		return nil, fmt.Errorf("unsupported geometry type %q", g.GeoJSONType)
+	}
+
+	return v.problems, nil
+}
+
+type validator struct {
+	problems []Problem
+}
+
+func (v *validator) add(code Code, msg string, at *geometry.Point, path []int) {
+	if path == nil {
+		path = []int{}
+	}
+	v.problems = append(v.problems, Problem{Code: code, Message: msg, Location: at, Path: path})
+}
+
+func (v *validator) position(p geometry.Point, path []int) bool {
+	switch {
+	case math.IsNaN(p.Lat) || math.IsNaN(p.Lng) || math.IsInf(p.Lat, 0) || math.IsInf(p.Lng, 0):
+		v.add(OutOfRange, "coordinates must be finite numbers", nil, path)
+	case p.Lat < -90 || p.Lat > 90:
+		v.add(OutOfRange, fmt.Sprintf("latitude %v is out of range [-90, 90]", p.Lat), &p, path)
+	case p.Lng < -180 || p.Lng > 180:
+		v.add(OutOfRange, fmt.Sprintf("longitude %v is out of range [-180, 180]", p.Lng), &p, path)
+	default:
+		return true
+	}
+	return false
+}
+
+// positions checks the coordinates and the repeated positions of a line or ring.
+func (v *validator) positions(ps []geometry.Point, path []int) {
+	for i, p := range ps {
+		at := append(append([]int{}, path...), i)
+		v.position(p, at)
+		if i > 0 && p == ps[i-1] {
+			p := p
+			v.add(DuplicatePoint, "position repeats the previous one", &p, at)
+		}
+	}
+}
+
+func (v *validator) line(l []geometry.Point, path []int) {
+	v.positions(l, path)
+	if distinct(l) < 2 {
+		v.add(TooFewPoints, "a LineString must have at least two distinct positions", first(l), path)
+	}
+}
+
+// ring checks a ring on its own and returns false when it is too broken to check it further.
+func (v *validator) ring(r []geometry.Point, exterior bool, path []int) bool {
+	// the closing position repeating the first one isn't a duplicate
+	v.positions(r, path)
+	if len(r) > 0 && r[0] != r[len(r)-1] {
+		p := r[len(r)-1]
+		v.add(UnclosedRing, "the first and last positions of a ring must be the same", &p, path)
+	}
+	if len(r) < 4 || distinct(r) < 3 {
+		v.add(TooFewPoints, "a ring must have at least four positions and three distinct ones", first(r), path)
+		return false
+	}
+
+	a := signedArea(r)
+	switch {
+	case exterior && a < 0:
+		v.add(WrongWinding, "an exterior ring must be counterclockwise", first(r), path)
+	case !exterior && a > 0:
+		v.add(WrongWinding, "a hole must be clockwise", first(r), path)
+	}
+	return true
+}
+
+func (v *validator) polygons(polys [][][]geometry.Point, multi bool) {
+	var segs []segment
+	for i, rings := range polys {
+		var base []int
+		if multi {
+			base = []int{i}
+		}
+		var shell []geometry.Point
+		for j, r := range rings {
+			path := append(append([]int{}, base...), j)
+			if !v.ring(r, j == 0, path) {
+				continue
+			}
+			if j == 0 {
+				shell = r
+			} else if shell != nil && !inside(r, shell) {
+				v.add(HoleOutsideShell, "a hole must be inside the exterior ring", first(r), path)
+			}
+			// the segments without length are left out, the ones around them being adjacent
+			start := len(segs)
+			for k := 0; k+1 < len(r); k++ {
+				if r[k] != r[k+1] {
+					segs = append(segs, segment{a: r[k], b: r[k+1], polygon: i, ring: j, index: k, seq: len(segs) - start})
+				}
+			}
+			for k := start; k < len(segs); k++ {
+				segs[k].last = len(segs) - start - 1
+			}
+		}
+	}
+
+	for _, x := range intersections(segs) {
+		path := []int{x.s.ring, x.s.index}
+		if multi {
+			path = append([]int{x.s.polygon}, path...)
+		}
+		p := x.at
+		v.add(SelfIntersection, x.message(), &p, path)
+	}
+}
+
+func first(ps []geometry.Point) *geometry.Point {
+	if len(ps) == 0 {
+		return nil
+	}
+	p := ps[0]
+	return &p
+}
+
+func distinct(ps []geometry.Point) int {
+	seen := make(map[geometry.Point]bool, len(ps))
+	for _, p := range ps {
+		seen[p] = true
+	}
+	return len(seen)
+}
+
+func signedArea(r []geometry.Point) float64 {
+	var a float64
+	for i := 0; i+1 < len(r); i++ {
+		a += r[i].Lng*r[i+1].Lat - r[i+1].Lng*r[i].Lat
+	}
+	return a / 2
+}
+
+// inside reports whether the hole is inside the shell, testing the first vertex of the hole that
+// isn't on the shell.
+func inside(hole, shell []geometry.Point) bool {
+	for _, p := range hole {
+		if onRing(p, shell) {
+			continue
+		}
+		in := false
+		for i, j := 0, len(shell)-1; i < len(shell); j, i = i, i+1 {
+			a, b := shell[i], shell[j]
+			if (a.Lat > p.Lat) != (b.Lat > p.Lat) && p.Lng < (b.Lng-a.Lng)*(p.Lat-a.Lat)/(b.Lat-a.Lat)+a.Lng {
+				in = !in
+			}
+		}
+		return in
+	}
+	return true
+}
+
+func onRing(p geometry.Point, r []geometry.Point) bool {
+	for i := 0; i+1 < len(r); i++ {
+		if between(r[i], r[i+1], p) && orientation(r[i], r[i+1], p) == 0 {
+			return true
+		}
+	}
+	return false
+}
+
+type segment struct {
+	a, b                 geometry.Point
+	polygon, ring, index int
+	seq, last            int // the position among the segments of the ring with a length, and the last one
+}
+
+type intersection struct {
+	s, t segment
+	at   geometry.Point
+}
+
+func (x intersection) message() string {
+	switch {
+	case x.s.polygon != x.t.polygon:
+		return fmt.Sprintf("polygon %d intersects polygon %d", x.s.polygon, x.t.polygon)
+	case x.s.ring != x.t.ring:
+		return fmt.Sprintf("ring %d intersects ring %d", x.s.ring, x.t.ring)
+	default:
+		return fmt.Sprintf("segment %d intersects segment %d of the same ring", x.s.index, x.t.index)
+	}
+}
+
+// intersections finds the segments crossing each other by sweeping them along the longitude axis.
+// Segments following each other in a ring may share their vertex, and different rings may touch
+// at a single vertex.
+func intersections(segs []segment) []intersection {
+	minLng := func(s segment) float64 { return math.Min(s.a.Lng, s.b.Lng) }
+	sort.Slice(segs, func(i, j int) bool { return minLng(segs[i]) < minLng(segs[j]) })
+
+	var res []intersection
+	for i, s := range segs {
+		maxLng := math.Max(s.a.Lng, s.b.Lng)
+		for _, t := range segs[i+1:] {
+			if minLng(t) > maxLng {
+				break
+			}
+			if adjacent(s, t) {
+				continue
+			}
+			if p, ok := intersect(s, t); ok {
+				res = append(res, intersection{s: s, t: t, at: p})
+			}
+		}
+	}
+	return res
+}
+
+func adjacent(s, t segment) bool {
+	if s.polygon != t.polygon || s.ring != t.ring {
+		return false
+	}
+	d := s.seq - t.seq
+	return d == 1 || d == -1 || d == s.last || d == -s.last
+}
+
+// intersect returns where the segments meet. Segments of different rings meeting at a vertex of
+// both are allowed to touch.
+func intersect(s, t segment) (geometry.Point, bool) {
+	d1 := orientation(t.a, t.b, s.a)
+	d2 := orientation(t.a, t.b, s.b)
+	d3 := orientation(s.a, s.b, t.a)
+	d4 := orientation(s.a, s.b, t.b)
+	if ((d1 > 0 && d2 < 0) || (d1 < 0 && d2 > 0)) && ((d3 > 0 && d4 < 0) || (d3 < 0 && d4 > 0)) {
+		r := d1 / (d1 - d2)
+		return geometry.Point{Lat: s.a.Lat + r*(s.b.Lat-s.a.Lat), Lng: s.a.Lng + r*(s.b.Lng-s.a.Lng)}, true
+	}
+
+	sameRing := s.polygon == t.polygon && s.ring == t.ring
+	for _, c := range []struct {
+		d    float64
+		a, b geometry.Point
+		p    geometry.Point
+	}{{d1, t.a, t.b, s.a}, {d2, t.a, t.b, s.b}, {d3, s.a, s.b, t.a}, {d4, s.a, s.b, t.b}} {
+		if c.d != 0 || !between(c.a, c.b, c.p) {
+			continue
+		}
+		// a vertex shared by two rings is a touch, a vertex on the interior of a segment of
+		// another ring means their boundaries overlap or cross
+		if !sameRing && (c.p == c.a || c.p == c.b) {
+			continue
+		}
+		return c.p, true
+	}
+	return geometry.Point{}, false
+}
+
+func between(a, b, p geometry.Point) bool {
+	return math.Min(a.Lng, b.Lng) <= p.Lng && p.Lng <= math.Max(a.Lng, b.Lng) &&
+		math.Min(a.Lat, b.Lat) <= p.Lat && p.Lat <= math.Max(a.Lat, b.Lat)
+}
+
+func orientation(a, b, c geometry.Point) float64 {
+	return (b.Lng-a.Lng)*(c.Lat-a.Lat) - (b.Lat-a.Lat)*(c.Lng-a.Lng)
+}
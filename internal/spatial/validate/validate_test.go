@@ -0,0 +1,117 @@
+package validate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/tomchavakis/geo-api/internal/spatial/geom"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+func ring(coords ...float64) []geometry.Point {
+	r := make([]geometry.Point, 0, len(coords)/2)
+	for i := 0; i+1 < len(coords); i += 2 {
+		r = append(r, geometry.Point{Lng: coords[i], Lat: coords[i+1]})
+	}
+	return r
+}
+
+func codes(ps []Problem) []Code {
+	res := []Code{}
+	for _, p := range ps {
+		res = append(res, p.Code)
+	}
+	return res
+}
+
+func TestValidate(t *testing.T) {
+	square := ring(0, 0, 2, 0, 2, 2, 0, 2, 0, 0)
+	hole := ring(0.5, 0.5, 0.5, 1.5, 1.5, 1.5, 1.5, 0.5, 0.5, 0.5)
+
+	tests := map[string]struct {
+		g    geometry.Geometry
+		want []Code
+	}{
+		"valid polygon":        {g: geom.PolygonGeometry([][]geometry.Point{square}), want: []Code{}},
+		"valid with hole":      {g: geom.PolygonGeometry([][]geometry.Point{square, hole}), want: []Code{}},
+		"valid line":           {g: geom.LineStringGeometry(ring(0, 0, 1, 1, 0, 1, 1, 0)), want: []Code{}},
+		"out of range point":   {g: geom.PointGeometry(geometry.Point{Lat: 91, Lng: 0}), want: []Code{OutOfRange}},
+		"out of range lng":     {g: geom.LineStringGeometry(ring(0, 0, 181, 0)), want: []Code{OutOfRange}},
+		"duplicate point":      {g: geom.LineStringGeometry(ring(0, 0, 1, 1, 1, 1)), want: []Code{DuplicatePoint}},
+		"collapsed line":       {g: geom.LineStringGeometry(ring(1, 1, 1, 1)), want: []Code{DuplicatePoint, TooFewPoints}},
+		"repeated vertex":      {g: geom.PolygonGeometry([][]geometry.Point{ring(0, 0, 2, 0, 2, 0, 2, 2, 0, 2, 0, 0)}), want: []Code{DuplicatePoint}},
+		"repeated last vertex": {g: geom.PolygonGeometry([][]geometry.Point{ring(0, 0, 2, 0, 2, 2, 0, 2, 0, 0, 0, 0)}), want: []Code{DuplicatePoint}},
+		"unclosed ring":        {g: geom.PolygonGeometry([][]geometry.Point{ring(0, 0, 2, 0, 2, 2, 0, 2)}), want: []Code{UnclosedRing}},
+		"too few vertices":     {g: geom.PolygonGeometry([][]geometry.Point{ring(0, 0, 2, 0, 0, 0)}), want: []Code{TooFewPoints}},
+		"clockwise exterior":   {g: geom.PolygonGeometry([][]geometry.Point{ring(0, 0, 0, 2, 2, 2, 2, 0, 0, 0)}), want: []Code{WrongWinding}},
+		"bowtie":               {g: geom.PolygonGeometry([][]geometry.Point{ring(0, 0, 2, 2, 2, 0, 0, 2, 0, 0)}), want: []Code{SelfIntersection}},
+		"hole outside shell":   {g: geom.PolygonGeometry([][]geometry.Point{square, ring(3, 3, 3, 4, 4, 4, 4, 3, 3, 3)}), want: []Code{HoleOutsideShell}},
+		"hole crossing shell":  {g: geom.PolygonGeometry([][]geometry.Point{square, ring(1, 1, 1, 3, 3, 3, 3, 1, 1, 1)}), want: []Code{SelfIntersection, SelfIntersection}},
+		"hole touching shell":  {g: geom.PolygonGeometry([][]geometry.Point{square, ring(0, 0, 1, 1.5, 1.5, 1, 0, 0)}), want: []Code{}},
+		"overlapping parts": {
+			g:    geom.MultiPolygonGeometry([][][]geometry.Point{{square}, {ring(1, 1, 3, 1, 3, 3, 1, 3, 1, 1)}}),
+			want: []Code{SelfIntersection, SelfIntersection},
+		},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := Validate(tt.g)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, codes(got))
+		})
+	}
+}
+
+func TestValidateLocation(t *testing.T) {
+	got, err := Validate(geom.MultiPolygonGeometry([][][]geometry.Point{{ring(0, 0, 2, 2, 2, 0, 0, 2, 0, 0)}}))
+	assert.NoError(t, err)
+	assert.Len(t, got, 1)
+	assert.Equal(t, &geometry.Point{Lat: 1, Lng: 1}, got[0].Location)
+	assert.Equal(t, []int{0, 0, 0}, got[0].Path)
+}
+
+func TestMakeValid(t *testing.T) {
+	tests := map[string]struct {
+		g     geometry.Geometry
+		typ   geojson.OBjectType
+		parts int
+	}{
+		"bowtie":             {g: geom.PolygonGeometry([][]geometry.Point{ring(0, 0, 2, 2, 2, 0, 0, 2, 0, 0)}), typ: geojson.MultiPolygon, parts: 2},
+		"unclosed clockwise": {g: geom.PolygonGeometry([][]geometry.Point{ring(0, 0, 0, 2, 2, 2, 2, 0)}), typ: geojson.Polygon, parts: 1},
+		"overlapping parts": {
+			g:   geom.MultiPolygonGeometry([][][]geometry.Point{{ring(0, 0, 2, 0, 2, 2, 0, 2, 0, 0)}, {ring(1, 1, 3, 1, 3, 3, 1, 3, 1, 1)}}),
+			typ: geojson.Polygon, parts: 1,
+		},
+		"collapsed polygon": {g: geom.PolygonGeometry([][]geometry.Point{ring(0, 0, 2, 0, 0, 0)}), typ: geojson.MultiPolygon, parts: 0},
+		"collapsed line":    {g: geom.LineStringGeometry(ring(1, 1, 1, 1)), typ: geojson.Point},
+		"line":              {g: geom.LineStringGeometry(ring(0, 0, 1, 1, 1, 1, 200, 95)), typ: geojson.LineString},
+	}
+
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := MakeValid(tt.g)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.typ, got.GeoJSONType)
+
+			problems, err := Validate(*got)
+			assert.NoError(t, err)
+			assert.Empty(t, problems)
+
+			if tt.typ == geojson.Polygon || tt.typ == geojson.MultiPolygon {
+				polys, err := geom.Polygons(*got)
+				assert.NoError(t, err)
+				assert.Len(t, polys, tt.parts)
+			}
+		})
+	}
+}
+
+func TestMakeValidLine(t *testing.T) {
+	got, err := MakeValid(geom.LineStringGeometry(ring(0, 0, 1, 1, 1, 1, 200, 95)))
+	assert.NoError(t, err)
+	l, err := geom.Line(*got)
+	assert.NoError(t, err)
+	assert.Equal(t, ring(0, 0, 1, 1, -160, 90), l)
+}
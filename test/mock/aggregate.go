@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/aggregate"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// AggregateRepository defines mock functions for Aggregate repository.
+type AggregateRepository struct {
+	AggregateFn        func(zones [][][][]geometry.Point, points []aggregate.Point, properties []string) ([]aggregate.Stats, error)
+	AggregateHexGridFn func(points []aggregate.Point, cellSize float64, units string, properties []string) ([]aggregate.Cell, error)
+}
+
+// NewMockAggregateRepository builds a mock Repository.
+func NewMockAggregateRepository() *AggregateRepository {
+	return &AggregateRepository{}
+}
+
+// Aggregate ...
+func (r *AggregateRepository) Aggregate(zones [][][][]geometry.Point, points []aggregate.Point, properties []string) ([]aggregate.Stats, error) {
+	if r.AggregateFn != nil {
+		return r.AggregateFn(zones, points, properties)
+	}
+	return nil, nil
+}
+
+// AggregateHexGrid ...
+func (r *AggregateRepository) AggregateHexGrid(points []aggregate.Point, cellSize float64, units string, properties []string) ([]aggregate.Cell, error) {
+	if r.AggregateHexGridFn != nil {
+		return r.AggregateHexGridFn(points, cellSize, units, properties)
+	}
+	return nil, nil
+}
@@ -0,0 +1,42 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/s2"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// CellRepository defines mock functions for Cell repository.
+type CellRepository struct {
+	GetCellIDFn       func(p geometry.Point, level int) (*s2.CellID, error)
+	GetCellPolygonFn  func(id s2.CellID) (*geometry.Polygon, error)
+	GetCellCoveringFn func(polygons [][][]geometry.Point, minLevel, maxLevel, maxCells int) ([]s2.CellID, error)
+}
+
+// NewMockCellRepository builds a mock Repository.
+func NewMockCellRepository() *CellRepository {
+	return &CellRepository{}
+}
+
+// GetCellID ...
+func (r *CellRepository) GetCellID(p geometry.Point, level int) (*s2.CellID, error) {
+	if r.GetCellIDFn != nil {
+		return r.GetCellIDFn(p, level)
+	}
+	return nil, nil
+}
+
+// GetCellPolygon ...
+func (r *CellRepository) GetCellPolygon(id s2.CellID) (*geometry.Polygon, error) {
+	if r.GetCellPolygonFn != nil {
+		return r.GetCellPolygonFn(id)
+	}
+	return nil, nil
+}
+
+// GetCellCovering ...
+func (r *CellRepository) GetCellCovering(polygons [][][]geometry.Point, minLevel, maxLevel, maxCells int) ([]s2.CellID, error) {
+	if r.GetCellCoveringFn != nil {
+		return r.GetCellCoveringFn(polygons, minLevel, maxLevel, maxCells)
+	}
+	return nil, nil
+}
@@ -0,0 +1,57 @@
+package mock
+
+import "github.com/tomchavakis/geojson/geometry"
+
+// CentreRepository defines mock functions for Centre repository.
+type CentreRepository struct {
+	GetCentroidFn              func(gs []geometry.Geometry) (*geometry.Point, error)
+	GetCenterOfMassFn          func(gs []geometry.Geometry) (*geometry.Point, error)
+	GetBBoxCenterFn            func(gs []geometry.Geometry) (*geometry.Point, error)
+	GetPointOnSurfaceFn        func(gs []geometry.Geometry) (*geometry.Point, error)
+	GetPoleOfInaccessibilityFn func(gs []geometry.Geometry, tolerance float64) (*geometry.Point, error)
+}
+
+// NewMockCentreRepository builds a mock Repository.
+func NewMockCentreRepository() *CentreRepository {
+	return &CentreRepository{}
+}
+
+// GetCentroid ...
+func (r *CentreRepository) GetCentroid(gs []geometry.Geometry) (*geometry.Point, error) {
+	if r.GetCentroidFn != nil {
+		return r.GetCentroidFn(gs)
+	}
+	return nil, nil
+}
+
+// GetCenterOfMass ...
+func (r *CentreRepository) GetCenterOfMass(gs []geometry.Geometry) (*geometry.Point, error) {
+	if r.GetCenterOfMassFn != nil {
+		return r.GetCenterOfMassFn(gs)
+	}
+	return nil, nil
+}
+
+// GetBBoxCenter ...
+func (r *CentreRepository) GetBBoxCenter(gs []geometry.Geometry) (*geometry.Point, error) {
+	if r.GetBBoxCenterFn != nil {
+		return r.GetBBoxCenterFn(gs)
+	}
+	return nil, nil
+}
+
+// GetPointOnSurface ...
+func (r *CentreRepository) GetPointOnSurface(gs []geometry.Geometry) (*geometry.Point, error) {
+	if r.GetPointOnSurfaceFn != nil {
+		return r.GetPointOnSurfaceFn(gs)
+	}
+	return nil, nil
+}
+
+// GetPoleOfInaccessibility ...
+func (r *CentreRepository) GetPoleOfInaccessibility(gs []geometry.Geometry, tolerance float64) (*geometry.Point, error) {
+	if r.GetPoleOfInaccessibilityFn != nil {
+		return r.GetPoleOfInaccessibilityFn(gs, tolerance)
+	}
+	return nil, nil
+}
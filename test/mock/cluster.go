@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/cluster"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// ClusterRepository defines mock functions for Cluster repository.
+type ClusterRepository struct {
+	ClusterDBSCANFn func(points []geometry.Point, eps float64, units string, minPoints int) (*cluster.Result, error)
+	ClusterKMeansFn func(points []geometry.Point, k int) (*cluster.Result, error)
+}
+
+// NewMockClusterRepository builds a mock Repository.
+func NewMockClusterRepository() *ClusterRepository {
+	return &ClusterRepository{}
+}
+
+// ClusterDBSCAN ...
+func (r *ClusterRepository) ClusterDBSCAN(points []geometry.Point, eps float64, units string, minPoints int) (*cluster.Result, error) {
+	if r.ClusterDBSCANFn != nil {
+		return r.ClusterDBSCANFn(points, eps, units, minPoints)
+	}
+	return nil, nil
+}
+
+// ClusterKMeans ...
+func (r *ClusterRepository) ClusterKMeans(points []geometry.Point, k int) (*cluster.Result, error) {
+	if r.ClusterKMeansFn != nil {
+		return r.ClusterKMeansFn(points, k)
+	}
+	return nil, nil
+}
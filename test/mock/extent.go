@@ -0,0 +1,60 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// ExtentRepository defines mock functions for Extent repository.
+type ExtentRepository struct {
+	GetBBoxFn        func(gs []geometry.Geometry) (*geojson.BBOX, error)
+	GetBBoxPolygonFn func(b geojson.BBOX) (*geometry.Geometry, error)
+	ExpandBBoxFn     func(b geojson.BBOX, distance float64, units string) (*geojson.BBOX, error)
+	IntersectBBoxFn  func(a, b geojson.BBOX) (*geojson.BBOX, error)
+	UnionBBoxFn      func(bs []geojson.BBOX) (*geojson.BBOX, error)
+}
+
+// NewMockExtentRepository builds a mock Repository.
+func NewMockExtentRepository() *ExtentRepository {
+	return &ExtentRepository{}
+}
+
+// GetBBox ...
+func (r *ExtentRepository) GetBBox(gs []geometry.Geometry) (*geojson.BBOX, error) {
+	if r.GetBBoxFn != nil {
+		return r.GetBBoxFn(gs)
+	}
+	return nil, nil
+}
+
+// GetBBoxPolygon ...
+func (r *ExtentRepository) GetBBoxPolygon(b geojson.BBOX) (*geometry.Geometry, error) {
+	if r.GetBBoxPolygonFn != nil {
+		return r.GetBBoxPolygonFn(b)
+	}
+	return nil, nil
+}
+
+// ExpandBBox ...
+func (r *ExtentRepository) ExpandBBox(b geojson.BBOX, distance float64, units string) (*geojson.BBOX, error) {
+	if r.ExpandBBoxFn != nil {
+		return r.ExpandBBoxFn(b, distance, units)
+	}
+	return nil, nil
+}
+
+// IntersectBBox ...
+func (r *ExtentRepository) IntersectBBox(a, b geojson.BBOX) (*geojson.BBOX, error) {
+	if r.IntersectBBoxFn != nil {
+		return r.IntersectBBoxFn(a, b)
+	}
+	return nil, nil
+}
+
+// UnionBBox ...
+func (r *ExtentRepository) UnionBBox(bs []geojson.BBOX) (*geojson.BBOX, error) {
+	if r.UnionBBoxFn != nil {
+		return r.UnionBBoxFn(bs)
+	}
+	return nil, nil
+}
@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/gazetteer"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// GeocodeRepository defines mock functions for Geocode repository.
+type GeocodeRepository struct {
+	ReverseGeocodeFn func(p geometry.Point) (*gazetteer.Location, error)
+	GeocodeFn        func(q gazetteer.Query) ([]gazetteer.Candidate, error)
+}
+
+// NewMockGeocodeRepository builds a mock Repository.
+func NewMockGeocodeRepository() *GeocodeRepository {
+	return &GeocodeRepository{}
+}
+
+// ReverseGeocode ...
+func (r *GeocodeRepository) ReverseGeocode(p geometry.Point) (*gazetteer.Location, error) {
+	if r.ReverseGeocodeFn != nil {
+		return r.ReverseGeocodeFn(p)
+	}
+	return nil, nil
+}
+
+// Geocode ...
+func (r *GeocodeRepository) Geocode(q gazetteer.Query) ([]gazetteer.Candidate, error) {
+	if r.GeocodeFn != nil {
+		return r.GeocodeFn(q)
+	}
+	return nil, nil
+}
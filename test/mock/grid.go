@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/grid"
+	"github.com/tomchavakis/geojson"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// GridRepository defines mock functions for Grid repository.
+type GridRepository struct {
+	GetGridFn func(kind grid.Kind, b *geojson.BBOX, cellSize float64, units string, mask [][][]geometry.Point) (*grid.Grid, error)
+}
+
+// NewMockGridRepository builds a mock Repository.
+func NewMockGridRepository() *GridRepository {
+	return &GridRepository{}
+}
+
+// GetGrid ...
+func (r *GridRepository) GetGrid(kind grid.Kind, b *geojson.BBOX, cellSize float64, units string, mask [][][]geometry.Point) (*grid.Grid, error) {
+	if r.GetGridFn != nil {
+		return r.GetGridFn(kind, b, cellSize, units, mask)
+	}
+	return nil, nil
+}
@@ -0,0 +1,37 @@
+package mock
+
+import (
+	"context"
+	"image"
+
+	"github.com/tomchavakis/geo-api/internal/spatial/density"
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+	"github.com/tomchavakis/geojson"
+)
+
+// HeatmapRepository defines mock functions for Heatmap repository.
+type HeatmapRepository struct {
+	GetHeatmapTileFn func(ctx context.Context, points []density.Point, kernel density.Kernel, bandwidth float64, t tile.Tile, scale float64) (*image.NRGBA, error)
+	GetHeatmapGridFn func(points []density.Point, kernel density.Kernel, bandwidth float64, b geojson.BBOX, cellSize float64, units string) (*density.Cells, error)
+}
+
+// NewMockHeatmapRepository builds a mock Repository.
+func NewMockHeatmapRepository() *HeatmapRepository {
+	return &HeatmapRepository{}
+}
+
+// GetHeatmapTile ...
+func (r *HeatmapRepository) GetHeatmapTile(ctx context.Context, points []density.Point, kernel density.Kernel, bandwidth float64, t tile.Tile, scale float64) (*image.NRGBA, error) {
+	if r.GetHeatmapTileFn != nil {
+		return r.GetHeatmapTileFn(ctx, points, kernel, bandwidth, t, scale)
+	}
+	return nil, nil
+}
+
+// GetHeatmapGrid ...
+func (r *HeatmapRepository) GetHeatmapGrid(points []density.Point, kernel density.Kernel, bandwidth float64, b geojson.BBOX, cellSize float64, units string) (*density.Cells, error) {
+	if r.GetHeatmapGridFn != nil {
+		return r.GetHeatmapGridFn(points, kernel, bandwidth, b, cellSize, units)
+	}
+	return nil, nil
+}
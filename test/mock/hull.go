@@ -0,0 +1,39 @@
+package mock
+
+import "github.com/tomchavakis/geojson/geometry"
+
+// HullRepository defines mock functions for Hull repository.
+type HullRepository struct {
+	GetConvexHullFn             func(points []geometry.Point) (*geometry.Geometry, error)
+	GetConcaveHullFn            func(points []geometry.Point, maxEdge float64, units string) (*geometry.Geometry, error)
+	GetConcaveHullByConcavityFn func(points []geometry.Point, concavity float64) (*geometry.Geometry, error)
+}
+
+// NewMockHullRepository builds a mock Repository.
+func NewMockHullRepository() *HullRepository {
+	return &HullRepository{}
+}
+
+// GetConvexHull ...
+func (r *HullRepository) GetConvexHull(points []geometry.Point) (*geometry.Geometry, error) {
+	if r.GetConvexHullFn != nil {
+		return r.GetConvexHullFn(points)
+	}
+	return nil, nil
+}
+
+// GetConcaveHull ...
+func (r *HullRepository) GetConcaveHull(points []geometry.Point, maxEdge float64, units string) (*geometry.Geometry, error) {
+	if r.GetConcaveHullFn != nil {
+		return r.GetConcaveHullFn(points, maxEdge, units)
+	}
+	return nil, nil
+}
+
+// GetConcaveHullByConcavity ...
+func (r *HullRepository) GetConcaveHullByConcavity(points []geometry.Point, concavity float64) (*geometry.Geometry, error) {
+	if r.GetConcaveHullByConcavityFn != nil {
+		return r.GetConcaveHullByConcavityFn(points, concavity)
+	}
+	return nil, nil
+}
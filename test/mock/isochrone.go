@@ -0,0 +1,34 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/isochrone"
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// IsochroneRepository defines mock functions for Isochrone repository.
+type IsochroneRepository struct {
+	GetIsochronesFn   func(origin geometry.Point, profile *roads.Profile, seconds []float64) ([]isochrone.Contour, error)
+	GetIsodistancesFn func(origin geometry.Point, profile *roads.Profile, meters []float64) ([]isochrone.Contour, error)
+}
+
+// NewMockIsochroneRepository builds a mock Repository.
+func NewMockIsochroneRepository() *IsochroneRepository {
+	return &IsochroneRepository{}
+}
+
+// GetIsochrones ...
+func (r *IsochroneRepository) GetIsochrones(origin geometry.Point, profile *roads.Profile, seconds []float64) ([]isochrone.Contour, error) {
+	if r.GetIsochronesFn != nil {
+		return r.GetIsochronesFn(origin, profile, seconds)
+	}
+	return nil, nil
+}
+
+// GetIsodistances ...
+func (r *IsochroneRepository) GetIsodistances(origin geometry.Point, profile *roads.Profile, meters []float64) ([]isochrone.Contour, error) {
+	if r.GetIsodistancesFn != nil {
+		return r.GetIsodistancesFn(origin, profile, meters)
+	}
+	return nil, nil
+}
@@ -0,0 +1,75 @@
+package mock
+
+import "github.com/tomchavakis/geojson/geometry"
+
+// LineOpsRepository defines mock functions for LineOps repository.
+type LineOpsRepository struct {
+	SplitLineFn            func(line, points []geometry.Point) ([][]geometry.Point, error)
+	SplitLineByLineFn      func(line, splitter []geometry.Point) ([][]geometry.Point, error)
+	SliceLineFn            func(line []geometry.Point, start, stop geometry.Point) ([]geometry.Point, error)
+	SliceLineAlongFn       func(line []geometry.Point, start, stop float64, units string) ([]geometry.Point, error)
+	OffsetLineFn           func(line []geometry.Point, distance float64, units string) ([]geometry.Point, error)
+	ChunkLineFn            func(line []geometry.Point, length float64, units string) ([][]geometry.Point, error)
+	GetLineIntersectionsFn func(a, b []geometry.Point) ([]geometry.Point, error)
+}
+
+// NewMockLineOpsRepository builds a mock Repository.
+func NewMockLineOpsRepository() *LineOpsRepository {
+	return &LineOpsRepository{}
+}
+
+// SplitLine ...
+func (r *LineOpsRepository) SplitLine(line, points []geometry.Point) ([][]geometry.Point, error) {
+	if r.SplitLineFn != nil {
+		return r.SplitLineFn(line, points)
+	}
+	return nil, nil
+}
+
+// SplitLineByLine ...
+func (r *LineOpsRepository) SplitLineByLine(line, splitter []geometry.Point) ([][]geometry.Point, error) {
+	if r.SplitLineByLineFn != nil {
+		return r.SplitLineByLineFn(line, splitter)
+	}
+	return nil, nil
+}
+
+// SliceLine ...
+func (r *LineOpsRepository) SliceLine(line []geometry.Point, start, stop geometry.Point) ([]geometry.Point, error) {
+	if r.SliceLineFn != nil {
+		return r.SliceLineFn(line, start, stop)
+	}
+	return nil, nil
+}
+
+// SliceLineAlong ...
+func (r *LineOpsRepository) SliceLineAlong(line []geometry.Point, start, stop float64, units string) ([]geometry.Point, error) {
+	if r.SliceLineAlongFn != nil {
+		return r.SliceLineAlongFn(line, start, stop, units)
+	}
+	return nil, nil
+}
+
+// OffsetLine ...
+func (r *LineOpsRepository) OffsetLine(line []geometry.Point, distance float64, units string) ([]geometry.Point, error) {
+	if r.OffsetLineFn != nil {
+		return r.OffsetLineFn(line, distance, units)
+	}
+	return nil, nil
+}
+
+// ChunkLine ...
+func (r *LineOpsRepository) ChunkLine(line []geometry.Point, length float64, units string) ([][]geometry.Point, error) {
+	if r.ChunkLineFn != nil {
+		return r.ChunkLineFn(line, length, units)
+	}
+	return nil, nil
+}
+
+// GetLineIntersections ...
+func (r *LineOpsRepository) GetLineIntersections(a, b []geometry.Point) ([]geometry.Point, error) {
+	if r.GetLineIntersectionsFn != nil {
+		return r.GetLineIntersectionsFn(a, b)
+	}
+	return nil, nil
+}
@@ -0,0 +1,24 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/mapmatch"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// MapMatchRepository defines mock functions for MapMatch repository.
+type MapMatchRepository struct {
+	MatchTraceFn func(trace []geometry.Point, opts mapmatch.Options) (*mapmatch.Result, error)
+}
+
+// NewMockMapMatchRepository builds a mock Repository.
+func NewMockMapMatchRepository() *MapMatchRepository {
+	return &MapMatchRepository{}
+}
+
+// MatchTrace ...
+func (r *MapMatchRepository) MatchTrace(trace []geometry.Point, opts mapmatch.Options) (*mapmatch.Result, error) {
+	if r.MatchTraceFn != nil {
+		return r.MatchTraceFn(trace, opts)
+	}
+	return nil, nil
+}
@@ -0,0 +1,23 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/optimize"
+)
+
+// OptimizeRepository defines mock functions for Optimize repository.
+type OptimizeRepository struct {
+	OptimizeRouteFn func(req optimize.Request) (*optimize.Tour, error)
+}
+
+// NewMockOptimizeRepository builds a mock Repository.
+func NewMockOptimizeRepository() *OptimizeRepository {
+	return &OptimizeRepository{}
+}
+
+// OptimizeRoute ...
+func (r *OptimizeRepository) OptimizeRoute(req optimize.Request) (*optimize.Tour, error) {
+	if r.OptimizeRouteFn != nil {
+		return r.OptimizeRouteFn(req)
+	}
+	return nil, nil
+}
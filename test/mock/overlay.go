@@ -0,0 +1,24 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/overlay"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// OverlayRepository defines mock functions for Overlay repository.
+type OverlayRepository struct {
+	OverlayFn func(subject, clip [][][]geometry.Point, op overlay.Op) (*geometry.Geometry, error)
+}
+
+// NewMockOverlayRepository builds a mock Repository.
+func NewMockOverlayRepository() *OverlayRepository {
+	return &OverlayRepository{}
+}
+
+// Overlay ...
+func (r *OverlayRepository) Overlay(subject, clip [][][]geometry.Point, op overlay.Op) (*geometry.Geometry, error) {
+	if r.OverlayFn != nil {
+		return r.OverlayFn(subject, clip, op)
+	}
+	return nil, nil
+}
@@ -0,0 +1,51 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/olc"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// PlusCodeRepository defines mock functions for PlusCode repository.
+type PlusCodeRepository struct {
+	EncodePlusCodeFn  func(p geometry.Point, length int) (*string, error)
+	DecodePlusCodeFn  func(code string) (*olc.CodeArea, error)
+	ShortenPlusCodeFn func(code string, ref geometry.Point) (*string, error)
+	RecoverPlusCodeFn func(code string, ref geometry.Point) (*string, error)
+}
+
+// NewMockPlusCodeRepository builds a mock Repository.
+func NewMockPlusCodeRepository() *PlusCodeRepository {
+	return &PlusCodeRepository{}
+}
+
+// EncodePlusCode ...
+func (r *PlusCodeRepository) EncodePlusCode(p geometry.Point, length int) (*string, error) {
+	if r.EncodePlusCodeFn != nil {
+		return r.EncodePlusCodeFn(p, length)
+	}
+	return nil, nil
+}
+
+// DecodePlusCode ...
+func (r *PlusCodeRepository) DecodePlusCode(code string) (*olc.CodeArea, error) {
+	if r.DecodePlusCodeFn != nil {
+		return r.DecodePlusCodeFn(code)
+	}
+	return nil, nil
+}
+
+// ShortenPlusCode ...
+func (r *PlusCodeRepository) ShortenPlusCode(code string, ref geometry.Point) (*string, error) {
+	if r.ShortenPlusCodeFn != nil {
+		return r.ShortenPlusCodeFn(code, ref)
+	}
+	return nil, nil
+}
+
+// RecoverPlusCode ...
+func (r *PlusCodeRepository) RecoverPlusCode(code string, ref geometry.Point) (*string, error) {
+	if r.RecoverPlusCodeFn != nil {
+		return r.RecoverPlusCodeFn(code, ref)
+	}
+	return nil, nil
+}
@@ -0,0 +1,25 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/roads"
+	"github.com/tomchavakis/geo-api/internal/spatial/routing"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// RoutingRepository defines mock functions for Routing repository.
+type RoutingRepository struct {
+	GetRouteFn func(a, b geometry.Point, profile *roads.Profile) (*routing.Result, error)
+}
+
+// NewMockRoutingRepository builds a mock Repository.
+func NewMockRoutingRepository() *RoutingRepository {
+	return &RoutingRepository{}
+}
+
+// GetRoute ...
+func (r *RoutingRepository) GetRoute(a, b geometry.Point, profile *roads.Profile) (*routing.Result, error) {
+	if r.GetRouteFn != nil {
+		return r.GetRouteFn(a, b, profile)
+	}
+	return nil, nil
+}
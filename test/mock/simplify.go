@@ -0,0 +1,24 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/simplify"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// SimplifyRepository defines mock functions for Simplify repository.
+type SimplifyRepository struct {
+	SimplifyFn func(gs []geometry.Geometry, tolerance float64, units string, algorithm simplify.Algorithm, preserveTopology bool) ([]geometry.Geometry, error)
+}
+
+// NewMockSimplifyRepository builds a mock Repository.
+func NewMockSimplifyRepository() *SimplifyRepository {
+	return &SimplifyRepository{}
+}
+
+// Simplify ...
+func (r *SimplifyRepository) Simplify(gs []geometry.Geometry, tolerance float64, units string, algorithm simplify.Algorithm, preserveTopology bool) ([]geometry.Geometry, error) {
+	if r.SimplifyFn != nil {
+		return r.SimplifyFn(gs, tolerance, units, algorithm, preserveTopology)
+	}
+	return nil, nil
+}
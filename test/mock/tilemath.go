@@ -0,0 +1,51 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// TileMathRepository defines mock functions for TileMath repository.
+type TileMathRepository struct {
+	GetTileAtFn          func(p geometry.Point, z int) (*tile.Tile, error)
+	GetTileFromTMSFn     func(z, x, y int) (*tile.Tile, error)
+	GetTileFromQuadkeyFn func(quadkey string) (*tile.Tile, error)
+	GetTileCoverFn       func(polygons [][][]geometry.Point, z int) ([]tile.Tile, error)
+}
+
+// NewMockTileMathRepository builds a mock Repository.
+func NewMockTileMathRepository() *TileMathRepository {
+	return &TileMathRepository{}
+}
+
+// GetTileAt ...
+func (r *TileMathRepository) GetTileAt(p geometry.Point, z int) (*tile.Tile, error) {
+	if r.GetTileAtFn != nil {
+		return r.GetTileAtFn(p, z)
+	}
+	return nil, nil
+}
+
+// GetTileFromTMS ...
+func (r *TileMathRepository) GetTileFromTMS(z, x, y int) (*tile.Tile, error) {
+	if r.GetTileFromTMSFn != nil {
+		return r.GetTileFromTMSFn(z, x, y)
+	}
+	return nil, nil
+}
+
+// GetTileFromQuadkey ...
+func (r *TileMathRepository) GetTileFromQuadkey(quadkey string) (*tile.Tile, error) {
+	if r.GetTileFromQuadkeyFn != nil {
+		return r.GetTileFromQuadkeyFn(quadkey)
+	}
+	return nil, nil
+}
+
+// GetTileCover ...
+func (r *TileMathRepository) GetTileCover(polygons [][][]geometry.Point, z int) ([]tile.Tile, error) {
+	if r.GetTileCoverFn != nil {
+		return r.GetTileCoverFn(polygons, z)
+	}
+	return nil, nil
+}
@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/tile"
+)
+
+// TilesRepository defines mock functions for Tiles repository.
+type TilesRepository struct {
+	GetTileETagFn   func(dataset string, t tile.Tile) (string, error)
+	GetVectorTileFn func(dataset string, t tile.Tile) ([]byte, error)
+}
+
+// NewMockTilesRepository builds a mock Repository.
+func NewMockTilesRepository() *TilesRepository {
+	return &TilesRepository{}
+}
+
+// GetTileETag ...
+func (r *TilesRepository) GetTileETag(dataset string, t tile.Tile) (string, error) {
+	if r.GetTileETagFn != nil {
+		return r.GetTileETagFn(dataset, t)
+	}
+	return "", nil
+}
+
+// GetVectorTile ...
+func (r *TilesRepository) GetVectorTile(dataset string, t tile.Tile) ([]byte, error) {
+	if r.GetVectorTileFn != nil {
+		return r.GetVectorTileFn(dataset, t)
+	}
+	return nil, nil
+}
@@ -0,0 +1,32 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/track"
+)
+
+// TrackRepository defines mock functions for Track repository.
+type TrackRepository struct {
+	AnalyzeTrackFn func(points []track.Point, opts track.StopOptions) (*track.Analysis, error)
+	CleanTrackFn   func(points []track.Point, opts track.CleanOptions) (*track.Cleaned, error)
+}
+
+// NewMockTrackRepository builds a mock Repository.
+func NewMockTrackRepository() *TrackRepository {
+	return &TrackRepository{}
+}
+
+// AnalyzeTrack ...
+func (r *TrackRepository) AnalyzeTrack(points []track.Point, opts track.StopOptions) (*track.Analysis, error) {
+	if r.AnalyzeTrackFn != nil {
+		return r.AnalyzeTrackFn(points, opts)
+	}
+	return nil, nil
+}
+
+// CleanTrack ...
+func (r *TrackRepository) CleanTrack(points []track.Point, opts track.CleanOptions) (*track.Cleaned, error) {
+	if r.CleanTrackFn != nil {
+		return r.CleanTrackFn(points, opts)
+	}
+	return nil, nil
+}
@@ -0,0 +1,39 @@
+package mock
+
+import "github.com/tomchavakis/geojson/geometry"
+
+// TransformRepository defines mock functions for Transform repository.
+type TransformRepository struct {
+	TranslateFn func(gs []geometry.Geometry, distance float64, bearing float64, units string) ([]geometry.Geometry, error)
+	RotateFn    func(gs []geometry.Geometry, angle float64, pivot *geometry.Point) ([]geometry.Geometry, error)
+	ScaleFn     func(gs []geometry.Geometry, factor float64, origin *geometry.Point) ([]geometry.Geometry, error)
+}
+
+// NewMockTransformRepository builds a mock Repository.
+func NewMockTransformRepository() *TransformRepository {
+	return &TransformRepository{}
+}
+
+// Translate ...
+func (r *TransformRepository) Translate(gs []geometry.Geometry, distance float64, bearing float64, units string) ([]geometry.Geometry, error) {
+	if r.TranslateFn != nil {
+		return r.TranslateFn(gs, distance, bearing, units)
+	}
+	return nil, nil
+}
+
+// Rotate ...
+func (r *TransformRepository) Rotate(gs []geometry.Geometry, angle float64, pivot *geometry.Point) ([]geometry.Geometry, error) {
+	if r.RotateFn != nil {
+		return r.RotateFn(gs, angle, pivot)
+	}
+	return nil, nil
+}
+
+// Scale ...
+func (r *TransformRepository) Scale(gs []geometry.Geometry, factor float64, origin *geometry.Point) ([]geometry.Geometry, error) {
+	if r.ScaleFn != nil {
+		return r.ScaleFn(gs, factor, origin)
+	}
+	return nil, nil
+}
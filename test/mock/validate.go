@@ -0,0 +1,33 @@
+package mock
+
+import (
+	"github.com/tomchavakis/geo-api/internal/spatial/validate"
+	"github.com/tomchavakis/geojson/geometry"
+)
+
+// ValidateRepository defines mock functions for Validate repository.
+type ValidateRepository struct {
+	ValidateFn  func(gs []geometry.Geometry) ([][]validate.Problem, error)
+	MakeValidFn func(gs []geometry.Geometry) ([]geometry.Geometry, error)
+}
+
+// NewMockValidateRepository builds a mock Repository.
+func NewMockValidateRepository() *ValidateRepository {
+	return &ValidateRepository{}
+}
+
+// Validate ...
+func (r *ValidateRepository) Validate(gs []geometry.Geometry) ([][]validate.Problem, error) {
+	if r.ValidateFn != nil {
+		return r.ValidateFn(gs)
+	}
+	return nil, nil
+}
+
+// MakeValid ...
+func (r *ValidateRepository) MakeValid(gs []geometry.Geometry) ([]geometry.Geometry, error) {
+	if r.MakeValidFn != nil {
+		return r.MakeValidFn(gs)
+	}
+	return nil, nil
+}